@@ -0,0 +1,60 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"net"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func (s *Server) AddReplicationEntries(ctx context.Context, in *idl.AddReplicationEntriesRequest) (*idl.AddReplicationEntriesReply, error) {
+	gplog.Info("agent received request to add replication entries")
+
+	var mErr error
+	for _, entry := range in.GetEntries() {
+		addresses, err := hbaAddresses(entry.GetMirrorHost(), in.GetUseHbaHostnames())
+		if err != nil {
+			mErr = errorlist.Append(mErr, err)
+			continue
+		}
+
+		entries := upgrade.ReplicationHbaEntries(in.GetUser(), addresses)
+		if err := upgrade.AddHbaEntries(entry.GetDataDir(), entries); err != nil {
+			mErr = errorlist.Append(mErr, xerrors.Errorf("add replication entries to %q: %w", entry.GetDataDir(), err))
+		}
+	}
+
+	return &idl.AddReplicationEntriesReply{}, mErr
+}
+
+// hbaAddresses returns the pg_hba.conf addresses of the host, which are its
+// hostname when using hostnames and otherwise each of its IP addresses.
+func hbaAddresses(host string, useHbaHostnames bool) ([]string, error) {
+	if useHbaHostnames {
+		return []string{host}, nil
+	}
+
+	ips, err := net.LookupHost(host)
+	if err != nil {
+		return nil, xerrors.Errorf("resolve mirror host %q: %w", host, err)
+	}
+
+	var addresses []string
+	for _, ip := range ips {
+		if net.ParseIP(ip).To4() != nil {
+			addresses = append(addresses, ip+"/32")
+		} else {
+			addresses = append(addresses, ip+"/128")
+		}
+	}
+
+	return addresses, nil
+}
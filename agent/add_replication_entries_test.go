@@ -0,0 +1,83 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestAddReplicationEntries(t *testing.T) {
+	testlog.SetupLogger()
+	server := agent.NewServer(agent.Config{})
+
+	t.Run("adds entries for each address of the mirror host once", func(t *testing.T) {
+		dataDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dataDir)
+
+		testutils.MustWriteToFile(t, filepath.Join(dataDir, "pg_hba.conf"), "local all gpadmin ident")
+
+		request := &idl.AddReplicationEntriesRequest{
+			Entries: []*idl.ReplicationEntry{{DataDir: dataDir, MirrorHost: "127.0.0.1"}},
+			User:    "gpadmin",
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := server.AddReplicationEntries(context.Background(), request)
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		}
+
+		hba := testutils.MustReadFile(t, filepath.Join(dataDir, "pg_hba.conf"))
+		expected := "local all gpadmin ident\n" +
+			"host\tall\tgpadmin\t127.0.0.1/32\ttrust\n" +
+			"host\treplication\tgpadmin\t127.0.0.1/32\ttrust\n"
+		if hba != expected {
+			t.Errorf("got pg_hba.conf %q want %q", hba, expected)
+		}
+	})
+
+	t.Run("adds entries for the mirror hostname when using hostnames", func(t *testing.T) {
+		dataDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dataDir)
+
+		testutils.MustWriteToFile(t, filepath.Join(dataDir, "pg_hba.conf"), "")
+
+		_, err := server.AddReplicationEntries(context.Background(), &idl.AddReplicationEntriesRequest{
+			Entries:         []*idl.ReplicationEntry{{DataDir: dataDir, MirrorHost: "msdw1"}},
+			User:            "gpadmin",
+			UseHbaHostnames: true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		hba := testutils.MustReadFile(t, filepath.Join(dataDir, "pg_hba.conf"))
+		expected := "host\tall\tgpadmin\tmsdw1\ttrust\nhost\treplication\tgpadmin\tmsdw1\ttrust\n"
+		if hba != expected {
+			t.Errorf("got pg_hba.conf %q want %q", hba, expected)
+		}
+	})
+
+	t.Run("errors when the pg_hba.conf is missing", func(t *testing.T) {
+		dataDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dataDir)
+
+		_, err := server.AddReplicationEntries(context.Background(), &idl.AddReplicationEntriesRequest{
+			Entries:         []*idl.ReplicationEntry{{DataDir: dataDir, MirrorHost: "msdw1"}},
+			User:            "gpadmin",
+			UseHbaHostnames: true,
+		})
+		if err == nil {
+			t.Error("expected error, returned nil")
+		}
+	})
+}
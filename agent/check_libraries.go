@@ -0,0 +1,21 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+func (s *Server) CheckLibraries(ctx context.Context, in *idl.CheckLibrariesRequest) (*idl.CheckLibrariesReply, error) {
+	gplog.Info("agent received request to check libraries")
+
+	libraries, extensions := upgrade.MissingLibraries(in.GetGpHome(), in.GetLibraries(), in.GetExtensions())
+
+	return &idl.CheckLibrariesReply{MissingLibraries: libraries, MissingExtensions: extensions}, nil
+}
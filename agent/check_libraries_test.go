@@ -0,0 +1,42 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func TestServer_CheckLibraries(t *testing.T) {
+	testhelper.SetupTestLogger()
+	server := agent.NewServer(agent.Config{})
+
+	t.Run("reports the libraries and extensions missing from the GPHOME", func(t *testing.T) {
+		request := &idl.CheckLibrariesRequest{
+			GpHome:     "/does/not/exist",
+			Libraries:  []string{"$libdir/postgis-2.5"},
+			Extensions: []string{"postgis"},
+		}
+
+		reply, err := server.CheckLibraries(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := &idl.CheckLibrariesReply{
+			MissingLibraries:  []string{"$libdir/postgis-2.5"},
+			MissingExtensions: []string{"postgis"},
+		}
+		if !reflect.DeepEqual(reply.GetMissingLibraries(), expected.GetMissingLibraries()) ||
+			!reflect.DeepEqual(reply.GetMissingExtensions(), expected.GetMissingExtensions()) {
+			t.Errorf("got %v want %v", reply, expected)
+		}
+	})
+}
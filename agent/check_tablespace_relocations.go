@@ -0,0 +1,112 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func (s *Server) CheckTablespaceRelocations(ctx context.Context, in *idl.CheckTablespaceRelocationsRequest) (*idl.CheckTablespaceRelocationsReply, error) {
+	gplog.Info("agent received request to check tablespace relocations")
+
+	return &idl.CheckTablespaceRelocationsReply{}, CheckTablespaceRelocations(disk.Local, in.GetRelocations())
+}
+
+// CheckTablespaceRelocations verifies that each relocated tablespace directory
+// can be created, and that each filesystem has enough space available for the
+// source tablespace directories being relocated onto it.
+func CheckTablespaceRelocations(d disk.Disk, relocations []*idl.CheckTablespaceRelocationsRequest_Relocation) error {
+	required := make(map[uint64]uint64)
+	dirs := make(map[uint64]string)
+
+	var mErr error
+	for _, relocation := range relocations {
+		dir, err := creatableDir(relocation.GetTargetDir())
+		if err != nil {
+			mErr = errorlist.Append(mErr, err)
+			continue
+		}
+
+		size, err := disk.DirectorySize(relocation.GetSourceDir())
+		if err != nil {
+			mErr = errorlist.Append(mErr, err)
+			continue
+		}
+
+		stat, err := d.Stat(dir)
+		if err != nil {
+			mErr = errorlist.Append(mErr, xerrors.Errorf("stat'ing %s: %w", dir, err))
+			continue
+		}
+
+		required[uint64(stat.Dev)] += size
+		dirs[uint64(stat.Dev)] = dir
+	}
+
+	if mErr != nil {
+		return mErr
+	}
+
+	for dev, size := range required {
+		usage, err := d.Usage(dirs[dev])
+		if err != nil {
+			mErr = errorlist.Append(mErr, xerrors.Errorf("getting fs usage for %s: %w", dirs[dev], err))
+			continue
+		}
+
+		if usage.Avail < size {
+			mErr = errorlist.Append(mErr, xerrors.Errorf(
+				"insufficient disk space for relocated tablespaces in %q: %d bytes available, %d bytes required",
+				dirs[dev], usage.Avail, size))
+		}
+	}
+
+	return mErr
+}
+
+// creatableDir returns the closest existing ancestor of the directory after
+// verifying that the directory does not already contain files and that the
+// ancestor is a writable directory.
+func creatableDir(target string) (string, error) {
+	entries, err := ioutil.ReadDir(target)
+	if err == nil && len(entries) > 0 {
+		return "", xerrors.Errorf("relocated tablespace directory %q already exists and is not empty", target)
+	}
+
+	dir := target
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return "", xerrors.Errorf("relocated tablespace directory %q: %q is not a directory", target, dir)
+			}
+			break
+		}
+
+		// Keep walking up to report which parent is not a directory.
+		missing := os.IsNotExist(err) || xerrors.Is(err, unix.ENOTDIR)
+		if !missing || dir == filepath.Dir(dir) {
+			return "", xerrors.Errorf("relocated tablespace directory %q: %w", target, err)
+		}
+
+		dir = filepath.Dir(dir)
+	}
+
+	if err := unix.Access(dir, unix.W_OK|unix.X_OK); err != nil {
+		return "", xerrors.Errorf("relocated tablespace directory %q: %q is not writable: %w", target, dir, err)
+	}
+
+	return dir, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sigar "github.com/cloudfoundry/gosigar"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"golang.org/x/sys/unix"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+// relocationDisk reports every path as being on one filesystem with the given
+// available space.
+type relocationDisk struct {
+	avail uint64
+}
+
+func (d relocationDisk) Filesystems() (sigar.FileSystemList, error) {
+	return sigar.FileSystemList{List: []sigar.FileSystem{{DirName: "/"}}}, nil
+}
+
+func (d relocationDisk) Usage(string) (sigar.FileSystemUsage, error) {
+	return sigar.FileSystemUsage{Avail: d.avail}, nil
+}
+
+func (d relocationDisk) Stat(string) (*unix.Stat_t, error) {
+	return &unix.Stat_t{Dev: 1}, nil
+}
+
+func TestCheckTablespaceRelocations(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	sourceDir := filepath.Join(dir, "source", "16384", "2")
+	if err := os.MkdirAll(sourceDir, 0700); err != nil {
+		t.Fatalf("creating %q: %v", sourceDir, err)
+	}
+	testutils.MustWriteToFile(t, filepath.Join(sourceDir, "16385"), "0123456789")
+
+	relocation := func(targetDir string) []*idl.CheckTablespaceRelocationsRequest_Relocation {
+		return []*idl.CheckTablespaceRelocationsRequest_Relocation{
+			{SourceDir: sourceDir, TargetDir: targetDir},
+		}
+	}
+
+	t.Run("succeeds when the relocated directory can be created and there is enough space", func(t *testing.T) {
+		target := filepath.Join(dir, "relocated", "16384", "2")
+
+		err := agent.CheckTablespaceRelocations(relocationDisk{avail: 10}, relocation(target))
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("errors when there is not enough space for the relocated tablespaces", func(t *testing.T) {
+		relocations := append(relocation(filepath.Join(dir, "relocated", "16384", "2")),
+			relocation(filepath.Join(dir, "relocated", "16384", "3"))...)
+
+		err := agent.CheckTablespaceRelocations(relocationDisk{avail: 15}, relocations)
+		if err == nil || !strings.Contains(err.Error(), "15 bytes available, 20 bytes required") {
+			t.Errorf("got error %v, want insufficient disk space", err)
+		}
+	})
+
+	t.Run("errors when the relocated directory is not empty", func(t *testing.T) {
+		target := filepath.Join(dir, "existing")
+		if err := os.Mkdir(target, 0700); err != nil {
+			t.Fatalf("creating %q: %v", target, err)
+		}
+		testutils.MustWriteToFile(t, filepath.Join(target, "file"), "")
+
+		err := agent.CheckTablespaceRelocations(relocationDisk{avail: 10}, relocation(target))
+		if err == nil || !strings.Contains(err.Error(), "not empty") {
+			t.Errorf("got error %v, want not empty", err)
+		}
+	})
+
+	t.Run("errors when a parent of the relocated directory is not a directory", func(t *testing.T) {
+		file := filepath.Join(dir, "file")
+		testutils.MustWriteToFile(t, file, "")
+
+		err := agent.CheckTablespaceRelocations(relocationDisk{avail: 10}, relocation(filepath.Join(file, "16384", "2")))
+		if err == nil || !strings.Contains(err.Error(), "is not a directory") {
+			t.Errorf("got error %v, want not a directory", err)
+		}
+	})
+}
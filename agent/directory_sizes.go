@@ -0,0 +1,32 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func (s *Server) GetDirectorySizes(ctx context.Context, in *idl.GetDirectorySizesRequest) (*idl.GetDirectorySizesReply, error) {
+	gplog.Info("agent received request to get directory sizes")
+
+	var mErr error
+	sizes := make(map[string]uint64)
+	for _, dir := range in.Directories {
+		size, err := disk.DirectorySize(dir)
+		if err != nil {
+			mErr = errorlist.Append(mErr, err)
+			continue
+		}
+
+		sizes[dir] = size
+	}
+
+	return &idl.GetDirectorySizesReply{Sizes: sizes}, mErr
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestServer_GetDirectorySizes(t *testing.T) {
+	testhelper.SetupTestLogger()
+	server := agent.NewServer(agent.Config{})
+
+	t.Run("returns the size of each directory", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		testutils.MustWriteToFile(t, filepath.Join(dir, "PG_VERSION"), "9.4")
+		missing := filepath.Join(dir, "does-not-exist")
+
+		reply, err := server.GetDirectorySizes(context.Background(), &idl.GetDirectorySizesRequest{
+			Directories: []string{dir, missing},
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+
+		expected := map[string]uint64{dir: 3, missing: 0}
+		if !reflect.DeepEqual(reply.Sizes, expected) {
+			t.Errorf("got sizes %v want %v", reply.Sizes, expected)
+		}
+	})
+}
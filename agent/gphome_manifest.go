@@ -0,0 +1,50 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"sort"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+// ManifestChunkSize is the number of files sent per reply, which keeps each
+// well within the gRPC message size limit.
+const ManifestChunkSize = 1000
+
+func (s *Server) GetGPHomeManifest(in *idl.GetGPHomeManifestRequest, stream idl.Agent_GetGPHomeManifestServer) error {
+	gplog.Info("agent received request to compute the manifest of %s", in.GetGpHome())
+
+	manifest, err := upgrade.GPHomeManifest(in.GetGpHome())
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	for path := range manifest {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for start := 0; start < len(paths); start += ManifestChunkSize {
+		end := start + ManifestChunkSize
+		if end > len(paths) {
+			end = len(paths)
+		}
+
+		chunk := make(map[string][]byte)
+		for _, path := range paths[start:end] {
+			chunk[path] = manifest[path]
+		}
+
+		if err := stream.Send(&idl.GetGPHomeManifestReply{Checksums: chunk}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
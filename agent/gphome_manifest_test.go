@@ -0,0 +1,107 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+// manifestStream collects the replies sent by GetGPHomeManifest.
+type manifestStream struct {
+	grpc.ServerStream
+	replies []*idl.GetGPHomeManifestReply
+	err     error
+}
+
+func (m *manifestStream) Send(reply *idl.GetGPHomeManifestReply) error {
+	m.replies = append(m.replies, reply)
+	return m.err
+}
+
+func TestServer_GetGPHomeManifest(t *testing.T) {
+	testhelper.SetupTestLogger()
+	server := agent.NewServer(agent.Config{})
+
+	gphome := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, gphome)
+
+	if err := os.Mkdir(filepath.Join(gphome, "bin"), 0755); err != nil {
+		t.Fatalf("creating GPHOME: %v", err)
+	}
+	testutils.MustWriteToFile(t, filepath.Join(gphome, "bin", "postgres"), "postgres")
+
+	t.Run("streams the checksums of the GPHOME files", func(t *testing.T) {
+		stream := &manifestStream{}
+		err := server.GetGPHomeManifest(&idl.GetGPHomeManifestRequest{GpHome: gphome}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if len(stream.replies) != 1 {
+			t.Fatalf("got %d replies want 1", len(stream.replies))
+		}
+
+		checksums := stream.replies[0].GetChecksums()
+		if _, ok := checksums["bin/postgres"]; !ok || len(checksums) != 1 {
+			t.Errorf("got checksums %v want bin/postgres", checksums)
+		}
+	})
+
+	t.Run("splits large manifests into chunks", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		files := agent.ManifestChunkSize + 1
+		for i := 0; i < files; i++ {
+			testutils.MustWriteToFile(t, filepath.Join(dir, fmt.Sprintf("file%d", i)), "contents")
+		}
+
+		stream := &manifestStream{}
+		err := server.GetGPHomeManifest(&idl.GetGPHomeManifestRequest{GpHome: dir}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if len(stream.replies) != 2 {
+			t.Fatalf("got %d replies want 2", len(stream.replies))
+		}
+
+		count := 0
+		for _, reply := range stream.replies {
+			count += len(reply.GetChecksums())
+		}
+
+		if count != files {
+			t.Errorf("got %d checksums want %d", count, files)
+		}
+	})
+
+	t.Run("errors when sending fails", func(t *testing.T) {
+		expected := errors.New("connection reset")
+		stream := &manifestStream{err: expected}
+
+		err := server.GetGPHomeManifest(&idl.GetGPHomeManifestRequest{GpHome: gphome}, stream)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("errors when the GPHOME does not exist", func(t *testing.T) {
+		err := server.GetGPHomeManifest(&idl.GetGPHomeManifestRequest{GpHome: "/does/not/exist"}, &manifestStream{})
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
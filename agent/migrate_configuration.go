@@ -0,0 +1,61 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+
+	"github.com/blang/semver/v4"
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func (s *Server) MigrateConfiguration(ctx context.Context, in *idl.MigrateConfigurationRequest) (*idl.MigrateConfigurationReply, error) {
+	gplog.Info("agent starting %s", idl.Substep_MIGRATE_CONFIGURATION)
+
+	sourceVersion, err := semver.Parse(in.GetSourceVersion())
+	if err != nil {
+		return &idl.MigrateConfigurationReply{}, xerrors.Errorf("parsing source version: %w", err)
+	}
+
+	targetVersion, err := semver.Parse(in.GetTargetVersion())
+	if err != nil {
+		return &idl.MigrateConfigurationReply{}, xerrors.Errorf("parsing target version: %w", err)
+	}
+
+	// Without a target GPHOME the settings are not checked against the target.
+	var targetSettings map[string]bool
+	if in.GetTargetGPHome() != "" {
+		targetSettings, err = upgrade.TargetSettingNames(in.GetTargetGPHome())
+		if err != nil {
+			return &idl.MigrateConfigurationReply{}, err
+		}
+	}
+
+	reply := &idl.MigrateConfigurationReply{}
+	var mErr error
+	for _, pair := range in.GetDataDirPairs() {
+		changes, err := upgrade.MigrateConfig(pair.GetSourceDataDir(), pair.GetTargetDataDir(), sourceVersion, targetVersion, targetSettings)
+		if err != nil {
+			mErr = errorlist.Append(mErr, err)
+			continue
+		}
+
+		for _, change := range changes {
+			reply.Changes = append(reply.Changes, &idl.ConfigurationChange{
+				DataDir: pair.GetTargetDataDir(),
+				File:    change.File,
+				Name:    change.Name,
+				Action:  change.Action,
+				Detail:  change.Detail,
+			})
+		}
+	}
+
+	return reply, mErr
+}
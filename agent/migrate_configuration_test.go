@@ -0,0 +1,87 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func TestServer_MigrateConfiguration(t *testing.T) {
+	testhelper.SetupTestLogger()
+	server := agent.NewServer(agent.Config{})
+
+	t.Run("reports the changes to each target segment", func(t *testing.T) {
+		sourceDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, sourceDir)
+
+		targetDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, targetDir)
+
+		testutils.MustWriteToFile(t, filepath.Join(sourceDir, "postgresql.conf"), "work_mem = '64MB'\n")
+		testutils.MustWriteToFile(t, filepath.Join(sourceDir, "pg_hba.conf"), "")
+		testutils.MustWriteToFile(t, filepath.Join(targetDir, "postgresql.conf"), "")
+		testutils.MustWriteToFile(t, filepath.Join(targetDir, "pg_hba.conf"), "")
+
+		reply, err := server.MigrateConfiguration(context.Background(), &idl.MigrateConfigurationRequest{
+			SourceVersion: "6.20.0",
+			TargetVersion: "6.20.0",
+			DataDirPairs:  []*idl.DataDirPair{{SourceDataDir: sourceDir, TargetDataDir: targetDir}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := &idl.ConfigurationChange{
+			DataDir: targetDir,
+			File:    "postgresql.conf",
+			Name:    "work_mem",
+			Action:  upgrade.ConfigApplied,
+			Detail:  "'64MB' replacing the target default",
+		}
+
+		if len(reply.GetChanges()) != 1 || reply.GetChanges()[0].String() != expected.String() {
+			t.Errorf("got changes %v want %v", reply.GetChanges(), expected)
+		}
+	})
+
+	t.Run("bubbles up errors when the configuration files do not exist", func(t *testing.T) {
+		pairs := []*idl.DataDirPair{
+			{SourceDataDir: "/tmp/source1", TargetDataDir: "/tmp/target1"},
+			{SourceDataDir: "/tmp/source2", TargetDataDir: "/tmp/target2"},
+		}
+
+		_, err := server.MigrateConfiguration(context.Background(), &idl.MigrateConfigurationRequest{
+			SourceVersion: "6.20.0",
+			TargetVersion: "6.20.0",
+			DataDirPairs:  pairs,
+		})
+
+		var errs errorlist.Errors
+		if !xerrors.As(err, &errs) {
+			t.Fatalf("error %#v does not contain type %T", err, errs)
+		}
+
+		if len(errs) != len(pairs) {
+			t.Fatalf("got error count %d, want %d", len(errs), len(pairs))
+		}
+
+		for _, err := range errs {
+			if !os.IsNotExist(err) {
+				t.Errorf("got error %#v, want a not exist error", err)
+			}
+		}
+	})
+}
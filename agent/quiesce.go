@@ -0,0 +1,42 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func (s *Server) QuiesceSegments(ctx context.Context, in *idl.QuiesceRequest) (*idl.QuiesceReply, error) {
+	gplog.Info("agent starting %s", idl.Substep_QUIESCE_SOURCE_CLUSTER)
+
+	var mErr error
+	for _, dir := range in.GetDataDirs() {
+		err := upgrade.Quiesce(in.GetBinDir(), dir, in.GetAdmins())
+		if err != nil {
+			mErr = errorlist.Append(mErr, err)
+		}
+	}
+
+	return &idl.QuiesceReply{}, mErr
+}
+
+func (s *Server) UnquiesceSegments(ctx context.Context, in *idl.QuiesceRequest) (*idl.QuiesceReply, error) {
+	gplog.Info("agent starting %s", idl.Substep_UNQUIESCE_SOURCE_CLUSTER)
+
+	var mErr error
+	for _, dir := range in.GetDataDirs() {
+		err := upgrade.Unquiesce(in.GetBinDir(), dir)
+		if err != nil {
+			mErr = errorlist.Append(mErr, err)
+		}
+	}
+
+	return &idl.QuiesceReply{}, mErr
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func TestServer_QuiesceSegments(t *testing.T) {
+	testhelper.SetupTestLogger()
+	server := agent.NewServer(agent.Config{})
+
+	t.Run("bubbles up errors when no pg_hba.conf files exist", func(t *testing.T) {
+		dirs := []string{"/tmp/test1", "/tmp/test2"}
+		request := &idl.QuiesceRequest{BinDir: "/usr/local/gpdb/bin", DataDirs: dirs, Admins: []string{"gpadmin"}}
+		_, err := server.QuiesceSegments(context.Background(), request)
+
+		var errs errorlist.Errors
+		if !xerrors.As(err, &errs) {
+			t.Fatalf("error %#v does not contain type %T", err, errs)
+		}
+
+		if len(errs) != len(dirs) {
+			t.Fatalf("got error count %d, want %d", len(errs), len(dirs))
+		}
+
+		for _, err := range errs {
+			if !os.IsNotExist(err) {
+				t.Errorf("got error %#v, want a not exist error", err)
+			}
+		}
+	})
+}
+
+func TestServer_UnquiesceSegments(t *testing.T) {
+	testhelper.SetupTestLogger()
+	server := agent.NewServer(agent.Config{})
+
+	t.Run("leaves segments that are not quiesced unchanged", func(t *testing.T) {
+		dataDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dataDir)
+
+		path := filepath.Join(dataDir, "pg_hba.conf")
+		testutils.MustWriteToFile(t, path, "local all all trust\n")
+
+		request := &idl.QuiesceRequest{BinDir: "/usr/local/gpdb/bin", DataDirs: []string{dataDir}}
+		_, err := server.UnquiesceSegments(context.Background(), request)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		if contents := testutils.MustReadFile(t, path); contents != "local all all trust\n" {
+			t.Errorf("got pg_hba.conf %q, want it unchanged", contents)
+		}
+
+		if upgrade.PathExists(filepath.Join(dataDir, upgrade.HbaBackupName)) {
+			t.Errorf("expected no saved pg_hba.conf")
+		}
+	})
+}
@@ -8,7 +8,9 @@ import (
 	"os"
 	"sync"
 
+	"github.com/blang/semver/v4"
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -44,8 +46,18 @@ func (s *Server) RsyncTablespaceDirectories(ctx context.Context, in *idl.RsyncRe
 		sourceDirs = append(sourceDirs, pair.GetSource())
 	}
 
+	version, err := semver.Parse(in.GetSourceVersion())
+	if err != nil {
+		return &idl.RsyncReply{}, xerrors.Errorf("parse source version: %w", err)
+	}
+
 	// NOTE: Rsync will still be called if a given sourceDir is empty.
-	if err := upgrade.Verify5XTablespaceDirectories(sourceDirs); err != nil {
+	verify := upgrade.Verify5XTablespaceDirectories
+	if version.Major >= 6 {
+		verify = upgrade.Verify6XTablespaceDirectories
+	}
+
+	if err := verify(sourceDirs); err != nil {
 		return &idl.RsyncReply{}, err
 	}
 
@@ -65,11 +77,16 @@ func rsyncRequestDirs(in *idl.RsyncRequest) error {
 
 			opts := []rsync.Option{
 				rsync.WithSources(pair.GetSource() + string(os.PathSeparator)),
-				rsync.WithDestinationHost(pair.GetDestinationHost()),
 				rsync.WithDestination(pair.GetDestination()),
 				rsync.WithOptions(in.GetOptions()...),
 				rsync.WithExcludedFiles(in.GetExcludes()...),
 			}
+
+			// An empty destination host copies within this host.
+			if pair.GetDestinationHost() != "" {
+				opts = append(opts, rsync.WithDestinationHost(pair.GetDestinationHost()))
+			}
+
 			errs <- rsync.Rsync(opts...)
 		}()
 	}
@@ -225,8 +225,38 @@ func TestRsyncTablespaceDirectories(t *testing.T) {
 				DestinationHost: "sdw1",
 				Destination:     destination,
 			}},
-			Options:  options,
-			Excludes: excludes,
+			Options:       options,
+			Excludes:      excludes,
+			SourceVersion: "5.28.0",
+		}
+
+		_, err := server.RsyncTablespaceDirectories(context.Background(), request)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("successfully rsyncs 6X tablespace directories", func(t *testing.T) {
+		dbIDDir := testutils.MustMake6XTablespaceDir(t, 4)
+		defer testutils.MustRemoveAll(t, filepath.Dir(dbIDDir))
+
+		defer rsync.SetRsyncCommand(exec.Command)
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(agent.Success, func(utility string, args ...string) {
+			src := args[3]
+			expected := dbIDDir + string(os.PathSeparator)
+			if src != expected {
+				t.Errorf("got source %q want %q", src, expected)
+			}
+		}))
+
+		request := &idl.RsyncRequest{
+			Pairs: []*idl.RsyncPair{{
+				Source:          dbIDDir,
+				DestinationHost: "sdw1",
+				Destination:     "/tmp/tablespace/2",
+			}},
+			Options:       hub.Options,
+			SourceVersion: "6.15.0",
 		}
 
 		_, err := server.RsyncTablespaceDirectories(context.Background(), request)
@@ -235,6 +265,33 @@ func TestRsyncTablespaceDirectories(t *testing.T) {
 		}
 	})
 
+	t.Run("errors when a 6X tablespace directory is missing the source version directory", func(t *testing.T) {
+		var rsyncCalled bool
+		defer rsync.SetRsyncCommand(exec.Command)
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(agent.Success, func(utility string, args ...string) {
+			rsyncCalled = true
+		}))
+
+		dbIDDir := testutils.MustMake6XTablespaceDir(t, 4)
+		defer testutils.MustRemoveAll(t, filepath.Dir(dbIDDir))
+
+		testutils.MustRemoveAll(t, filepath.Join(dbIDDir, "GPDB_6_301908232"))
+
+		request := &idl.RsyncRequest{
+			Pairs:         []*idl.RsyncPair{{Source: dbIDDir, Destination: destination}},
+			SourceVersion: "6.15.0",
+		}
+
+		_, err := server.RsyncTablespaceDirectories(context.Background(), request)
+		if !errors.Is(err, upgrade.ErrInvalidTablespaceDirectory) {
+			t.Errorf("got error %#v want %#v", err, upgrade.ErrInvalidTablespaceDirectory)
+		}
+
+		if rsyncCalled {
+			t.Errorf("expected rsync to not be called")
+		}
+	})
+
 	t.Run("errors when failing to verify tablespace directory", func(t *testing.T) {
 		var rsyncCalled bool
 		defer rsync.SetRsyncCommand(exec.Command)
@@ -251,9 +308,10 @@ func TestRsyncTablespaceDirectories(t *testing.T) {
 			t.Fatalf("removing PG_VERSION from %q: %v", dbOidDir, err)
 		}
 
-		request := &idl.RsyncRequest{Pairs: []*idl.RsyncPair{
-			{Source: invalidTablespaceDir, Destination: destination},
-		}}
+		request := &idl.RsyncRequest{
+			Pairs:         []*idl.RsyncPair{{Source: invalidTablespaceDir, Destination: destination}},
+			SourceVersion: "5.28.0",
+		}
 
 		_, err = server.RsyncTablespaceDirectories(context.Background(), request)
 
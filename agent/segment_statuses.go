@@ -0,0 +1,38 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func (s *Server) GetSegmentStatuses(ctx context.Context, in *idl.GetSegmentStatusesRequest) (*idl.GetSegmentStatusesReply, error) {
+	gplog.Info("agent received request to get segment upgrade statuses")
+
+	var mErr error
+	reply := &idl.GetSegmentStatusesReply{}
+	for _, content := range in.Contents {
+		status, err := upgrade.ReadSegmentStatus(upgrade.SegmentWorkingDirectory(s.conf.StateDir, int(content)))
+		if err != nil {
+			mErr = errorlist.Append(mErr, err)
+			continue
+		}
+
+		reply.Statuses = append(reply.Statuses, &idl.SegmentStatus{
+			Content:  content,
+			LinkMode: status.LinkMode,
+			Started:  status.Started(),
+			Finished: status.Finished(),
+			Recorded: status.Recorded,
+		})
+	}
+
+	return reply, mErr
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+func TestServer_GetSegmentStatuses(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	server := agent.NewServer(agent.Config{StateDir: stateDir})
+
+	t.Run("returns the pg_upgrade status of each segment", func(t *testing.T) {
+		linked := upgrade.SegmentWorkingDirectory(stateDir, 0)
+		copied := upgrade.SegmentWorkingDirectory(stateDir, 1)
+		for _, dir := range []string{linked, copied} {
+			if err := os.MkdirAll(dir, 0700); err != nil {
+				t.Fatalf("creating directory %q: %+v", dir, err)
+			}
+		}
+
+		if err := upgrade.MarkUpgradeStarted(linked, true); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if err := upgrade.MarkUpgradeStarted(copied, false); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if err := upgrade.MarkUpgradeFinished(copied); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		reply, err := server.GetSegmentStatuses(context.Background(), &idl.GetSegmentStatusesRequest{
+			Contents: []int32{0, 1, 2},
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+
+		expected := []*idl.SegmentStatus{
+			{Content: 0, LinkMode: true, Started: true, Finished: false, Recorded: true},
+			{Content: 1, LinkMode: false, Started: true, Finished: true, Recorded: true},
+			{Content: 2, LinkMode: false, Started: false, Finished: false, Recorded: false},
+		}
+		if !reflect.DeepEqual(reply.Statuses, expected) {
+			t.Errorf("got statuses %v want %v", reply.Statuses, expected)
+		}
+	})
+}
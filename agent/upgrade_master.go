@@ -0,0 +1,95 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"os"
+
+	"github.com/blang/semver/v4"
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+func (s *Server) UpgradeMaster(ctx context.Context, request *idl.UpgradeMasterRequest) (*idl.UpgradeMasterReply, error) {
+	if request.CheckOnly {
+		gplog.Info("agent starting %s", idl.Substep_CHECK_UPGRADE)
+	} else {
+		gplog.Info("agent starting %s", idl.Substep_UPGRADE_MASTER)
+	}
+
+	err := UpgradeMaster(s.conf.StateDir, request)
+
+	return &idl.UpgradeMasterReply{}, err
+}
+
+// UpgradeMaster upgrades the master on this host for a hub that does not run
+// on the master host.
+func UpgradeMaster(stateDir string, request *idl.UpgradeMasterRequest) error {
+	wd := upgrade.MasterWorkingDirectory(stateDir)
+	if err := utils.System.MkdirAll(wd, 0700); err != nil {
+		return xerrors.Errorf("creating pg_upgrade work directory: %w", err)
+	}
+
+	pair := request.GetDataDirPair()
+
+	// Restore the backup of the initialized target master taken during
+	// initialize, since pg_upgrade may have been run against it before.
+	options := []rsync.Option{
+		rsync.WithSources(request.MasterBackupDir + string(os.PathSeparator)),
+		rsync.WithDestination(pair.GetTargetDataDir()),
+		rsync.WithOptions("--archive", "--delete"),
+		rsync.WithExcludedFiles("pg_log/*"),
+	}
+
+	if err := rsync.Rsync(options...); err != nil {
+		return xerrors.Errorf("restore master data directory backup: %w", err)
+	}
+
+	dbid := int(pair.GetDBID())
+	segmentPair := upgrade.SegmentPair{
+		Source: &upgrade.Segment{BinDir: request.SourceBinDir, DataDir: pair.GetSourceDataDir(), DBID: dbid, Port: int(pair.GetSourcePort())},
+		Target: &upgrade.Segment{BinDir: request.TargetBinDir, DataDir: pair.GetTargetDataDir(), DBID: dbid, Port: int(pair.GetTargetPort())},
+	}
+
+	upgradeOptions := []upgrade.Option{
+		upgrade.WithExecCommand(execCommand),
+		upgrade.WithWorkDir(wd),
+	}
+
+	if request.CheckOnly {
+		upgradeOptions = append(upgradeOptions, upgrade.WithCheckOnly())
+	}
+
+	if request.UseLinkMode {
+		upgradeOptions = append(upgradeOptions, upgrade.WithLinkMode())
+	}
+
+	if request.OldOptions != "" {
+		upgradeOptions = append(upgradeOptions, upgrade.WithOldOptions(request.OldOptions))
+	}
+
+	if !request.CheckOnly {
+		if err := upgrade.MarkUpgradeStarted(wd, request.UseLinkMode); err != nil {
+			return xerrors.Errorf("record master upgrade status: %w", err)
+		}
+	}
+
+	if err := upgrade.Run(segmentPair, semver.MustParse(request.TargetVersion), upgradeOptions...); err != nil {
+		return xerrors.Errorf("see the pg_upgrade logs in %q: %w", wd, err)
+	}
+
+	if !request.CheckOnly {
+		if err := upgrade.MarkUpgradeFinished(wd); err != nil {
+			return xerrors.Errorf("record master upgrade status: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+func TestUpgradeMaster(t *testing.T) {
+	testlog.SetupLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	request := &idl.UpgradeMasterRequest{
+		SourceBinDir:  "/old/bin",
+		TargetBinDir:  "/new/bin",
+		TargetVersion: "6.15.0",
+		DataDirPair: &idl.DataDirPair{
+			SourceDataDir: "/data/qddir/seg-1",
+			TargetDataDir: "/data/qddir/seg-1_123ABC",
+			SourcePort:    5432,
+			TargetPort:    6432,
+			Content:       -1,
+			DBID:          1,
+		},
+		OldOptions:      "-x 8",
+		MasterBackupDir: "/state/master.bak",
+	}
+
+	t.Run("restores the master backup and upgrades the master in dispatcher mode", func(t *testing.T) {
+		defer ResetCommands()
+
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(agent.Success, func(utility string, args ...string) {
+			call := strings.Join(args, " ")
+			if !strings.Contains(call, "/state/master.bak/ /data/qddir/seg-1_123ABC") {
+				t.Errorf("got rsync arguments %q want the backup restored to the target master", call)
+			}
+		}))
+
+		agent.SetExecCommand(exectest.NewCommandWithVerifier(agent.Success, func(utility string, args ...string) {
+			expected := []string{"--mode", "dispatcher"}
+			if !containsSequence(args, expected) {
+				t.Errorf("got pg_upgrade arguments %q want %q", args, expected)
+			}
+
+			expected = []string{"--old-options", "-x 8"}
+			if !containsSequence(args, expected) {
+				t.Errorf("got pg_upgrade arguments %q want %q", args, expected)
+			}
+		}))
+
+		err := agent.UpgradeMaster(stateDir, request)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		status, err := upgrade.ReadSegmentStatus(upgrade.MasterWorkingDirectory(stateDir))
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if !status.Finished() {
+			t.Errorf("expected the master upgrade to be recorded as finished")
+		}
+	})
+
+	t.Run("returns an error naming the working directory when pg_upgrade fails", func(t *testing.T) {
+		defer ResetCommands()
+
+		rsync.SetRsyncCommand(exectest.NewCommand(agent.Success))
+		agent.SetExecCommand(exectest.NewCommand(agent.FailedMain))
+
+		err := agent.UpgradeMaster(stateDir, request)
+		if err == nil {
+			t.Fatal("expected error, returned nil")
+		}
+
+		wd := upgrade.MasterWorkingDirectory(stateDir)
+		if !strings.Contains(err.Error(), wd) {
+			t.Errorf("error %q does not contain the working directory %q", err, wd)
+		}
+	})
+
+	t.Run("returns an error when restoring the backup fails", func(t *testing.T) {
+		defer ResetCommands()
+
+		rsync.SetRsyncCommand(exectest.NewCommand(agent.FailedRsync))
+		agent.SetExecCommand(nil)
+
+		err := agent.UpgradeMaster(stateDir, request)
+		if err == nil || !strings.Contains(err.Error(), "restore master data directory backup") {
+			t.Errorf("got error %#v want restore failure", err)
+		}
+	})
+}
+
+func containsSequence(args []string, sequence []string) bool {
+	for i := 0; i+len(sequence) <= len(args); i++ {
+		if reflect.DeepEqual(args[i:i+len(sequence)], sequence) {
+			return true
+		}
+	}
+
+	return false
+}
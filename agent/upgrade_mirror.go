@@ -0,0 +1,168 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+
+	"github.com/blang/semver/v4"
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+// mirrorExcludes are the primary files that must not be copied to the mirror,
+// either because they describe the running primary or because they are
+// written separately for the mirror. Like pg_basebackup, the replication
+// slots of the primary are not copied.
+var mirrorExcludes = []string{
+	"postmaster.pid", "postmaster.opts", "internal.auto.conf", "recovery.conf",
+	"standby.signal",
+	"backup_label", "backup_label.old", "pg_log", "pg_replslot/*",
+}
+
+func (s *Server) UpgradeMirror(ctx context.Context, in *idl.UpgradeMirrorRequest) (*idl.UpgradeMirrorReply, error) {
+	gplog.Info("agent received request to upgrade mirror for content %d", in.GetContent())
+
+	return &idl.UpgradeMirrorReply{}, UpgradeMirror(in)
+}
+
+// UpgradeMirror creates a mirror by copying its stopped, upgraded primary and
+// configuring the copy to replicate from the primary when started.
+func UpgradeMirror(in *idl.UpgradeMirrorRequest) error {
+	opts := []rsync.Option{
+		rsync.WithSourceHost(in.GetPrimaryHost()),
+		rsync.WithSources(in.GetPrimaryDataDir() + string(os.PathSeparator)),
+		rsync.WithDestination(in.GetMirrorDataDir()),
+		rsync.WithOptions("--archive", "--delete"),
+		rsync.WithExcludedFiles(mirrorExcludes...),
+	}
+
+	if err := rsync.Rsync(opts...); err != nil {
+		return xerrors.Errorf("copy primary %s:%s to mirror %s: %w",
+			in.GetPrimaryHost(), in.GetPrimaryDataDir(), in.GetMirrorDataDir(), err)
+	}
+
+	if err := writeMirrorDbid(in.GetMirrorDataDir(), int(in.GetMirrorDbid())); err != nil {
+		return err
+	}
+
+	if err := setMirrorPort(in.GetMirrorDataDir(), int(in.GetMirrorPort())); err != nil {
+		return err
+	}
+
+	version, err := semver.Parse(in.GetTargetVersion())
+	if err != nil {
+		return xerrors.Errorf("parse target version: %w", err)
+	}
+
+	conninfo, err := primaryConninfo(in)
+	if err != nil {
+		return err
+	}
+
+	// 7X removed recovery.conf. A standby is instead signaled by the presence
+	// of standby.signal and reads primary_conninfo from its configuration.
+	if version.LT(semver.MustParse("7.0.0")) {
+		return writeRecoveryConf(in.GetMirrorDataDir(), conninfo)
+	}
+
+	return writeStandbySignal(in.GetMirrorDataDir(), conninfo)
+}
+
+func writeMirrorDbid(dataDir string, dbid int) error {
+	path := filepath.Join(dataDir, "internal.auto.conf")
+	contents := fmt.Sprintf("gp_dbid=%d\n", dbid)
+
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		return xerrors.Errorf("write mirror dbid: %w", err)
+	}
+
+	return nil
+}
+
+var portPattern = regexp.MustCompile(`(?m)^port[ \t]*=[ \t]*[0-9]+`)
+
+func setMirrorPort(dataDir string, port int) error {
+	path := filepath.Join(dataDir, "postgresql.conf")
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return xerrors.Errorf("read mirror configuration: %w", err)
+	}
+
+	contents = portPattern.ReplaceAll(contents, []byte(fmt.Sprintf("port=%d", port)))
+
+	if err := ioutil.WriteFile(path, contents, 0600); err != nil {
+		return xerrors.Errorf("write mirror configuration: %w", err)
+	}
+
+	return nil
+}
+
+// primaryConninfo returns the settings the mirror replicates from its primary
+// with, using the configured user and sslmode when set as gpaddmirrors would.
+func primaryConninfo(in *idl.UpgradeMirrorRequest) (string, error) {
+	username := in.GetUser()
+	if username == "" {
+		currentUser, err := user.Current()
+		if err != nil {
+			return "", err
+		}
+
+		username = currentUser.Username
+	}
+
+	sslMode := in.GetSslMode()
+	if sslMode == "" {
+		sslMode = "prefer"
+	}
+
+	return fmt.Sprintf("primary_conninfo = 'user=%s host=%s port=%d sslmode=%s sslcompression=1 application_name=gp_walreceiver'\n"+
+		"primary_slot_name = '%s'\n",
+		username, in.GetPrimaryHost(), in.GetPrimaryPort(), sslMode, upgrade.ReplicationSlot), nil
+}
+
+func writeRecoveryConf(dataDir string, conninfo string) error {
+	contents := "standby_mode = 'on'\n" + conninfo
+
+	path := filepath.Join(dataDir, "recovery.conf")
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		return xerrors.Errorf("write mirror recovery configuration: %w", err)
+	}
+
+	return nil
+}
+
+func writeStandbySignal(dataDir string, conninfo string) error {
+	path := filepath.Join(dataDir, "postgresql.auto.conf")
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return xerrors.Errorf("open mirror auto configuration: %w", err)
+	}
+
+	_, err = file.WriteString(conninfo)
+	if cErr := file.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return xerrors.Errorf("write mirror primary connection: %w", err)
+	}
+
+	path = filepath.Join(dataDir, "standby.signal")
+	if err := ioutil.WriteFile(path, nil, 0600); err != nil {
+		return xerrors.Errorf("write mirror standby signal: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+func TestUpgradeMirror(t *testing.T) {
+	testlog.SetupLogger()
+	server := agent.NewServer(agent.Config{})
+
+	t.Run("copies the primary and configures the mirror", func(t *testing.T) {
+		mirrorDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, mirrorDir)
+
+		testutils.MustWriteToFile(t, filepath.Join(mirrorDir, "postgresql.conf"), "listen_addresses='*'\nport=25432\t# primary port\n")
+
+		defer rsync.SetRsyncCommand(exec.Command)
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(agent.Success, func(utility string, args ...string) {
+			if utility != "rsync" {
+				t.Errorf("got %q want rsync", utility)
+			}
+
+			expected := []string{"--archive", "--delete", "sdw1:/data/dbfast1/seg1/", mirrorDir}
+			if !reflect.DeepEqual(args[:4], expected) {
+				t.Errorf("got args %q want %q", args[:4], expected)
+			}
+
+			if !strings.Contains(strings.Join(args, " "), "--exclude pg_replslot/*") {
+				t.Errorf("expected args %q to exclude the replication slots", args)
+			}
+		}))
+
+		_, err := server.UpgradeMirror(context.Background(), &idl.UpgradeMirrorRequest{
+			Content:        0,
+			PrimaryHost:    "sdw1",
+			PrimaryDataDir: "/data/dbfast1/seg1",
+			PrimaryPort:    25432,
+			MirrorDataDir:  mirrorDir,
+			MirrorPort:     25435,
+			MirrorDbid:     4,
+			TargetVersion:  "6.15.0",
+			User:           "etl",
+			SslMode:        "require",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		dbid := testutils.MustReadFile(t, filepath.Join(mirrorDir, "internal.auto.conf"))
+		if dbid != "gp_dbid=4\n" {
+			t.Errorf("got internal.auto.conf %q want %q", dbid, "gp_dbid=4\n")
+		}
+
+		conf := testutils.MustReadFile(t, filepath.Join(mirrorDir, "postgresql.conf"))
+		expected := "listen_addresses='*'\nport=25435\t# primary port\n"
+		if conf != expected {
+			t.Errorf("got postgresql.conf %q want %q", conf, expected)
+		}
+
+		recovery := testutils.MustReadFile(t, filepath.Join(mirrorDir, "recovery.conf"))
+		for _, expected := range []string{"standby_mode = 'on'", "user=etl host=sdw1 port=25432 sslmode=require", "application_name=gp_walreceiver", "primary_slot_name = 'internal_wal_replication_slot'"} {
+			if !strings.Contains(recovery, expected) {
+				t.Errorf("expected recovery.conf %q to contain %q", recovery, expected)
+			}
+		}
+	})
+
+	t.Run("configures a 7X mirror to replicate using standby.signal", func(t *testing.T) {
+		mirrorDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, mirrorDir)
+
+		testutils.MustWriteToFile(t, filepath.Join(mirrorDir, "postgresql.conf"), "port=7000\n")
+		testutils.MustWriteToFile(t, filepath.Join(mirrorDir, "postgresql.auto.conf"), "# Do not edit this file manually!\n")
+
+		defer rsync.SetRsyncCommand(exec.Command)
+		rsync.SetRsyncCommand(exectest.NewCommand(agent.Success))
+
+		_, err := server.UpgradeMirror(context.Background(), &idl.UpgradeMirrorRequest{
+			Content:        0,
+			PrimaryHost:    "sdw1",
+			PrimaryDataDir: "/data/dbfast1/seg1",
+			PrimaryPort:    7000,
+			MirrorDataDir:  mirrorDir,
+			MirrorPort:     7003,
+			MirrorDbid:     4,
+			TargetVersion:  "7.0.0",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if _, err := os.Stat(filepath.Join(mirrorDir, "standby.signal")); err != nil {
+			t.Errorf("expected standby.signal to exist: %v", err)
+		}
+
+		if _, err := os.Stat(filepath.Join(mirrorDir, "recovery.conf")); !os.IsNotExist(err) {
+			t.Errorf("expected recovery.conf to not exist: %v", err)
+		}
+
+		auto := testutils.MustReadFile(t, filepath.Join(mirrorDir, "postgresql.auto.conf"))
+		for _, expected := range []string{"# Do not edit this file manually!\n", "primary_conninfo = '", "host=sdw1 port=7000 sslmode=prefer", "application_name=gp_walreceiver", "primary_slot_name = 'internal_wal_replication_slot'"} {
+			if !strings.Contains(auto, expected) {
+				t.Errorf("expected postgresql.auto.conf %q to contain %q", auto, expected)
+			}
+		}
+	})
+
+	t.Run("errors when copying the primary fails", func(t *testing.T) {
+		defer rsync.SetRsyncCommand(exec.Command)
+		rsync.SetRsyncCommand(exectest.NewCommand(agent.FailedRsync))
+
+		_, err := server.UpgradeMirror(context.Background(), &idl.UpgradeMirrorRequest{})
+
+		var rsyncErr rsync.RsyncError
+		if !errors.As(err, &rsyncErr) {
+			t.Errorf("got error %#v want type %T", err, rsyncErr)
+		}
+	})
+}
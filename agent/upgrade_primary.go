@@ -73,7 +73,23 @@ func performUpgrade(segment Segment, request *idl.UpgradePrimariesRequest) error
 		options = append(options, upgrade.WithLinkMode())
 	}
 
-	return upgrade.Run(segmentPair, semver.MustParse(request.TargetVersion), options...)
+	if request.CheckOnly {
+		return upgrade.Run(segmentPair, semver.MustParse(request.TargetVersion), options...)
+	}
+
+	if err := upgrade.MarkUpgradeStarted(segment.WorkDir, request.UseLinkMode); err != nil {
+		return xerrors.Errorf("record segment upgrade status: %w", err)
+	}
+
+	if err := upgrade.Run(segmentPair, semver.MustParse(request.TargetVersion), options...); err != nil {
+		return err
+	}
+
+	if err := upgrade.MarkUpgradeFinished(segment.WorkDir); err != nil {
+		return xerrors.Errorf("record segment upgrade status: %w", err)
+	}
+
+	return nil
 }
 
 func restoreBackup(request *idl.UpgradePrimariesRequest, segment Segment) error {
@@ -111,10 +127,18 @@ func RestoreTablespaces(request *idl.UpgradePrimariesRequest, segment Segment) e
 		targetDir := greenplum.GetTablespaceLocationForDbId(tablespace, int(segment.DBID))
 		sourceDir := greenplum.GetMasterTablespaceLocation(filepath.Dir(request.TablespacesMappingFilePath), int(oid))
 
+		// A relocated tablespace location may not exist yet.
+		if err := utils.System.MkdirAll(filepath.Dir(targetDir), 0700); err != nil {
+			return xerrors.Errorf("create tablespace location %q: %w", filepath.Dir(targetDir), err)
+		}
+
+		// A 6X source cluster uses the same dbId directory for its own
+		// GPDB_6_<catalogVersion> directory, so protect the top level entries
+		// not being copied from deletion.
 		options := []rsync.Option{
 			rsync.WithSources(sourceDir + string(os.PathSeparator)),
 			rsync.WithDestination(targetDir),
-			rsync.WithOptions("--archive", "--delete"),
+			rsync.WithOptions("--archive", "--delete", "--filter", "protect /*"),
 		}
 
 		if err := rsync.Rsync(options...); err != nil {
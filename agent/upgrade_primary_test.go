@@ -64,7 +64,7 @@ func TestRestoreTablespaces(t *testing.T) {
 
 		// all the args for multiple invocations of rsync
 		expectedRsyncArgs := []string{
-			"--archive", "--delete",
+			"--archive", "--delete", "--filter", "protect /*",
 			"/tmp/tablespaces/1663/1/", "/tmp/default/1663/2",
 		}
 
@@ -336,8 +336,28 @@ _gpupgrade_config_show()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--finalize-archived-source-master-datadir")
+    local_nonpersistent_flags+=("--finalize-archived-source-master-datadir")
+    flags+=("--finalize-end-time")
+    local_nonpersistent_flags+=("--finalize-end-time")
+    flags+=("--finalize-log-archive-dir")
+    local_nonpersistent_flags+=("--finalize-log-archive-dir")
+    flags+=("--finalize-start-time")
+    local_nonpersistent_flags+=("--finalize-start-time")
+    flags+=("--finalize-target-version")
+    local_nonpersistent_flags+=("--finalize-target-version")
+    flags+=("--gpinitsystem-config")
+    local_nonpersistent_flags+=("--gpinitsystem-config")
     flags+=("--id")
     local_nonpersistent_flags+=("--id")
+    flags+=("--revert-end-time")
+    local_nonpersistent_flags+=("--revert-end-time")
+    flags+=("--revert-log-archive-dir")
+    local_nonpersistent_flags+=("--revert-log-archive-dir")
+    flags+=("--revert-source-version")
+    local_nonpersistent_flags+=("--revert-source-version")
+    flags+=("--revert-start-time")
+    local_nonpersistent_flags+=("--revert-start-time")
     flags+=("--source-gphome")
     local_nonpersistent_flags+=("--source-gphome")
     flags+=("--target-datadir")
@@ -523,37 +543,113 @@ _gpupgrade_initialize()
     flags+=("--?")
     flags+=("-?")
     local_nonpersistent_flags+=("--?")
+    flags+=("--active-session-policy=")
+    two_word_flags+=("--active-session-policy")
+    local_nonpersistent_flags+=("--active-session-policy=")
+    flags+=("--active-session-wait=")
+    two_word_flags+=("--active-session-wait")
+    local_nonpersistent_flags+=("--active-session-wait=")
     flags+=("--agent-port=")
     two_word_flags+=("--agent-port")
     local_nonpersistent_flags+=("--agent-port=")
+    flags+=("--analyze-jobs=")
+    two_word_flags+=("--analyze-jobs")
+    local_nonpersistent_flags+=("--analyze-jobs=")
+    flags+=("--analyze-target-cluster")
+    local_nonpersistent_flags+=("--analyze-target-cluster")
+    flags+=("--application-name=")
+    two_word_flags+=("--application-name")
+    local_nonpersistent_flags+=("--application-name=")
     flags+=("--automatic")
     flags+=("-a")
     local_nonpersistent_flags+=("--automatic")
+    flags+=("--db-password-env=")
+    two_word_flags+=("--db-password-env")
+    local_nonpersistent_flags+=("--db-password-env=")
+    flags+=("--db-user=")
+    two_word_flags+=("--db-user")
+    local_nonpersistent_flags+=("--db-user=")
     flags+=("--disk-free-ratio=")
     two_word_flags+=("--disk-free-ratio")
     local_nonpersistent_flags+=("--disk-free-ratio=")
+    flags+=("--distribute")
+    local_nonpersistent_flags+=("--distribute")
+    flags+=("--distribute-gphome")
+    local_nonpersistent_flags+=("--distribute-gphome")
     flags+=("--file=")
     two_word_flags+=("--file")
     two_word_flags+=("-f")
     local_nonpersistent_flags+=("--file=")
+    flags+=("--gphome-allowlist=")
+    two_word_flags+=("--gphome-allowlist")
+    local_nonpersistent_flags+=("--gphome-allowlist=")
+    flags+=("--gpinitsystem-override=")
+    two_word_flags+=("--gpinitsystem-override")
+    local_nonpersistent_flags+=("--gpinitsystem-override=")
+    flags+=("--host-mapping=")
+    two_word_flags+=("--host-mapping")
+    local_nonpersistent_flags+=("--host-mapping=")
     flags+=("--hub-port=")
     two_word_flags+=("--hub-port")
     local_nonpersistent_flags+=("--hub-port=")
+    flags+=("--mirror-sync-timeout=")
+    two_word_flags+=("--mirror-sync-timeout")
+    local_nonpersistent_flags+=("--mirror-sync-timeout=")
+    flags+=("--mirror-upgrade-jobs=")
+    two_word_flags+=("--mirror-upgrade-jobs")
+    local_nonpersistent_flags+=("--mirror-upgrade-jobs=")
+    flags+=("--mirror-upgrade-strategy=")
+    two_word_flags+=("--mirror-upgrade-strategy")
+    local_nonpersistent_flags+=("--mirror-upgrade-strategy=")
     flags+=("--mode=")
     two_word_flags+=("--mode")
     local_nonpersistent_flags+=("--mode=")
+    flags+=("--pgpass-file=")
+    two_word_flags+=("--pgpass-file")
+    local_nonpersistent_flags+=("--pgpass-file=")
+    flags+=("--quiesce")
+    local_nonpersistent_flags+=("--quiesce")
     flags+=("--source-gphome=")
     two_word_flags+=("--source-gphome")
     local_nonpersistent_flags+=("--source-gphome=")
+    flags+=("--source-master-host=")
+    two_word_flags+=("--source-master-host")
+    local_nonpersistent_flags+=("--source-master-host=")
     flags+=("--source-master-port=")
     two_word_flags+=("--source-master-port")
     local_nonpersistent_flags+=("--source-master-port=")
+    flags+=("--sslcert=")
+    two_word_flags+=("--sslcert")
+    local_nonpersistent_flags+=("--sslcert=")
+    flags+=("--sslkey=")
+    two_word_flags+=("--sslkey")
+    local_nonpersistent_flags+=("--sslkey=")
+    flags+=("--sslmode=")
+    two_word_flags+=("--sslmode")
+    local_nonpersistent_flags+=("--sslmode=")
+    flags+=("--sslrootcert=")
+    two_word_flags+=("--sslrootcert")
+    local_nonpersistent_flags+=("--sslrootcert=")
+    flags+=("--tablespace-mapping-file=")
+    two_word_flags+=("--tablespace-mapping-file")
+    local_nonpersistent_flags+=("--tablespace-mapping-file=")
+    flags+=("--target-datadir-base=")
+    two_word_flags+=("--target-datadir-base")
+    local_nonpersistent_flags+=("--target-datadir-base=")
     flags+=("--target-gphome=")
     two_word_flags+=("--target-gphome")
     local_nonpersistent_flags+=("--target-gphome=")
     flags+=("--temp-port-range=")
     two_word_flags+=("--temp-port-range")
     local_nonpersistent_flags+=("--temp-port-range=")
+    flags+=("--update-extensions")
+    local_nonpersistent_flags+=("--update-extensions")
+    flags+=("--update-extensions-allow=")
+    two_word_flags+=("--update-extensions-allow")
+    local_nonpersistent_flags+=("--update-extensions-allow=")
+    flags+=("--update-extensions-deny=")
+    two_word_flags+=("--update-extensions-deny")
+    local_nonpersistent_flags+=("--update-extensions-deny=")
     flags+=("--use-hba-hostnames")
     local_nonpersistent_flags+=("--use-hba-hostnames")
     flags+=("--verbose")
@@ -643,6 +739,10 @@ _gpupgrade_revert()
     flags+=("--?")
     flags+=("-?")
     local_nonpersistent_flags+=("--?")
+    flags+=("--checksum")
+    local_nonpersistent_flags+=("--checksum")
+    flags+=("--plan")
+    local_nonpersistent_flags+=("--plan")
     flags+=("--verbose")
     flags+=("-v")
     local_nonpersistent_flags+=("--verbose")
@@ -0,0 +1,71 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func RevertPlan(client idl.CliToHubClient) (*idl.RevertPlanReply, error) {
+	reply, err := client.GetRevertPlan(context.Background(), &idl.RevertPlanRequest{})
+	if err != nil {
+		return nil, xerrors.Errorf("get revert plan: %w", err)
+	}
+
+	return reply, nil
+}
+
+// FormatRevertPlan describes the substeps revert will run, along with the
+// directories each substep deletes, rsyncs, restores or archives. The substeps
+// run by the CLI after the hub has reverted are always included.
+func FormatRevertPlan(plan *idl.RevertPlanReply) string {
+	var b strings.Builder
+	b.WriteString("Revert will carry out the following steps:\n")
+
+	var t tabwriter.Writer
+	t.Init(&b, 0, 0, 2, ' ', 0)
+
+	var deleteBytes, rsyncBytes uint64
+	for _, substep := range plan.GetSubsteps() {
+		fmt.Fprintf(&t, "\n - %s", SubstepDescriptions[substep.GetSubstep()].HelpText)
+		if substep.GetCompleted() {
+			fmt.Fprint(&t, " (completed, will be skipped)")
+		}
+		fmt.Fprintln(&t)
+
+		for _, action := range substep.GetActions() {
+			switch action.GetOperation() {
+			case idl.RevertAction_DELETE:
+				fmt.Fprintf(&t, "     delete\t%s:%s\t%s\n", action.GetHost(), action.GetDirectory(), FormatBytes(action.GetBytes()/1024))
+				deleteBytes += action.GetBytes()
+			case idl.RevertAction_RSYNC:
+				fmt.Fprintf(&t, "     rsync\t%s:%s -> %s:%s\t%s\n", action.GetSourceHost(), action.GetSourceDirectory(),
+					action.GetHost(), action.GetDirectory(), FormatBytes(action.GetBytes()/1024))
+				rsyncBytes += action.GetBytes()
+			case idl.RevertAction_RESTORE_PGCONTROL:
+				fmt.Fprintf(&t, "     restore pg_control\t%s:%s\t\n", action.GetHost(), action.GetDirectory())
+			case idl.RevertAction_ARCHIVE:
+				fmt.Fprintf(&t, "     archive\t%s:%s\t\n", action.GetHost(), action.GetDirectory())
+			}
+		}
+	}
+
+	for _, substep := range []idl.Substep{idl.Substep_STOP_HUB_AND_AGENTS, idl.Substep_DELETE_MASTER_STATEDIR} {
+		fmt.Fprintf(&t, "\n - %s\n", SubstepDescriptions[substep].HelpText)
+	}
+
+	t.Flush()
+
+	fmt.Fprintf(&b, "\nEstimated data to delete: %s\n", FormatBytes(deleteBytes/1024))
+	fmt.Fprintf(&b, "Estimated data to rsync:  %s\n", FormatBytes(rsyncBytes/1024))
+
+	return b.String()
+}
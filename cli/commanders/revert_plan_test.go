@@ -0,0 +1,51 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func TestFormatRevertPlan(t *testing.T) {
+	plan := &idl.RevertPlanReply{
+		Substeps: []*idl.RevertPlanSubstep{
+			{
+				Substep:   idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS,
+				Completed: true,
+				Actions: []*idl.RevertAction{
+					{Operation: idl.RevertAction_DELETE, Host: "mdw", Directory: "/data/qddir_upgrade", Bytes: 2 * 1024 * 1024},
+				},
+			},
+			{
+				Substep: idl.Substep_RESTORE_SOURCE_CLUSTER,
+				Actions: []*idl.RevertAction{
+					{Operation: idl.RevertAction_RSYNC, Host: "sdw1", Directory: "/data/dbfast1/seg1",
+						SourceHost: "sdw2", SourceDirectory: "/data/dbfast_mirror1/seg1", Bytes: 3 * 1024 * 1024},
+				},
+			},
+		},
+	}
+
+	output := commanders.FormatRevertPlan(plan)
+
+	expected := []string{
+		commanders.SubstepDescriptions[idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS].HelpText + " (completed, will be skipped)",
+		"mdw:/data/qddir_upgrade  2 MiB",
+		"sdw2:/data/dbfast_mirror1/seg1 -> sdw1:/data/dbfast1/seg1  3 MiB",
+		commanders.SubstepDescriptions[idl.Substep_STOP_HUB_AND_AGENTS].HelpText,
+		commanders.SubstepDescriptions[idl.Substep_DELETE_MASTER_STATEDIR].HelpText,
+		"Estimated data to delete: 2 MiB",
+		"Estimated data to rsync:  3 MiB",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(output, e) {
+			t.Errorf("expected output %q to contain %q", output, e)
+		}
+	}
+}
@@ -102,8 +102,8 @@ func Finalize(client idl.CliToHubClient, verbose bool) (idl.FinalizeResponse, er
 	return *finalizeResponse, nil
 }
 
-func Revert(client idl.CliToHubClient, verbose bool) (idl.RevertResponse, error) {
-	stream, err := client.Revert(context.Background(), &idl.RevertRequest{})
+func Revert(client idl.CliToHubClient, verbose bool, checksum bool) (idl.RevertResponse, error) {
+	stream, err := client.Revert(context.Background(), &idl.RevertRequest{Checksum: checksum})
 	if err != nil {
 		gplog.Error(err.Error())
 		return idl.RevertResponse{}, err
@@ -42,4 +42,17 @@ var SubstepDescriptions = map[idl.Substep]substepText{
 	idl.Substep_START_SOURCE_CLUSTER:                     substepText{"Starting source cluster...", "Start source cluster"},
 	idl.Substep_RESTORE_PGCONTROL:                        substepText{"Re-enabling source cluster...", "Re-enable source cluster"},
 	idl.Substep_RECOVERSEG_SOURCE_CLUSTER:                substepText{"Recovering source cluster mirrors...", "Recover source cluster mirrors"},
+	idl.Substep_ANALYZE_TARGET_CLUSTER:                   substepText{"Analyzing target cluster databases...", "Analyze target cluster databases (optional)"},
+	idl.Substep_CHECK_MIRRORS_AND_STANDBY:                substepText{"Checking mirror and standby master replication...", "Check mirror and standby master replication"},
+	idl.Substep_CHECK_TABLESPACE_RELOCATIONS:             substepText{"Checking tablespace relocations...", "Check tablespace relocations (optional)"},
+	idl.Substep_COPY_SOURCE_PRIMARIES:                    substepText{"Copying source primary segments to the target hosts...", "Copy source primary segments to the target hosts (optional)"},
+	idl.Substep_CHECK_ACTIVE_SESSIONS:                    substepText{"Checking active sessions on the source cluster...", "Check active sessions on the source cluster"},
+	idl.Substep_QUIESCE_SOURCE_CLUSTER:                   substepText{"Blocking client connections to the source cluster...", "Block client connections to the source cluster (optional)"},
+	idl.Substep_UNQUIESCE_SOURCE_CLUSTER:                 substepText{"Restoring client access to the source cluster...", "Restore client access to the source cluster (optional)"},
+	idl.Substep_MIGRATE_CONFIGURATION:                    substepText{"Migrating source cluster configuration to the target cluster...", "Migrate source cluster configuration to the target cluster"},
+	idl.Substep_CHECK_LOCALE_AND_ENCODING:                substepText{"Checking encoding, locale, and data checksums...", "Check encoding, locale, and data checksums"},
+	idl.Substep_CHECK_LIBRARIES:                          substepText{"Checking extensions and libraries on the target hosts...", "Check extensions and libraries on the target hosts"},
+	idl.Substep_UPDATE_EXTENSIONS:                        substepText{"Updating target cluster extensions...", "Update target cluster extensions (optional)"},
+	idl.Substep_CHECK_TARGET_GPHOME:                      substepText{"Checking target GPHOME installation on all hosts...", "Check target GPHOME installation on all hosts"},
+	idl.Substep_DISTRIBUTE:                               substepText{"Distributing gpupgrade to all hosts...", "Distribute gpupgrade to all hosts (optional)"},
 }
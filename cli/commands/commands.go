@@ -157,6 +157,16 @@ func createConfigShowSubcommand() *cobra.Command {
 	subShow.Flags().Bool("source-gphome", false, "show path for the source Greenplum installation")
 	subShow.Flags().Bool("target-gphome", false, "show path for the target Greenplum installation")
 	subShow.Flags().Bool("target-datadir", false, "show temporary data directory for target gpdb cluster")
+	subShow.Flags().Bool("gpinitsystem-config", false, "show the gpinitsystem_config used to create the target cluster")
+	subShow.Flags().Bool("finalize-target-version", false, "show the version of the finalized target cluster")
+	subShow.Flags().Bool("finalize-log-archive-dir", false, "show the directory the logs were archived to by finalize")
+	subShow.Flags().Bool("finalize-archived-source-master-datadir", false, "show the archived source master data directory")
+	subShow.Flags().Bool("finalize-start-time", false, "show the time finalize was started")
+	subShow.Flags().Bool("finalize-end-time", false, "show the time finalize completed")
+	subShow.Flags().Bool("revert-source-version", false, "show the version of the reverted source cluster")
+	subShow.Flags().Bool("revert-log-archive-dir", false, "show the directory the logs were archived to by revert")
+	subShow.Flags().Bool("revert-start-time", false, "show the time revert was started")
+	subShow.Flags().Bool("revert-end-time", false, "show the time revert completed")
 
 	return subShow
 }
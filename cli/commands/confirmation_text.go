@@ -15,15 +15,42 @@ gpupgrade initialize will perform a series of steps, including:
 gpupgrade log files can be found on all hosts in %s
 
 gpupgrade initialize will use these values from %s
-source_gphome:      %s
-target_gphome:      %s
-mode:               %s
-disk_free_ratio:    %.1f
-use_hba_hostnames:  %t
-source_master_port: %d
-temp_port_range:    %s
-hub_port:           %d
-agent_port:         %d
+source_gphome:           %s
+target_gphome:           %s
+mode:                    %s
+disk_free_ratio:         %.1f
+use_hba_hostnames:       %t
+source_master_port:      %d
+temp_port_range:         %s
+hub_port:                %d
+agent_port:              %d
+analyze_target_cluster:  %t
+analyze_jobs:            %d
+update_extensions:       %t
+update_extensions_allow: %s
+update_extensions_deny:  %s
+gphome_allowlist:        %s
+distribute:              %t
+distribute_gphome:       %t
+mirror_upgrade_strategy: %s
+mirror_upgrade_jobs:     %d
+mirror_sync_timeout:     %d
+active_session_policy:   %s
+active_session_wait:     %d
+quiesce:                 %t
+tablespace_mapping_file: %s
+target_datadir_base:     %s
+host_mapping:            %s
+gpinitsystem_overrides:  %s
+source_master_host:      %s
+db_user:                 %s
+pgpass_file:             %s
+db_password_env:         %s
+sslmode:                 %s
+sslcert:                 %s
+sslkey:                  %s
+sslrootcert:             %s
+application_name:        %s
 
 You will still have the opportunity to revert the cluster to its original state 
 after this step.
@@ -67,6 +94,8 @@ gpupgrade finalize will perform a series of steps, including:
  - Update target master configuration files
  - Upgrade standby master
  - Upgrade mirror segments
+ - Update target cluster extensions (if enabled)
+ - Analyze target cluster databases (if enabled)
 
 gpupgrade log files can be found on all hosts in %s
 
@@ -26,29 +26,42 @@ func init() {
 	InitializeHelp = GenerateHelpString(initializeHelp, []idl.Substep{
 		idl.Substep_START_HUB,
 		idl.Substep_SAVING_SOURCE_CLUSTER_CONFIG,
+		idl.Substep_DISTRIBUTE,
 		idl.Substep_START_AGENTS,
+		idl.Substep_CHECK_TARGET_GPHOME,
+		idl.Substep_CHECK_TABLESPACE_RELOCATIONS,
+		idl.Substep_CHECK_LOCALE_AND_ENCODING,
+		idl.Substep_CHECK_LIBRARIES,
 		idl.Substep_CHECK_DISK_SPACE,
 		idl.Substep_GENERATE_TARGET_CONFIG,
 		idl.Substep_INIT_TARGET_CLUSTER,
 		idl.Substep_SHUTDOWN_TARGET_CLUSTER,
 		idl.Substep_BACKUP_TARGET_MASTER,
 		idl.Substep_CHECK_UPGRADE,
+		idl.Substep_QUIESCE_SOURCE_CLUSTER,
 	})
 	ExecuteHelp = GenerateHelpString(executeHelp, []idl.Substep{
+		idl.Substep_CHECK_ACTIVE_SESSIONS,
 		idl.Substep_SHUTDOWN_SOURCE_CLUSTER,
 		idl.Substep_UPGRADE_MASTER,
 		idl.Substep_COPY_MASTER,
+		idl.Substep_COPY_SOURCE_PRIMARIES,
 		idl.Substep_UPGRADE_PRIMARIES,
+		idl.Substep_MIGRATE_CONFIGURATION,
 		idl.Substep_START_TARGET_CLUSTER,
 	})
 	FinalizeHelp = GenerateHelpString(finalizeHelp, []idl.Substep{
 		idl.Substep_SHUTDOWN_TARGET_CLUSTER,
 		idl.Substep_UPDATE_TARGET_CATALOG_AND_CLUSTER_CONFIG,
+		idl.Substep_UNQUIESCE_SOURCE_CLUSTER,
 		idl.Substep_UPDATE_DATA_DIRECTORIES,
 		idl.Substep_UPDATE_TARGET_CONF_FILES,
 		idl.Substep_START_TARGET_CLUSTER,
 		idl.Substep_UPGRADE_STANDBY,
 		idl.Substep_UPGRADE_MIRRORS,
+		idl.Substep_CHECK_MIRRORS_AND_STANDBY,
+		idl.Substep_UPDATE_EXTENSIONS,
+		idl.Substep_ANALYZE_TARGET_CLUSTER,
 		idl.Substep_ARCHIVE_LOG_DIRECTORIES,
 		idl.Substep_DELETE_SEGMENT_STATEDIRS,
 		idl.Substep_STOP_HUB_AND_AGENTS,
@@ -62,6 +75,7 @@ func init() {
 		idl.Substep_RESTORE_SOURCE_CLUSTER,
 		idl.Substep_START_SOURCE_CLUSTER,
 		idl.Substep_RECOVERSEG_SOURCE_CLUSTER,
+		idl.Substep_UNQUIESCE_SOURCE_CLUSTER,
 		idl.Substep_ARCHIVE_LOG_DIRECTORIES,
 		idl.Substep_DELETE_SEGMENT_STATEDIRS,
 		idl.Substep_STOP_HUB_AND_AGENTS,
@@ -151,6 +165,12 @@ Optional Flags:
 
   -h, --help      displays help output for revert
   -v, --verbose   outputs detailed logs for revert
+      --plan      prints the steps revert will carry out, including the
+                  directories that will be deleted or restored, without
+                  running them
+      --checksum  compares file checksums rather than sizes and modification
+                  times when restoring the source cluster from its mirrors
+                  and standby. This is slower but detects all differences.
 
 NOTE: After running revert, you must execute data migration scripts. 
 Refer to documentation for instructions.
@@ -18,7 +18,9 @@ import (
 
 	"github.com/greenplum-db/gpupgrade/cli"
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/db/connURI"
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -30,8 +32,9 @@ const InitializeWarningMessage = `
 WARNING
 _______
 The source cluster does not have %s.
-After "gpupgrade execute" has been run, there will be no way to
-return the cluster to its original state using "gpupgrade revert".
+Since link mode is being used, after "gpupgrade execute" has been run
+there will be no way to return the cluster to its original state using
+"gpupgrade revert".
 
 If you do not already have a backup, we strongly recommend that
 you run "gpupgrade revert" now and take a backup of the cluster.
@@ -51,6 +54,33 @@ func initialize() *cobra.Command {
 	var ports string
 	var mode string
 	var useHbaHostnames bool
+	var analyzeTargetCluster bool
+	var analyzeJobs int
+	var updateExtensions bool
+	var updateExtensionsAllow string
+	var updateExtensionsDeny string
+	var gphomeAllowlist string
+	var distribute bool
+	var distributeGPHome bool
+	var mirrorUpgradeStrategy string
+	var mirrorUpgradeJobs int
+	var mirrorSyncTimeout int
+	var activeSessionPolicy string
+	var activeSessionWait int
+	var quiesce bool
+	var tablespaceMappingFile string
+	var targetDatadirBase string
+	var hostMapping string
+	var gpinitsystemOverrides []string
+	var sourceMasterHost string
+	var dbUser string
+	var pgpassFile string
+	var sslMode string
+	var dbPasswordEnv string
+	var sslCert string
+	var sslKey string
+	var sslRootCert string
+	var applicationName string
 
 	subInit := &cobra.Command{
 		Use:   "initialize",
@@ -142,11 +172,129 @@ func initialize() *cobra.Command {
 				)
 			}
 
+			if analyzeJobs < 1 || analyzeJobs > hub.MaxAnalyzeJobs {
+				// Match Cobra's option-error format.
+				return fmt.Errorf(
+					`invalid argument %d for "--analyze-jobs" flag: value must be between 1 and %d`,
+					analyzeJobs, hub.MaxAnalyzeJobs,
+				)
+			}
+
+			mirrorUpgradeStrategy, err = parseMirrorUpgradeStrategy(mirrorUpgradeStrategy)
+			if err != nil {
+				return err
+			}
+
+			if mirrorUpgradeJobs < 1 || mirrorUpgradeJobs > hub.MaxMirrorUpgradeJobs {
+				// Match Cobra's option-error format.
+				return fmt.Errorf(
+					`invalid argument %d for "--mirror-upgrade-jobs" flag: value must be between 1 and %d`,
+					mirrorUpgradeJobs, hub.MaxMirrorUpgradeJobs,
+				)
+			}
+
+			if mirrorSyncTimeout < 1 {
+				// Match Cobra's option-error format.
+				return fmt.Errorf(
+					`invalid argument %d for "--mirror-sync-timeout" flag: value must be at least 1`,
+					mirrorSyncTimeout,
+				)
+			}
+
+			activeSessionPolicy, err = parseActiveSessionPolicy(activeSessionPolicy)
+			if err != nil {
+				return err
+			}
+
+			if activeSessionWait < 1 {
+				// Match Cobra's option-error format.
+				return fmt.Errorf(
+					`invalid argument %d for "--active-session-wait" flag: value must be at least 1`,
+					activeSessionWait,
+				)
+			}
+
 			parsedPorts, err := parsePorts(ports)
 			if err != nil {
 				return err
 			}
 
+			var relocations []*idl.TablespaceRelocation
+			if tablespaceMappingFile != "" {
+				if linkMode {
+					return errors.New(`"--tablespace-mapping-file" can only be used with copy mode`)
+				}
+
+				relocations, err = parseTablespaceMappingFile(tablespaceMappingFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			var placement []*idl.DataDirPlacementRule
+			if targetDatadirBase != "" {
+				if linkMode {
+					return errors.New(`"--target-datadir-base" can only be used with copy mode`)
+				}
+
+				placement, err = parseTargetDatadirBase(targetDatadirBase)
+				if err != nil {
+					return err
+				}
+			}
+
+			var mapping map[string]string
+			if hostMapping != "" {
+				if linkMode {
+					return errors.New(`"--host-mapping" can only be used with copy mode`)
+				}
+
+				mapping, err = parseHostMapping(hostMapping)
+				if err != nil {
+					return err
+				}
+			}
+
+			overrides, err := parseGpinitsystemOverrides(gpinitsystemOverrides)
+			if err != nil {
+				return err
+			}
+
+			allowedExtensions, err := parseExtensionList("update-extensions-allow", updateExtensionsAllow)
+			if err != nil {
+				return err
+			}
+
+			deniedExtensions, err := parseExtensionList("update-extensions-deny", updateExtensionsDeny)
+			if err != nil {
+				return err
+			}
+
+			allowedGPHomeFiles, err := parseGPHomeAllowlist(gphomeAllowlist)
+			if err != nil {
+				return err
+			}
+
+			if sslMode != "" {
+				sslMode, err = parseSSLMode(sslMode)
+				if err != nil {
+					return err
+				}
+			}
+
+			// The hub may run in a different working directory, so make the
+			// connection files absolute.
+			for _, path := range []*string{&pgpassFile, &sslCert, &sslKey, &sslRootCert} {
+				if *path == "" {
+					continue
+				}
+
+				*path, err = filepath.Abs(*path)
+				if err != nil {
+					return err
+				}
+			}
+
 			logdir, err := utils.GetLogDir()
 			if err != nil {
 				return err
@@ -171,7 +319,12 @@ func initialize() *cobra.Command {
 			}
 
 			confirmationText := fmt.Sprintf(initializeConfirmationText, logdir, configPath, sourceGPHome, targetGPHome,
-				mode, diskFreeRatio, useHbaHostnames, sourcePort, ports, hubPort, agentPort)
+				mode, diskFreeRatio, useHbaHostnames, sourcePort, ports, hubPort, agentPort, analyzeTargetCluster, analyzeJobs,
+				updateExtensions, updateExtensionsAllow, updateExtensionsDeny, gphomeAllowlist,
+				distribute, distributeGPHome,
+				mirrorUpgradeStrategy, mirrorUpgradeJobs, mirrorSyncTimeout, activeSessionPolicy, activeSessionWait, quiesce, tablespaceMappingFile, targetDatadirBase, hostMapping,
+				strings.Join(gpinitsystemOverrides, ", "),
+				sourceMasterHost, dbUser, pgpassFile, dbPasswordEnv, sslMode, sslCert, sslKey, sslRootCert, applicationName)
 
 			st, err := commanders.NewStep(idl.Step_INITIALIZE,
 				&step.BufferedStreams{},
@@ -193,12 +346,17 @@ func initialize() *cobra.Command {
 					return nil
 				}
 
-				err := greenplum.VerifyCompatibleGPDBVersions(sourceGPHome, targetGPHome)
+				nextActions := fmt.Sprintf(`Please address the above issue and run "gpupgrade %s" again.`, strings.ToLower(idl.Step_INITIALIZE.String()))
+
+				path, err := greenplum.VerifyCompatibleGPDBVersions(sourceGPHome, targetGPHome)
 				if err != nil {
-					nextActions := fmt.Sprintf(`Please address the above issue and run "gpupgrade %s" again.`, strings.ToLower(idl.Step_INITIALIZE.String()))
 					return cli.NewNextActions(err, nextActions)
 				}
 
+				if linkMode && !path.LinkModeSupported {
+					return cli.NewNextActions(fmt.Errorf("link mode is not supported when upgrading from %s", path), nextActions)
+				}
+
 				return nil
 			})
 
@@ -218,13 +376,41 @@ func initialize() *cobra.Command {
 				}
 
 				request := &idl.InitializeRequest{
-					AgentPort:       int32(agentPort),
-					SourceGPHome:    filepath.Clean(sourceGPHome),
-					TargetGPHome:    filepath.Clean(targetGPHome),
-					SourcePort:      int32(sourcePort),
-					UseLinkMode:     linkMode,
-					UseHbaHostnames: useHbaHostnames,
-					Ports:           parsedPorts,
+					AgentPort:                int32(agentPort),
+					SourceGPHome:             filepath.Clean(sourceGPHome),
+					TargetGPHome:             filepath.Clean(targetGPHome),
+					SourcePort:               int32(sourcePort),
+					UseLinkMode:              linkMode,
+					UseHbaHostnames:          useHbaHostnames,
+					Ports:                    parsedPorts,
+					AnalyzeTargetCluster:     analyzeTargetCluster,
+					AnalyzeJobs:              int32(analyzeJobs),
+					MirrorUpgradeStrategy:    mirrorUpgradeStrategy,
+					MirrorUpgradeJobs:        int32(mirrorUpgradeJobs),
+					MirrorSyncTimeoutSeconds: int32(mirrorSyncTimeout),
+					ActiveSessionPolicy:      activeSessionPolicy,
+					ActiveSessionWaitMinutes: int32(activeSessionWait),
+					Quiesce:                  quiesce,
+					TablespaceRelocations:    relocations,
+					TargetDataDirPlacement:   placement,
+					HostMapping:              mapping,
+					SourceMasterHost:         sourceMasterHost,
+					DbUser:                   dbUser,
+					PgpassFile:               pgpassFile,
+					SslMode:                  sslMode,
+					DbPasswordEnv:            dbPasswordEnv,
+					SslCert:                  sslCert,
+					SslKey:                   sslKey,
+					SslRootCert:              sslRootCert,
+					ApplicationName:          applicationName,
+					GpinitsystemOverrides:    overrides,
+					UpdateExtensions:         updateExtensions,
+					UpdateExtensionsAllow:    allowedExtensions,
+					UpdateExtensionsDeny:     deniedExtensions,
+					GphomeManifestAllowlist:  allowedGPHomeFiles,
+					Distribute:               distribute,
+					DistributeTargetGPHome:   distributeGPHome,
+					SkipVersionCheck:         skipVersionCheck,
 				}
 				err = commanders.Initialize(client, request, verbose)
 				if err != nil {
@@ -258,7 +444,7 @@ func initialize() *cobra.Command {
 				return nil
 			})
 
-			warningMessage := InitializeWarningMessageIfAny(response)
+			warningMessage := InitializeWarningMessageIfAny(response, linkMode)
 
 			return st.Complete(fmt.Sprintf(`
 Initialize completed successfully.
@@ -287,6 +473,33 @@ To return the cluster to its original state, run "gpupgrade revert".`,
 	subInit.Flags().StringVar(&ports, "temp-port-range", "50432-65535", "set of ports to use when initializing the target cluster")
 	subInit.Flags().StringVar(&mode, "mode", "copy", "performs upgrade in either copy or link mode. Default is copy.")
 	subInit.Flags().BoolVar(&useHbaHostnames, "use-hba-hostnames", false, "use hostnames in pg_hba.conf")
+	subInit.Flags().BoolVar(&analyzeTargetCluster, "analyze-target-cluster", false, "regenerate optimizer statistics on the target cluster during finalize")
+	subInit.Flags().IntVar(&analyzeJobs, "analyze-jobs", hub.DefaultAnalyzeJobs, "the number of tables analyzed in parallel per database (from 1 - 10)")
+	subInit.Flags().BoolVar(&updateExtensions, "update-extensions", false, "update the target cluster extensions to their default versions during finalize")
+	subInit.Flags().StringVar(&updateExtensionsAllow, "update-extensions-allow", "", "comma separated extensions to update, defaulting to all of them")
+	subInit.Flags().StringVar(&updateExtensionsDeny, "update-extensions-deny", "", "comma separated extensions never to update")
+	subInit.Flags().BoolVar(&distribute, "distribute", false, "copy the gpupgrade binary of this host to all hosts before checking their versions")
+	subInit.Flags().BoolVar(&distributeGPHome, "distribute-gphome", false, "also copy the target GPHOME of this host to all hosts when distributing")
+	subInit.Flags().StringVar(&gphomeAllowlist, "gphome-allowlist", "", "comma separated patterns of target GPHOME files expected to differ across hosts")
+	subInit.Flags().StringVar(&mirrorUpgradeStrategy, "mirror-upgrade-strategy", hub.GpaddmirrorsStrategy, "upgrades the mirrors during finalize using either gpaddmirrors or rsync")
+	subInit.Flags().IntVar(&mirrorUpgradeJobs, "mirror-upgrade-jobs", hub.DefaultMirrorUpgradeJobs, "the number of mirrors copied in parallel per host by the rsync strategy (from 1 - 32)")
+	subInit.Flags().IntVar(&mirrorSyncTimeout, "mirror-sync-timeout", int(hub.DefaultMirrorSyncTimeout.Seconds()), "the number of seconds to wait for the upgraded mirrors to synchronize during finalize")
+	subInit.Flags().StringVar(&activeSessionPolicy, "active-session-policy", hub.FailPolicy, "what execute does about sessions connected to the source cluster: fail, wait, terminate, or proceed")
+	subInit.Flags().IntVar(&activeSessionWait, "active-session-wait", int(hub.DefaultActiveSessionWait.Minutes()), "the number of minutes the wait policy waits for sessions to disconnect")
+	subInit.Flags().BoolVar(&quiesce, "quiesce", false, "block client connections to the source cluster other than those of the upgrade until finalize or revert")
+	subInit.Flags().StringVar(&tablespaceMappingFile, "tablespace-mapping-file", "", "file mapping old tablespace location prefixes to new prefixes on each primary host (copy mode only)")
+	subInit.Flags().StringVar(&targetDatadirBase, "target-datadir-base", "", "base directories for the target data directories by role, host, or host/role (copy mode only)")
+	subInit.Flags().StringVar(&hostMapping, "host-mapping", "", "source segment hosts to upgrade onto new target hosts as source:target pairs (copy mode only)")
+	subInit.Flags().StringArrayVar(&gpinitsystemOverrides, "gpinitsystem-override", nil, "a gpinitsystem_config parameter for the target cluster as NAME=VALUE, which may be repeated")
+	subInit.Flags().StringVar(&sourceMasterHost, "source-master-host", "", "the source master host when the hub does not run on it")
+	subInit.Flags().StringVar(&dbUser, "db-user", "", "the user to connect to the source and target clusters as")
+	subInit.Flags().StringVar(&pgpassFile, "pgpass-file", "", "the password file used to connect to the source and target clusters")
+	subInit.Flags().StringVar(&dbPasswordEnv, "db-password-env", "", "the environment variable of the hub holding the password used to connect to the source and target clusters")
+	subInit.Flags().StringVar(&sslMode, "sslmode", "", "the SSL mode used to connect to the source and target clusters")
+	subInit.Flags().StringVar(&sslCert, "sslcert", "", "the client certificate used for SSL connections")
+	subInit.Flags().StringVar(&sslKey, "sslkey", "", "the private key of the client certificate")
+	subInit.Flags().StringVar(&sslRootCert, "sslrootcert", "", "the certificate authorities used to verify the server")
+	subInit.Flags().StringVar(&applicationName, "application-name", "", "the application_name reported by the gpupgrade connections")
 	subInit.Flags().BoolVar(&skipVersionCheck, "skip-version-check", false, "disable source and target version check")
 	subInit.Flags().MarkHidden("skip-version-check") //nolint
 	return addHelpToCommand(subInit, InitializeHelp)
@@ -349,6 +562,163 @@ func isLinkMode(input string) (bool, error) {
 	return false, fmt.Errorf("Invalid input %q. Please specify either %s.", input, strings.Join(choices, " or "))
 }
 
+func parseMirrorUpgradeStrategy(input string) (string, error) {
+	strategy := strings.ToLower(strings.TrimSpace(input))
+	for _, choice := range hub.MirrorUpgradeStrategies {
+		if strategy == choice {
+			return strategy, nil
+		}
+	}
+
+	return "", fmt.Errorf("Invalid mirror upgrade strategy %q. Please specify either %s.", input, strings.Join(hub.MirrorUpgradeStrategies, " or "))
+}
+
+func parseActiveSessionPolicy(input string) (string, error) {
+	policy := strings.ToLower(strings.TrimSpace(input))
+	for _, choice := range hub.ActiveSessionPolicies {
+		if policy == choice {
+			return policy, nil
+		}
+	}
+
+	return "", fmt.Errorf(`invalid argument %q for "--active-session-policy" flag: value must be one of %s`, input, strings.Join(hub.ActiveSessionPolicies, ", "))
+}
+
+func parseSSLMode(input string) (string, error) {
+	mode := strings.ToLower(strings.TrimSpace(input))
+	for _, choice := range connURI.SSLModes {
+		if mode == choice {
+			return mode, nil
+		}
+	}
+
+	return "", fmt.Errorf(`invalid argument %q for "--sslmode" flag: value must be one of %s`, input, strings.Join(connURI.SSLModes, ", "))
+}
+
+// parseTablespaceMappingFile reads the tablespace relocations from the mapping
+// file. See greenplum.ParseTablespaceRelocations for the file format.
+func parseTablespaceMappingFile(path string) ([]*idl.TablespaceRelocation, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	relocations, err := greenplum.ParseTablespaceRelocations(file)
+	if err != nil {
+		return nil, xerrors.Errorf("in file %q: %w", path, err)
+	}
+
+	var result []*idl.TablespaceRelocation
+	for _, relocation := range relocations {
+		result = append(result, &idl.TablespaceRelocation{
+			Host:      relocation.Host,
+			OldPrefix: relocation.OldPrefix,
+			NewPrefix: relocation.NewPrefix,
+		})
+	}
+
+	return result, nil
+}
+
+// parseTargetDatadirBase parses the placement of the target data directories.
+// See upgrade.ParseDataDirPlacement for the format.
+func parseTargetDatadirBase(value string) ([]*idl.DataDirPlacementRule, error) {
+	placement, err := upgrade.ParseDataDirPlacement(value)
+	if err != nil {
+		return nil, xerrors.Errorf(`invalid argument %q for "--target-datadir-base" flag: %w`, value, err)
+	}
+
+	var result []*idl.DataDirPlacementRule
+	for _, rule := range placement {
+		result = append(result, &idl.DataDirPlacementRule{
+			Host:    rule.Host,
+			Role:    rule.Role,
+			BaseDir: rule.BaseDir,
+		})
+	}
+
+	return result, nil
+}
+
+// parseHostMapping parses the source to target host mapping. See
+// greenplum.ParseHostMapping for the format.
+func parseHostMapping(value string) (map[string]string, error) {
+	mapping, err := greenplum.ParseHostMapping(value)
+	if err != nil {
+		return nil, xerrors.Errorf(`invalid argument %q for "--host-mapping" flag: %w`, value, err)
+	}
+
+	return mapping, nil
+}
+
+// parseExtensionList parses the comma separated extension names of the flag.
+func parseExtensionList(flag string, value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var extensions []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf(`invalid argument %q for "--%s" flag: extension names must not be empty`, value, flag)
+		}
+
+		extensions = append(extensions, name)
+	}
+
+	return extensions, nil
+}
+
+// parseGPHomeAllowlist parses the comma separated file patterns, relative to
+// the target GPHOME, which may differ across hosts.
+func parseGPHomeAllowlist(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			return nil, fmt.Errorf(`invalid argument %q for "--gphome-allowlist" flag: patterns must not be empty`, value)
+		}
+
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, xerrors.Errorf(`invalid argument %q for "--gphome-allowlist" flag: pattern %q: %w`, value, pattern, err)
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+// parseGpinitsystemOverrides parses the NAME=VALUE gpinitsystem parameters.
+func parseGpinitsystemOverrides(values []string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf(`invalid argument %q for "--gpinitsystem-override" flag: value must be of the form NAME=VALUE`, value)
+		}
+
+		if err := hub.ValidateInitsystemOverride(name); err != nil {
+			return nil, xerrors.Errorf(`invalid argument %q for "--gpinitsystem-override" flag: %w`, value, err)
+		}
+
+		if _, ok := overrides[name]; ok {
+			return nil, fmt.Errorf(`invalid argument %q for "--gpinitsystem-override" flag: %q is overridden more than once`, value, name)
+		}
+
+		overrides[name] = strings.TrimSpace(parts[1])
+	}
+
+	return overrides, nil
+}
+
 func addFlags(cmd *cobra.Command, flags map[string]string) error {
 	for name, value := range flags {
 		flag := cmd.Flag(name)
@@ -360,7 +730,14 @@ func addFlags(cmd *cobra.Command, flags map[string]string) error {
 			return xerrors.Errorf("The configuration parameter %q was not found in the list of supported parameters: %s.", name, strings.Join(names, ", "))
 		}
 
-		err := flag.Value.Set(value)
+		// The entries of a config file section are set one per line.
+		var err error
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			err = slice.Replace(strings.Split(value, "\n"))
+		} else {
+			err = flag.Value.Set(value)
+		}
+
 		if err != nil {
 			return xerrors.Errorf("set %q to %q: %w", name, value, err)
 		}
@@ -371,7 +748,14 @@ func addFlags(cmd *cobra.Command, flags map[string]string) error {
 	return nil
 }
 
-func InitializeWarningMessageIfAny(response idl.InitializeResponse) string {
+// InitializeWarningMessageIfAny warns when revert will not be possible after
+// execute. This is only the case in link mode, since copy mode never modifies
+// the source cluster's data directories.
+func InitializeWarningMessageIfAny(response idl.InitializeResponse, linkMode bool) string {
+	if !linkMode {
+		return ""
+	}
+
 	message := ""
 	if !response.GetHasStandby() && !response.GetHasMirrors() {
 		message = "standby and mirror segments"
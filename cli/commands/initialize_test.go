@@ -5,14 +5,18 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
 )
 
 func TestParsePorts(t *testing.T) {
@@ -137,6 +141,246 @@ func TestIsLinkMode(t *testing.T) {
 	}
 }
 
+func TestParseMirrorUpgradeStrategy(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"gpaddmirrors", hub.GpaddmirrorsStrategy},
+		{"rsync", hub.RsyncStrategy},
+		{" RSync\t", hub.RsyncStrategy},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("parses %q", c.input), func(t *testing.T) {
+			strategy, err := parseMirrorUpgradeStrategy(c.input)
+			if err != nil {
+				t.Errorf("unexpected error %#v", err)
+			}
+
+			if strategy != c.expected {
+				t.Errorf("got %q want %q", strategy, c.expected)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "basebackup"} {
+		t.Run(fmt.Sprintf("errors on %q", input), func(t *testing.T) {
+			_, err := parseMirrorUpgradeStrategy(input)
+			if err == nil {
+				t.Errorf("parseMirrorUpgradeStrategy(%q) returned nil instead of an error", input)
+			}
+		})
+	}
+}
+
+func TestParseTablespaceMappingFile(t *testing.T) {
+	t.Run("parses the tablespace relocations", func(t *testing.T) {
+		path := filepath.Join(testutils.GetTempDir(t, ""), "tablespace_mapping")
+		defer testutils.MustRemoveAll(t, filepath.Dir(path))
+
+		testutils.MustWriteToFile(t, path, "sdw1,/data/tablespaces,/mnt/tablespaces\n")
+
+		relocations, err := parseTablespaceMappingFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []*idl.TablespaceRelocation{
+			{Host: "sdw1", OldPrefix: "/data/tablespaces", NewPrefix: "/mnt/tablespaces"},
+		}
+		if !reflect.DeepEqual(relocations, expected) {
+			t.Errorf("got %v want %v", relocations, expected)
+		}
+	})
+
+	t.Run("errors when the mapping file is invalid", func(t *testing.T) {
+		path := filepath.Join(testutils.GetTempDir(t, ""), "tablespace_mapping")
+		defer testutils.MustRemoveAll(t, filepath.Dir(path))
+
+		testutils.MustWriteToFile(t, path, "sdw1,data/tablespaces,/mnt/tablespaces\n")
+
+		_, err := parseTablespaceMappingFile(path)
+		if err == nil || !strings.Contains(err.Error(), path) {
+			t.Errorf("got error %v, want error referencing %q", err, path)
+		}
+	})
+}
+
+func TestParseTargetDatadirBase(t *testing.T) {
+	t.Run("parses the placement of the target data directories", func(t *testing.T) {
+		placement, err := parseTargetDatadirBase("primary:/ssd/primary, sdw1/mirror:/ssd/{role}")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []*idl.DataDirPlacementRule{
+			{Role: "primary", BaseDir: "/ssd/primary"},
+			{Host: "sdw1", Role: "mirror", BaseDir: "/ssd/{role}"},
+		}
+		if !reflect.DeepEqual(placement, expected) {
+			t.Errorf("got %v want %v", placement, expected)
+		}
+	})
+
+	t.Run("errors when the placement is invalid", func(t *testing.T) {
+		_, err := parseTargetDatadirBase("primary:ssd")
+		if err == nil || !strings.Contains(err.Error(), "--target-datadir-base") {
+			t.Errorf("got error %v, want error referencing the flag", err)
+		}
+	})
+}
+
+func TestParseHostMapping(t *testing.T) {
+	t.Run("parses the host mapping", func(t *testing.T) {
+		mapping, err := parseHostMapping("sdw1:new-sdw1,sdw2:new-sdw2")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := map[string]string{"sdw1": "new-sdw1", "sdw2": "new-sdw2"}
+		if !reflect.DeepEqual(mapping, expected) {
+			t.Errorf("got %v want %v", mapping, expected)
+		}
+	})
+
+	t.Run("errors when the host mapping is invalid", func(t *testing.T) {
+		_, err := parseHostMapping("sdw1")
+		if err == nil || !strings.Contains(err.Error(), "--host-mapping") {
+			t.Errorf("got error %v, want error referencing the flag", err)
+		}
+	})
+}
+
+func TestParseActiveSessionPolicy(t *testing.T) {
+	t.Run("accepts the active session policies", func(t *testing.T) {
+		policy, err := parseActiveSessionPolicy(" Terminate ")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if policy != hub.TerminatePolicy {
+			t.Errorf("got %q want %q", policy, hub.TerminatePolicy)
+		}
+	})
+
+	t.Run("errors when the policy is invalid", func(t *testing.T) {
+		_, err := parseActiveSessionPolicy("ignore")
+		if err == nil || !strings.Contains(err.Error(), "--active-session-policy") {
+			t.Errorf("got error %v, want error referencing the flag", err)
+		}
+	})
+}
+
+func TestParseSSLMode(t *testing.T) {
+	t.Run("accepts the libpq ssl modes", func(t *testing.T) {
+		mode, err := parseSSLMode(" Verify-Full ")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if mode != "verify-full" {
+			t.Errorf("got %q want %q", mode, "verify-full")
+		}
+	})
+
+	t.Run("errors when the ssl mode is invalid", func(t *testing.T) {
+		_, err := parseSSLMode("strict")
+		if err == nil || !strings.Contains(err.Error(), "--sslmode") {
+			t.Errorf("got error %v, want error referencing the flag", err)
+		}
+	})
+}
+
+func TestParseExtensionList(t *testing.T) {
+	t.Run("parses the extension names", func(t *testing.T) {
+		extensions, err := parseExtensionList("update-extensions-allow", " postgis, madlib ")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []string{"postgis", "madlib"}
+		if !reflect.DeepEqual(extensions, expected) {
+			t.Errorf("got %q want %q", extensions, expected)
+		}
+	})
+
+	t.Run("returns no extensions when empty", func(t *testing.T) {
+		extensions, err := parseExtensionList("update-extensions-allow", "")
+		if err != nil || extensions != nil {
+			t.Errorf("got %q and error %v, want no extensions", extensions, err)
+		}
+	})
+
+	t.Run("errors when an extension name is empty", func(t *testing.T) {
+		_, err := parseExtensionList("update-extensions-deny", "postgis,,madlib")
+		if err == nil || !strings.Contains(err.Error(), "--update-extensions-deny") {
+			t.Errorf("got error %v, want error referencing the flag", err)
+		}
+	})
+}
+
+func TestParseGPHomeAllowlist(t *testing.T) {
+	t.Run("parses the patterns", func(t *testing.T) {
+		patterns, err := parseGPHomeAllowlist(" share/postgresql/timezone, *.log ")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []string{"share/postgresql/timezone", "*.log"}
+		if !reflect.DeepEqual(patterns, expected) {
+			t.Errorf("got %q want %q", patterns, expected)
+		}
+	})
+
+	t.Run("returns no patterns when empty", func(t *testing.T) {
+		patterns, err := parseGPHomeAllowlist("")
+		if err != nil || patterns != nil {
+			t.Errorf("got %q and error %v, want no patterns", patterns, err)
+		}
+	})
+
+	t.Run("errors on invalid patterns", func(t *testing.T) {
+		for _, value := range []string{"*.log,,*.pyc", "lib/[a-"} {
+			_, err := parseGPHomeAllowlist(value)
+			if err == nil || !strings.Contains(err.Error(), "--gphome-allowlist") {
+				t.Errorf("parseGPHomeAllowlist(%q) got error %v, want error referencing the flag", value, err)
+			}
+		}
+	})
+}
+
+func TestParseGpinitsystemOverrides(t *testing.T) {
+	t.Run("parses the gpinitsystem parameters", func(t *testing.T) {
+		overrides, err := parseGpinitsystemOverrides([]string{"ENCODING=UTF8", " LOCALE = en_US.utf8 "})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := map[string]string{"ENCODING": "UTF8", "LOCALE": "en_US.utf8"}
+		if !reflect.DeepEqual(overrides, expected) {
+			t.Errorf("got %v want %v", overrides, expected)
+		}
+	})
+
+	errorCases := map[string][]string{
+		"missing value":         {"ENCODING"},
+		"empty value":           {"ENCODING="},
+		"invalid name":          {"encoding=UTF8"},
+		"generated parameter":   {"PRIMARY_ARRAY=(sdw1~sdw1~6000~/data/primary/gpseg0~2~0)"},
+		"duplicated parameters": {"ENCODING=UTF8", "ENCODING=LATIN1"},
+	}
+
+	for name, values := range errorCases {
+		t.Run(fmt.Sprintf("errors on %s", name), func(t *testing.T) {
+			_, err := parseGpinitsystemOverrides(values)
+			if err == nil || !strings.Contains(err.Error(), "--gpinitsystem-override") {
+				t.Errorf("got error %v, want error referencing the flag", err)
+			}
+		})
+	}
+}
+
 func TestAddFlags(t *testing.T) {
 	t.Run("sets flags to correct value and marks them as changed", func(t *testing.T) {
 		var name string
@@ -199,6 +443,22 @@ func TestAddFlags(t *testing.T) {
 		}
 	})
 
+	t.Run("sets each line of a section as an entry of array flags", func(t *testing.T) {
+		var entries []string
+		cmd := cobra.Command{}
+		cmd.Flags().StringArrayVar(&entries, "entry", nil, "")
+
+		err := addFlags(&cmd, map[string]string{"entry": "ENCODING=UTF8\nHEAP_CHECKSUM=on"})
+		if err != nil {
+			t.Errorf("addFlags returned error %+v", err)
+		}
+
+		expected := []string{"ENCODING=UTF8", "HEAP_CHECKSUM=on"}
+		if !reflect.DeepEqual(entries, expected) {
+			t.Errorf("got %q want %q", entries, expected)
+		}
+	})
+
 	t.Run("errors when adding unknown parameter", func(t *testing.T) {
 		flags := map[string]string{
 			"unknown": "value",
@@ -252,9 +512,18 @@ func TestInitializeWarningMessageIfAny(t *testing.T) {
 	}
 
 	for _, c := range cases {
-		resultMessage := InitializeWarningMessageIfAny(c.input)
+		resultMessage := InitializeWarningMessageIfAny(c.input, true)
 		if resultMessage != c.expected {
 			t.Errorf("got %q, want %q", resultMessage, c.expected)
 		}
 	}
+
+	t.Run("does not warn in copy mode", func(t *testing.T) {
+		response := idl.InitializeResponse{HasMirrors: false, HasStandby: false}
+
+		resultMessage := InitializeWarningMessageIfAny(response, false)
+		if resultMessage != "" {
+			t.Errorf("got %q, want no warning", resultMessage)
+		}
+	})
 }
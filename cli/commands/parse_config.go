@@ -13,6 +13,13 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
+// sections maps the config file sections to the parameter collecting their
+// entries. Each entry of a section is passed as NAME=VALUE, one per line, to
+// the flag of that parameter.
+var sections = map[string]string{
+	"gpinitsystem": "gpinitsystem_override",
+}
+
 type parameter struct {
 	name  string
 	value string
@@ -42,7 +49,10 @@ func ParseConfig(config io.Reader) (map[string]string, error) {
 
 func parseParams(config io.Reader) (map[string]string, error) {
 	params := make(map[string]string)
+	sectionEntries := make(map[string][]string)
+	sectionNames := make(map[string]bool)
 
+	section := ""
 	scanner := bufio.NewScanner(config)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -50,11 +60,34 @@ func parseParams(config io.Reader) (map[string]string, error) {
 			continue
 		}
 
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(strings.Trim(line, "[]"))
+			if _, ok := sections[section]; !ok {
+				return nil, xerrors.Errorf("section %q is not supported", section)
+			}
+
+			continue
+		}
+
 		param, err := parseLine(line)
 		if err != nil {
 			return nil, err
 		}
 
+		if section != "" {
+			if param.value == "" {
+				return nil, xerrors.Errorf("no value found for %q in section %q", param.name, section)
+			}
+
+			if sectionNames[section+"."+param.name] {
+				return nil, xerrors.Errorf("%q declared more than once in section %q", param.name, section)
+			}
+
+			sectionNames[section+"."+param.name] = true
+			sectionEntries[section] = append(sectionEntries[section], param.name+"="+param.value)
+			continue
+		}
+
 		if _, ok := params[param.name]; ok {
 			return nil, xerrors.Errorf("parameter %q declared more than once", param.name)
 		}
@@ -66,6 +99,15 @@ func parseParams(config io.Reader) (map[string]string, error) {
 		return nil, xerrors.Errorf("scanning config: %w", err)
 	}
 
+	for section, entries := range sectionEntries {
+		name := sections[section]
+		if _, ok := params[name]; ok {
+			return nil, xerrors.Errorf("parameter %q declared in addition to section %q", name, section)
+		}
+
+		params[name] = strings.Join(entries, "\n")
+	}
+
 	return params, nil
 }
 
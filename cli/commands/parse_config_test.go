@@ -81,6 +81,12 @@ func TestConfig(t *testing.T) {
 			parameter:   "name",
 			expected:    `value`,
 		},
+		{
+			description: "collects the entries of a section",
+			config:      "name = value\n[gpinitsystem]\nENCODING = UTF8\nHEAP_CHECKSUM=on # comment",
+			parameter:   "gpinitsystem-override",
+			expected:    "ENCODING=UTF8\nHEAP_CHECKSUM=on",
+		},
 	}
 
 	for _, c := range cases {
@@ -137,6 +143,22 @@ func TestConfig(t *testing.T) {
 			description: "parameter value is empty without equal sign and inline comment containing an equal sign",
 			config:      "name # comment name = ",
 		},
+		{
+			description: "section is not supported",
+			config:      "[unknown]\nname = value",
+		},
+		{
+			description: "section entry is specified multiple times",
+			config:      "[gpinitsystem]\nENCODING = UTF8\nENCODING = LATIN1",
+		},
+		{
+			description: "section entry value is empty",
+			config:      "[gpinitsystem]\nENCODING = ",
+		},
+		{
+			description: "section parameter is also specified",
+			config:      "gpinitsystem_override = ENCODING=UTF8\n[gpinitsystem]\nENCODING = UTF8",
+		},
 	}
 
 	for _, c := range errorCases {
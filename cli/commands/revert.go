@@ -19,12 +19,29 @@ import (
 func revert() *cobra.Command {
 	var verbose bool
 	var nonInteractive bool
+	var plan bool
+	var checksum bool
 
 	cmd := &cobra.Command{
 		Use:   "revert",
 		Short: "reverts the upgrade and returns the cluster to its original state",
 		Long:  RevertHelp,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if plan {
+				client, err := connectToHub()
+				if err != nil {
+					return err
+				}
+
+				reply, err := commanders.RevertPlan(client)
+				if err != nil {
+					return err
+				}
+
+				fmt.Print(commanders.FormatRevertPlan(reply))
+				return nil
+			}
+
 			var response idl.RevertResponse
 
 			logdir, err := utils.GetLogDir()
@@ -55,7 +72,7 @@ func revert() *cobra.Command {
 					return err
 				}
 
-				response, err = commanders.Revert(client, verbose)
+				response, err = commanders.Revert(client, verbose, checksum)
 				if err != nil {
 					return err
 				}
@@ -97,6 +114,8 @@ To restart the upgrade, run "gpupgrade initialize" again.`,
 	}
 
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "print the output stream from all substeps")
+	cmd.Flags().BoolVar(&plan, "plan", false, "print the steps revert will carry out without running them")
+	cmd.Flags().BoolVar(&checksum, "checksum", false, "use checksums to determine which files to restore from the mirrors and standby")
 	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt for confirmation to proceed")
 	cmd.Flags().MarkHidden("non-interactive") //nolint
 
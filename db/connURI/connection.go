@@ -4,7 +4,10 @@
 package connURI
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"os"
 
 	"github.com/blang/semver/v4"
 )
@@ -18,25 +21,125 @@ import (
 type Conn struct {
 	sourceVersion semver.Version
 	targetVersion semver.Version
+
+	// defaults are applied to every URI before the options of the call, and
+	// hold the master host and authentication settings.
+	defaults optionList
 }
 
-func Connection(sourceVersion semver.Version, targetVersion semver.Version) *Conn {
+// DefaultHost is used when no master host is configured, which is the case
+// when the hub runs on the master host.
+const DefaultHost = "localhost"
+
+// Connection returns a Conn for the source and target versions. The defaults
+// such as Host, User, PassFile, PasswordEnv, and SSLMode apply to every URI.
+func Connection(sourceVersion semver.Version, targetVersion semver.Version, defaults ...Option) *Conn {
 	conn := new(Conn)
 	conn.sourceVersion = sourceVersion
 	conn.targetVersion = targetVersion
+	conn.defaults = *newOptionList(defaults...)
 
 	return conn
 }
 
+func (c *Conn) SourceVersion() semver.Version {
+	return c.sourceVersion
+}
+
+func (c *Conn) TargetVersion() semver.Version {
+	return c.targetVersion
+}
+
+// Host returns the host of the master, which is DefaultHost unless configured.
+func (c *Conn) Host() string {
+	if c.defaults.host == "" {
+		return DefaultHost
+	}
+
+	return c.defaults.host
+}
+
+// User returns the configured database user. When empty the user of the
+// current process is used.
+func (c *Conn) User() string {
+	return c.defaults.user
+}
+
+// PassFile returns the configured pgpass file providing the password. The
+// driver does not accept it as part of a URI, so it is used by setting
+// PGPASSFILE instead.
+func (c *Conn) PassFile() string {
+	return c.defaults.passFile
+}
+
+// SSLMode returns the configured sslmode.
+func (c *Conn) SSLMode() string {
+	return c.defaults.sslMode
+}
+
+// Password returns the password read from the configured PasswordEnv
+// environment variable. When empty the pgpass file provides the password.
+func (c *Conn) Password() string {
+	return c.defaults.password()
+}
+
+// Params returns the configured SSL and application_name URI parameters.
+func (c *Conn) Params() url.Values {
+	return c.defaults.params()
+}
+
+// EnvKeys are the libpq environment variables set by Env.
+var EnvKeys = []string{"PGUSER", "PGPASSFILE", "PGPASSWORD", "PGSSLMODE", "PGSSLCERT", "PGSSLKEY", "PGSSLROOTCERT", "PGAPPNAME"}
+
+// Env returns the configured settings as libpq environment variables in the
+// key=value format of os/exec.Cmd.Env, such that the Greenplum utilities run
+// by gpupgrade connect the same way as gpupgrade itself.
+func (c *Conn) Env() []string {
+	values := []string{c.defaults.user, c.defaults.passFile, c.Password(), c.defaults.sslMode,
+		c.defaults.sslCert, c.defaults.sslKey, c.defaults.sslRootCert, c.defaults.applicationName}
+
+	var env []string
+	for i, key := range EnvKeys {
+		if values[i] != "" {
+			env = append(env, key+"="+values[i])
+		}
+	}
+
+	return env
+}
+
 func (c *Conn) URI(options ...Option) string {
-	opts := newOptionList(options...)
+	opts := c.defaults
+	for _, option := range options {
+		option(&opts)
+	}
 
 	version := c.sourceVersion
 	if opts.connectToTarget {
 		version = c.targetVersion
 	}
 
-	connURI := fmt.Sprintf("postgresql://localhost:%d/template1?search_path=", opts.port)
+	host := opts.host
+	if host == "" {
+		host = DefaultHost
+	}
+
+	if password := opts.password(); password != "" {
+		host = url.UserPassword(opts.user, password).String() + "@" + host
+	} else if opts.user != "" {
+		host = url.User(opts.user).String() + "@" + host
+	}
+
+	database := opts.database
+	if database == "" {
+		database = "template1"
+	}
+
+	connURI := fmt.Sprintf("postgresql://%s:%d/%s?search_path=", host, opts.port, url.PathEscape(database))
+
+	if params := opts.params(); len(params) > 0 {
+		connURI += "&" + params.Encode()
+	}
 
 	if opts.utilityMode {
 		if version.LT(semver.MustParse("7.0.0")) {
@@ -53,6 +156,17 @@ func (c *Conn) URI(options ...Option) string {
 	return connURI
 }
 
+// Redact returns the connection URI with any password replaced, such that it
+// can be included in errors and logs.
+func Redact(connURI string) string {
+	u, err := url.Parse(connURI)
+	if err != nil {
+		return "<unparseable connection URI>"
+	}
+
+	return u.Redacted()
+}
+
 type Option func(*optionList)
 
 func ToSource() Option {
@@ -85,11 +199,122 @@ func AllowSystemTableMods() Option {
 	}
 }
 
+// Database connects to the given database rather than template1.
+func Database(name string) Option {
+	return func(options *optionList) {
+		options.database = name
+	}
+}
+
+// Host connects to the master on the given host rather than DefaultHost.
+func Host(host string) Option {
+	return func(options *optionList) {
+		options.host = host
+	}
+}
+
+// User connects as the given database user.
+func User(user string) Option {
+	return func(options *optionList) {
+		options.user = user
+	}
+}
+
+// PassFile sets the pgpass file providing the password. See Conn.PassFile.
+func PassFile(path string) Option {
+	return func(options *optionList) {
+		options.passFile = path
+	}
+}
+
+// SSLModes are the libpq sslmode values.
+var SSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// SSLMode sets the libpq sslmode such as "require" or "verify-full".
+func SSLMode(mode string) Option {
+	return func(options *optionList) {
+		options.sslMode = mode
+	}
+}
+
+// PasswordEnv reads the password from the named environment variable of the
+// hub. The password itself is never persisted.
+func PasswordEnv(name string) Option {
+	return func(options *optionList) {
+		options.passwordEnv = name
+	}
+}
+
+// SSLCert sets the client certificate used for SSL connections.
+func SSLCert(path string) Option {
+	return func(options *optionList) {
+		options.sslCert = path
+	}
+}
+
+// SSLKey sets the private key of the client certificate.
+func SSLKey(path string) Option {
+	return func(options *optionList) {
+		options.sslKey = path
+	}
+}
+
+// SSLRootCert sets the certificate authorities used to verify the server.
+func SSLRootCert(path string) Option {
+	return func(options *optionList) {
+		options.sslRootCert = path
+	}
+}
+
+// ApplicationName sets the application_name reported by the connections.
+func ApplicationName(name string) Option {
+	return func(options *optionList) {
+		options.applicationName = name
+	}
+}
+
 type optionList struct {
 	connectToTarget      bool
 	port                 int
 	utilityMode          bool
 	allowSystemTableMods bool
+	database             string
+
+	host            string
+	user            string
+	passFile        string
+	passwordEnv     string
+	sslMode         string
+	sslCert         string
+	sslKey          string
+	sslRootCert     string
+	applicationName string
+}
+
+func (o *optionList) password() string {
+	if o.passwordEnv == "" {
+		return ""
+	}
+
+	return os.Getenv(o.passwordEnv)
+}
+
+func (o *optionList) params() url.Values {
+	params := make(url.Values)
+
+	for key, value := range map[string]string{
+		"sslmode":          o.sslMode,
+		"sslcert":          o.sslCert,
+		"sslkey":           o.sslKey,
+		"sslrootcert":      o.sslRootCert,
+		"application_name": o.applicationName,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+
+	return params
 }
 
 func newOptionList(opts ...Option) *optionList {
@@ -99,3 +324,62 @@ func newOptionList(opts ...Option) *optionList {
 	}
 	return o
 }
+
+// connJSON is the persisted form of a Conn, such that the hub retains the
+// versions and connection settings across restarts.
+type connJSON struct {
+	SourceVersion   string
+	TargetVersion   string
+	Host            string `json:",omitempty"`
+	User            string `json:",omitempty"`
+	PassFile        string `json:",omitempty"`
+	PasswordEnv     string `json:",omitempty"`
+	SSLMode         string `json:",omitempty"`
+	SSLCert         string `json:",omitempty"`
+	SSLKey          string `json:",omitempty"`
+	SSLRootCert     string `json:",omitempty"`
+	ApplicationName string `json:",omitempty"`
+}
+
+func (c *Conn) MarshalJSON() ([]byte, error) {
+	return json.Marshal(connJSON{
+		SourceVersion:   c.sourceVersion.String(),
+		TargetVersion:   c.targetVersion.String(),
+		Host:            c.defaults.host,
+		User:            c.defaults.user,
+		PassFile:        c.defaults.passFile,
+		PasswordEnv:     c.defaults.passwordEnv,
+		SSLMode:         c.defaults.sslMode,
+		SSLCert:         c.defaults.sslCert,
+		SSLKey:          c.defaults.sslKey,
+		SSLRootCert:     c.defaults.sslRootCert,
+		ApplicationName: c.defaults.applicationName,
+	})
+}
+
+func (c *Conn) UnmarshalJSON(data []byte) error {
+	var persisted connJSON
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return err
+	}
+
+	var versions [2]semver.Version
+	for i, version := range []string{persisted.SourceVersion, persisted.TargetVersion} {
+		if version == "" {
+			continue
+		}
+
+		var err error
+		versions[i], err = semver.Parse(version)
+		if err != nil {
+			return err
+		}
+	}
+
+	*c = *Connection(versions[0], versions[1],
+		Host(persisted.Host), User(persisted.User), PassFile(persisted.PassFile), PasswordEnv(persisted.PasswordEnv),
+		SSLMode(persisted.SSLMode), SSLCert(persisted.SSLCert), SSLKey(persisted.SSLKey),
+		SSLRootCert(persisted.SSLRootCert), ApplicationName(persisted.ApplicationName))
+
+	return nil
+}
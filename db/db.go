@@ -4,13 +4,16 @@
 package db
 
 import (
+	"net/url"
 	"os"
 	"os/user"
 
 	"github.com/greenplum-db/gp-common-go-libs/dbconn"
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq" //_ import for the side effect of having postgres driver available
 
+	"github.com/greenplum-db/gpupgrade/db/connURI"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
 
@@ -48,6 +51,54 @@ func NewDBConn(masterHost string, masterPort int, dbname string) *dbconn.DBConn
 	}
 }
 
+// NewDBConnWithSettings returns a DBConn to the master using the host, user,
+// password, and SSL settings of the connection. A nil conn connects to the
+// local host.
+func NewDBConnWithSettings(conn *connURI.Conn, masterPort int, dbname string) *dbconn.DBConn {
+	if conn == nil {
+		return NewDBConn(connURI.DefaultHost, masterPort, dbname)
+	}
+
+	dbConn := NewDBConn(conn.Host(), masterPort, dbname)
+	if conn.User() != "" {
+		dbConn.User = conn.User()
+	}
+
+	params := conn.Params()
+	if len(params) > 0 || conn.Password() != "" {
+		dbConn.Driver = settingsDriver{params: params, password: conn.Password()}
+	}
+
+	return dbConn
+}
+
+// settingsDriver adds the password and connection parameters to the URI built
+// by dbconn, replacing the sslmode=disable it hardcodes.
+type settingsDriver struct {
+	dbconn.GPDBDriver
+	params   url.Values
+	password string
+}
+
+func (d settingsDriver) Connect(driverName string, dataSourceName string) (*sqlx.DB, error) {
+	uri, err := url.Parse(dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.password != "" {
+		uri.User = url.UserPassword(uri.User.Username(), d.password)
+	}
+
+	query := uri.Query()
+	for key := range d.params {
+		query.Set(key, d.params.Get(key))
+	}
+	uri.RawQuery = query.Encode()
+
+	return d.GPDBDriver.Connect(driverName, uri.String())
+}
+
 func tryEnv(key string, defaultValue string) string {
 	val, ok := os.LookupEnv(key)
 	if !ok {
@@ -4,9 +4,14 @@
 package db
 
 import (
+	"net/url"
 	"os"
+	"reflect"
 	"testing"
 
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
 	"github.com/greenplum-db/gpupgrade/testutils"
 )
 
@@ -56,6 +61,53 @@ func TestNewDBConn(t *testing.T) {
 	})
 }
 
+func TestNewDBConnWithSettings(t *testing.T) {
+	t.Run("connects to the local host without settings", func(t *testing.T) {
+		conn := NewDBConnWithSettings(nil, 5432, "template1")
+		if conn.Host != connURI.DefaultHost {
+			t.Errorf("got host %q want %q", conn.Host, connURI.DefaultHost)
+		}
+	})
+
+	t.Run("uses the host, user, and sslmode of the settings", func(t *testing.T) {
+		settings := connURI.Connection(semver.MustParse("6.0.0"), semver.MustParse("7.0.0"),
+			connURI.Host("mdw"), connURI.User("gpadmin"), connURI.SSLMode("require"))
+
+		conn := NewDBConnWithSettings(settings, 5432, "template1")
+		if conn.Host != "mdw" {
+			t.Errorf("got host %q want %q", conn.Host, "mdw")
+		}
+
+		if conn.User != "gpadmin" {
+			t.Errorf("got user %q want %q", conn.User, "gpadmin")
+		}
+
+		expected := settingsDriver{params: url.Values{"sslmode": {"require"}}}
+		if !reflect.DeepEqual(conn.Driver, expected) {
+			t.Errorf("got driver %#v want %#v", conn.Driver, expected)
+		}
+	})
+
+	t.Run("uses the password and ssl settings", func(t *testing.T) {
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_TEST_PASSWORD", "secret")
+		defer resetEnv()
+
+		settings := connURI.Connection(semver.MustParse("6.0.0"), semver.MustParse("7.0.0"),
+			connURI.PasswordEnv("GPUPGRADE_TEST_PASSWORD"), connURI.SSLRootCert("/certs/root.crt"),
+			connURI.ApplicationName("gpupgrade"))
+
+		conn := NewDBConnWithSettings(settings, 5432, "template1")
+
+		expected := settingsDriver{
+			params:   url.Values{"sslrootcert": {"/certs/root.crt"}, "application_name": {"gpupgrade"}},
+			password: "secret",
+		}
+		if !reflect.DeepEqual(conn.Driver, expected) {
+			t.Errorf("got driver %#v want %#v", conn.Driver, expected)
+		}
+	})
+}
+
 func TestUserUtils(t *testing.T) {
 	t.Run("tryEnv returns environment variables", func(t *testing.T) {
 		expected := "val"
@@ -37,6 +37,7 @@ mv data-migration-scripts/migration_executor_sql.bash  %{buildroot}%{prefix}/usr
 mkdir -p %{buildroot}%{prefix}/usr/local/bin/greenplum/%{name}
 mv data-migration-scripts %{buildroot}%{prefix}/usr/local/bin/greenplum/%{name}
 mv gpupgrade_config %{buildroot}%{prefix}/usr/local/bin/greenplum/%{name}
+mv version_policy.json %{buildroot}%{prefix}/usr/local/bin/greenplum/%{name}
 mv gpupgrade.bash %{buildroot}%{prefix}/usr/local/bin/greenplum/%{name}
 mv open_source_licenses.txt %{buildroot}%{prefix}/usr/local/bin/greenplum/%{name}
 
@@ -57,5 +58,6 @@ mv open_source_licenses.txt %{buildroot}%{prefix}/usr/local/bin/greenplum/%{name
 
 %{prefix}/usr/local/bin/greenplum/%{name}/data-migration-scripts
 %config %{prefix}/usr/local/bin/greenplum/%{name}/gpupgrade_config
+%config %{prefix}/usr/local/bin/greenplum/%{name}/version_policy.json
 %{prefix}/usr/local/bin/greenplum/%{name}/gpupgrade.bash
 %{prefix}/usr/local/bin/greenplum/%{name}/open_source_licenses.txt
@@ -14,11 +14,10 @@ import (
 
 func TestAllowedVersions(t *testing.T) {
 	cases := []struct {
-		name          string
-		versions      []string
-		validator     semver.Range
-		validatorName string
-		expected      bool
+		name     string
+		versions []string
+		validate func(version semver.Version) error
+		expected bool
 	}{
 		{
 			"allowed source versions",
@@ -30,8 +29,10 @@ func TestAllowedVersions(t *testing.T) {
 				"6.15.1",
 				"6.50.1",
 			},
-			sourceVersionAllowed,
-			"sourceVersionAllowed",
+			func(version semver.Version) error {
+				_, err := DefaultVersionPolicy.Verify(version, semver.MustParse("6.15.0"))
+				return err
+			},
 			true,
 		}, {
 			"disallowed source versions",
@@ -44,8 +45,10 @@ func TestAllowedVersions(t *testing.T) {
 				"6.14.9",
 				"7.0.0",
 			},
-			sourceVersionAllowed,
-			"sourceVersionAllowed",
+			func(version semver.Version) error {
+				_, err := DefaultVersionPolicy.Verify(version, semver.MustParse("6.15.0"))
+				return err
+			},
 			false,
 		}, {
 			"allowed target versions",
@@ -54,8 +57,10 @@ func TestAllowedVersions(t *testing.T) {
 				"6.15.1",
 				"6.50.1",
 			},
-			targetVersionAllowed,
-			"targetVersionAllowed",
+			func(version semver.Version) error {
+				_, err := DefaultVersionPolicy.Verify(semver.MustParse("5.28.6"), version)
+				return err
+			},
 			true,
 		}, {
 			"disallowed target versions",
@@ -69,8 +74,10 @@ func TestAllowedVersions(t *testing.T) {
 				"6.14.0",
 				"7.0.0",
 			},
-			targetVersionAllowed,
-			"targetVersionAllowed",
+			func(version semver.Version) error {
+				_, err := DefaultVersionPolicy.Verify(semver.MustParse("5.28.6"), version)
+				return err
+			},
 			false,
 		},
 	}
@@ -78,11 +85,11 @@ func TestAllowedVersions(t *testing.T) {
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			for _, v := range c.versions {
-				ver := semver.MustParse(v)
-				actual := c.validator(ver)
+				err := c.validate(semver.MustParse(v))
 
+				actual := err == nil
 				if actual != c.expected {
-					t.Errorf("%s(%q) = %t, want %t", c.validatorName, v, actual, c.expected)
+					t.Errorf("version %q allowed = %t, want %t (error: %v)", v, actual, c.expected, err)
 				}
 			}
 		})
@@ -98,11 +105,15 @@ func TestValidateVersions(t *testing.T) {
 			gpdbVersion = LocalVersion
 		}()
 
-		err := VerifyCompatibleGPDBVersions("/does/not/matter", "/does/not/matter")
+		path, err := VerifyCompatibleGPDBVersions("/does/not/matter", "/does/not/matter")
 		if err != nil {
 			t.Errorf("got unexpected error %#v", err)
 		}
 
+		if path.Source != 6 || path.Target != 6 {
+			t.Errorf("got upgrade path %s want Greenplum 6 to Greenplum 6", path)
+		}
+
 	})
 }
 
@@ -127,8 +138,8 @@ func TestValidateVersionsErrorCases(t *testing.T) {
 			func(string) (semver.Version, error) {
 				return semver.MustParse("6.8.0"), nil
 			},
-			"source cluster version 6.8.0 is not supported.  The minimum required version is 6.15.0. We recommend the latest version.",
-			"target cluster version 6.8.0 is not supported.  The minimum required version is 6.15.0. We recommend the latest version.",
+			"source cluster version 6.8.0 is not supported when upgrading from Greenplum 6 to Greenplum 6.  The minimum required version is 6.15.0. We recommend the latest version.",
+			"target cluster version 6.8.0 is not supported when upgrading from Greenplum 6 to Greenplum 6.  The minimum required version is 6.15.0. We recommend the latest version.",
 		},
 		{
 			"fails when sourceVersion and targetVersion have unsupported major versions",
@@ -147,7 +158,7 @@ func TestValidateVersionsErrorCases(t *testing.T) {
 				gpdbVersion = LocalVersion
 			}()
 
-			err := VerifyCompatibleGPDBVersions("/does/not/matter", "/does/not/matter")
+			_, err := VerifyCompatibleGPDBVersions("/does/not/matter", "/does/not/matter")
 
 			// make sure both source and target produce an error and that they match
 			// the expected error string
@@ -10,6 +10,7 @@ import (
 
 	"github.com/greenplum-db/gp-common-go-libs/dbconn"
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/kballard/go-shellquote"
 	"github.com/pkg/errors"
 	"golang.org/x/xerrors"
 
@@ -36,6 +37,11 @@ type Cluster struct {
 
 	GPHome  string
 	Version dbconn.GPDBVersion
+
+	// RemoteMaster is set when gpupgrade does not run on the master host. The
+	// master utilities such as gpstart are then run on the master host over
+	// ssh.
+	RemoteMaster bool
 }
 
 // ClusterFromDB will create a Cluster by querying the passed DBConn for
@@ -294,7 +300,7 @@ func (c *Cluster) GetDirForContent(contentID int) string {
 }
 
 func (c *Cluster) Start(stream step.OutStreams) error {
-	return runStartStopCmd(stream, c.GPHome, fmt.Sprintf("gpstart -a -d %[1]s", c.MasterDataDir()), fmt.Sprintf("MASTER_DATA_DIRECTORY=%s", c.MasterDataDir()))
+	return c.runStartStopCmd(stream, fmt.Sprintf("gpstart -a -d %[1]s", c.MasterDataDir()), fmt.Sprintf("MASTER_DATA_DIRECTORY=%s", c.MasterDataDir()))
 }
 
 func (c *Cluster) Stop(stream step.OutStreams) error {
@@ -311,11 +317,11 @@ func (c *Cluster) Stop(stream step.OutStreams) error {
 		return errors.New("master is already stopped")
 	}
 
-	return runStartStopCmd(stream, c.GPHome, fmt.Sprintf("gpstop -a -d %[1]s", c.MasterDataDir()), fmt.Sprintf("MASTER_DATA_DIRECTORY=%s", c.MasterDataDir()))
+	return c.runStartStopCmd(stream, fmt.Sprintf("gpstop -a -d %[1]s", c.MasterDataDir()), fmt.Sprintf("MASTER_DATA_DIRECTORY=%s", c.MasterDataDir()))
 }
 
 func (c *Cluster) StartMasterOnly(stream step.OutStreams) error {
-	return runStartStopCmd(stream, c.GPHome, fmt.Sprintf("gpstart -m -a -d %[1]s", c.MasterDataDir()), fmt.Sprintf("MASTER_DATA_DIRECTORY=%s", c.MasterDataDir()))
+	return c.runStartStopCmd(stream, fmt.Sprintf("gpstart -m -a -d %[1]s", c.MasterDataDir()), fmt.Sprintf("MASTER_DATA_DIRECTORY=%s", c.MasterDataDir()))
 }
 
 func (c *Cluster) StopMasterOnly(stream step.OutStreams) error {
@@ -332,16 +338,16 @@ func (c *Cluster) StopMasterOnly(stream step.OutStreams) error {
 		return errors.New("master is already stopped")
 	}
 
-	return runStartStopCmd(stream, c.GPHome, fmt.Sprintf("gpstop -m -a -d %[1]s", c.MasterDataDir()), fmt.Sprintf("MASTER_DATA_DIRECTORY=%s", c.MasterDataDir()))
+	return c.runStartStopCmd(stream, fmt.Sprintf("gpstop -m -a -d %[1]s", c.MasterDataDir()), fmt.Sprintf("MASTER_DATA_DIRECTORY=%s", c.MasterDataDir()))
 }
 
-func runStartStopCmd(stream step.OutStreams, gphome, command string, env string) error {
+func (c *Cluster) runStartStopCmd(stream step.OutStreams, command string, env string) error {
 	commandWithEnv := fmt.Sprintf("source %[1]s/greenplum_path.sh && %[2]s %[1]s/bin/%[3]s",
-		gphome,
+		c.GPHome,
 		env,
 		command)
 
-	cmd := execCommand("bash", "-c", commandWithEnv)
+	cmd := c.masterCommand("bash", "-c", commandWithEnv)
 	gplog.Info("running command: %q", cmd)
 	cmd.Stdout = stream.Stdout()
 	cmd.Stderr = stream.Stderr()
@@ -351,11 +357,18 @@ func runStartStopCmd(stream step.OutStreams, gphome, command string, env string)
 // IsMasterRunning returns whether the cluster's master process is running.
 func (c *Cluster) IsMasterRunning(stream step.OutStreams) (bool, error) {
 	path := filepath.Join(c.MasterDataDir(), "postmaster.pid")
-	if !upgrade.PathExists(path) {
-		return false, nil
-	}
 
-	cmd := execCommand("pgrep", "-F", path)
+	var cmd *exec.Cmd
+	if c.RemoteMaster {
+		// A missing pid file also exits with 1 and is treated as not running.
+		cmd = c.masterCommand("bash", "-c", fmt.Sprintf("test -f %[1]s && pgrep -F %[1]s", shellquote.Join(path)))
+	} else {
+		if !upgrade.PathExists(path) {
+			return false, nil
+		}
+
+		cmd = execCommand("pgrep", "-F", path)
+	}
 
 	cmd.Stdout = stream.Stdout()
 	cmd.Stderr = stream.Stderr()
@@ -377,3 +390,13 @@ func (c *Cluster) IsMasterRunning(stream step.OutStreams) (bool, error) {
 
 	return true, nil
 }
+
+// masterCommand returns a command that runs on the master host, over ssh when
+// RemoteMaster is set.
+func (c *Cluster) masterCommand(name string, args ...string) *exec.Cmd {
+	if !c.RemoteMaster {
+		return execCommand(name, args...)
+	}
+
+	return execCommand("ssh", c.MasterHostname(), shellquote.Join(append([]string{name}, args...)...))
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// HostMapping maps the segment hosts of the source cluster to the hosts their
+// target segments are created on. Segments on hosts that are not mapped are
+// upgraded on their source host.
+type HostMapping map[string]string
+
+// ParseHostMapping parses a comma separated list of source:target hostname
+// pairs. For example:
+//
+//   sdw1:new-sdw1, sdw2:new-sdw2
+func ParseHostMapping(value string) (HostMapping, error) {
+	mapping := make(HostMapping)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 {
+			return nil, xerrors.Errorf("host mapping %q is not of the form source:target", entry)
+		}
+
+		source, target := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if source == "" || target == "" {
+			return nil, xerrors.Errorf("host mapping %q is missing a hostname", entry)
+		}
+
+		if source == target {
+			return nil, xerrors.Errorf("host mapping %q maps a host to itself", entry)
+		}
+
+		if _, ok := mapping[source]; ok {
+			return nil, xerrors.Errorf("host %s is mapped more than once", source)
+		}
+
+		mapping[source] = target
+	}
+
+	return mapping, nil
+}
+
+// TargetHost returns the host the segments of the source host are created on.
+func (m HostMapping) TargetHost(host string) string {
+	if target, ok := m[host]; ok {
+		return target
+	}
+
+	return host
+}
+
+// IsRemapped returns whether the segment is created on another host.
+func (m HostMapping) IsRemapped(seg SegConfig) bool {
+	return m.TargetHost(seg.Hostname) != seg.Hostname
+}
+
+// TargetHosts returns the sorted hosts the source hosts are mapped to.
+func (m HostMapping) TargetHosts() []string {
+	var hosts []string
+	seen := make(map[string]bool)
+	for _, target := range m {
+		if !seen[target] {
+			seen[target] = true
+			hosts = append(hosts, target)
+		}
+	}
+
+	sort.Strings(hosts)
+	return hosts
+}
+
+// Validate ensures every mapped host is a segment host of the source cluster
+// other than the master host, and that no target host is already used by the
+// source cluster. Since the target hosts do not contain any source segments,
+// the source cluster is left untouched.
+func (m HostMapping) Validate(source *Cluster) error {
+	sourceHosts := make(map[string]bool)
+	for _, seg := range source.SelectSegments(func(seg *SegConfig) bool { return true }) {
+		sourceHosts[seg.Hostname] = true
+	}
+
+	var hosts []string
+	for host := range m {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		switch {
+		case host == source.MasterHostname():
+			return xerrors.Errorf("mapping the master host %s to another host is not supported", host)
+		case !sourceHosts[host]:
+			return xerrors.Errorf("mapped host %s is not a host of the source cluster", host)
+		case sourceHosts[m[host]]:
+			return xerrors.Errorf("host %s cannot be mapped to %s which is a host of the source cluster", host, m[host])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+)
+
+func TestParseHostMapping(t *testing.T) {
+	t.Run("parses the host mapping", func(t *testing.T) {
+		mapping, err := greenplum.ParseHostMapping("sdw1:new-sdw1, sdw2 : new-sdw2,")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := greenplum.HostMapping{"sdw1": "new-sdw1", "sdw2": "new-sdw2"}
+		if !reflect.DeepEqual(mapping, expected) {
+			t.Errorf("got %v want %v", mapping, expected)
+		}
+	})
+
+	errCases := []struct {
+		name  string
+		value string
+	}{
+		{"missing separator", "sdw1"},
+		{"missing hostname", "sdw1:"},
+		{"host mapped to itself", "sdw1:sdw1"},
+		{"host mapped more than once", "sdw1:new-sdw1,sdw1:new-sdw2"},
+	}
+
+	for _, c := range errCases {
+		t.Run("errors on "+c.name, func(t *testing.T) {
+			_, err := greenplum.ParseHostMapping(c.value)
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestHostMapping(t *testing.T) {
+	cluster := greenplum.MustCreateCluster(t, []greenplum.SegConfig{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw2", Role: greenplum.MirrorRole},
+	})
+
+	t.Run("returns the target host of a segment", func(t *testing.T) {
+		mapping := greenplum.HostMapping{"sdw1": "new-sdw1"}
+
+		if host := mapping.TargetHost("sdw1"); host != "new-sdw1" {
+			t.Errorf("got %q want %q", host, "new-sdw1")
+		}
+
+		if host := mapping.TargetHost("sdw2"); host != "sdw2" {
+			t.Errorf("got %q want %q", host, "sdw2")
+		}
+
+		if !mapping.IsRemapped(cluster.Primaries[0]) || mapping.IsRemapped(cluster.Mirrors[0]) {
+			t.Errorf("expected only the primary to be remapped")
+		}
+	})
+
+	t.Run("accepts mappings of segment hosts to new hosts", func(t *testing.T) {
+		err := greenplum.HostMapping{"sdw1": "new-sdw1", "sdw2": "new-sdw2"}.Validate(cluster)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	errCases := []struct {
+		name     string
+		mapping  greenplum.HostMapping
+		expected string
+	}{
+		{"the master host", greenplum.HostMapping{"mdw": "new-mdw"}, "master host"},
+		{"an unknown host", greenplum.HostMapping{"sdw3": "new-sdw3"}, "not a host of the source cluster"},
+		{"a source host as target", greenplum.HostMapping{"sdw1": "sdw2"}, "which is a host of the source cluster"},
+	}
+
+	for _, c := range errCases {
+		t.Run("errors when mapping "+c.name, func(t *testing.T) {
+			err := c.mapping.Validate(cluster)
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("got error %v want %q", err, c.expected)
+			}
+		})
+	}
+}
@@ -5,12 +5,15 @@ package greenplum
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/kballard/go-shellquote"
 
+	"github.com/greenplum-db/gpupgrade/db/connURI"
 	"github.com/greenplum-db/gpupgrade/step"
 )
 
@@ -19,12 +22,18 @@ type Runner interface {
 }
 
 func NewRunner(c *Cluster, streams step.OutStreams) Runner {
-	return &runner{
+	r := &runner{
 		masterPort:          c.MasterPort(),
 		masterDataDirectory: c.MasterDataDir(),
 		gphome:              c.GPHome,
 		streams:             streams,
 	}
+
+	if c.RemoteMaster {
+		r.remoteHost = c.MasterHostname()
+	}
+
+	return r
 }
 
 func (e *runner) Run(utilityName string, arguments ...string) error {
@@ -36,9 +45,30 @@ func (e *runner) Run(utilityName string, arguments ...string) error {
 	withGreenplumPath := fmt.Sprintf("source %s/greenplum_path.sh && %s", e.gphome, script)
 	gplog.Debug(withGreenplumPath)
 
-	command := exec.Command("bash", "-c", withGreenplumPath)
-	command.Env = append(command.Env, fmt.Sprintf("%v=%v", "MASTER_DATA_DIRECTORY", e.masterDataDirectory))
-	command.Env = append(command.Env, fmt.Sprintf("%v=%v", "PGPORT", e.masterPort))
+	env := []string{
+		fmt.Sprintf("%v=%v", "MASTER_DATA_DIRECTORY", e.masterDataDirectory),
+		fmt.Sprintf("%v=%v", "PGPORT", e.masterPort),
+	}
+
+	// Connect the utilities with the database user, password, and SSL settings
+	// configured for gpupgrade.
+	for _, key := range connURI.EnvKeys {
+		if val, ok := os.LookupEnv(key); ok {
+			env = append(env, fmt.Sprintf("%v=%v", key, val))
+		}
+	}
+
+	var command *exec.Cmd
+	if e.remoteHost == "" {
+		command = execCommand("bash", "-c", withGreenplumPath)
+		command.Env = append(command.Env, env...)
+	} else {
+		// ssh does not forward the environment, so set it on the remote host.
+		// The script is sent over stdin rather than as an argument such that
+		// the password is not visible in the process list of either host.
+		command = execCommand("ssh", e.remoteHost, "bash -s")
+		command.Stdin = strings.NewReader(remoteScript(env, withGreenplumPath))
+	}
 
 	command.Stdout = e.streams.Stdout()
 	command.Stderr = e.streams.Stderr()
@@ -46,9 +76,23 @@ func (e *runner) Run(utilityName string, arguments ...string) error {
 	return command.Run()
 }
 
+// remoteScript exports the environment before running the script.
+func remoteScript(env []string, script string) string {
+	var lines []string
+	for _, entry := range env {
+		lines = append(lines, "export "+shellquote.Join(entry))
+	}
+
+	return strings.Join(append(lines, script), "\n") + "\n"
+}
+
 type runner struct {
 	gphome              string
 	masterDataDirectory string
 	masterPort          int
 	streams             step.OutStreams
+
+	// remoteHost is the master host the utilities are run on over ssh, when
+	// gpupgrade does not run on the master host.
+	remoteHost string
 }
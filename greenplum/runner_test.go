@@ -0,0 +1,74 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+// EchoStdin writes the script received over stdin to stdout.
+func EchoStdin() {
+	if _, err := io.Copy(os.Stdout, os.Stdin); err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	exectest.RegisterMains(
+		EchoStdin,
+	)
+}
+
+func TestRunner(t *testing.T) {
+	testlog.SetupLogger()
+
+	t.Run("sends the environment to a remote master over stdin", func(t *testing.T) {
+		if err := os.Setenv("PGPASSWORD", "s3cret pass"); err != nil {
+			t.Fatalf("setting PGPASSWORD: %v", err)
+		}
+		defer os.Unsetenv("PGPASSWORD")
+
+		execCommand = exectest.NewCommandWithVerifier(EchoStdin, func(name string, args ...string) {
+			if name != "ssh" {
+				t.Errorf("got command %q want ssh", name)
+			}
+
+			if strings.Contains(strings.Join(args, " "), "s3cret") {
+				t.Errorf("expected args %q to not contain the password", args)
+			}
+		})
+		defer ResetExecCommand()
+
+		cluster := MustCreateCluster(t, []SegConfig{
+			{ContentID: -1, DbID: 1, Port: 15432, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: PrimaryRole},
+		})
+		cluster.GPHome = "/usr/local/gpdb"
+		cluster.RemoteMaster = true
+
+		streams := &step.BufferedStreams{}
+		err := NewRunner(cluster, streams).Run("gpstart", "-a")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		script := streams.StdoutBuf.String()
+		for _, expected := range []string{
+			"export MASTER_DATA_DIRECTORY=/data/qddir/seg-1\n",
+			"export PGPORT=15432\n",
+			"export 'PGPASSWORD=s3cret pass'\n",
+			"source /usr/local/gpdb/greenplum_path.sh && /usr/local/gpdb/bin/gpstart -a\n",
+		} {
+			if !strings.Contains(script, expected) {
+				t.Errorf("expected script %q to contain %q", script, expected)
+			}
+		}
+	})
+}
@@ -233,4 +233,30 @@ func TestStartOrStopCluster(t *testing.T) {
 			t.Errorf("unexpected error %#v", err)
 		}
 	})
+
+	t.Run("runs the utilities on a remote master host over ssh", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mock, cleanup := greenplum.MockExecCommand(ctrl)
+		defer cleanup()
+
+		remote := greenplum.MustCreateCluster(t, []greenplum.SegConfig{
+			{ContentID: -1, DbID: 1, Port: 15432, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: "p"},
+		})
+		remote.GPHome = "/usr/local/source"
+		remote.RemoteMaster = true
+
+		pgrep := "'test -f /data/qddir/seg-1/postmaster.pid && pgrep -F /data/qddir/seg-1/postmaster.pid'"
+		gpstop := "'source /usr/local/source/greenplum_path.sh " +
+			"&& MASTER_DATA_DIRECTORY=/data/qddir/seg-1 /usr/local/source/bin/gpstop -a -d /data/qddir/seg-1'"
+
+		mock.EXPECT().Command("ssh", []string{"mdw", "bash -c " + pgrep})
+		mock.EXPECT().Command("ssh", []string{"mdw", "bash -c " + gpstop})
+
+		err := remote.Stop(step.DevNullStream)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
 }
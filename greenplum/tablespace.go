@@ -35,6 +35,29 @@ const tablespacesQuery = `
 			ON fsefsoid = spcfsoid
 		) upgrade_tablespace`
 
+// In 6X the tablespace directory of each segment is the tablespace location
+// followed by the segment's dbid. Since mirrors use the location of their
+// primary, the location is retrieved for each content and the dbid is stripped
+// to match the layout of 5X tablespace locations.
+const tablespacesQuery6X = `
+	SELECT
+		c.dbid,
+		t.oid,
+		t.spcname as name,
+		case when is_user_defined_tablespace then regexp_replace(l.tblspc_loc, '/[0-9]+$', '') else c.datadir end as location,
+		(is_user_defined_tablespace::int) as userdefined
+	FROM gp_segment_configuration c
+		CROSS JOIN (
+			SELECT
+				oid,
+				spcname,
+				(spcname not in ('pg_default', 'pg_global')) as is_user_defined_tablespace
+			FROM pg_tablespace
+		) t
+		LEFT JOIN LATERAL gp_tablespace_location(t.oid) l
+			ON l.gp_segment_id = c.content
+	ORDER BY c.dbid, t.oid`
+
 // map<tablespaceOid, tablespaceInfo>
 type SegmentTablespaces map[int]TablespaceInfo
 
@@ -64,6 +87,19 @@ func (t Tablespaces) GetMasterTablespaces() SegmentTablespaces {
 	return t[MasterDbid]
 }
 
+// HasUserDefined returns true if any segment has a user-defined tablespace.
+func (t Tablespaces) HasUserDefined() bool {
+	for _, segTablespaces := range t {
+		for _, tsInfo := range segTablespaces {
+			if tsInfo.IsUserDefined() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (t *TablespaceInfo) IsUserDefined() bool {
 	return t.UserDefined == 1
 }
@@ -77,14 +113,20 @@ func GetMasterTablespaceLocation(basePath string, oid int) string {
 }
 
 func GetTablespaceTuples(connection *dbconn.DBConn) (TablespaceTuples, error) {
-	if !connection.Version.Is("5") {
+	var query string
+	switch {
+	case connection.Version.Is("5"):
+		query = tablespacesQuery
+	case connection.Version.Is("6"):
+		query = tablespacesQuery6X
+	default:
 		return nil, errors.New("version not supported to retrieve tablespace information")
 	}
 
 	results := make(TablespaceTuples, 0)
-	err := connection.Select(&results, tablespacesQuery)
+	err := connection.Select(&results, query)
 	if err != nil {
-		return nil, xerrors.Errorf("tablespace query %q: %w", tablespacesQuery, err)
+		return nil, xerrors.Errorf("tablespace query %q: %w", query, err)
 	}
 
 	return results, nil
@@ -130,6 +172,9 @@ func (t TablespaceTuples) Write(w io.Writer) error {
 // 1. query the database to get tablespace information
 // 2. write the tablespace information to a file
 // 3. converts the tablespace information to an internal structure
+//
+// The file is passed to pg_upgrade as the old tablespaces file, so it always
+// has the source locations. Relocations only apply to the target tablespaces.
 func TablespacesFromDB(conn *dbconn.DBConn, tablespacesFile string) (Tablespaces, error) {
 	if err := conn.Connect(1); err != nil {
 		return nil, xerrors.Errorf("connect to cluster: %w", err)
@@ -152,3 +197,19 @@ func TablespacesFromDB(conn *dbconn.DBConn, tablespacesFile string) (Tablespaces
 
 	return NewTablespaces(tablespaceTuples), nil
 }
+
+// QueryTablespaces returns the tablespaces of the cluster without writing the
+// tablespace file, for upgrade paths which do not upgrade tablespaces.
+func QueryTablespaces(conn *dbconn.DBConn) (Tablespaces, error) {
+	if err := conn.Connect(1); err != nil {
+		return nil, xerrors.Errorf("connect to cluster: %w", err)
+	}
+	defer conn.Close()
+
+	tablespaceTuples, err := GetTablespaceTuples(conn)
+	if err != nil {
+		return nil, xerrors.Errorf("retrieve tablespace information: %w", err)
+	}
+
+	return NewTablespaces(tablespaceTuples), nil
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// TablespaceRelocation moves the user defined tablespaces of the segments on
+// a primary host whose location starts with OldPrefix to the same path under
+// NewPrefix.
+type TablespaceRelocation struct {
+	Host      string
+	OldPrefix string
+	NewPrefix string
+}
+
+type TablespaceRelocations []TablespaceRelocation
+
+// ParseTablespaceRelocations reads a tablespace mapping file. Each line of the
+// file contains a primary hostname, an old tablespace location prefix, and the
+// new prefix separated by commas. Lines starting with '#' are ignored.
+func ParseTablespaceRelocations(r io.Reader) (TablespaceRelocations, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, xerrors.Errorf("read tablespace mapping: %w", err)
+	}
+
+	var relocations TablespaceRelocations
+	seen := make(map[string]bool)
+	for _, record := range records {
+		relocation := TablespaceRelocation{
+			Host:      strings.TrimSpace(record[0]),
+			OldPrefix: strings.TrimSpace(record[1]),
+			NewPrefix: strings.TrimSpace(record[2]),
+		}
+
+		if err := relocation.validate(); err != nil {
+			return nil, xerrors.Errorf("invalid tablespace mapping %q: %w", strings.Join(record, ","), err)
+		}
+
+		relocation.OldPrefix = filepath.Clean(relocation.OldPrefix)
+		relocation.NewPrefix = filepath.Clean(relocation.NewPrefix)
+
+		key := relocation.Host + ":" + relocation.OldPrefix
+		if seen[key] {
+			return nil, xerrors.Errorf("duplicate tablespace mapping for %q on host %s", relocation.OldPrefix, relocation.Host)
+		}
+		seen[key] = true
+
+		relocations = append(relocations, relocation)
+	}
+
+	return relocations, nil
+}
+
+func (r TablespaceRelocation) validate() error {
+	if r.Host == "" {
+		return xerrors.New("missing hostname")
+	}
+
+	if !filepath.IsAbs(r.OldPrefix) || !filepath.IsAbs(r.NewPrefix) {
+		return xerrors.New("locations must be absolute paths")
+	}
+
+	if filepath.Clean(r.OldPrefix) == "/" || filepath.Clean(r.NewPrefix) == "/" {
+		return xerrors.New("locations must not be the root directory")
+	}
+
+	if filepath.Clean(r.OldPrefix) == filepath.Clean(r.NewPrefix) {
+		return xerrors.New("old and new locations are the same")
+	}
+
+	return nil
+}
+
+func (r TablespaceRelocation) String() string {
+	return fmt.Sprintf("%s:%s -> %s", r.Host, r.OldPrefix, r.NewPrefix)
+}
+
+// Matches returns true if the location is the old prefix or is within it.
+func (r TablespaceRelocation) Matches(location string) bool {
+	location = filepath.Clean(location)
+	return location == r.OldPrefix || strings.HasPrefix(location, r.OldPrefix+string(filepath.Separator))
+}
+
+// Relocate returns the location of a primary segment's tablespace after
+// applying the relocation with the longest matching prefix for its host. Only
+// primary segments are relocated. The master tablespaces are placed by
+// pg_upgrade, and the target mirrors are created at the tablespace locations
+// of their primaries.
+func (r TablespaceRelocations) Relocate(cluster *Cluster, dbID int, location string) string {
+	host, ok := primaryHost(cluster, dbID)
+	if !ok {
+		return location
+	}
+
+	var match *TablespaceRelocation
+	for i, relocation := range r {
+		if relocation.Host != host || !relocation.Matches(location) {
+			continue
+		}
+
+		if match == nil || len(relocation.OldPrefix) > len(match.OldPrefix) {
+			match = &r[i]
+		}
+	}
+
+	if match == nil {
+		return location
+	}
+
+	return filepath.Join(match.NewPrefix, strings.TrimPrefix(filepath.Clean(location), match.OldPrefix))
+}
+
+// primaryHost returns the host of the primary segment with the given dbID. It
+// returns false for the master and all other dbIDs.
+func primaryHost(cluster *Cluster, dbID int) (string, bool) {
+	for content, primary := range cluster.Primaries {
+		if content >= 0 && primary.DbID == dbID {
+			return primary.Hostname, true
+		}
+	}
+
+	return "", false
+}
+
+// Relocate returns a copy of the tablespaces with the user defined tablespace
+// locations relocated.
+func (t Tablespaces) Relocate(cluster *Cluster, relocations TablespaceRelocations) Tablespaces {
+	if len(relocations) == 0 {
+		return t
+	}
+
+	relocated := make(Tablespaces)
+	for dbID, segTablespaces := range t {
+		relocated[dbID] = make(SegmentTablespaces)
+		for oid, info := range segTablespaces {
+			if info.IsUserDefined() {
+				info.Location = relocations.Relocate(cluster, dbID, info.Location)
+			}
+
+			relocated[dbID][oid] = info
+		}
+	}
+
+	return relocated
+}
+
+// Unused returns the relocations that do not match the location of any user
+// defined tablespace, which likely indicates a mistake in the mapping file.
+func (r TablespaceRelocations) Unused(cluster *Cluster, tablespaces Tablespaces) TablespaceRelocations {
+	var unused TablespaceRelocations
+	for _, relocation := range r {
+		used := false
+		for dbID, segTablespaces := range tablespaces {
+			host, ok := primaryHost(cluster, dbID)
+			if !ok || host != relocation.Host {
+				continue
+			}
+
+			for _, info := range segTablespaces {
+				if info.IsUserDefined() && relocation.Matches(info.Location) {
+					used = true
+				}
+			}
+		}
+
+		if !used {
+			unused = append(unused, relocation)
+		}
+	}
+
+	return unused
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+)
+
+func TestParseTablespaceRelocations(t *testing.T) {
+	t.Run("parses the mapping file", func(t *testing.T) {
+		input := `# host,old prefix,new prefix
+sdw1,/data/tablespaces,/mnt/fast/tablespaces
+sdw2, /data/tablespaces/ , /mnt/fast/tablespaces/
+`
+		relocations, err := greenplum.ParseTablespaceRelocations(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := greenplum.TablespaceRelocations{
+			{Host: "sdw1", OldPrefix: "/data/tablespaces", NewPrefix: "/mnt/fast/tablespaces"},
+			{Host: "sdw2", OldPrefix: "/data/tablespaces", NewPrefix: "/mnt/fast/tablespaces"},
+		}
+		if !reflect.DeepEqual(relocations, expected) {
+			t.Errorf("got %+v want %+v", relocations, expected)
+		}
+	})
+
+	errCases := []struct {
+		name  string
+		input string
+	}{
+		{"missing fields", "sdw1,/data/tablespaces\n"},
+		{"missing hostname", ",/data/tablespaces,/mnt/tablespaces\n"},
+		{"relative old prefix", "sdw1,data/tablespaces,/mnt/tablespaces\n"},
+		{"relative new prefix", "sdw1,/data/tablespaces,mnt/tablespaces\n"},
+		{"root directory", "sdw1,/,/mnt/tablespaces\n"},
+		{"same locations", "sdw1,/data/tablespaces,/data/tablespaces/\n"},
+		{"duplicate mappings", "sdw1,/data/tablespaces,/mnt/a\nsdw1,/data/tablespaces,/mnt/b\n"},
+	}
+
+	for _, c := range errCases {
+		t.Run("errors on "+c.name, func(t *testing.T) {
+			_, err := greenplum.ParseTablespaceRelocations(strings.NewReader(c.input))
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestTablespaceRelocations(t *testing.T) {
+	cluster := greenplum.MustCreateCluster(t, []greenplum.SegConfig{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 1, Hostname: "sdw2", Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", Role: greenplum.MirrorRole},
+	})
+
+	relocations := greenplum.TablespaceRelocations{
+		{Host: "mdw", OldPrefix: "/data", NewPrefix: "/mnt/master"},
+		{Host: "sdw1", OldPrefix: "/data", NewPrefix: "/mnt/data"},
+		{Host: "sdw1", OldPrefix: "/data/fast", NewPrefix: "/mnt/fast"},
+		{Host: "sdw2", OldPrefix: "/unused", NewPrefix: "/mnt/unused"},
+	}
+
+	t.Run("relocates primary tablespaces using the longest matching prefix of their host", func(t *testing.T) {
+		cases := []struct {
+			dbID     int
+			location string
+			expected string
+		}{
+			{2, "/data/ts/16384", "/mnt/data/ts/16384"},
+			{2, "/data/fast/16385", "/mnt/fast/16385"},
+			{2, "/data", "/mnt/data"},
+			{2, "/database/16384", "/database/16384"},
+			{3, "/data/ts/16384", "/data/ts/16384"},
+			{1, "/data/ts/16384", "/data/ts/16384"},
+			{4, "/data/ts/16384", "/data/ts/16384"},
+		}
+
+		for _, c := range cases {
+			actual := relocations.Relocate(cluster, c.dbID, c.location)
+			if actual != c.expected {
+				t.Errorf("Relocate(dbID %d, %q) got %q want %q", c.dbID, c.location, actual, c.expected)
+			}
+		}
+	})
+
+	t.Run("relocates only user defined tablespaces", func(t *testing.T) {
+		tablespaces := greenplum.Tablespaces{
+			2: {
+				1663:  {Location: "/data/gpseg0", UserDefined: 0},
+				16384: {Location: "/data/ts/16384", UserDefined: 1},
+			},
+		}
+
+		expected := greenplum.Tablespaces{
+			2: {
+				1663:  {Location: "/data/gpseg0", UserDefined: 0},
+				16384: {Location: "/mnt/data/ts/16384", UserDefined: 1},
+			},
+		}
+
+		relocated := tablespaces.Relocate(cluster, relocations)
+		if !reflect.DeepEqual(relocated, expected) {
+			t.Errorf("got %+v want %+v", relocated, expected)
+		}
+
+		if tablespaces[2][16384].Location != "/data/ts/16384" {
+			t.Errorf("expected the original tablespaces to be unchanged")
+		}
+	})
+
+	t.Run("returns the relocations not matching any primary tablespace", func(t *testing.T) {
+		tablespaces := greenplum.Tablespaces{
+			1: {16384: {Location: "/data/ts/16384", UserDefined: 1}},
+			2: {16384: {Location: "/data/ts/16384", UserDefined: 1}},
+			3: {16384: {Location: "/data/ts/16384", UserDefined: 1}},
+		}
+
+		expected := greenplum.TablespaceRelocations{relocations[0], relocations[2], relocations[3]}
+
+		unused := relocations.Unused(cluster, tablespaces)
+		if !reflect.DeepEqual(unused, expected) {
+			t.Errorf("got %+v want %+v", unused, expected)
+		}
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/greenplum-db/gp-common-go-libs/dbconn"
 	"github.com/greenplum-db/gp-common-go-libs/testhelper"
 	"github.com/pkg/errors"
+	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/utils"
 )
@@ -54,10 +55,36 @@ func TestGetTablespaces(t *testing.T) {
 			}},
 			error: nil,
 		},
+		{
+			name: "successfully returns tablespace tuples from a 6X db",
+			rows: [][]driver.Value{
+				{1, 1663, "pg_default", "/data/qddir/demoDataDir-1", 0},
+				{1, 16386, "my_tablespace", "/tmp/my_tablespace", 1},
+			},
+			versionStr: "6.15.0",
+			expectedTuples: TablespaceTuples{{
+				DbId: 1,
+				Oid:  1663,
+				Name: "pg_default",
+				TablespaceInfo: &TablespaceInfo{
+					Location:    "/data/qddir/demoDataDir-1",
+					UserDefined: 0,
+				},
+			}, {
+				DbId: 1,
+				Oid:  16386,
+				Name: "my_tablespace",
+				TablespaceInfo: &TablespaceInfo{
+					Location:    "/tmp/my_tablespace",
+					UserDefined: 1,
+				},
+			}},
+			error: nil,
+		},
 		{
 			name:           "not supported version",
 			rows:           nil,
-			versionStr:     "6.1.0",
+			versionStr:     "7.0.0",
 			expectedTuples: nil,
 			error:          errors.New("version not supported to retrieve tablespace information"),
 		},
@@ -352,6 +379,54 @@ func TestTablespacesFromDB(t *testing.T) {
 	})
 }
 
+func TestQueryTablespaces(t *testing.T) {
+	t.Run("returns the tablespaces without writing the tablespace file", func(t *testing.T) {
+		conn, mock := testhelper.CreateMockDBConn()
+
+		testhelper.ExpectVersionQuery(mock, "5.3.4")
+		mock.ExpectQuery("SELECT .* upgrade_tablespace").WillReturnRows(MockTablespaceQueryResult())
+
+		utils.System.Create = func(name string) (*os.File, error) {
+			t.Errorf("unexpected call to Create(%q)", name)
+			return nil, nil
+		}
+		defer func() {
+			utils.System = utils.InitializeSystemFunctions()
+		}()
+
+		tablespaces, err := QueryTablespaces(conn)
+		if err != nil {
+			t.Errorf("got unexpected error: %+v", err)
+		}
+
+		expected := Tablespaces{
+			1: {1663: {Location: "/tmp/master_tablespace", UserDefined: 0}},
+			2: {1663: {Location: "/tmp/my_tablespace", UserDefined: 0}},
+		}
+
+		if !reflect.DeepEqual(tablespaces, expected) {
+			t.Errorf("expected: %#v got: %#v", expected, tablespaces)
+		}
+	})
+
+	t.Run("returns an error if the tablespace query fails", func(t *testing.T) {
+		conn, mock := testhelper.CreateMockDBConn()
+		testhelper.ExpectVersionQuery(mock, "5.3.4")
+
+		queryErr := errors.New("failed to get tablespace information")
+		mock.ExpectQuery("SELECT .* upgrade_tablespace").WillReturnError(queryErr)
+
+		tablespaces, err := QueryTablespaces(conn)
+		if !xerrors.Is(err, queryErr) {
+			t.Errorf("got error %#v want %#v", err, queryErr)
+		}
+
+		if tablespaces != nil {
+			t.Errorf("Expected tablespaces to be nil, got %#v", tablespaces)
+		}
+	})
+}
+
 func TestWrite(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,323 @@
+//  Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+//  SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// VersionPolicyFile is shipped alongside the gpupgrade executable without any
+// paths, and may be edited to override the compiled-in upgrade paths without a
+// new release. Only the paths it lists replace those of DefaultVersionPolicy,
+// such that an installed copy does not mask later changes to the defaults.
+const VersionPolicyFile = "version_policy.json"
+
+// UpgradePath describes an allowed upgrade from one major version of GPDB to
+// another, along with the features gpupgrade supports for that upgrade.
+type UpgradePath struct {
+	Source int `json:"source"`
+	Target int `json:"target"`
+
+	MinSourceVersion string `json:"minSourceVersion"`
+	MinTargetVersion string `json:"minTargetVersion"`
+
+	// KnownBadSourceVersions and KnownBadTargetVersions map releases that
+	// must not be used to the reason they are disallowed.
+	KnownBadSourceVersions map[string]string `json:"knownBadSourceVersions,omitempty"`
+	KnownBadTargetVersions map[string]string `json:"knownBadTargetVersions,omitempty"`
+
+	TablespacesSupported bool `json:"tablespacesSupported"`
+	LinkModeSupported    bool `json:"linkModeSupported"`
+}
+
+func (p UpgradePath) String() string {
+	return fmt.Sprintf("Greenplum %d to Greenplum %d", p.Source, p.Target)
+}
+
+// Verify returns an error for each of the source and target versions that
+// cannot be used for this upgrade path.
+func (p UpgradePath) Verify(source, target semver.Version) error {
+	var err error
+
+	vErr := p.verifyVersion(source, "source", p.MinSourceVersion, p.KnownBadSourceVersions)
+	err = errorlist.Append(err, vErr)
+
+	vErr = p.verifyVersion(target, "target", p.MinTargetVersion, p.KnownBadTargetVersions)
+	err = errorlist.Append(err, vErr)
+
+	return err
+}
+
+func (p UpgradePath) verifyVersion(version semver.Version, context string, min string, knownBad map[string]string) error {
+	if version.LT(semver.MustParse(min)) {
+		return fmt.Errorf("%s cluster version %s is not supported when upgrading from %s.  "+
+			"The minimum required version is %s. "+
+			"We recommend the latest version.",
+			context, version, p, min)
+	}
+
+	for bad, reason := range knownBad {
+		if version.EQ(semver.MustParse(bad)) {
+			return fmt.Errorf("%s cluster version %s is not supported when upgrading from %s: %s  "+
+				"We recommend the latest version.",
+				context, version, p, reason)
+		}
+	}
+
+	return nil
+}
+
+var ErrUnsupportedUpgradePath = errors.New("unsupported upgrade path")
+
+type UnsupportedUpgradePathError struct {
+	Source    semver.Version
+	Target    semver.Version
+	Supported []UpgradePath
+}
+
+func (e UnsupportedUpgradePathError) Error() string {
+	var paths []string
+	for _, p := range e.Supported {
+		paths = append(paths, p.String())
+	}
+
+	return fmt.Sprintf("upgrading from Greenplum %s to Greenplum %s is not supported.  "+
+		"The supported upgrade paths are: %s.",
+		e.Source, e.Target, strings.Join(paths, ", "))
+}
+
+func (e UnsupportedUpgradePathError) Is(err error) bool {
+	return err == ErrUnsupportedUpgradePath
+}
+
+// VersionPolicy is the set of upgrade paths allowed by gpupgrade.
+type VersionPolicy struct {
+	Paths []UpgradePath `json:"paths"`
+}
+
+// DefaultVersionPolicy is used for any upgrade path not overridden by the
+// version policy file. Modify it to control what will be allowed by the
+// utility.
+var DefaultVersionPolicy = VersionPolicy{
+	Paths: []UpgradePath{
+		{
+			Source:               5,
+			Target:               6,
+			MinSourceVersion:     "5.28.6",
+			MinTargetVersion:     "6.15.0",
+			TablespacesSupported: true,
+			LinkModeSupported:    true,
+		},
+		{
+			// GPDB 6 minor releases share a catalog version, which names
+			// the tablespace directories of both clusters.
+			Source:               6,
+			Target:               6,
+			MinSourceVersion:     "6.15.0",
+			MinTargetVersion:     "6.15.0",
+			TablespacesSupported: false,
+			LinkModeSupported:    true,
+		},
+		{
+			Source:               6,
+			Target:               7,
+			MinSourceVersion:     "6.15.0",
+			MinTargetVersion:     "7.0.0",
+			TablespacesSupported: true,
+			LinkModeSupported:    true,
+		},
+	},
+}
+
+// Path returns the upgrade path between the major versions of the source and
+// target, without regard to the minimum and known bad versions of that path.
+func (v VersionPolicy) Path(source, target semver.Version) (UpgradePath, error) {
+	for _, p := range v.Paths {
+		if uint64(p.Source) == source.Major && uint64(p.Target) == target.Major {
+			return p, nil
+		}
+	}
+
+	return UpgradePath{}, UnsupportedUpgradePathError{Source: source, Target: target, Supported: v.Paths}
+}
+
+// UnverifiedPath returns the upgrade path used when the version check is
+// skipped and the policy has no path between the source and target. As with
+// the paths of the default policy, tablespaces are only upgraded between major
+// versions, since the tablespace directories are named by catalog version and
+// the minor releases of a major version share one.
+func UnverifiedPath(source, target semver.Version) UpgradePath {
+	return UpgradePath{
+		Source:               int(source.Major),
+		Target:               int(target.Major),
+		MinSourceVersion:     source.String(),
+		MinTargetVersion:     target.String(),
+		TablespacesSupported: source.Major != target.Major,
+		LinkModeSupported:    true,
+	}
+}
+
+// Verify returns the upgrade path for the given source and target versions,
+// or path-specific errors explaining why the versions cannot be upgraded.
+func (v VersionPolicy) Verify(source, target semver.Version) (UpgradePath, error) {
+	path, pErr := v.Path(source, target)
+	if pErr == nil {
+		return path, path.Verify(source, target)
+	}
+
+	// When a version cannot be used with any path, report the lowest version
+	// that can be rather than the path.
+	var err error
+
+	if min, ok := v.minVersion(source, func(p UpgradePath) (int, string) { return p.Source, p.MinSourceVersion }); !ok {
+		err = errorlist.Append(err, unsupportedVersionError(source, "source", min))
+	}
+
+	if min, ok := v.minVersion(target, func(p UpgradePath) (int, string) { return p.Target, p.MinTargetVersion }); !ok {
+		err = errorlist.Append(err, unsupportedVersionError(target, "target", min))
+	}
+
+	if err == nil {
+		err = pErr
+	}
+
+	return UpgradePath{}, err
+}
+
+// minVersion returns true if any path supports the major version of the given
+// version. Otherwise it returns the lowest minimum version of all paths.
+func (v VersionPolicy) minVersion(version semver.Version, side func(UpgradePath) (int, string)) (string, bool) {
+	var lowest semver.Version
+	for _, p := range v.Paths {
+		major, min := side(p)
+		if uint64(major) == version.Major {
+			return "", true
+		}
+
+		minVersion := semver.MustParse(min)
+		if lowest.EQ(semver.Version{}) || minVersion.LT(lowest) {
+			lowest = minVersion
+		}
+	}
+
+	return lowest.String(), false
+}
+
+func unsupportedVersionError(version semver.Version, context string, min string) error {
+	return fmt.Errorf("%s cluster version %s is not supported.  "+
+		"The minimum required version is %s. "+
+		"We recommend the latest version.",
+		context, version, min)
+}
+
+// validate ensures each version in the policy can be parsed, so that a bad
+// override is reported when it is loaded rather than when it is used.
+func (v VersionPolicy) validate() error {
+	seen := make(map[[2]int]bool)
+
+	for _, p := range v.Paths {
+		key := [2]int{p.Source, p.Target}
+		if seen[key] {
+			return fmt.Errorf("duplicate upgrade path from %s", p)
+		}
+		seen[key] = true
+
+		versions := []string{p.MinSourceVersion, p.MinTargetVersion}
+		for bad := range p.KnownBadSourceVersions {
+			versions = append(versions, bad)
+		}
+		for bad := range p.KnownBadTargetVersions {
+			versions = append(versions, bad)
+		}
+
+		for _, version := range versions {
+			if _, err := semver.Parse(version); err != nil {
+				return xerrors.Errorf("upgrade path from %s: %w", p, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadVersionPolicy returns the DefaultVersionPolicy with any paths from the
+// given policy file replacing the default paths between the same major
+// versions. The default policy is returned if the file does not exist.
+func LoadVersionPolicy(path string) (VersionPolicy, error) {
+	policy := VersionPolicy{Paths: append([]UpgradePath(nil), DefaultVersionPolicy.Paths...)}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return policy, nil
+	}
+	if err != nil {
+		return VersionPolicy{}, err
+	}
+
+	var overrides VersionPolicy
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return VersionPolicy{}, xerrors.Errorf("decode version policy %q: %w", path, err)
+	}
+
+	if err := overrides.validate(); err != nil {
+		return VersionPolicy{}, xerrors.Errorf("version policy %q: %w", path, err)
+	}
+
+	for _, override := range overrides.Paths {
+		replaced := false
+		for i, p := range policy.Paths {
+			if p.Source == override.Source && p.Target == override.Target {
+				policy.Paths[i] = override
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			policy.Paths = append(policy.Paths, override)
+		}
+	}
+
+	sort.Slice(policy.Paths, func(i, j int) bool {
+		if policy.Paths[i].Source != policy.Paths[j].Source {
+			return policy.Paths[i].Source < policy.Paths[j].Source
+		}
+		return policy.Paths[i].Target < policy.Paths[j].Target
+	})
+
+	return policy, nil
+}
+
+// VersionPolicyPath returns the location of the version policy file installed
+// with the gpupgrade executable.
+func VersionPolicyPath() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(executable), "greenplum", "gpupgrade", VersionPolicyFile), nil
+}
+
+// LoadInstalledVersionPolicy loads the version policy file installed with the
+// gpupgrade executable.
+func LoadInstalledVersionPolicy() (VersionPolicy, error) {
+	path, err := VersionPolicyPath()
+	if err != nil {
+		return VersionPolicy{}, err
+	}
+
+	return LoadVersionPolicy(path)
+}
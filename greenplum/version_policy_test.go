@@ -0,0 +1,235 @@
+//  Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+//  SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/blang/semver/v4"
+)
+
+func TestVersionPolicyVerify(t *testing.T) {
+	policy := VersionPolicy{Paths: []UpgradePath{
+		{
+			Source:                 5,
+			Target:                 6,
+			MinSourceVersion:       "5.28.6",
+			MinTargetVersion:       "6.15.0",
+			KnownBadTargetVersions: map[string]string{"6.16.0": "It corrupts append-optimized tables."},
+			TablespacesSupported:   true,
+			LinkModeSupported:      true,
+		},
+		{
+			Source:           6,
+			Target:           6,
+			MinSourceVersion: "6.15.0",
+			MinTargetVersion: "6.15.0",
+		},
+		{
+			Source:           6,
+			Target:           7,
+			MinSourceVersion: "6.20.0",
+			MinTargetVersion: "7.0.0",
+		},
+	}}
+
+	t.Run("returns the upgrade path for supported versions", func(t *testing.T) {
+		path, err := policy.Verify(semver.MustParse("5.28.10"), semver.MustParse("6.17.0"))
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if !reflect.DeepEqual(path, policy.Paths[0]) {
+			t.Errorf("got path %+v want %+v", path, policy.Paths[0])
+		}
+	})
+
+	t.Run("errors for known bad versions of the upgrade path", func(t *testing.T) {
+		_, err := policy.Verify(semver.MustParse("5.28.10"), semver.MustParse("6.16.0"))
+
+		expected := "target cluster version 6.16.0 is not supported when upgrading from Greenplum 5 to Greenplum 6: " +
+			"It corrupts append-optimized tables.  We recommend the latest version."
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+
+	t.Run("errors when no upgrade path exists between supported versions", func(t *testing.T) {
+		_, err := policy.Verify(semver.MustParse("5.28.6"), semver.MustParse("7.0.0"))
+		if !errors.Is(err, ErrUnsupportedUpgradePath) {
+			t.Fatalf("got error %#v want %#v", err, ErrUnsupportedUpgradePath)
+		}
+
+		expected := "upgrading from Greenplum 5.28.6 to Greenplum 7.0.0 is not supported.  " +
+			"The supported upgrade paths are: Greenplum 5 to Greenplum 6, Greenplum 6 to Greenplum 6, Greenplum 6 to Greenplum 7."
+		if err.Error() != expected {
+			t.Errorf("got error %q want %q", err.Error(), expected)
+		}
+	})
+
+	t.Run("reports the lowest minimum version when a version is not part of any path", func(t *testing.T) {
+		_, err := policy.Verify(semver.MustParse("4.3.0"), semver.MustParse("6.15.0"))
+
+		expected := "source cluster version 4.3.0 is not supported.  The minimum required version is 5.28.6. We recommend the latest version."
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+}
+
+func TestUnverifiedPath(t *testing.T) {
+	t.Run("upgrades tablespaces for major version upgrades", func(t *testing.T) {
+		path := UnverifiedPath(semver.MustParse("6.20.0"), semver.MustParse("8.0.0"))
+
+		expected := UpgradePath{
+			Source:               6,
+			Target:               8,
+			MinSourceVersion:     "6.20.0",
+			MinTargetVersion:     "8.0.0",
+			TablespacesSupported: true,
+			LinkModeSupported:    true,
+		}
+		if !reflect.DeepEqual(path, expected) {
+			t.Errorf("got path %+v want %+v", path, expected)
+		}
+	})
+
+	t.Run("does not upgrade tablespaces for minor version upgrades as the default policy", func(t *testing.T) {
+		path := UnverifiedPath(semver.MustParse("7.0.0"), semver.MustParse("7.1.0"))
+		if path.TablespacesSupported {
+			t.Errorf("expected tablespaces to not be supported")
+		}
+
+		defaultPath, err := DefaultVersionPolicy.Path(semver.MustParse("6.15.0"), semver.MustParse("6.20.0"))
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if UnverifiedPath(semver.MustParse("6.15.0"), semver.MustParse("6.20.0")).TablespacesSupported != defaultPath.TablespacesSupported {
+			t.Errorf("expected the unverified path to match the default 6 to 6 path")
+		}
+	})
+}
+
+func TestLoadVersionPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("creating temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, VersionPolicyFile)
+
+	writePolicy := func(t *testing.T, contents string) {
+		if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("writing policy file: %+v", err)
+		}
+	}
+
+	t.Run("returns the default policy when there is no policy file", func(t *testing.T) {
+		policy, err := LoadVersionPolicy(filepath.Join(dir, "does-not-exist.json"))
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if !reflect.DeepEqual(policy, DefaultVersionPolicy) {
+			t.Errorf("got policy %+v want %+v", policy, DefaultVersionPolicy)
+		}
+	})
+
+	t.Run("replaces and adds the upgrade paths from the policy file", func(t *testing.T) {
+		writePolicy(t, `{
+			"paths": [
+				{"source": 7, "target": 7, "minSourceVersion": "7.1.0", "minTargetVersion": "7.1.0"},
+				{"source": 6, "target": 6, "minSourceVersion": "6.18.0", "minTargetVersion": "6.18.0",
+				 "knownBadSourceVersions": {"6.19.0": "Bad release."}, "linkModeSupported": true}
+			]
+		}`)
+
+		policy, err := LoadVersionPolicy(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		expected := VersionPolicy{Paths: []UpgradePath{
+			DefaultVersionPolicy.Paths[0],
+			{
+				Source:                 6,
+				Target:                 6,
+				MinSourceVersion:       "6.18.0",
+				MinTargetVersion:       "6.18.0",
+				KnownBadSourceVersions: map[string]string{"6.19.0": "Bad release."},
+				LinkModeSupported:      true,
+			},
+			DefaultVersionPolicy.Paths[2],
+			{Source: 7, Target: 7, MinSourceVersion: "7.1.0", MinTargetVersion: "7.1.0"},
+		}}
+
+		if !reflect.DeepEqual(policy, expected) {
+			t.Errorf("got policy %+v want %+v", policy, expected)
+		}
+
+		if DefaultVersionPolicy.Paths[1].MinSourceVersion != "6.15.0" {
+			t.Errorf("expected the default policy to not be modified")
+		}
+	})
+
+	t.Run("errors when the policy file contains an invalid version", func(t *testing.T) {
+		writePolicy(t, `{
+			"paths": [{"source": 6, "target": 6, "minSourceVersion": "6.x", "minTargetVersion": "6.15.0"}]
+		}`)
+
+		_, err := LoadVersionPolicy(path)
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+
+	t.Run("errors when the policy file contains duplicate upgrade paths", func(t *testing.T) {
+		writePolicy(t, `{
+			"paths": [
+				{"source": 6, "target": 6, "minSourceVersion": "6.15.0", "minTargetVersion": "6.15.0"},
+				{"source": 6, "target": 6, "minSourceVersion": "6.16.0", "minTargetVersion": "6.16.0"}
+			]
+		}`)
+
+		_, err := LoadVersionPolicy(path)
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+
+	t.Run("the shipped policy file overrides no default paths", func(t *testing.T) {
+		data, err := ioutil.ReadFile(filepath.Join("..", VersionPolicyFile))
+		if err != nil {
+			t.Fatalf("reading shipped policy: %+v", err)
+		}
+
+		// Decode the file directly, as loading it would fill in any paths
+		// missing from the file with those of the default policy.
+		var policy VersionPolicy
+		if err := json.Unmarshal(data, &policy); err != nil {
+			t.Fatalf("decoding shipped policy: %+v", err)
+		}
+
+		if len(policy.Paths) != 0 {
+			t.Errorf("got paths %+v want none", policy.Paths)
+		}
+
+		loaded, err := LoadVersionPolicy(filepath.Join("..", VersionPolicyFile))
+		if err != nil {
+			t.Fatalf("loading shipped policy: %+v", err)
+		}
+
+		if !reflect.DeepEqual(loaded, DefaultVersionPolicy) {
+			t.Errorf("got policy %+v want %+v", loaded, DefaultVersionPolicy)
+		}
+	})
+}
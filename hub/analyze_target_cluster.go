@@ -0,0 +1,148 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// DefaultAnalyzeJobs matches the analyzedb default for the number of tables
+// analyzed in parallel.
+const DefaultAnalyzeJobs = 5
+
+// MaxAnalyzeJobs is the upper limit analyzedb accepts for its parallel level.
+const MaxAnalyzeJobs = 10
+
+// AnalyzedDatabasesFile records the databases that have been analyzed so that
+// a failed or interrupted analyze resumes with the remaining databases.
+const AnalyzedDatabasesFile = "analyzed_databases"
+
+// AnalyzeTargetCluster regenerates the optimizer statistics of every database
+// in the target cluster, since pg_upgrade does not carry them over.
+func AnalyzeTargetCluster(streams step.OutStreams, stateDir string, conn *connURI.Conn, masterPort int, targetRunner greenplum.Runner, jobs int) (err error) {
+	db, err := utils.System.SqlOpen("pgx", conn.URI(connURI.ToTarget(), connURI.Port(masterPort)))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	databases, err := getDatabasesToAnalyze(db)
+	if err != nil {
+		return err
+	}
+
+	return analyzeDatabases(streams, filepath.Join(stateDir, AnalyzedDatabasesFile), databases, targetRunner, jobs)
+}
+
+func getDatabasesToAnalyze(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`SELECT datname FROM pg_database WHERE datallowconn AND datname != 'template0' ORDER BY datname;`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying databases: %w", err)
+	}
+	defer rows.Close() // XXX lost error
+
+	var databases []string
+	for rows.Next() {
+		var database string
+		if err := rows.Scan(&database); err != nil {
+			return nil, xerrors.Errorf("scanning databases: %w", err)
+		}
+
+		databases = append(databases, database)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating databases: %w", err)
+	}
+
+	return databases, nil
+}
+
+func analyzeDatabases(streams step.OutStreams, progressPath string, databases []string, targetRunner greenplum.Runner, jobs int) error {
+	analyzed, err := readAnalyzedDatabases(progressPath)
+	if err != nil {
+		return err
+	}
+
+	for i, database := range databases {
+		if analyzed[database] {
+			fmt.Fprintf(streams.Stdout(), "Skipping already analyzed database %q (%d of %d)\n", database, i+1, len(databases))
+			continue
+		}
+
+		fmt.Fprintf(streams.Stdout(), "Analyzing database %q (%d of %d)...\n", database, i+1, len(databases))
+		gplog.Info("analyzing target database %q", database)
+
+		err := targetRunner.Run("analyzedb", "-a", "-d", database, "-p", strconv.Itoa(jobs))
+		if err != nil {
+			return xerrors.Errorf("analyze database %q: %w", database, err)
+		}
+
+		if err := appendAnalyzedDatabase(progressPath, database); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readAnalyzedDatabases(path string) (map[string]bool, error) {
+	analyzed := make(map[string]bool)
+
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		if utils.System.IsNotExist(err) {
+			return analyzed, nil
+		}
+
+		return nil, xerrors.Errorf("read analyze progress: %w", err)
+	}
+
+	for _, database := range strings.Split(string(contents), "\n") {
+		if database != "" {
+			analyzed[database] = true
+		}
+	}
+
+	return analyzed, nil
+}
+
+func appendAnalyzedDatabase(path string, database string) (err error) {
+	file, err := utils.System.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return xerrors.Errorf("open analyze progress: %w", err)
+	}
+
+	defer func() {
+		if cErr := file.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	_, err = fmt.Fprintln(file, database)
+	if err != nil {
+		return xerrors.Errorf("write analyze progress: %w", err)
+	}
+
+	return nil
+}
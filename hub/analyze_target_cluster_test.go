@@ -0,0 +1,173 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestAnalyzeTargetCluster(t *testing.T) {
+	testlog.SetupLogger()
+
+	conn := connURI.Connection(semver.MustParse("5.0.0"), semver.MustParse("6.0.0"))
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	progressPath := filepath.Join(stateDir, AnalyzedDatabasesFile)
+
+	t.Run("analyzes each database of the target cluster and reports progress", func(t *testing.T) {
+		defer testutils.MustRemoveAll(t, progressPath)
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+			expected := conn.URI(connURI.ToTarget(), connURI.Port(123))
+			if dataSourceName != expected {
+				t.Errorf("got: %q want: %q", dataSourceName, expected)
+			}
+
+			return db, nil
+		}
+		defer func() {
+			utils.System = utils.InitializeSystemFunctions()
+		}()
+
+		expectDatabasesAndReturn(mock, "postgres", "template1")
+		mock.ExpectClose()
+
+		var analyzed [][]string
+		stub := &greenplumStub{run: func(utility string, arguments ...string) error {
+			if utility != "analyzedb" {
+				t.Errorf("ran utility %q, want %q", utility, "analyzedb")
+			}
+
+			analyzed = append(analyzed, arguments)
+			return nil
+		}}
+
+		stdout := new(bytes.Buffer)
+		err = AnalyzeTargetCluster(testutils.DevNullSpy{OutStream: stdout}, stateDir, conn, 123, stub, 3)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+
+		expected := [][]string{
+			{"-a", "-d", "postgres", "-p", "3"},
+			{"-a", "-d", "template1", "-p", "3"},
+		}
+		if !reflect.DeepEqual(analyzed, expected) {
+			t.Errorf("got arguments %q want %q", analyzed, expected)
+		}
+
+		expectedOutput := "Analyzing database \"postgres\" (1 of 2)...\nAnalyzing database \"template1\" (2 of 2)...\n"
+		if stdout.String() != expectedOutput {
+			t.Errorf("got output %q want %q", stdout.String(), expectedOutput)
+		}
+
+		contents := testutils.MustReadFile(t, progressPath)
+		if contents != "postgres\ntemplate1\n" {
+			t.Errorf("got progress %q want %q", contents, "postgres\ntemplate1\n")
+		}
+	})
+
+	t.Run("resumes with the databases that have not been analyzed", func(t *testing.T) {
+		defer testutils.MustRemoveAll(t, progressPath)
+		testutils.MustWriteToFile(t, progressPath, "postgres\n")
+
+		var databases []string
+		stub := &greenplumStub{run: func(utility string, arguments ...string) error {
+			databases = append(databases, arguments[2])
+			return nil
+		}}
+
+		stdout := new(bytes.Buffer)
+		err := analyzeDatabases(testutils.DevNullSpy{OutStream: stdout}, progressPath, []string{"postgres", "template1"}, stub, 1)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+
+		if !reflect.DeepEqual(databases, []string{"template1"}) {
+			t.Errorf("analyzed %q want %q", databases, []string{"template1"})
+		}
+
+		if !strings.Contains(stdout.String(), `Skipping already analyzed database "postgres" (1 of 2)`) {
+			t.Errorf("expected output %q to report skipping postgres", stdout.String())
+		}
+	})
+
+	t.Run("does not record a database whose analyze failed", func(t *testing.T) {
+		defer testutils.MustRemoveAll(t, progressPath)
+
+		expected := errors.New("permission denied")
+		stub := &greenplumStub{run: func(utility string, arguments ...string) error {
+			if arguments[2] == "template1" {
+				return expected
+			}
+			return nil
+		}}
+
+		err := analyzeDatabases(testutils.DevNullSpy{OutStream: new(bytes.Buffer)}, progressPath, []string{"postgres", "template1"}, stub, 1)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+
+		contents := testutils.MustReadFile(t, progressPath)
+		if contents != "postgres\n" {
+			t.Errorf("got progress %q want %q", contents, "postgres\n")
+		}
+	})
+
+	t.Run("returns error when failing to query databases", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+			return db, nil
+		}
+		defer func() {
+			utils.System = utils.InitializeSystemFunctions()
+		}()
+
+		expected := errors.New("connection refused")
+		mock.ExpectQuery("SELECT datname FROM pg_database").WillReturnError(expected)
+		mock.ExpectClose()
+
+		err = AnalyzeTargetCluster(testutils.DevNullSpy{OutStream: new(bytes.Buffer)}, stateDir, conn, 123, &greenplumStub{}, 1)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func expectDatabasesAndReturn(mock sqlmock.Sqlmock, databases ...string) {
+	rows := sqlmock.NewRows([]string{"datname"})
+	for _, database := range databases {
+		rows.AddRow(database)
+	}
+
+	mock.ExpectQuery(`SELECT datname FROM pg_database WHERE datallowconn AND datname != 'template0' ORDER BY datname;`).
+		WillReturnRows(rows)
+}
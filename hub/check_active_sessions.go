@@ -0,0 +1,265 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// The active session policies select what execute does about sessions
+// connected to the source cluster before shutting it down. Prepared
+// transactions always fail the check, as they would be lost by the upgrade.
+const (
+	FailPolicy      = "fail"
+	WaitPolicy      = "wait"
+	TerminatePolicy = "terminate"
+	ProceedPolicy   = "proceed"
+)
+
+var ActiveSessionPolicies = []string{FailPolicy, WaitPolicy, TerminatePolicy, ProceedPolicy}
+
+// DefaultActiveSessionWait is how long the wait policy waits for the active
+// sessions to disconnect.
+const DefaultActiveSessionWait = 5 * time.Minute
+
+// Session is a client connected to the source master.
+type Session struct {
+	Pid             int
+	User            string
+	Database        string
+	ApplicationName string
+	ClientAddr      string
+	Query           string
+}
+
+func (s Session) String() string {
+	return fmt.Sprintf("pid %d user %q database %q application %q client %q query %q",
+		s.Pid, s.User, s.Database, s.ApplicationName, s.ClientAddr, s.Query)
+}
+
+// PreparedTransaction is a prepared, and possibly in-doubt distributed,
+// transaction on the source master or a primary.
+type PreparedTransaction struct {
+	Content  int
+	Gid      string
+	Owner    string
+	Database string
+	Prepared string
+}
+
+func (p PreparedTransaction) String() string {
+	return fmt.Sprintf("content %d gid %q owner %q database %q prepared at %s",
+		p.Content, p.Gid, p.Owner, p.Database, p.Prepared)
+}
+
+var ErrActiveSessions = errors.New("active sessions")
+
+type ActiveSessionsError struct {
+	Sessions             []Session
+	PreparedTransactions []PreparedTransaction
+}
+
+func (e ActiveSessionsError) Error() string {
+	var report []string
+	if len(e.PreparedTransactions) > 0 {
+		report = append(report, fmt.Sprintf("Found %d prepared transactions which must be committed or rolled back before upgrading:", len(e.PreparedTransactions)))
+		for _, p := range e.PreparedTransactions {
+			report = append(report, "  "+p.String())
+		}
+	}
+
+	if len(e.Sessions) > 0 {
+		report = append(report, sessionReport(e.Sessions))
+		report = append(report, fmt.Sprintf(`Disconnect the sessions, or set "--active-session-policy" to one of %s.`,
+			strings.Join(ActiveSessionPolicies, ", ")))
+	}
+
+	return strings.Join(report, "\n")
+}
+
+func (e ActiveSessionsError) Is(err error) bool {
+	return err == ErrActiveSessions
+}
+
+func sessionReport(sessions []Session) string {
+	report := []string{fmt.Sprintf("Found %d active sessions on the source cluster:", len(sessions))}
+	for _, s := range sessions {
+		report = append(report, "  "+s.String())
+	}
+
+	return strings.Join(report, "\n")
+}
+
+// CheckActiveSessions reports the sessions connected to the source cluster and
+// its prepared transactions before it is shut down. Prepared transactions
+// always fail the check. Active sessions are handled according to the policy,
+// where the wait policy waits up to the given duration for them to disconnect.
+func CheckActiveSessions(streams step.OutStreams, conn *connURI.Conn, masterPort int, policy string, wait time.Duration) error {
+	options := []connURI.Option{
+		connURI.ToSource(),
+		connURI.Port(masterPort),
+	}
+
+	db, err := utils.System.SqlOpen("pgx", conn.URI(options...))
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	return checkActiveSessions(streams, db, conn.SourceVersion(), policy, wait)
+}
+
+func checkActiveSessions(streams step.OutStreams, db *sql.DB, version semver.Version, policy string, wait time.Duration) error {
+	startTime := time.Now()
+	terminated := false
+	for {
+		prepared, err := queryPreparedTransactions(db)
+		if err != nil {
+			return err
+		}
+
+		sessions, err := querySessions(db, version)
+		if err != nil {
+			return err
+		}
+
+		if len(prepared) > 0 {
+			return ActiveSessionsError{Sessions: sessions, PreparedTransactions: prepared}
+		}
+
+		if len(sessions) == 0 {
+			return nil
+		}
+
+		switch policy {
+		case ProceedPolicy:
+			_, err := fmt.Fprintf(streams.Stdout(), "%s\nProceeding to stop the source cluster.\n", sessionReport(sessions))
+			return err
+
+		case TerminatePolicy:
+			if terminated {
+				return xerrors.Errorf("terminating sessions: %w", ActiveSessionsError{Sessions: sessions})
+			}
+
+			if err := terminateSessions(db, sessions); err != nil {
+				return err
+			}
+
+			terminated = true
+
+		case WaitPolicy:
+			if time.Since(startTime) > wait {
+				return xerrors.Errorf("%s timeout exceeded waiting for sessions to disconnect: %w", wait, ActiveSessionsError{Sessions: sessions})
+			}
+
+		default:
+			return ActiveSessionsError{Sessions: sessions}
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+// querySessions returns the client sessions of the source master other than
+// the one running the query.
+func querySessions(db *sql.DB, version semver.Version) ([]Session, error) {
+	pid, query := "pid", "query"
+	if version.Major < 6 {
+		pid, query = "procpid", "current_query"
+	}
+
+	rows, err := db.Query(fmt.Sprintf(`
+		SELECT %[1]s, COALESCE(usename, ''), COALESCE(datname, ''), COALESCE(application_name, ''),
+			COALESCE(host(client_addr), ''), COALESCE(%[2]s, '')
+		FROM pg_stat_activity
+		WHERE %[1]s <> pg_backend_pid()
+		ORDER BY %[1]s`, pid, query))
+	if err != nil {
+		return nil, xerrors.Errorf("querying active sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []Session
+	for rows.Next() {
+		var s Session
+		if err := rows.Scan(&s.Pid, &s.User, &s.Database, &s.ApplicationName, &s.ClientAddr, &s.Query); err != nil {
+			return nil, xerrors.Errorf("scanning active sessions: %w", err)
+		}
+
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating active sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// queryPreparedTransactions returns the prepared transactions of the master and
+// primaries, which include in-doubt distributed transactions. gp_dist_random
+// only dispatches tables, so the primaries run pg_prepared_xact() over gp_id
+// and its owner and database are named on the master as pg_prepared_xacts
+// does.
+func queryPreparedTransactions(db *sql.DB) ([]PreparedTransaction, error) {
+	rows, err := db.Query(`
+		SELECT -1, gid, COALESCE(owner, ''), COALESCE(database, ''), prepared::text FROM pg_prepared_xacts
+		UNION ALL
+		SELECT p.content, p.gid, COALESCE(u.rolname, ''), COALESCE(d.datname, ''), p.prepared::text
+		FROM (
+			SELECT gp_execution_segment() AS content, (pg_prepared_xact()).*
+			FROM gp_dist_random('gp_id')
+		) p
+			LEFT JOIN pg_authid u ON p.ownerid = u.oid
+			LEFT JOIN pg_database d ON p.dbid = d.oid
+		ORDER BY 1, 2`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying prepared transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var prepared []PreparedTransaction
+	for rows.Next() {
+		var p PreparedTransaction
+		if err := rows.Scan(&p.Content, &p.Gid, &p.Owner, &p.Database, &p.Prepared); err != nil {
+			return nil, xerrors.Errorf("scanning prepared transactions: %w", err)
+		}
+
+		prepared = append(prepared, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating prepared transactions: %w", err)
+	}
+
+	return prepared, nil
+}
+
+func terminateSessions(db *sql.DB, sessions []Session) error {
+	for _, s := range sessions {
+		gplog.Info("terminating session %s", s)
+
+		rows, err := db.Query("SELECT pg_terminate_backend($1)", s.Pid)
+		if err != nil {
+			return xerrors.Errorf("terminating session %d: %w", s.Pid, err)
+		}
+
+		if err := rows.Close(); err != nil {
+			return xerrors.Errorf("closing terminate session results: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestCheckActiveSessions(t *testing.T) {
+	testlog.SetupLogger()
+
+	conn := connURI.Connection(semver.MustParse("6.0.0"), semver.MustParse("7.0.0"))
+
+	mockDB := func(t *testing.T) (sqlmock.Sqlmock, func()) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+
+		utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+			expected := conn.URI(connURI.ToSource(), connURI.Port(15432))
+			if dataSourceName != expected {
+				t.Errorf("got: %q want: %q", dataSourceName, expected)
+			}
+
+			return db, nil
+		}
+
+		return mock, func() {
+			utils.System = utils.InitializeSystemFunctions()
+			testutils.FinishMock(mock, t)
+		}
+	}
+
+	session := Session{Pid: 1234, User: "etl", Database: "postgres", ApplicationName: "psql", ClientAddr: "10.0.0.5", Query: "SELECT 1"}
+	prepared := PreparedTransaction{Content: 0, Gid: "1612345678-0000000042", Owner: "gpadmin", Database: "postgres", Prepared: "2021-03-01 12:00:00"}
+
+	t.Run("succeeds when there are no sessions or prepared transactions", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectPreparedTransactions(mock)
+		expectSessions(mock)
+
+		err := CheckActiveSessions(step.DevNullStream, conn, 15432, FailPolicy, DefaultActiveSessionWait)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("fails with a report of the active sessions", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectPreparedTransactions(mock)
+		expectSessions(mock, session)
+
+		err := CheckActiveSessions(step.DevNullStream, conn, 15432, FailPolicy, DefaultActiveSessionWait)
+		var sessionsErr ActiveSessionsError
+		if !errors.As(err, &sessionsErr) {
+			t.Fatalf("got error %#v want type %T", err, sessionsErr)
+		}
+
+		if len(sessionsErr.Sessions) != 1 || sessionsErr.Sessions[0] != session {
+			t.Errorf("got sessions %v want %v", sessionsErr.Sessions, []Session{session})
+		}
+
+		if !strings.Contains(err.Error(), "pid 1234") {
+			t.Errorf("expected error %q to report the session", err)
+		}
+	})
+
+	t.Run("fails on prepared transactions regardless of the policy", func(t *testing.T) {
+		for _, policy := range ActiveSessionPolicies {
+			t.Run(policy, func(t *testing.T) {
+				mock, cleanup := mockDB(t)
+				defer cleanup()
+
+				expectPreparedTransactions(mock, prepared)
+				expectSessions(mock)
+
+				err := CheckActiveSessions(step.DevNullStream, conn, 15432, policy, DefaultActiveSessionWait)
+				if !errors.Is(err, ErrActiveSessions) {
+					t.Fatalf("got error %#v want %#v", err, ErrActiveSessions)
+				}
+
+				if !strings.Contains(err.Error(), prepared.Gid) {
+					t.Errorf("expected error %q to report the prepared transaction", err)
+				}
+			})
+		}
+	})
+
+	t.Run("proceeds with active sessions when the policy allows it", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectPreparedTransactions(mock)
+		expectSessions(mock, session)
+
+		streams := new(step.BufferedStreams)
+		err := CheckActiveSessions(streams, conn, 15432, ProceedPolicy, DefaultActiveSessionWait)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+
+		if !strings.Contains(streams.StdoutBuf.String(), "pid 1234") {
+			t.Errorf("expected stdout %q to report the session", streams.StdoutBuf.String())
+		}
+	})
+
+	t.Run("terminates the active sessions", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectPreparedTransactions(mock)
+		expectSessions(mock, session)
+		mock.ExpectQuery(`SELECT pg_terminate_backend\(\$1\)`).
+			WithArgs(1234).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_terminate_backend"}).AddRow(true))
+		expectPreparedTransactions(mock)
+		expectSessions(mock)
+
+		err := CheckActiveSessions(step.DevNullStream, conn, 15432, TerminatePolicy, DefaultActiveSessionWait)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("waits for the active sessions to disconnect", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectPreparedTransactions(mock)
+		expectSessions(mock, session)
+		expectPreparedTransactions(mock)
+		expectSessions(mock)
+
+		err := CheckActiveSessions(step.DevNullStream, conn, 15432, WaitPolicy, DefaultActiveSessionWait)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("times out waiting for the active sessions to disconnect", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectPreparedTransactions(mock)
+		expectSessions(mock, session)
+		expectPreparedTransactions(mock)
+		expectSessions(mock, session)
+
+		err := CheckActiveSessions(step.DevNullStream, conn, 15432, WaitPolicy, 500*time.Millisecond)
+		if !errors.Is(err, ErrActiveSessions) {
+			t.Errorf("got error %#v want %#v", err, ErrActiveSessions)
+		}
+	})
+
+	t.Run("queries the GPDB 5 activity columns", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery(`SELECT procpid, .* COALESCE\(current_query, ''\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"procpid", "usename", "datname", "application_name", "client_addr", "current_query"}))
+
+		_, err = querySessions(db, semver.MustParse("5.28.0"))
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+}
+
+func expectPreparedTransactions(mock sqlmock.Sqlmock, prepared ...PreparedTransaction) {
+	rows := sqlmock.NewRows([]string{"content", "gid", "owner", "database", "prepared"})
+	for _, p := range prepared {
+		rows.AddRow(p.Content, p.Gid, p.Owner, p.Database, p.Prepared)
+	}
+
+	mock.ExpectQuery(`SELECT -1, gid, COALESCE\(owner, ''\), COALESCE\(database, ''\), prepared::text FROM pg_prepared_xacts
+		UNION ALL
+		SELECT p.content, .*
+		FROM \(
+			SELECT gp_execution_segment\(\) AS content, \(pg_prepared_xact\(\)\).\*
+			FROM gp_dist_random\('gp_id'\)
+		\) p`).
+		WillReturnRows(rows)
+}
+
+func expectSessions(mock sqlmock.Sqlmock, sessions ...Session) {
+	rows := sqlmock.NewRows([]string{"pid", "usename", "datname", "application_name", "client_addr", "query"})
+	for _, s := range sessions {
+		rows.AddRow(s.Pid, s.User, s.Database, s.ApplicationName, s.ClientAddr, s.Query)
+	}
+
+	mock.ExpectQuery(`SELECT pid, .* FROM pg_stat_activity`).
+		WillReturnRows(rows)
+}
@@ -6,6 +6,7 @@ package hub
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sync"
 
 	"golang.org/x/xerrors"
@@ -24,53 +25,66 @@ func (s *Server) CheckDiskSpace(ctx context.Context, in *idl.CheckDiskSpaceReque
 		return reply, err
 	}
 
-	reply.Failed, err = checkDiskSpace(ctx, s.Source, agents, disk.Local, in)
+	reply.Failed, err = checkDiskSpace(ctx, s.Source, s.TargetInitializeConfig, agents, disk.Local, in)
 	return reply, err
 }
 
-func checkDiskSpace(ctx context.Context, cluster *greenplum.Cluster, agents []*Connection, d disk.Disk, in *idl.CheckDiskSpaceRequest) (disk.SpaceFailures, error) {
+// checkDiskSpace checks the filesystems of the source data directories, and
+// of any target data directories placed on other filesystems.
+func checkDiskSpace(ctx context.Context, cluster *greenplum.Cluster, target InitializeConfig, agents []*Connection, d disk.Disk, in *idl.CheckDiskSpaceRequest) (disk.SpaceFailures, error) {
 	var wg sync.WaitGroup
 	errs := make(chan error, len(agents)+1)
 	failures := make(chan disk.SpaceFailures, len(agents)+1)
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
+	// When the hub does not run on the master host the agent there checks
+	// the master along with any other segments.
+	if !cluster.RemoteMaster {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
 
-		failed, err := disk.CheckUsage(d, in.Ratio, cluster.MasterDataDir())
-		if err != nil {
-			errs <- xerrors.Errorf("check disk space on master host: %w", err)
-		}
+			paths := append([]string{cluster.MasterDataDir()}, placedDirs(target, cluster.MasterHostname(), cluster.Primaries[-1])...)
 
-		if len(failed) > 0 {
-			masterHost := cluster.GetHostForContent(-1)
-			failures <- prefixWith(masterHost, failed)
-		}
-	}()
+			failed, err := disk.CheckUsage(d, in.Ratio, paths...)
+			if err != nil {
+				errs <- xerrors.Errorf("check disk space on master host: %w", err)
+			}
+
+			if len(failed) > 0 {
+				masterHost := cluster.GetHostForContent(-1)
+				failures <- prefixWith(masterHost, failed)
+			}
+		}()
+	}
 
 	for i := range agents {
 		agent := agents[i]
 		wg.Add(1)
 
-		// We want to check disk space for the standby, primaries, and mirrors.
+		// We want to check disk space for the standby, primaries, and mirrors,
+		// as well as the master when the hub does not run on its host.
 		excludingMaster := func(seg *greenplum.SegConfig) bool {
-			return seg.IsOnHost(agent.Hostname) && !seg.IsMaster()
+			return !seg.IsMaster() || cluster.RemoteMaster
 		}
 
 		go func() {
 			defer wg.Done()
 
 			segments := cluster.SelectSegments(excludingMaster)
-			if len(segments) == 0 {
-				errs <- greenplum.UnknownHostError{Hostname: agent.Hostname}
-				return
-			}
 
 			req := &idl.CheckSegmentDiskSpaceRequest{
 				Request: in,
 			}
 			for _, s := range segments {
-				req.Datadirs = append(req.Datadirs, s.DataDir)
+				if s.IsOnHost(agent.Hostname) {
+					req.Datadirs = append(req.Datadirs, s.DataDir)
+				}
+			}
+			req.Datadirs = append(req.Datadirs, placedDirs(target, agent.Hostname, segments...)...)
+
+			if len(req.Datadirs) == 0 {
+				errs <- greenplum.UnknownHostError{Hostname: agent.Hostname}
+				return
 			}
 
 			reply, err := agent.AgentClient.CheckDiskSpace(ctx, req)
@@ -109,6 +123,39 @@ func checkDiskSpace(ctx context.Context, cluster *greenplum.Cluster, agents []*C
 	return result, nil
 }
 
+// placedDirs returns the parent directories on the host of the target data
+// directories that are placed outside the parent directory of their source, or
+// that are created on another host by a host mapping. For the primaries of a
+// mapped host this includes the parent directory of the copied source data
+// directory.
+func placedDirs(target InitializeConfig, host string, segments ...greenplum.SegConfig) []string {
+	var dirs []string
+	seen := make(map[string]bool)
+
+	for _, seg := range segments {
+		final := finalSegment(seg, target)
+		if final.Hostname != host {
+			continue
+		}
+
+		candidates := []string{filepath.Dir(final.DataDir)}
+		if seg.Hostname != host && seg.IsPrimary() {
+			candidates = append(candidates, filepath.Dir(seg.DataDir))
+		}
+
+		for _, dir := range candidates {
+			if (seg.Hostname == host && dir == filepath.Dir(seg.DataDir)) || seen[dir] {
+				continue
+			}
+
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
 // prefixWith adds a string prefix to every key in the failure map.
 func prefixWith(prefix string, failures disk.SpaceFailures) disk.SpaceFailures {
 	prefixed := make(disk.SpaceFailures)
@@ -26,6 +26,7 @@ import (
 func TestCheckDiskSpace(t *testing.T) {
 	var d halfFullDisk
 	var c *greenplum.Cluster
+	var target InitializeConfig
 	var agents []*Connection
 	var req *idl.CheckDiskSpaceRequest
 	ctx := context.Background()
@@ -37,7 +38,7 @@ func TestCheckDiskSpace(t *testing.T) {
 	check := func(t *testing.T, expected disk.SpaceFailures) {
 		t.Helper()
 
-		actual, err := checkDiskSpace(ctx, c, agents, d, req)
+		actual, err := checkDiskSpace(ctx, c, target, agents, d, req)
 		if err != nil {
 			t.Errorf("returned error %#v", err)
 		}
@@ -147,6 +148,40 @@ func TestCheckDiskSpace(t *testing.T) {
 		})
 	})
 
+	t.Run("checks the filesystems of target data directories placed elsewhere", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		c = MustCreateCluster(t, []greenplum.SegConfig{
+			{ContentID: -1, Hostname: "mdw", DataDir: "/data/master/seg-1", Role: "p"},
+			{ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/seg0", Role: "p"},
+			{ContentID: 1, Hostname: "sdw1", DataDir: "/data/primary/seg1", Role: "p"},
+		})
+		target = InitializeConfig{
+			Master: greenplum.SegConfig{ContentID: -1, Hostname: "mdw", DataDir: "/data/master/seg.AAAAAAAAAAA.-1", Role: "p"},
+			Primaries: []greenplum.SegConfig{
+				{ContentID: 0, Hostname: "sdw1", DataDir: "/ssd/primary/seg.AAAAAAAAAAA.0", Role: "p"},
+				{ContentID: 1, Hostname: "sdw1", DataDir: "/ssd/primary/seg.AAAAAAAAAAA.1", Role: "p"},
+			},
+		}
+		defer func() { target = InitializeConfig{} }()
+		req = &idl.CheckDiskSpaceRequest{Ratio: 0.25}
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().
+			CheckDiskSpace(ctx, equivalentRequest(&idl.CheckSegmentDiskSpaceRequest{
+				Request:  req,
+				Datadirs: []string{"/data/primary/seg0", "/data/primary/seg1", "/ssd/primary"},
+			})).
+			Return(&idl.CheckDiskSpaceReply{}, nil)
+
+		agents = []*Connection{
+			{Hostname: "sdw1", AgentClient: sdw1},
+		}
+
+		check(t, disk.SpaceFailures{})
+	})
+
 	t.Run("bubbles up any errors in parallel", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -176,7 +211,7 @@ func TestCheckDiskSpace(t *testing.T) {
 			{Hostname: "sdw2", AgentClient: sdw2}, // invalid hostname
 		}
 
-		_, err := checkDiskSpace(ctx, c, agents, d, req)
+		_, err := checkDiskSpace(ctx, c, target, agents, d, req)
 
 		expected := []error{d.err, agentErr, greenplum.ErrUnknownHost}
 		checkErrorContents(t, err, expected)
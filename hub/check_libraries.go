@@ -0,0 +1,292 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+const (
+	ExtensionKind = "extension"
+	LibraryKind   = "library"
+)
+
+// sharedPreloadLibraries is reported as the user of the libraries it lists.
+const sharedPreloadLibraries = "shared_preload_libraries"
+
+var ErrMissingLibraries = errors.New("missing libraries")
+
+// SourceLibraries maps the extensions and loadable libraries of the source
+// cluster to the databases using them.
+type SourceLibraries struct {
+	Extensions map[string][]string
+	Libraries  map[string][]string
+}
+
+func NewSourceLibraries() SourceLibraries {
+	return SourceLibraries{
+		Extensions: make(map[string][]string),
+		Libraries:  make(map[string][]string),
+	}
+}
+
+// MissingLibrary is a source extension or library which is not installed in
+// the target GPHOME of a host.
+type MissingLibrary struct {
+	Host   string
+	Kind   string
+	Name   string
+	UsedBy []string
+}
+
+func (m MissingLibrary) String() string {
+	return fmt.Sprintf("%s %q used by %s", m.Kind, m.Name, strings.Join(m.UsedBy, ", "))
+}
+
+type MissingLibrariesError struct {
+	GPHome  string
+	Missing []MissingLibrary
+}
+
+func (e MissingLibrariesError) Error() string {
+	report := []string{fmt.Sprintf("Found %d extensions and libraries of the source cluster which are not installed in the target GPHOME %s:", len(e.Missing), e.GPHome)}
+
+	host := ""
+	for _, m := range e.Missing {
+		if m.Host != host {
+			host = m.Host
+			report = append(report, fmt.Sprintf("  host %s:", host))
+		}
+
+		report = append(report, "    "+m.String())
+	}
+
+	report = append(report, "Install them into the target GPHOME on each host before upgrading.")
+	return strings.Join(report, "\n")
+}
+
+func (e MissingLibrariesError) Is(err error) bool {
+	return err == ErrMissingLibraries
+}
+
+// CheckLibraries verifies the extensions and loadable libraries used by the
+// source cluster are installed in the target GPHOME of every host, since
+// pg_upgrade only checks them once the target cluster exists. The master host
+// is checked by the hub unless it runs on another host.
+func CheckLibraries(agentConns []*Connection, conn *connURI.Conn, source *greenplum.Cluster, targetGPHome string) error {
+	libraries, err := QuerySourceLibraries(conn, source.MasterPort())
+	if err != nil {
+		return err
+	}
+
+	extensionNames := sortedKeys(libraries.Extensions)
+	libraryNames := sortedKeys(libraries.Libraries)
+
+	var mu sync.Mutex
+	var missing []MissingLibrary
+	add := func(host string, missingLibraries, missingExtensions []string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, name := range missingExtensions {
+			missing = append(missing, MissingLibrary{Host: host, Kind: ExtensionKind, Name: name, UsedBy: libraries.Extensions[name]})
+		}
+
+		for _, name := range missingLibraries {
+			missing = append(missing, MissingLibrary{Host: host, Kind: LibraryKind, Name: name, UsedBy: libraries.Libraries[name]})
+		}
+	}
+
+	if !source.RemoteMaster {
+		missingLibraries, missingExtensions := upgrade.MissingLibraries(targetGPHome, libraryNames, extensionNames)
+		add(source.MasterHostname(), missingLibraries, missingExtensions)
+	}
+
+	request := func(conn *Connection) error {
+		if !source.RemoteMaster && conn.Hostname == source.MasterHostname() {
+			return nil
+		}
+
+		reply, err := conn.AgentClient.CheckLibraries(context.Background(), &idl.CheckLibrariesRequest{
+			GpHome:     targetGPHome,
+			Libraries:  libraryNames,
+			Extensions: extensionNames,
+		})
+		if err != nil {
+			return xerrors.Errorf("check libraries on host %s: %w", conn.Hostname, err)
+		}
+
+		add(conn.Hostname, reply.GetMissingLibraries(), reply.GetMissingExtensions())
+		return nil
+	}
+
+	if err := ExecuteRPC(agentConns, request); err != nil {
+		return err
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].Host != missing[j].Host {
+			return missing[i].Host < missing[j].Host
+		}
+
+		if missing[i].Kind != missing[j].Kind {
+			return missing[i].Kind < missing[j].Kind
+		}
+
+		return missing[i].Name < missing[j].Name
+	})
+
+	return MissingLibrariesError{GPHome: targetGPHome, Missing: missing}
+}
+
+// QuerySourceLibraries returns the shared_preload_libraries of the source
+// cluster, along with the extensions and C function libraries of each
+// database accepting connections.
+func QuerySourceLibraries(conn *connURI.Conn, masterPort int) (libraries SourceLibraries, err error) {
+	db, err := utils.System.SqlOpen("pgx", conn.URI(connURI.ToSource(), connURI.Port(masterPort)))
+	if err != nil {
+		return SourceLibraries{}, err
+	}
+
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	libraries = NewSourceLibraries()
+
+	var preload string
+	if err := db.QueryRow(`SELECT current_setting('shared_preload_libraries')`).Scan(&preload); err != nil {
+		return SourceLibraries{}, xerrors.Errorf("querying shared_preload_libraries: %w", err)
+	}
+
+	for _, library := range strings.Split(preload, ",") {
+		library = strings.Trim(strings.TrimSpace(library), `"`)
+		if library != "" {
+			libraries.Libraries[library] = append(libraries.Libraries[library], sharedPreloadLibraries)
+		}
+	}
+
+	databases, err := getConnectableDatabases(db)
+	if err != nil {
+		return SourceLibraries{}, err
+	}
+
+	for _, database := range databases {
+		err := queryDatabaseLibraries(conn, masterPort, database, libraries)
+		if err != nil {
+			return SourceLibraries{}, err
+		}
+	}
+
+	return libraries, nil
+}
+
+func getConnectableDatabases(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`SELECT datname FROM pg_database WHERE datallowconn ORDER BY datname`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying databases: %w", err)
+	}
+	defer rows.Close()
+
+	var databases []string
+	for rows.Next() {
+		var database string
+		if err := rows.Scan(&database); err != nil {
+			return nil, xerrors.Errorf("scanning databases: %w", err)
+		}
+
+		databases = append(databases, database)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating databases: %w", err)
+	}
+
+	return databases, nil
+}
+
+func queryDatabaseLibraries(conn *connURI.Conn, masterPort int, database string, libraries SourceLibraries) (err error) {
+	db, err := utils.System.SqlOpen("pgx", conn.URI(connURI.ToSource(), connURI.Port(masterPort), connURI.Database(database)))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	// Objects created by initdb are skipped as in pg_upgrade, since the
+	// target provides its own.
+	queries := []struct {
+		kind  string
+		names map[string][]string
+		query string
+	}{
+		{ExtensionKind, libraries.Extensions, `SELECT extname FROM pg_extension WHERE oid >= 16384 ORDER BY extname`},
+		{LibraryKind, libraries.Libraries, `
+			SELECT DISTINCT p.probin
+			FROM pg_proc p JOIN pg_language l ON p.prolang = l.oid
+			WHERE l.lanname = 'c' AND p.probin IS NOT NULL AND p.oid >= 16384
+			ORDER BY p.probin`},
+	}
+
+	for _, q := range queries {
+		rows, err := db.Query(q.query)
+		if err != nil {
+			return xerrors.Errorf("querying %s names in database %q: %w", q.kind, database, err)
+		}
+
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				rows.Close()
+				return xerrors.Errorf("scanning %s names in database %q: %w", q.kind, database, err)
+			}
+
+			q.names[name] = append(q.names[name], database)
+		}
+
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return xerrors.Errorf("iterating %s names in database %q: %w", q.kind, database, err)
+		}
+
+		rows.Close()
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// mockSourceLibraries mocks a source cluster with the postgis extension in
+// the gis database and a preloaded metrics_collector library.
+func mockSourceLibraries(t *testing.T, conn *connURI.Conn) func() {
+	template1, template1Mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("couldn't create sqlmock: %v", err)
+	}
+
+	gis, gisMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("couldn't create sqlmock: %v", err)
+	}
+
+	template1Mock.ExpectQuery(`SELECT current_setting\('shared_preload_libraries'\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"shared_preload_libraries"}).AddRow("metrics_collector"))
+	template1Mock.ExpectQuery("SELECT datname FROM pg_database WHERE datallowconn").
+		WillReturnRows(sqlmock.NewRows([]string{"datname"}).AddRow("gis"))
+	template1Mock.ExpectClose()
+
+	gisMock.ExpectQuery("SELECT extname FROM pg_extension").
+		WillReturnRows(sqlmock.NewRows([]string{"extname"}).AddRow("postgis"))
+	gisMock.ExpectQuery("SELECT DISTINCT p.probin").
+		WillReturnRows(sqlmock.NewRows([]string{"probin"}).AddRow("$libdir/postgis-2.5"))
+	gisMock.ExpectClose()
+
+	utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+		if dataSourceName == conn.URI(connURI.ToSource(), connURI.Port(15432), connURI.Database("gis")) {
+			return gis, nil
+		}
+
+		return template1, nil
+	}
+
+	return func() {
+		utils.System = utils.InitializeSystemFunctions()
+		testutils.FinishMock(template1Mock, t)
+		testutils.FinishMock(gisMock, t)
+	}
+}
+
+func TestCheckLibraries(t *testing.T) {
+	conn := connURI.Connection(semver.MustParse("6.0.0"), semver.MustParse("7.0.0"))
+
+	source := MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Port: 15432, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Port: 25432, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 1, DbID: 3, Port: 25433, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+	})
+	source.Version = dbconn.NewVersion("6.20.0")
+	source.RemoteMaster = true
+
+	request := &idl.CheckLibrariesRequest{
+		GpHome:     "/usr/local/gpdb7",
+		Libraries:  []string{"$libdir/postgis-2.5", "metrics_collector"},
+		Extensions: []string{"postgis"},
+	}
+
+	t.Run("succeeds when every host has the libraries installed", func(t *testing.T) {
+		cleanup := mockSourceLibraries(t, conn)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var agentConns []*Connection
+		for _, host := range []string{"mdw", "sdw1", "sdw2"} {
+			client := mock_idl.NewMockAgentClient(ctrl)
+			client.EXPECT().CheckLibraries(gomock.Any(), request).Return(&idl.CheckLibrariesReply{}, nil)
+			agentConns = append(agentConns, &Connection{nil, client, host, nil})
+		}
+
+		err := CheckLibraries(agentConns, conn, source, "/usr/local/gpdb7")
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("reports the missing libraries of each host", func(t *testing.T) {
+		cleanup := mockSourceLibraries(t, conn)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+		mdw.EXPECT().CheckLibraries(gomock.Any(), request).Return(&idl.CheckLibrariesReply{}, nil)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckLibraries(gomock.Any(), request).Return(&idl.CheckLibrariesReply{
+			MissingLibraries:  []string{"$libdir/postgis-2.5"},
+			MissingExtensions: []string{"postgis"},
+		}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().CheckLibraries(gomock.Any(), request).Return(&idl.CheckLibrariesReply{
+			MissingLibraries: []string{"metrics_collector"},
+		}, nil)
+
+		agentConns := []*Connection{
+			{nil, mdw, "mdw", nil},
+			{nil, sdw1, "sdw1", nil},
+			{nil, sdw2, "sdw2", nil},
+		}
+
+		err := CheckLibraries(agentConns, conn, source, "/usr/local/gpdb7")
+
+		var missingErr MissingLibrariesError
+		if !errors.As(err, &missingErr) {
+			t.Fatalf("got error %#v want type %T", err, missingErr)
+		}
+
+		expected := []MissingLibrary{
+			{Host: "sdw1", Kind: ExtensionKind, Name: "postgis", UsedBy: []string{"gis"}},
+			{Host: "sdw1", Kind: LibraryKind, Name: "$libdir/postgis-2.5", UsedBy: []string{"gis"}},
+			{Host: "sdw2", Kind: LibraryKind, Name: "metrics_collector", UsedBy: []string{"shared_preload_libraries"}},
+		}
+		if !reflect.DeepEqual(missingErr.Missing, expected) {
+			t.Errorf("got %+v want %+v", missingErr.Missing, expected)
+		}
+
+		if !errors.Is(err, ErrMissingLibraries) || !strings.Contains(err.Error(), "  host sdw2:\n    library \"metrics_collector\"") {
+			t.Errorf("got error %q want a report per host", err.Error())
+		}
+	})
+
+	t.Run("errors when an agent fails", func(t *testing.T) {
+		cleanup := mockSourceLibraries(t, conn)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckLibraries(gomock.Any(), request).Return(nil, expected)
+
+		err := CheckLibraries([]*Connection{{nil, sdw1, "sdw1", nil}}, conn, source, "/usr/local/gpdb7")
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// initdbDatabases are created by gpinitsystem for the target cluster, and
+// pg_upgrade requires their collation and character type to match those of
+// the source. The remaining databases are recreated with their own locale.
+var initdbDatabases = []string{"template0", "template1", "postgres"}
+
+// gpinitsystem enables data checksums unless HEAP_CHECKSUM is overridden.
+const defaultHeapChecksum = "on"
+
+var ErrLocaleMismatch = errors.New("locale mismatch")
+
+// LocaleMismatch is a source setting pg_upgrade requires the target cluster
+// to match, along with the gpinitsystem setting that makes it match.
+type LocaleMismatch struct {
+	Database string
+	Setting  string
+	Source   string
+	Target   string
+	Fix      string
+}
+
+func (m LocaleMismatch) String() string {
+	if m.Database == "" {
+		return fmt.Sprintf("%s: source %q target %q", m.Setting, m.Source, m.Target)
+	}
+
+	return fmt.Sprintf("database %q %s: source %q target %q", m.Database, m.Setting, m.Source, m.Target)
+}
+
+type LocaleMismatchError struct {
+	Mismatches []LocaleMismatch
+}
+
+func (e LocaleMismatchError) Error() string {
+	report := []string{"The encoding, locale, or data checksums of the target cluster would not match the source cluster:"}
+
+	fixes := make(map[string]bool)
+	for _, m := range e.Mismatches {
+		report = append(report, "  "+m.String())
+		fixes[m.Fix] = true
+	}
+
+	var settings []string
+	for fix := range fixes {
+		settings = append(settings, fix)
+	}
+	sort.Strings(settings)
+
+	report = append(report, `Add the following to the [gpinitsystem] section of the gpupgrade config file, or pass each with "--gpinitsystem-override":`)
+	for _, setting := range settings {
+		report = append(report, "  "+setting)
+	}
+
+	return strings.Join(report, "\n")
+}
+
+func (e LocaleMismatchError) Is(err error) bool {
+	return err == ErrLocaleMismatch
+}
+
+// InitdbSettings are the encoding, locale, and data checksums of a cluster.
+type InitdbSettings struct {
+	Encoding     string
+	DataChecksum string
+	Locales      []DatabaseLocale
+}
+
+// DatabaseLocale is the collation and character type of a database.
+type DatabaseLocale struct {
+	Database string
+	Collate  string
+	Ctype    string
+}
+
+// CheckLocaleAndEncoding ensures the encoding, collation, character type,
+// and data checksums of the source cluster match those gpinitsystem will
+// create the target cluster with, since pg_upgrade only checks them once the
+// target cluster exists. The target settings are the gpinitsystem overrides,
+// or else the defaults of gpinitsystem on the master host.
+func CheckLocaleAndEncoding(conn *connURI.Conn, masterPort int, host string, overrides map[string]string) error {
+	options := []connURI.Option{
+		connURI.ToSource(),
+		connURI.Port(masterPort),
+	}
+
+	db, err := utils.System.SqlOpen("pgx", conn.URI(options...))
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	source, err := SourceInitdbSettings(db, conn.SourceVersion())
+	if err != nil {
+		return err
+	}
+
+	collate, ctype, err := defaultInitdbLocale(host)
+	if err != nil {
+		return err
+	}
+
+	target := TargetInitdbSettings(overrides, source.Encoding, collate, ctype)
+
+	return LocaleMismatches(source, target)
+}
+
+// SourceInitdbSettings returns the encoding, data checksums, and the locale of
+// the databases gpinitsystem creates for the target cluster. 5X has a single
+// locale for the cluster rather than one per database.
+func SourceInitdbSettings(db *sql.DB, version semver.Version) (InitdbSettings, error) {
+	var settings InitdbSettings
+
+	err := db.QueryRow(`SELECT current_setting('server_encoding'), current_setting('data_checksums')`).
+		Scan(&settings.Encoding, &settings.DataChecksum)
+	if err != nil {
+		return InitdbSettings{}, xerrors.Errorf("querying source encoding and data checksums: %w", err)
+	}
+
+	collate, ctype := "datcollate", "datctype"
+	if version.Major < 6 {
+		collate, ctype = "current_setting('lc_collate')", "current_setting('lc_ctype')"
+	}
+
+	rows, err := db.Query(fmt.Sprintf(`
+		SELECT datname, %s, %s
+		FROM pg_database
+		WHERE datname IN ('%s')
+		ORDER BY datname`, collate, ctype, strings.Join(initdbDatabases, "', '")))
+	if err != nil {
+		return InitdbSettings{}, xerrors.Errorf("querying source database locales: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l DatabaseLocale
+		if err := rows.Scan(&l.Database, &l.Collate, &l.Ctype); err != nil {
+			return InitdbSettings{}, xerrors.Errorf("scanning source database locales: %w", err)
+		}
+
+		settings.Locales = append(settings.Locales, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return InitdbSettings{}, xerrors.Errorf("iterating source database locales: %w", err)
+	}
+
+	return settings, nil
+}
+
+// TargetInitdbSettings returns the settings gpinitsystem will create the
+// target cluster with. gpupgrade carries over the source encoding unless it is
+// overridden.
+func TargetInitdbSettings(overrides map[string]string, sourceEncoding, defaultCollate, defaultCtype string) InitdbSettings {
+	value := func(def string, names ...string) string {
+		for _, name := range names {
+			if v, ok := overrides[name]; ok {
+				return unquoteInitsystemValue(v)
+			}
+		}
+
+		return def
+	}
+
+	target := InitdbSettings{
+		Encoding:     value(sourceEncoding, "ENCODING"),
+		DataChecksum: value(defaultHeapChecksum, "HEAP_CHECKSUM"),
+	}
+
+	for _, database := range initdbDatabases {
+		target.Locales = append(target.Locales, DatabaseLocale{
+			Database: database,
+			Collate:  value(defaultCollate, "LC_COLLATE", "LOCALE"),
+			Ctype:    value(defaultCtype, "LC_CTYPE", "LOCALE"),
+		})
+	}
+
+	return target
+}
+
+// LocaleMismatches returns a LocaleMismatchError listing the source settings
+// which the target settings do not match.
+func LocaleMismatches(source, target InitdbSettings) error {
+	var mismatches []LocaleMismatch
+
+	if !matchesEncoding(target.Encoding, source.Encoding) {
+		mismatches = append(mismatches, LocaleMismatch{Setting: "server_encoding", Source: source.Encoding, Target: target.Encoding,
+			Fix: "ENCODING=" + source.Encoding})
+	}
+
+	if !matchesBool(target.DataChecksum, source.DataChecksum) {
+		mismatches = append(mismatches, LocaleMismatch{Setting: "data_checksums", Source: source.DataChecksum, Target: target.DataChecksum,
+			Fix: "HEAP_CHECKSUM=" + source.DataChecksum})
+	}
+
+	targetLocales := make(map[string]DatabaseLocale)
+	for _, l := range target.Locales {
+		targetLocales[l.Database] = l
+	}
+
+	for _, l := range source.Locales {
+		t, ok := targetLocales[l.Database]
+		if !ok {
+			continue
+		}
+
+		if !matchesLocale(t.Collate, l.Collate) {
+			mismatches = append(mismatches, LocaleMismatch{Database: l.Database, Setting: "lc_collate", Source: l.Collate, Target: t.Collate,
+				Fix: "LC_COLLATE=" + l.Collate})
+		}
+
+		if !matchesLocale(t.Ctype, l.Ctype) {
+			mismatches = append(mismatches, LocaleMismatch{Database: l.Database, Setting: "lc_ctype", Source: l.Ctype, Target: t.Ctype,
+				Fix: "LC_CTYPE=" + l.Ctype})
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return LocaleMismatchError{Mismatches: mismatches}
+	}
+
+	return nil
+}
+
+// defaultInitdbLocale returns the collation and character type initdb
+// defaults to when run by gpinitsystem on the host, which is the locale of its
+// environment.
+func defaultInitdbLocale(host string) (string, string, error) {
+	cmd := commandOnHost(host, "locale")
+	if host == "" {
+		// Use the environment gpinitsystem is run with.
+		cmd.Env = filterEnv([]string{"HOME", "USER", "LOGNAME"})
+	}
+
+	stdout := new(bytes.Buffer)
+	cmd.Stdout = stdout
+
+	if err := cmd.Run(); err != nil {
+		return "", "", xerrors.Errorf("determining the default locale of gpinitsystem: %w", err)
+	}
+
+	return parseLocale(stdout.String())
+}
+
+// parseLocale returns LC_COLLATE and LC_CTYPE of the locale utility output,
+// where the POSIX locale is named C as in the database.
+func parseLocale(output string) (string, string, error) {
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		value := strings.Trim(parts[1], `"`)
+		if value == "POSIX" {
+			value = "C"
+		}
+
+		values[parts[0]] = value
+	}
+
+	collate, ok := values["LC_COLLATE"]
+	if !ok {
+		return "", "", xerrors.Errorf("LC_COLLATE not found in locale output %q", output)
+	}
+
+	ctype, ok := values["LC_CTYPE"]
+	if !ok {
+		return "", "", xerrors.Errorf("LC_CTYPE not found in locale output %q", output)
+	}
+
+	return collate, ctype, nil
+}
+
+func matchesEncoding(value, source string) bool {
+	return normalizeCharset(value) == normalizeCharset(source)
+}
+
+// matchesLocale compares locales ignoring the spelling of their codeset, such
+// that en_US.UTF-8 matches en_US.utf8.
+func matchesLocale(value, source string) bool {
+	valueParts := strings.SplitN(value, ".", 2)
+	sourceParts := strings.SplitN(source, ".", 2)
+	if valueParts[0] != sourceParts[0] || len(valueParts) != len(sourceParts) {
+		return false
+	}
+
+	return len(valueParts) == 1 || normalizeCharset(valueParts[1]) == normalizeCharset(sourceParts[1])
+}
+
+func normalizeCharset(charset string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(charset))
+}
+
+func matchesBool(value, source string) bool {
+	parse := func(v string) string {
+		switch strings.ToLower(v) {
+		case "on", "true", "yes", "1":
+			return "on"
+		case "off", "false", "no", "0":
+			return "off"
+		default:
+			return v
+		}
+	}
+
+	return parse(value) == parse(source)
+}
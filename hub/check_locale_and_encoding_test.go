@@ -0,0 +1,218 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func localePOSIX() {
+	os.Stdout.WriteString(`LANG=
+LC_CTYPE="POSIX"
+LC_COLLATE="POSIX"
+LC_ALL=
+`)
+}
+
+func init() {
+	exectest.RegisterMains(
+		localePOSIX,
+	)
+}
+
+func expectSourceInitdbSettings(mock sqlmock.Sqlmock, collate string) {
+	mock.ExpectQuery(`SELECT current_setting\('server_encoding'\), current_setting\('data_checksums'\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_encoding", "data_checksums"}).AddRow("UTF8", "on"))
+
+	rows := sqlmock.NewRows([]string{"datname", "datcollate", "datctype"})
+	for _, database := range []string{"postgres", "template0", "template1"} {
+		rows.AddRow(database, collate, collate)
+	}
+
+	mock.ExpectQuery("SELECT datname, datcollate, datctype FROM pg_database").WillReturnRows(rows)
+}
+
+func TestCheckLocaleAndEncoding(t *testing.T) {
+	conn := connURI.Connection(semver.MustParse("6.0.0"), semver.MustParse("7.0.0"))
+
+	mockDB := func(t *testing.T) (sqlmock.Sqlmock, func()) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+
+		utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+			return db, nil
+		}
+
+		execCommand = exectest.NewCommand(localePOSIX)
+
+		return mock, func() {
+			execCommand = nil
+			utils.System = utils.InitializeSystemFunctions()
+			testutils.FinishMock(mock, t)
+		}
+	}
+
+	t.Run("succeeds when the target defaults match the source", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectSourceInitdbSettings(mock, "C")
+
+		err := CheckLocaleAndEncoding(conn, 15432, "", nil)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("succeeds when the overrides match the source", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectSourceInitdbSettings(mock, "en_US.utf8")
+
+		err := CheckLocaleAndEncoding(conn, 15432, "", map[string]string{"LOCALE": "en_US.UTF-8"})
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("reports the gpinitsystem settings matching the source", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectSourceInitdbSettings(mock, "en_US.utf8")
+
+		err := CheckLocaleAndEncoding(conn, 15432, "", map[string]string{"HEAP_CHECKSUM": "off"})
+		if !errors.Is(err, ErrLocaleMismatch) {
+			t.Fatalf("got error %#v want %v", err, ErrLocaleMismatch)
+		}
+
+		for _, fix := range []string{"LC_COLLATE=en_US.utf8", "LC_CTYPE=en_US.utf8", "HEAP_CHECKSUM=on"} {
+			if !strings.Contains(err.Error(), "  "+fix) {
+				t.Errorf("expected error %q to contain %q", err.Error(), fix)
+			}
+		}
+	})
+
+	t.Run("errors when the source settings cannot be queried", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expected := errors.New("permission denied")
+		mock.ExpectQuery("SELECT current_setting").WillReturnError(expected)
+
+		err := CheckLocaleAndEncoding(conn, 15432, "", nil)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestSourceInitdbSettings(t *testing.T) {
+	t.Run("uses the cluster locale for 5X", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery("SELECT current_setting").
+			WillReturnRows(sqlmock.NewRows([]string{"server_encoding", "data_checksums"}).AddRow("UTF8", "off"))
+		mock.ExpectQuery(`SELECT datname, current_setting\('lc_collate'\), current_setting\('lc_ctype'\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"datname", "lc_collate", "lc_ctype"}).AddRow("template0", "C", "en_US.utf8"))
+
+		settings, err := SourceInitdbSettings(db, semver.MustParse("5.28.0"))
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := InitdbSettings{
+			Encoding:     "UTF8",
+			DataChecksum: "off",
+			Locales:      []DatabaseLocale{{Database: "template0", Collate: "C", Ctype: "en_US.utf8"}},
+		}
+		if !reflect.DeepEqual(settings, expected) {
+			t.Errorf("got %+v want %+v", settings, expected)
+		}
+	})
+}
+
+func TestLocaleMismatches(t *testing.T) {
+	source := InitdbSettings{
+		Encoding:     "UTF8",
+		DataChecksum: "on",
+		Locales: []DatabaseLocale{
+			{Database: "postgres", Collate: "en_US.utf8", Ctype: "en_US.utf8"},
+			{Database: "template1", Collate: "C", Ctype: "en_US.utf8"},
+		},
+	}
+
+	t.Run("matches equivalent settings", func(t *testing.T) {
+		target := InitdbSettings{
+			Encoding:     "utf-8",
+			DataChecksum: "true",
+			Locales: []DatabaseLocale{
+				{Database: "postgres", Collate: "en_US.UTF-8", Ctype: "en_US.UTF-8"},
+				{Database: "template1", Collate: "C", Ctype: "en_US.utf8"},
+			},
+		}
+
+		if err := LocaleMismatches(source, target); err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("reports each mismatch", func(t *testing.T) {
+		target := TargetInitdbSettings(map[string]string{"ENCODING": "LATIN1", "HEAP_CHECKSUM": "off"}, "UTF8", "C", "C")
+
+		err := LocaleMismatches(source, target)
+
+		var mismatchErr LocaleMismatchError
+		if !errors.As(err, &mismatchErr) {
+			t.Fatalf("got error %#v want type %T", err, mismatchErr)
+		}
+
+		expected := []LocaleMismatch{
+			{Setting: "server_encoding", Source: "UTF8", Target: "LATIN1", Fix: "ENCODING=UTF8"},
+			{Setting: "data_checksums", Source: "on", Target: "off", Fix: "HEAP_CHECKSUM=on"},
+			{Database: "postgres", Setting: "lc_collate", Source: "en_US.utf8", Target: "C", Fix: "LC_COLLATE=en_US.utf8"},
+			{Database: "postgres", Setting: "lc_ctype", Source: "en_US.utf8", Target: "C", Fix: "LC_CTYPE=en_US.utf8"},
+			{Database: "template1", Setting: "lc_ctype", Source: "en_US.utf8", Target: "C", Fix: "LC_CTYPE=en_US.utf8"},
+		}
+		if !reflect.DeepEqual(mismatchErr.Mismatches, expected) {
+			t.Errorf("got %+v want %+v", mismatchErr.Mismatches, expected)
+		}
+	})
+}
+
+func TestParseLocale(t *testing.T) {
+	collate, ctype, err := parseLocale("LANG=en_US.UTF-8\nLC_CTYPE=\"en_US.UTF-8\"\nLC_COLLATE=\"POSIX\"\n")
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	if collate != "C" || ctype != "en_US.UTF-8" {
+		t.Errorf("got collate %q ctype %q want %q and %q", collate, ctype, "C", "en_US.UTF-8")
+	}
+
+	_, _, err = parseLocale("LANG=C\n")
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
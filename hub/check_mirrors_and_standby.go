@@ -0,0 +1,73 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// CheckMirrorsAndStandby verifies the upgraded target cluster is usable with
+// its mirrors and standby. It waits up to timeout for every mirror and the
+// standby to synchronize, and then ensures distributed transactions can be
+// committed.
+func CheckMirrorsAndStandby(streams step.OutStreams, conn *connURI.Conn, masterPort int, timeout time.Duration) error {
+	options := []connURI.Option{
+		connURI.ToTarget(),
+		connURI.Port(masterPort),
+	}
+
+	db, err := utils.System.SqlOpen("pgx", conn.URI(options...))
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	if err := waitForMirrors(streams.Stdout(), db, conn.TargetVersion(), allMirrors, timeout); err != nil {
+		return err
+	}
+
+	return checkTransactions(db)
+}
+
+// checkTransactions commits a distributed transaction that writes to every
+// primary, which requires each synchronous mirror to acknowledge the commit.
+func checkTransactions(db *sql.DB) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return xerrors.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(); rErr != nil && !errors.Is(rErr, sql.ErrTxDone) {
+				err = errorlist.Append(err, rErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec("CREATE TEMP TABLE gpupgrade_check_transactions (a int) DISTRIBUTED RANDOMLY")
+	if err != nil {
+		return xerrors.Errorf("create table: %w", err)
+	}
+
+	_, err = tx.Exec("INSERT INTO gpupgrade_check_transactions SELECT generate_series(1, 100)")
+	if err != nil {
+		return xerrors.Errorf("insert rows: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return xerrors.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestCheckMirrorsAndStandby(t *testing.T) {
+	conn := connURI.Connection(semver.MustParse("5.0.0"), semver.MustParse("6.0.0"))
+
+	mockDB := func(t *testing.T) (sqlmock.Sqlmock, func()) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+
+		utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+			expected := conn.URI(connURI.ToTarget(), connURI.Port(15432))
+			if dataSourceName != expected {
+				t.Errorf("got: %q want: %q", dataSourceName, expected)
+			}
+
+			return db, nil
+		}
+
+		return mock, func() {
+			utils.System = utils.InitializeSystemFunctions()
+			testutils.FinishMock(mock, t)
+		}
+	}
+
+	standby := MirrorStatus{Content: -1, Hostname: "smdw", Status: "u", Mode: "s"}
+	synchronized := []MirrorStatus{
+		{Content: -1, Hostname: "smdw", Status: "u", Mode: "s", Replicating: true, State: "streaming", SyncState: "sync"},
+		synchronizedMirror,
+	}
+
+	t.Run("succeeds when the mirrors and standby are synchronized and transactions commit", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, allMirrors, synchronized...)
+		expectTransactionCheck(mock)
+
+		err := CheckMirrorsAndStandby(step.DevNullStream, conn, 15432, DefaultMirrorSyncTimeout)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("waits for the mirrors and standby to synchronize", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, allMirrors, standby, synchronizedMirror)
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, allMirrors, synchronized...)
+		expectTransactionCheck(mock)
+
+		err := CheckMirrorsAndStandby(step.DevNullStream, conn, 15432, DefaultMirrorSyncTimeout)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("times out naming the mirrors and standby that are not synchronized", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, allMirrors,
+			standby,
+			synchronizedMirror,
+			MirrorStatus{Content: 1, Hostname: "sdw1", Status: "u", Mode: "c", Replicating: true, State: "catchup", SyncState: "async"})
+
+		err := CheckMirrorsAndStandby(step.DevNullStream, conn, 15432, -1*time.Second)
+		if !errors.Is(err, ErrMirrorsNotSynchronized) {
+			t.Fatalf("got error %#v want %#v", err, ErrMirrorsNotSynchronized)
+		}
+
+		expected := "-1s timeout exceeded waiting for mirrors to synchronize: " +
+			"standby master on smdw (not connected), " +
+			"content 1 on sdw1 (status u, mode c)"
+		if err.Error() != expected {
+			t.Errorf("got error %q want %q", err.Error(), expected)
+		}
+	})
+
+	t.Run("returns errors when a transaction cannot be committed", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, allMirrors, synchronized...)
+
+		expected := errors.New("could not commit")
+		mock.ExpectBegin()
+		mock.ExpectExec(`CREATE TEMP TABLE gpupgrade_check_transactions`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO gpupgrade_check_transactions`).WillReturnResult(sqlmock.NewResult(0, 100))
+		mock.ExpectCommit().WillReturnError(expected)
+
+		err := CheckMirrorsAndStandby(step.DevNullStream, conn, 15432, DefaultMirrorSyncTimeout)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("rolls back the transaction when it fails", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, allMirrors, synchronized...)
+
+		expected := errors.New("out of memory")
+		mock.ExpectBegin()
+		mock.ExpectExec(`CREATE TEMP TABLE gpupgrade_check_transactions`).WillReturnError(expected)
+		mock.ExpectRollback()
+
+		err := CheckMirrorsAndStandby(step.DevNullStream, conn, 15432, DefaultMirrorSyncTimeout)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func expectTransactionCheck(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`CREATE TEMP TABLE gpupgrade_check_transactions \(a int\) DISTRIBUTED RANDOMLY`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO gpupgrade_check_transactions SELECT generate_series\(1, 100\)`).
+		WillReturnResult(sqlmock.NewResult(0, 100))
+	mock.ExpectCommit()
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+// CheckTablespaceRelocations verifies the tablespace relocations before the
+// target cluster is created. Every relocation must match a tablespace, and the
+// hosts of the relocated primaries and their mirrors must be able to create
+// the relocated tablespace directories with enough space to hold them.
+func CheckTablespaceRelocations(agentConns []*Connection, source *greenplum.Cluster, tablespaces greenplum.Tablespaces, relocations greenplum.TablespaceRelocations) error {
+	if unused := relocations.Unused(source, tablespaces); len(unused) > 0 {
+		var names []string
+		for _, relocation := range unused {
+			names = append(names, relocation.String())
+		}
+
+		return xerrors.Errorf("tablespace relocations do not match any primary segment tablespace: %s", strings.Join(names, ", "))
+	}
+
+	requests := tablespaceRelocationRequests(source, tablespaces, relocations)
+
+	request := func(conn *Connection) error {
+		relocations := requests[conn.Hostname]
+		if len(relocations) == 0 {
+			return nil
+		}
+
+		_, err := conn.AgentClient.CheckTablespaceRelocations(context.Background(), &idl.CheckTablespaceRelocationsRequest{
+			Relocations: relocations,
+		})
+		if err != nil {
+			return xerrors.Errorf("check tablespace relocations on host %s: %w", conn.Hostname, err)
+		}
+
+		return nil
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
+
+// tablespaceRelocationRequests returns the relocated tablespace directories to
+// check on each host. The target mirrors are created at the tablespace
+// locations of their primaries, so they are checked on the mirror hosts using
+// the relocated location of their primary.
+func tablespaceRelocationRequests(source *greenplum.Cluster, tablespaces greenplum.Tablespaces, relocations greenplum.TablespaceRelocations) map[string][]*idl.CheckTablespaceRelocationsRequest_Relocation {
+	relocated := tablespaces.Relocate(source, relocations)
+	requests := make(map[string][]*idl.CheckTablespaceRelocationsRequest_Relocation)
+
+	for _, content := range source.ContentIDs {
+		if content == -1 {
+			continue
+		}
+
+		primary := source.Primaries[content]
+		mirror, hasMirror := source.Mirrors[content]
+
+		for _, oid := range sortedTablespaceOids(tablespaces[primary.DbID]) {
+			tsInfo := tablespaces[primary.DbID][oid]
+			location := relocated[primary.DbID][oid].Location
+			if !tsInfo.IsUserDefined() || location == tsInfo.Location {
+				continue
+			}
+
+			requests[primary.Hostname] = append(requests[primary.Hostname], &idl.CheckTablespaceRelocationsRequest_Relocation{
+				SourceDir: sourceTablespaceDir(source, tsInfo, primary.DbID),
+				TargetDir: filepath.Join(location, strconv.Itoa(primary.DbID)),
+			})
+
+			if !hasMirror {
+				continue
+			}
+
+			requests[mirror.Hostname] = append(requests[mirror.Hostname], &idl.CheckTablespaceRelocationsRequest_Relocation{
+				SourceDir: sourceTablespaceDir(source, tablespaces[mirror.DbID][oid], mirror.DbID),
+				TargetDir: filepath.Join(location, strconv.Itoa(mirror.DbID)),
+			})
+		}
+	}
+
+	return requests
+}
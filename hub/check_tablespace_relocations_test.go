@@ -0,0 +1,107 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+)
+
+func TestCheckTablespaceRelocations(t *testing.T) {
+	source := MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 4, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		{ContentID: 1, DbID: 3, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+	})
+	source.Version = dbconn.NewVersion("6.20.0")
+
+	tablespaces := greenplum.Tablespaces{
+		1: {16384: {Location: "/data/ts", UserDefined: 1}},
+		2: {
+			1663:  {Location: "/data/dbfast1/seg1", UserDefined: 0},
+			16384: {Location: "/data/ts", UserDefined: 1},
+		},
+		3: {16384: {Location: "/data/ts", UserDefined: 1}},
+		4: {16384: {Location: "/data/ts", UserDefined: 1}},
+	}
+
+	relocations := greenplum.TablespaceRelocations{
+		{Host: "sdw1", OldPrefix: "/data", NewPrefix: "/mnt/fast"},
+	}
+
+	t.Run("checks the relocated directories of the primaries and their mirrors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckTablespaceRelocations(gomock.Any(), &idl.CheckTablespaceRelocationsRequest{
+			Relocations: []*idl.CheckTablespaceRelocationsRequest_Relocation{
+				{SourceDir: "/data/ts/2", TargetDir: "/mnt/fast/ts/2"},
+			},
+		}).Return(&idl.CheckTablespaceRelocationsReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().CheckTablespaceRelocations(gomock.Any(), &idl.CheckTablespaceRelocationsRequest{
+			Relocations: []*idl.CheckTablespaceRelocationsRequest_Relocation{
+				{SourceDir: "/data/ts/4", TargetDir: "/mnt/fast/ts/4"},
+			},
+		}).Return(&idl.CheckTablespaceRelocationsReply{}, nil)
+
+		agentConns := []*Connection{
+			{nil, sdw1, "sdw1", nil},
+			{nil, sdw2, "sdw2", nil},
+		}
+
+		err := CheckTablespaceRelocations(agentConns, source, tablespaces, relocations)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("errors when a relocation does not match any tablespace", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		unused := append(relocations, greenplum.TablespaceRelocation{Host: "sdw2", OldPrefix: "/other", NewPrefix: "/mnt/other"})
+
+		err := CheckTablespaceRelocations(nil, source, tablespaces, unused)
+		if err == nil || !strings.Contains(err.Error(), "sdw2:/other -> /mnt/other") {
+			t.Errorf("got error %v, want unused relocation error", err)
+		}
+	})
+
+	t.Run("errors when an agent check fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckTablespaceRelocations(gomock.Any(), gomock.Any()).
+			Return(nil, expected)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().CheckTablespaceRelocations(gomock.Any(), gomock.Any()).
+			Return(&idl.CheckTablespaceRelocationsReply{}, nil)
+
+		agentConns := []*Connection{
+			{nil, sdw1, "sdw1", nil},
+			{nil, sdw2, "sdw2", nil},
+		}
+
+		err := CheckTablespaceRelocations(agentConns, source, tablespaces, relocations)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+// DefaultManifestAllowlist are the GPHOME files expected to differ across
+// hosts, such as the Python bytecode compiled on each host when first used.
+var DefaultManifestAllowlist = []string{"*.pyc", "__pycache__"}
+
+// The ways a GPHOME file of a host differs from the hub's.
+const (
+	ManifestMissing    = "missing"
+	ManifestDiffers    = "differs"
+	ManifestUnexpected = "unexpected"
+)
+
+var ErrGPHomeMismatch = errors.New("GPHOME mismatch")
+
+// ManifestDifference is a file of the GPHOME on a host which differs from the
+// GPHOME of the hub.
+type ManifestDifference struct {
+	Host string
+	Path string
+	Kind string
+}
+
+type GPHomeMismatchError struct {
+	GPHome      string
+	Differences []ManifestDifference
+}
+
+func (e GPHomeMismatchError) Error() string {
+	report := []string{fmt.Sprintf("The target GPHOME %s differs from the installation on the hub host:", e.GPHome)}
+
+	host := ""
+	for _, d := range e.Differences {
+		if d.Host != host {
+			host = d.Host
+			report = append(report, fmt.Sprintf("  host %s:", host))
+		}
+
+		report = append(report, fmt.Sprintf("    %s %s", d.Kind, d.Path))
+	}
+
+	report = append(report, `Reinstall the target GPHOME on these hosts, or add the files expected to differ to "--gphome-allowlist".`)
+	return strings.Join(report, "\n")
+}
+
+func (e GPHomeMismatchError) Is(err error) bool {
+	return err == ErrGPHomeMismatch
+}
+
+// CheckTargetGPHome verifies the target GPHOME of every host has the same
+// files as that of the hub, ignoring files matching the allowlist along with
+// DefaultManifestAllowlist. The hub host itself is skipped.
+func CheckTargetGPHome(agentConns []*Connection, hubHost string, gphome string, allowlist []string) error {
+	expected, err := upgrade.GPHomeManifest(gphome)
+	if err != nil {
+		return err
+	}
+
+	allowlist = append(append([]string{}, DefaultManifestAllowlist...), allowlist...)
+
+	var mu sync.Mutex
+	var differences []ManifestDifference
+	request := func(conn *Connection) error {
+		if conn.Hostname == hubHost {
+			return nil
+		}
+
+		manifest, err := receiveManifest(conn, gphome)
+		if err != nil {
+			return xerrors.Errorf("get GPHOME manifest on host %s: %w", conn.Hostname, err)
+		}
+
+		hostDifferences := CompareManifests(conn.Hostname, expected, manifest, allowlist)
+
+		mu.Lock()
+		defer mu.Unlock()
+		differences = append(differences, hostDifferences...)
+
+		return nil
+	}
+
+	if err := ExecuteRPC(agentConns, request); err != nil {
+		return err
+	}
+
+	if len(differences) == 0 {
+		return nil
+	}
+
+	sort.Slice(differences, func(i, j int) bool {
+		if differences[i].Host != differences[j].Host {
+			return differences[i].Host < differences[j].Host
+		}
+
+		return differences[i].Path < differences[j].Path
+	})
+
+	return GPHomeMismatchError{GPHome: gphome, Differences: differences}
+}
+
+// receiveManifest merges the chunks of the GPHOME manifest streamed by the
+// agent.
+func receiveManifest(conn *Connection, gphome string) (map[string][]byte, error) {
+	stream, err := conn.AgentClient.GetGPHomeManifest(context.Background(), &idl.GetGPHomeManifestRequest{GpHome: gphome})
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := make(map[string][]byte)
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return manifest, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for path, checksum := range reply.GetChecksums() {
+			manifest[path] = checksum
+		}
+	}
+}
+
+// CompareManifests returns the files of the host's manifest which are
+// missing, differ, or are unexpected compared to the expected manifest, other
+// than those matching the allowlist.
+func CompareManifests(host string, expected, actual map[string][]byte, allowlist []string) []ManifestDifference {
+	var differences []ManifestDifference
+
+	for path, checksum := range expected {
+		if manifestAllowed(path, allowlist) {
+			continue
+		}
+
+		hostChecksum, ok := actual[path]
+		switch {
+		case !ok:
+			differences = append(differences, ManifestDifference{Host: host, Path: path, Kind: ManifestMissing})
+		case !bytes.Equal(checksum, hostChecksum):
+			differences = append(differences, ManifestDifference{Host: host, Path: path, Kind: ManifestDiffers})
+		}
+	}
+
+	for path := range actual {
+		if _, ok := expected[path]; !ok && !manifestAllowed(path, allowlist) {
+			differences = append(differences, ManifestDifference{Host: host, Path: path, Kind: ManifestUnexpected})
+		}
+	}
+
+	return differences
+}
+
+// manifestAllowed returns whether the path relative to the GPHOME, or one of
+// its parent directories, matches an allowlist pattern. Patterns without a
+// slash match the base name at any depth, and the others the relative path.
+func manifestAllowed(path string, allowlist []string) bool {
+	for p := path; p != "." && p != "/"; p = filepath.Dir(p) {
+		for _, pattern := range allowlist {
+			name := p
+			if !strings.Contains(pattern, "/") {
+				name = filepath.Base(p)
+			}
+
+			if matched, _ := filepath.Match(pattern, name); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
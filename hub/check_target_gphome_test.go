@@ -0,0 +1,179 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+// manifestStream returns a stream of the manifest chunks followed by io.EOF.
+func manifestStream(ctrl *gomock.Controller, chunks ...map[string][]byte) idl.Agent_GetGPHomeManifestClient {
+	stream := mock_idl.NewMockAgent_GetGPHomeManifestClient(ctrl)
+
+	var calls []*gomock.Call
+	for _, chunk := range chunks {
+		calls = append(calls, stream.EXPECT().Recv().Return(&idl.GetGPHomeManifestReply{Checksums: chunk}, nil))
+	}
+	calls = append(calls, stream.EXPECT().Recv().Return(nil, io.EOF))
+	gomock.InOrder(calls...)
+
+	return stream
+}
+
+func TestCheckTargetGPHome(t *testing.T) {
+	gphome := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, gphome)
+
+	if err := os.MkdirAll(filepath.Join(gphome, "bin"), 0755); err != nil {
+		t.Fatalf("creating GPHOME: %v", err)
+	}
+	testutils.MustWriteToFile(t, filepath.Join(gphome, "bin", "postgres"), "postgres")
+	testutils.MustWriteToFile(t, filepath.Join(gphome, "bin", "gpstart"), "gpstart")
+
+	manifest, err := upgrade.GPHomeManifest(gphome)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	request := &idl.GetGPHomeManifestRequest{GpHome: gphome}
+
+	t.Run("succeeds when every host matches the hub", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// The hub host is not requested.
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetGPHomeManifest(gomock.Any(), request).Return(manifestStream(ctrl,
+			map[string][]byte{
+				"bin/postgres": manifest["bin/postgres"],
+				"bin/gpstart":  manifest["bin/gpstart"],
+			},
+			map[string][]byte{
+				"lib/python/gppylib/__init__.pyc": []byte("bytecode"),
+			},
+		), nil)
+
+		agentConns := []*Connection{
+			{nil, mdw, "mdw", nil},
+			{nil, sdw1, "sdw1", nil},
+		}
+
+		err := CheckTargetGPHome(agentConns, "mdw", gphome, nil)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("reports the differing files of each host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetGPHomeManifest(gomock.Any(), request).Return(manifestStream(ctrl, map[string][]byte{
+			"bin/postgres": []byte("patched"),
+			"bin/gpstart":  manifest["bin/gpstart"],
+			"etc/local.so": []byte("local"),
+		}), nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().GetGPHomeManifest(gomock.Any(), request).Return(manifestStream(ctrl, map[string][]byte{
+			"bin/postgres": manifest["bin/postgres"],
+		}), nil)
+
+		agentConns := []*Connection{
+			{nil, sdw2, "sdw2", nil},
+			{nil, sdw1, "sdw1", nil},
+		}
+
+		err := CheckTargetGPHome(agentConns, "mdw", gphome, nil)
+
+		var mismatchErr GPHomeMismatchError
+		if !errors.As(err, &mismatchErr) {
+			t.Fatalf("got error %#v want type %T", err, mismatchErr)
+		}
+
+		expected := []ManifestDifference{
+			{Host: "sdw1", Path: "bin/postgres", Kind: ManifestDiffers},
+			{Host: "sdw1", Path: "etc/local.so", Kind: ManifestUnexpected},
+			{Host: "sdw2", Path: "bin/gpstart", Kind: ManifestMissing},
+		}
+		if !reflect.DeepEqual(mismatchErr.Differences, expected) {
+			t.Errorf("got %+v want %+v", mismatchErr.Differences, expected)
+		}
+
+		if !errors.Is(err, ErrGPHomeMismatch) || !strings.Contains(err.Error(), "  host sdw2:\n    missing bin/gpstart") {
+			t.Errorf("got error %q want a report per host", err.Error())
+		}
+	})
+
+	t.Run("skips files of the allowlist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetGPHomeManifest(gomock.Any(), request).Return(manifestStream(ctrl, map[string][]byte{
+			"bin/postgres":         manifest["bin/postgres"],
+			"etc/local.so":         []byte("local"),
+			"share/timezone/UTC":   []byte("UTC"),
+			"share/timezone/Local": []byte("local"),
+		}), nil)
+
+		agentConns := []*Connection{{nil, sdw1, "sdw1", nil}}
+
+		err := CheckTargetGPHome(agentConns, "mdw", gphome, []string{"gpstart", "etc/*.so", "share/timezone"})
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("errors when receiving the manifest fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("connection reset")
+		stream := mock_idl.NewMockAgent_GetGPHomeManifestClient(ctrl)
+		stream.EXPECT().Recv().Return(nil, expected)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetGPHomeManifest(gomock.Any(), request).Return(stream, nil)
+
+		agentConns := []*Connection{{nil, sdw1, "sdw1", nil}}
+
+		err := CheckTargetGPHome(agentConns, "mdw", gphome, nil)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("errors when an agent fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetGPHomeManifest(gomock.Any(), request).Return(nil, expected)
+
+		agentConns := []*Connection{{nil, sdw1, "sdw1", nil}}
+
+		err := CheckTargetGPHome(agentConns, "mdw", gphome, nil)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -6,6 +6,7 @@ package hub
 import (
 	"sync"
 
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/step"
@@ -43,6 +44,7 @@ func (s *Server) CheckUpgrade(stream step.OutStreams, conns []*Connection) error
 			Stream:      stream,
 			CheckOnly:   true,
 			UseLinkMode: s.UseLinkMode,
+			AgentConns:  conns,
 		})
 	}()
 
@@ -56,6 +58,15 @@ func (s *Server) CheckUpgrade(stream step.OutStreams, conns []*Connection) error
 			return
 		}
 
+		// The source primaries are not copied to the target hosts of a host
+		// mapping until execute, so they cannot be checked yet.
+		for _, host := range s.HostMapping.TargetHosts() {
+			if len(dataDirPairMap[host]) > 0 {
+				gplog.Info("skipping the pg_upgrade check of the primary segments on mapped host %s", host)
+			}
+			delete(dataDirPairMap, host)
+		}
+
 		checkErrs <- upgrader.UpgradePrimaries(UpgradePrimaryArgs{
 			CheckOnly:       true,
 			MasterBackupDir: "",
@@ -5,6 +5,9 @@ package hub
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"time"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 
@@ -28,9 +31,42 @@ func (s *Server) GetConfig(ctx context.Context, in *idl.GetConfigRequest) (*idl.
 		if s.Target != nil {
 			resp.Value = s.Target.MasterDataDir()
 		}
+	case "gpinitsystem-config":
+		contents, err := ioutil.ReadFile(s.initsystemConfPath())
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		resp.Value = string(contents)
+	case "finalize-target-version":
+		resp.Value = s.FinalizeSummary.TargetVersion
+	case "finalize-log-archive-dir":
+		resp.Value = s.FinalizeSummary.LogArchiveDirectory
+	case "finalize-archived-source-master-datadir":
+		resp.Value = s.FinalizeSummary.ArchivedSourceMasterDataDirectory
+	case "finalize-start-time":
+		resp.Value = formatTime(s.FinalizeSummary.StartTime)
+	case "finalize-end-time":
+		resp.Value = formatTime(s.FinalizeSummary.EndTime)
+	case "revert-source-version":
+		resp.Value = s.RevertSummary.SourceVersion
+	case "revert-log-archive-dir":
+		resp.Value = s.RevertSummary.LogArchiveDirectory
+	case "revert-start-time":
+		resp.Value = formatTime(s.RevertSummary.StartTime)
+	case "revert-end-time":
+		resp.Value = formatTime(s.RevertSummary.EndTime)
 	default:
 		return nil, status.Errorf(codes.NotFound, "%s is not a valid configuration key", in.Name)
 	}
 
 	return resp, nil
 }
+
+// formatTime returns an empty string for times that have not been recorded.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func TestGetConfig(t *testing.T) {
+	conf := &hub.Config{
+		FinalizeSummary: hub.FinalizeSummary{
+			TargetVersion:                     "6.20.0",
+			LogArchiveDirectory:               "/home/gpadmin/gpAdminLogs/gpupgrade-finalize",
+			ArchivedSourceMasterDataDirectory: "/data/qddir/demoDataDir-1_old",
+			StartTime:                         time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC),
+		},
+		RevertSummary: hub.RevertSummary{
+			SourceVersion:       "5.28.10",
+			LogArchiveDirectory: "/home/gpadmin/gpAdminLogs/gpupgrade-revert",
+			StartTime:           time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC),
+			EndTime:             time.Date(2021, 1, 2, 3, 4, 0, 0, time.UTC),
+		},
+	}
+
+	server := hub.New(conf, nil, "")
+
+	cases := []struct {
+		name     string
+		expected string
+	}{
+		{"finalize-target-version", "6.20.0"},
+		{"finalize-log-archive-dir", "/home/gpadmin/gpAdminLogs/gpupgrade-finalize"},
+		{"finalize-archived-source-master-datadir", "/data/qddir/demoDataDir-1_old"},
+		{"finalize-start-time", "2021-01-02T03:00:00Z"},
+		{"finalize-end-time", ""},
+		{"revert-source-version", "5.28.10"},
+		{"revert-log-archive-dir", "/home/gpadmin/gpAdminLogs/gpupgrade-revert"},
+		{"revert-start-time", "2021-01-02T03:00:00Z"},
+		{"revert-end-time", "2021-01-02T03:04:00Z"},
+		{"gpinitsystem-config", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, err := server.GetConfig(context.Background(), &idl.GetConfigRequest{Name: c.name})
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+
+			if resp.Value != c.expected {
+				t.Errorf("got %q want %q", resp.Value, c.expected)
+			}
+		})
+	}
+
+	t.Run("errors for unknown keys", func(t *testing.T) {
+		_, err := server.GetConfig(context.Background(), &idl.GetConfigRequest{Name: "unknown"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("got error %#v want code %v", err, codes.NotFound)
+		}
+	})
+}
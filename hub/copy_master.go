@@ -5,13 +5,18 @@ package hub
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"path/filepath"
+	"strconv"
 	"sync"
 
 	"golang.org/x/xerrors"
 
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
@@ -22,7 +27,7 @@ type Result struct {
 	err    error
 }
 
-func Copy(streams step.OutStreams, destinationDir string, sourceDirs, hosts []string) error {
+func Copy(streams step.OutStreams, destinationDir string, sourceDirs, hosts []string, options ...string) error {
 	/*
 	 * Copy the directories once per host.
 	 */
@@ -44,6 +49,7 @@ func Copy(streams step.OutStreams, destinationDir string, sourceDirs, hosts []st
 				rsync.WithDestinationHost(hostname),
 				rsync.WithDestination(destinationDir),
 				rsync.WithOptions("--archive", "--compress", "--delete", "--stats"),
+				rsync.WithOptions(options...),
 				rsync.WithStream(stream),
 			}
 
@@ -79,12 +85,49 @@ func Copy(streams step.OutStreams, destinationDir string, sourceDirs, hosts []st
 }
 
 func (s *Server) CopyMasterDataDir(streams step.OutStreams, destination string) error {
+	if s.Target.RemoteMaster {
+		agentConns, err := s.AgentConns()
+		if err != nil {
+			return err
+		}
+
+		return CopyRemoteMasterDataDir(agentConns, s.Target, destination)
+	}
+
 	// Make sure sourceDir ends with a trailing slash so that rsync will
 	// transfer the directory contents and not the directory itself.
 	source := []string{filepath.Clean(s.Target.MasterDataDir()) + string(filepath.Separator)}
 	return Copy(streams, destination, source, s.Target.PrimaryHostnames())
 }
 
+// CopyRemoteMasterDataDir copies the upgraded master data directory to the
+// primary hosts through the agent on the master host, for a hub that does not
+// run on the master host.
+func CopyRemoteMasterDataDir(agentConns []*Connection, target *greenplum.Cluster, destination string) error {
+	conn, err := masterAgentConn(agentConns, target)
+	if err != nil {
+		return err
+	}
+
+	req := &idl.RsyncRequest{
+		Options: []string{"--archive", "--compress", "--delete", "--stats"},
+	}
+
+	for _, host := range target.PrimaryHostnames() {
+		req.Pairs = append(req.Pairs, &idl.RsyncPair{
+			Source:          filepath.Clean(target.MasterDataDir()),
+			DestinationHost: host,
+			Destination:     destination,
+		})
+	}
+
+	if _, err := conn.AgentClient.RsyncDataDirectories(context.Background(), req); err != nil {
+		return xerrors.Errorf("copying master data directory from host %s: %w", conn.Hostname, err)
+	}
+
+	return nil
+}
+
 func (s *Server) CopyMasterTablespaces(streams step.OutStreams, destinationDir string) error {
 	if s.Tablespaces == nil {
 		return nil
@@ -93,13 +136,39 @@ func (s *Server) CopyMasterTablespaces(streams step.OutStreams, destinationDir s
 	// include tablespace mapping file which is used as a parameter to pg_upgrade
 	sourcePaths := []string{s.TablespacesMappingFilePath}
 
-	// include all the master tablespace directories
-	for _, tablespace := range s.Tablespaces.GetMasterTablespaces() {
+	if !s.Source.Version.AtLeast("6") {
+		// include all the master tablespace directories
+		for _, tablespace := range s.Tablespaces.GetMasterTablespaces() {
+			if !tablespace.IsUserDefined() {
+				continue
+			}
+			sourcePaths = append(sourcePaths, tablespace.Location)
+		}
+
+		return Copy(streams, destinationDir, sourcePaths, s.Target.PrimaryHostnames())
+	}
+
+	if err := Copy(streams, destinationDir, sourcePaths, s.Target.PrimaryHostnames()); err != nil {
+		return err
+	}
+
+	// The 6X master tablespace location also contains the source cluster, so
+	// only copy the target version directory. The "/./" marks where the path
+	// recreated by --relative starts, resulting in the same
+	// <tablespaceOid>/<dbId>/GPDB_<majorVersion>_<catalogVersion> layout as 5X.
+	for oid, tablespace := range s.Tablespaces.GetMasterTablespaces() {
 		if !tablespace.IsUserDefined() {
 			continue
 		}
-		sourcePaths = append(sourcePaths, tablespace.Location)
+
+		dir := upgrade.TablespacePath("", greenplum.MasterDbid, s.Target.Version.SemVer.Major, s.TargetCatalogVersion)
+		source := filepath.Clean(tablespace.Location) + "/./" + dir
+		destination := filepath.Join(destinationDir, strconv.Itoa(oid))
+
+		if err := Copy(streams, destination, []string{source}, s.Target.PrimaryHostnames(), "--relative"); err != nil {
+			return err
+		}
 	}
 
-	return Copy(streams, destinationDir, sourcePaths, s.Target.PrimaryHostnames())
+	return nil
 }
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
 	"google.golang.org/grpc"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
@@ -198,14 +199,22 @@ func TestCopyMasterDataDir(t *testing.T) {
 }
 
 func TestCopyMasterTablespaces(t *testing.T) {
+	testlog.SetupLogger()
+
 	targetCluster := MustCreateCluster(t, []greenplum.SegConfig{
 		{ContentID: -1, DbID: 1, Port: 15432, Hostname: "localhost", DataDir: "/data/qddir/seg-1", Role: "p"},
 		{ContentID: 0, DbID: 2, Port: 25432, Hostname: "host1", DataDir: "/data/dbfast1/seg1", Role: "p"},
 		{ContentID: 1, DbID: 3, Port: 25433, Hostname: "host2", DataDir: "/data/dbfast2/seg2", Role: "p"},
 	})
 
+	sourceCluster := MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Port: 15432, Hostname: "localhost", DataDir: "/data/qddir/seg-1", Role: "p"},
+	})
+	sourceCluster.Version = dbconn.NewVersion("5.28.0")
+
 	t.Run("copies tablespace mapping file and master tablespace directory to each primary host", func(t *testing.T) {
 		conf := &Config{
+			Source: sourceCluster,
 			Target: targetCluster,
 			Tablespaces: greenplum.Tablespaces{
 				1: greenplum.SegmentTablespaces{
@@ -258,6 +267,120 @@ func TestCopyMasterTablespaces(t *testing.T) {
 		verifyHosts(hosts, expectedHosts, t)
 	})
 
+	t.Run("copies only the target version directory of 6X master tablespaces to each primary host", func(t *testing.T) {
+		source := MustCreateCluster(t, []greenplum.SegConfig{
+			{ContentID: -1, DbID: 1, Port: 15432, Hostname: "localhost", DataDir: "/data/qddir/seg-1", Role: "p"},
+		})
+		source.Version = dbconn.NewVersion("6.15.0")
+
+		target := *targetCluster
+		target.Version = dbconn.NewVersion("6.17.0")
+
+		conf := &Config{
+			Source: source,
+			Target: &target,
+			Tablespaces: greenplum.Tablespaces{
+				1: greenplum.SegmentTablespaces{
+					1663: greenplum.TablespaceInfo{
+						Location:    "/data/qddir/seg-1",
+						UserDefined: 0},
+					16386: greenplum.TablespaceInfo{
+						Location:    "/tmp/tblspc",
+						UserDefined: 1},
+				},
+			},
+			TablespacesMappingFilePath: "/tmp/mapping.txt",
+			TargetCatalogVersion:       "301908233",
+		}
+		hub := New(conf, grpc.DialContext, ".gpupgrade")
+
+		calls := make(chan []string, 2*len(target.PrimaryHostnames()))
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(Success, func(name string, args ...string) {
+			calls <- args
+		}))
+		defer rsync.SetRsyncCommand(exec.Command)
+
+		err := hub.CopyMasterTablespaces(step.DevNullStream, "foobar/path/")
+		if err != nil {
+			t.Errorf("copying master tablespace directories and mapping file: %+v", err)
+		}
+
+		close(calls)
+
+		var actual []string
+		for args := range calls {
+			actual = append(actual, strings.Join(args, " "))
+		}
+		sort.Strings(actual)
+
+		expected := []string{
+			"--archive --compress --delete --stats --relative /tmp/tblspc/./1/GPDB_6_301908233 host1:foobar/path/16386",
+			"--archive --compress --delete --stats --relative /tmp/tblspc/./1/GPDB_6_301908233 host2:foobar/path/16386",
+			"--archive --compress --delete --stats /tmp/mapping.txt host1:foobar/path/",
+			"--archive --compress --delete --stats /tmp/mapping.txt host2:foobar/path/",
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("rsync invoked with %q, want %q", actual, expected)
+		}
+	})
+
+	t.Run("copies only the 7X directory of 6X master tablespaces when upgrading to 7X", func(t *testing.T) {
+		source := MustCreateCluster(t, []greenplum.SegConfig{
+			{ContentID: -1, DbID: 1, Port: 15432, Hostname: "localhost", DataDir: "/data/qddir/seg-1", Role: "p"},
+		})
+		source.Version = dbconn.NewVersion("6.15.0")
+
+		target := *targetCluster
+		target.Version = dbconn.NewVersion("7.0.0")
+
+		conf := &Config{
+			Source: source,
+			Target: &target,
+			Tablespaces: greenplum.Tablespaces{
+				1: greenplum.SegmentTablespaces{
+					1663: greenplum.TablespaceInfo{
+						Location:    "/data/qddir/seg-1",
+						UserDefined: 0},
+					16386: greenplum.TablespaceInfo{
+						Location:    "/tmp/tblspc",
+						UserDefined: 1},
+				},
+			},
+			TablespacesMappingFilePath: "/tmp/mapping.txt",
+			TargetCatalogVersion:       "302206171",
+		}
+		hub := New(conf, grpc.DialContext, ".gpupgrade")
+
+		calls := make(chan []string, 2*len(target.PrimaryHostnames()))
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(Success, func(name string, args ...string) {
+			calls <- args
+		}))
+		defer rsync.SetRsyncCommand(exec.Command)
+
+		err := hub.CopyMasterTablespaces(step.DevNullStream, "foobar/path/")
+		if err != nil {
+			t.Errorf("copying master tablespace directories and mapping file: %+v", err)
+		}
+
+		close(calls)
+
+		var actual []string
+		for args := range calls {
+			actual = append(actual, strings.Join(args, " "))
+		}
+		sort.Strings(actual)
+
+		expected := []string{
+			"--archive --compress --delete --stats --relative /tmp/tblspc/./1/GPDB_7_302206171 host1:foobar/path/16386",
+			"--archive --compress --delete --stats --relative /tmp/tblspc/./1/GPDB_7_302206171 host2:foobar/path/16386",
+			"--archive --compress --delete --stats /tmp/mapping.txt host1:foobar/path/",
+			"--archive --compress --delete --stats /tmp/mapping.txt host2:foobar/path/",
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("rsync invoked with %q, want %q", actual, expected)
+		}
+	})
+
 	t.Run("CopyMasterTablespaces returns nil if there is no tablespaces", func(t *testing.T) {
 		conf := &Config{
 			Target: targetCluster,
@@ -0,0 +1,127 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// RelativeOption makes rsync recreate the full source path on the destination
+// host, including any missing parent directories.
+const RelativeOption = "--relative"
+
+// CopySourcePrimaries copies the data directories and tablespaces of the source
+// primaries on mapped hosts to the same locations on their target hosts, where
+// pg_upgrade is run against the copies. The source cluster is left untouched.
+func CopySourcePrimaries(agentConns []*Connection, source *greenplum.Cluster, tablespaces greenplum.Tablespaces, mapping greenplum.HostMapping) error {
+	options := append(RsyncOptions(false), RelativeOption)
+
+	request := func(conn *Connection) error {
+		primaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsOnHost(conn.Hostname) && seg.IsPrimary() && !seg.IsMaster() && mapping.IsRemapped(*seg)
+		})
+
+		if len(primaries) == 0 {
+			return nil
+		}
+
+		var pairs []*idl.RsyncPair
+		var tablespacePairs []*idl.RsyncPair
+		for _, primary := range primaries {
+			pairs = append(pairs, &idl.RsyncPair{
+				Source:          primary.DataDir,
+				DestinationHost: mapping.TargetHost(primary.Hostname),
+				Destination:     "/",
+			})
+
+			for _, oid := range sortedTablespaceOids(tablespaces[primary.DbID]) {
+				tsInfo := tablespaces[primary.DbID][oid]
+				if !tsInfo.IsUserDefined() {
+					continue
+				}
+
+				tablespacePairs = append(tablespacePairs, &idl.RsyncPair{
+					Source:          sourceTablespaceDir(source, tsInfo, primary.DbID),
+					DestinationHost: mapping.TargetHost(primary.Hostname),
+					Destination:     "/",
+				})
+			}
+		}
+
+		req := &idl.RsyncRequest{Options: options, Pairs: pairs}
+		if _, err := conn.AgentClient.RsyncDataDirectories(context.Background(), req); err != nil {
+			return err
+		}
+
+		if len(tablespacePairs) == 0 {
+			return nil
+		}
+
+		req = &idl.RsyncRequest{
+			Options:       options,
+			Pairs:         tablespacePairs,
+			SourceVersion: source.Version.SemVer.String(),
+		}
+
+		_, err := conn.AgentClient.RsyncTablespaceDirectories(context.Background(), req)
+		return err
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
+
+// sourcePrimaryCopies returns the source primaries on mapped hosts as copied
+// to their target hosts by CopySourcePrimaries.
+func sourcePrimaryCopies(source *greenplum.Cluster, mapping greenplum.HostMapping) greenplum.SegConfigs {
+	primaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+		return seg.IsPrimary() && !seg.IsMaster() && mapping.IsRemapped(*seg)
+	})
+
+	for i := range primaries {
+		primaries[i].Hostname = mapping.TargetHost(primaries[i].Hostname)
+	}
+
+	return primaries
+}
+
+// DeleteSourcePrimaryCopies removes the copies of the source primaries and
+// their tablespaces from the target hosts of a host mapping.
+func DeleteSourcePrimaryCopies(agentConns []*Connection, source *greenplum.Cluster, tablespaces greenplum.Tablespaces, mapping greenplum.HostMapping) error {
+	copies := sourcePrimaryCopies(source, mapping)
+	if len(copies) == 0 {
+		return nil
+	}
+
+	err := deleteDataDirectories(agentConns, copies)
+
+	request := func(conn *Connection) error {
+		var dirs []string
+		for _, seg := range copies {
+			if seg.Hostname != conn.Hostname {
+				continue
+			}
+
+			for _, oid := range sortedTablespaceOids(tablespaces[seg.DbID]) {
+				tsInfo := tablespaces[seg.DbID][oid]
+				if tsInfo.IsUserDefined() {
+					dirs = append(dirs, sourceTablespaceDir(source, tsInfo, seg.DbID))
+				}
+			}
+		}
+
+		if len(dirs) == 0 {
+			return nil
+		}
+
+		req := &idl.DeleteTablespaceRequest{Dirs: dirs}
+		_, err := conn.AgentClient.DeleteSourceTablespaceDirectories(context.Background(), req)
+		return err
+	}
+
+	return errorlist.Append(err, ExecuteRPC(agentConns, request))
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+)
+
+func TestCopySourcePrimaries(t *testing.T) {
+	source := hub.MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 1, DbID: 3, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 4, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		{ContentID: 1, DbID: 5, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg2", Role: greenplum.MirrorRole},
+	})
+	source.Version = dbconn.NewVersion("6.0.0")
+
+	tablespaces := greenplum.Tablespaces{
+		2: {
+			1663:  {Location: "/data/dbfast1/seg1", UserDefined: 0},
+			16384: {Location: "/data/tablespaces", UserDefined: 1},
+		},
+	}
+
+	mapping := greenplum.HostMapping{"sdw1": "new-sdw1"}
+	options := append(hub.RsyncOptions(false), hub.RelativeOption)
+
+	t.Run("copies the primaries of mapped hosts to their target hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().RsyncDataDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: options,
+				Pairs: []*idl.RsyncPair{{
+					Source:          "/data/dbfast1/seg1",
+					DestinationHost: "new-sdw1",
+					Destination:     "/",
+				}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+		sdw1.EXPECT().RsyncTablespaceDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: options,
+				Pairs: []*idl.RsyncPair{{
+					Source:          "/data/tablespaces/2",
+					DestinationHost: "new-sdw1",
+					Destination:     "/",
+				}},
+				SourceVersion: "6.0.0",
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		// The unmapped and target hosts do not copy anything.
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		newSdw1 := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: newSdw1, Hostname: "new-sdw1"},
+		}
+
+		err := hub.CopySourcePrimaries(agentConns, source, tablespaces, mapping)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("errors when copying fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().RsyncDataDirectories(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*hub.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.CopySourcePrimaries(agentConns, source, tablespaces, mapping)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("deletes the copied primaries from the target hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		newSdw1 := mock_idl.NewMockAgentClient(ctrl)
+		newSdw1.EXPECT().DeleteDataDirectories(
+			gomock.Any(),
+			&idl.DeleteDataDirectoriesRequest{Datadirs: []string{"/data/dbfast1/seg1"}},
+		).Return(&idl.DeleteDataDirectoriesReply{}, nil)
+		newSdw1.EXPECT().DeleteSourceTablespaceDirectories(
+			gomock.Any(),
+			&idl.DeleteTablespaceRequest{Dirs: []string{"/data/tablespaces/2"}},
+		).Return(&idl.DeleteTablespaceReply{}, nil)
+
+		// The source hosts are left untouched.
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: newSdw1, Hostname: "new-sdw1"},
+		}
+
+		err := hub.DeleteSourcePrimaryCopies(agentConns, source, tablespaces, mapping)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+}
@@ -21,7 +21,15 @@ func DeleteMirrorAndStandbyDataDirectories(agentConns []*Connection, cluster *gr
 	return deleteDataDirectories(agentConns, segs)
 }
 
-func DeleteMasterAndPrimaryDataDirectories(streams step.OutStreams, agentConns []*Connection, source InitializeConfig) error {
+// DeleteMasterAndPrimaryDataDirectories deletes the target master and primary
+// data directories. The master is deleted by the agent on the master host when
+// remoteMaster is set, as the hub does not run there.
+func DeleteMasterAndPrimaryDataDirectories(streams step.OutStreams, agentConns []*Connection, source InitializeConfig, remoteMaster bool) error {
+	if remoteMaster {
+		segs := append(greenplum.SegConfigs{source.Master}, source.Primaries...)
+		return deleteDataDirectories(agentConns, segs)
+	}
+
 	masterErr := make(chan error)
 	go func() {
 		masterErr <- upgrade.DeleteDirectories([]string{source.Master.DataDir}, upgrade.PostgresFiles, streams)
@@ -59,6 +67,13 @@ func deleteDataDirectories(agentConns []*Connection, segConfigs greenplum.SegCon
 }
 
 func DeleteTargetTablespaces(streams step.OutStreams, agentConns []*Connection, target *greenplum.Cluster, targetCatalogVersion string, sourceTablespaces greenplum.Tablespaces) error {
+	// Without a catalog version the target tablespace directories cannot be
+	// distinguished from those of a 6X source cluster. The catalog version is
+	// recorded before any target tablespaces are created.
+	if targetCatalogVersion == "" {
+		return nil
+	}
+
 	var wg sync.WaitGroup
 	errs := make(chan error, 2)
 
@@ -129,7 +129,7 @@ func TestDeleteSegmentDataDirs(t *testing.T) {
 				Primaries: primarySegConfigs,
 			}
 
-			err := hub.DeleteMasterAndPrimaryDataDirectories(step.DevNullStream, agentConns, source)
+			err := hub.DeleteMasterAndPrimaryDataDirectories(step.DevNullStream, agentConns, source, false)
 			if err != nil {
 				t.Errorf("unexpected err %#v", err)
 			}
@@ -162,7 +162,7 @@ func TestDeleteSegmentDataDirs(t *testing.T) {
 				Primaries: primarySegConfigs,
 			}
 
-			err := hub.DeleteMasterAndPrimaryDataDirectories(step.DevNullStream, agentConns, source)
+			err := hub.DeleteMasterAndPrimaryDataDirectories(step.DevNullStream, agentConns, source, false)
 
 			if !errors.Is(err, expected) {
 				t.Errorf("got error %#v, want %#v", err, expected)
@@ -368,6 +368,28 @@ func TestDeleteTablespaceDirectories(t *testing.T) {
 			t.Errorf("unexpected error %#v", err)
 		}
 	})
+
+	t.Run("does not delete tablespaces when the target catalog version is unknown", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().DeleteTablespaceDirectories(gomock.Any(), gomock.Any()).Times(0)
+
+		agentConns := []*hub.Connection{
+			{nil, sdw1, "sdw1", nil},
+		}
+
+		tablespaces := greenplum.Tablespaces{
+			1: {16386: {Location: "/does/not/exist/tblspc", UserDefined: 1}},
+			2: {16386: {Location: "/does/not/exist/tblspc", UserDefined: 1}},
+		}
+
+		err := hub.DeleteTargetTablespaces(step.DevNullStream, agentConns, target, "", tablespaces)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
 }
 
 func TestDeleteTablespacesOnMirrorsAndStandby(t *testing.T) {
@@ -0,0 +1,180 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// DistributeToAgentHosts copies the gpupgrade binary of the hub, and the
+// target GPHOME when distributeTargetGPHome is set, to the agent hosts. The
+// agents are then restarted with the distributed binary, as START_AGENTS may
+// have already completed when initialize is rerun. The distributed GPHOME is
+// verified by the CHECK_TARGET_GPHOME substep once the agents are started.
+func (s *Server) DistributeToAgentHosts(streams step.OutStreams, distributeTargetGPHome bool) error {
+	path, err := utils.GetGpupgradePath()
+	if err != nil {
+		return xerrors.Errorf("getting gpupgrade binary path: %w", err)
+	}
+
+	// The hub host already has the binary and GPHOME being distributed.
+	var hosts []string
+	for _, host := range s.AgentHosts() {
+		if host != s.hubHost() {
+			hosts = append(hosts, host)
+		}
+	}
+
+	targetGPHome := ""
+	if distributeTargetGPHome {
+		targetGPHome = s.TargetGPHome
+	}
+
+	if err := Distribute(streams, hosts, path, targetGPHome); err != nil {
+		return err
+	}
+
+	// Start any agents not yet running such that all of them can be stopped.
+	if _, err := RestartAgents(context.Background(), nil, s.AgentHosts(), s.AgentPort, s.StateDir); err != nil {
+		return xerrors.Errorf("starting agents: %w", err)
+	}
+
+	if err := s.StopAgents(); err != nil {
+		return xerrors.Errorf("stopping agents: %w", err)
+	}
+
+	// The connections to the stopped agents can no longer be used.
+	s.mu.Lock()
+	s.closeAgentConns()
+	s.agentConns = nil
+	s.mu.Unlock()
+
+	if _, err := RestartAgents(context.Background(), nil, s.AgentHosts(), s.AgentPort, s.StateDir); err != nil {
+		return xerrors.Errorf("restarting agents: %w", err)
+	}
+
+	return nil
+}
+
+// Distribute copies the gpupgrade binary to the same path on each host and
+// verifies its checksum there. The target GPHOME is copied as well unless
+// empty, and is verified once the agents are started.
+func Distribute(streams step.OutStreams, hosts []string, gpupgradePath string, targetGPHome string) error {
+	err := Copy(streams, gpupgradePath, []string{gpupgradePath}, hosts, "--checksum")
+	if err != nil {
+		return err
+	}
+
+	if targetGPHome != "" {
+		// Make sure the source ends with a trailing slash so that rsync will
+		// transfer the directory contents and not the directory itself.
+		source := filepath.Clean(targetGPHome) + string(filepath.Separator)
+		err := Copy(streams, targetGPHome, []string{source}, hosts, "--checksum")
+		if err != nil {
+			return err
+		}
+	}
+
+	return verifyChecksums(streams, hosts, gpupgradePath)
+}
+
+// verifyChecksums ensures the file on each host has the checksum of the local
+// file, and reports the result of each host.
+func verifyChecksums(streams step.OutStreams, hosts []string, path string) error {
+	expected, err := localChecksum(path)
+	if err != nil {
+		return err
+	}
+
+	type result struct {
+		host     string
+		checksum string
+		err      error
+	}
+
+	results := make(chan result, len(hosts))
+	var wg sync.WaitGroup
+
+	for _, host := range hosts {
+		host := host
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checksum, err := remoteChecksum(host, path)
+			results <- result{host: host, checksum: checksum, err: err}
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+
+	var sorted []result
+	for r := range results {
+		sorted = append(sorted, r)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].host < sorted[j].host })
+
+	var errs error
+	for _, r := range sorted {
+		switch {
+		case r.err != nil:
+			errs = errorlist.Append(errs, r.err)
+			fmt.Fprintf(streams.Stdout(), "%s: failed\n", r.host)
+		case r.checksum != expected:
+			errs = errorlist.Append(errs, xerrors.Errorf("checksum of %s on host %s is %s, want %s", path, r.host, r.checksum, expected))
+			fmt.Fprintf(streams.Stdout(), "%s: checksum mismatch\n", r.host)
+		default:
+			fmt.Fprintf(streams.Stdout(), "%s: distributed\n", r.host)
+		}
+	}
+
+	return errs
+}
+
+func localChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", xerrors.Errorf("computing checksum of %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func remoteChecksum(host string, path string) (string, error) {
+	cmd := commandOnHost(host, "sha256sum", path)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", xerrors.Errorf("computing checksum of %s on host %s: %w", path, host, err)
+	}
+
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return "", xerrors.Errorf("computing checksum of %s on host %s: unexpected output %q", path, host, output)
+	}
+
+	return fields[0], nil
+}
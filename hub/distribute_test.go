@@ -0,0 +1,160 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+const gpupgradeBinary = "gpupgrade binary"
+
+func sha256sum() {
+	fmt.Printf("%x  gpupgrade\n", sha256.Sum256([]byte(gpupgradeBinary)))
+}
+
+func sha256sum_Mismatch() {
+	fmt.Printf("%x  gpupgrade\n", sha256.Sum256([]byte("stale gpupgrade binary")))
+}
+
+func init() {
+	exectest.RegisterMains(
+		sha256sum,
+		sha256sum_Mismatch,
+	)
+}
+
+func TestDistribute(t *testing.T) {
+	testlog.SetupLogger()
+
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	path := filepath.Join(dir, "gpupgrade")
+	testutils.MustWriteToFile(t, path, gpupgradeBinary)
+
+	hosts := []string{"sdw1", "sdw2"}
+
+	t.Run("copies gpupgrade and the target GPHOME to each host and verifies the checksums", func(t *testing.T) {
+		var mu sync.Mutex
+		var copies []string
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(Success, func(name string, args ...string) {
+			mu.Lock()
+			defer mu.Unlock()
+			copies = append(copies, strings.Join(args, " "))
+		}))
+		defer rsync.ResetRsyncCommand()
+
+		var checked []string
+		SetExecCommand(exectest.NewCommandWithVerifier(sha256sum, func(name string, args ...string) {
+			mu.Lock()
+			defer mu.Unlock()
+			checked = append(checked, name+" "+strings.Join(args, " "))
+		}))
+		defer ResetExecCommand()
+
+		stream := new(step.BufferedStreams)
+		err := Distribute(stream, hosts, path, "/usr/local/gpdb7")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []string{
+			fmt.Sprintf("--archive --compress --delete --stats --checksum %s sdw1:%s", path, path),
+			fmt.Sprintf("--archive --compress --delete --stats --checksum %s sdw2:%s", path, path),
+			"--archive --compress --delete --stats --checksum /usr/local/gpdb7/ sdw1:/usr/local/gpdb7",
+			"--archive --compress --delete --stats --checksum /usr/local/gpdb7/ sdw2:/usr/local/gpdb7",
+		}
+		sort.Strings(copies)
+		sort.Strings(expected)
+		if !reflect.DeepEqual(copies, expected) {
+			t.Errorf("got rsync calls %q want %q", copies, expected)
+		}
+
+		expected = []string{
+			fmt.Sprintf("ssh sdw1 sha256sum %s", path),
+			fmt.Sprintf("ssh sdw2 sha256sum %s", path),
+		}
+		sort.Strings(checked)
+		if !reflect.DeepEqual(checked, expected) {
+			t.Errorf("got checksum calls %q want %q", checked, expected)
+		}
+
+		if stdout := stream.StdoutBuf.String(); stdout != "sdw1: distributed\nsdw2: distributed\n" {
+			t.Errorf("got stdout %q want a result per host", stdout)
+		}
+	})
+
+	t.Run("does not copy the target GPHOME when empty", func(t *testing.T) {
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(Success, func(name string, args ...string) {
+			if strings.Contains(strings.Join(args, " "), "gpdb7") {
+				t.Errorf("unexpected copy of the target GPHOME %q", args)
+			}
+		}))
+		defer rsync.ResetRsyncCommand()
+
+		SetExecCommand(exectest.NewCommand(sha256sum))
+		defer ResetExecCommand()
+
+		err := Distribute(step.DevNullStream, hosts, path, "")
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("errors when a checksum does not match", func(t *testing.T) {
+		rsync.SetRsyncCommand(exectest.NewCommand(Success))
+		defer rsync.ResetRsyncCommand()
+
+		SetExecCommand(exectest.NewCommand(sha256sum_Mismatch))
+		defer ResetExecCommand()
+
+		stream := new(step.BufferedStreams)
+		err := Distribute(stream, hosts, path, "")
+		if err == nil || !strings.Contains(err.Error(), "on host sdw2") {
+			t.Errorf("got error %v want checksum mismatch", err)
+		}
+
+		if stdout := stream.StdoutBuf.String(); stdout != "sdw1: checksum mismatch\nsdw2: checksum mismatch\n" {
+			t.Errorf("got stdout %q want a result per host", stdout)
+		}
+	})
+
+	t.Run("errors when copying fails", func(t *testing.T) {
+		rsync.SetRsyncCommand(exectest.NewCommand(RsyncFailure))
+		defer rsync.ResetRsyncCommand()
+
+		SetExecCommand(exectest.NewCommand(Failure))
+		defer ResetExecCommand()
+
+		err := Distribute(step.DevNullStream, hosts, path, "")
+
+		var errs errorlist.Errors
+		if !errors.As(err, &errs) {
+			t.Fatalf("returned %#v, want error type %T", err, errs)
+		}
+
+		var exitErr *exec.ExitError
+		for _, err := range errs {
+			if !errors.As(err, &exitErr) || exitErr.ExitCode() != rsyncExitCode {
+				t.Errorf("returned error %#v, want exit code %d", err, rsyncExitCode)
+			}
+		}
+	})
+}
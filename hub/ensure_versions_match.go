@@ -79,6 +79,7 @@ func EnsureVersionsMatch(agentHosts []string, version ObtainVersions) error {
 	}
 
 	return xerrors.Errorf(`%s version mismatch between gpupgrade hub and agent hosts. 
+    Rerun initialize with "--distribute" to copy gpupgrade, and "--distribute-gphome" to copy the target GPHOME, from the hub to the agent hosts.
     Hub version: %q
 
     Mismatched Agents:
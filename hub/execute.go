@@ -37,6 +37,10 @@ func (s *Server) Execute(request *idl.ExecuteRequest, stream idl.CliToHub_Execut
 		}
 	}()
 
+	st.Run(idl.Substep_CHECK_ACTIVE_SESSIONS, func(streams step.OutStreams) error {
+		return CheckActiveSessions(streams, s.Connection, s.Source.MasterPort(), s.ActiveSessionPolicy, s.ActiveSessionWait)
+	})
+
 	st.Run(idl.Substep_SHUTDOWN_SOURCE_CLUSTER, func(streams step.OutStreams) error {
 		err := s.Source.Stop(streams)
 
@@ -48,6 +52,11 @@ func (s *Server) Execute(request *idl.ExecuteRequest, stream idl.CliToHub_Execut
 	})
 
 	st.Run(idl.Substep_UPGRADE_MASTER, func(streams step.OutStreams) error {
+		agentConns, err := s.AgentConns()
+		if err != nil {
+			return xerrors.Errorf("connect to gpupgrade agent: %w", err)
+		}
+
 		stateDir := s.StateDir
 		return UpgradeMaster(UpgradeMasterArgs{
 			Source:      s.Source,
@@ -56,6 +65,7 @@ func (s *Server) Execute(request *idl.ExecuteRequest, stream idl.CliToHub_Execut
 			Stream:      streams,
 			CheckOnly:   false,
 			UseLinkMode: s.UseLinkMode,
+			AgentConns:  agentConns,
 		})
 	})
 
@@ -73,6 +83,17 @@ func (s *Server) Execute(request *idl.ExecuteRequest, stream idl.CliToHub_Execut
 		return nil
 	})
 
+	if len(s.HostMapping) > 0 {
+		st.Run(idl.Substep_COPY_SOURCE_PRIMARIES, func(_ step.OutStreams) error {
+			agentConns, err := s.AgentConns()
+			if err != nil {
+				return xerrors.Errorf("connect to gpupgrade agent: %w", err)
+			}
+
+			return CopySourcePrimaries(agentConns, s.Source, s.Tablespaces, s.HostMapping)
+		})
+	}
+
 	st.Run(idl.Substep_UPGRADE_PRIMARIES, func(_ step.OutStreams) error {
 		agentConns, err := s.AgentConns()
 
@@ -98,6 +119,20 @@ func (s *Server) Execute(request *idl.ExecuteRequest, stream idl.CliToHub_Execut
 		})
 	})
 
+	st.Run(idl.Substep_MIGRATE_CONFIGURATION, func(streams step.OutStreams) error {
+		agentConns, err := s.AgentConns()
+		if err != nil {
+			return xerrors.Errorf("connect to gpupgrade agent: %w", err)
+		}
+
+		dataDirPairs, err := s.GetDataDirPairs()
+		if err != nil {
+			return xerrors.Errorf("get source and target primary data directories: %w", err)
+		}
+
+		return MigrateConfiguration(streams, agentConns, s.Source, s.Target, dataDirPairs)
+	})
+
 	st.Run(idl.Substep_START_TARGET_CLUSTER, func(streams step.OutStreams) error {
 		err := s.Target.Start(streams)
 
@@ -7,11 +7,13 @@ import (
 	"database/sql"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/db"
+	"github.com/greenplum-db/gpupgrade/db/connURI"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
@@ -20,9 +22,10 @@ import (
 )
 
 // FillConfiguration populates as much of the passed Config as possible, given a
-// connection to the source cluster and the settings contained in an
-// InitializeRequest from the client. The configuration is then saved to disk.
-func FillConfiguration(config *Config, conn *sql.DB, _ step.OutStreams, request *idl.InitializeRequest, saveConfig func() error) error {
+// connection to the source cluster, the settings contained in an
+// InitializeRequest from the client, and the features supported by the upgrade
+// path. The configuration is then saved to disk.
+func FillConfiguration(config *Config, conn *sql.DB, _ step.OutStreams, request *idl.InitializeRequest, path greenplum.UpgradePath, saveConfig func() error) error {
 	config.AgentPort = int(request.AgentPort)
 	config.UseHbaHostnames = request.UseHbaHostnames
 
@@ -35,28 +38,104 @@ func FillConfiguration(config *Config, conn *sql.DB, _ step.OutStreams, request
 
 	// XXX ugly; we should just use the conn we're passed, but our DbConn
 	// concept (which isn't really used) gets in the way
-	dbconn := db.NewDBConn("localhost", int(request.SourcePort), "template1")
+	dbconn := db.NewDBConnWithSettings(config.Connection, int(request.SourcePort), "template1")
 	source, err := greenplum.ClusterFromDB(dbconn, request.SourceGPHome)
 	if err != nil {
 		return xerrors.Errorf("retrieve source configuration: %w", err)
 	}
 
+	// A configured master host means the hub runs elsewhere, so the master is
+	// accessed through ssh and the agent on the master host.
+	source.RemoteMaster = config.Connection != nil && config.Connection.Host() != connURI.DefaultHost
+
 	config.Source = source
 	config.TargetGPHome = request.TargetGPHome
 	config.UseLinkMode = request.UseLinkMode
+	config.AnalyzeTargetCluster = request.AnalyzeTargetCluster
+	config.AnalyzeJobs = int(request.AnalyzeJobs)
+	config.MirrorUpgradeStrategy = request.MirrorUpgradeStrategy
+	config.MirrorUpgradeJobs = int(request.MirrorUpgradeJobs)
+
+	config.TablespaceRelocations = tablespaceRelocations(request.TablespaceRelocations)
+	if len(config.TablespaceRelocations) > 0 {
+		if config.UseLinkMode {
+			return xerrors.New("relocating tablespaces requires copy mode")
+		}
+
+		if !path.TablespacesSupported {
+			return xerrors.Errorf("relocating tablespaces is not supported when upgrading from %s", path)
+		}
+	}
+
+	config.MirrorSyncTimeout = time.Duration(request.MirrorSyncTimeoutSeconds) * time.Second
+	if config.MirrorSyncTimeout == 0 {
+		config.MirrorSyncTimeout = DefaultMirrorSyncTimeout
+	}
+
+	config.ActiveSessionPolicy = request.ActiveSessionPolicy
+	config.ActiveSessionWait = time.Duration(request.ActiveSessionWaitMinutes) * time.Minute
+	if config.ActiveSessionWait == 0 {
+		config.ActiveSessionWait = DefaultActiveSessionWait
+	}
+
+	config.Quiesce = request.Quiesce
+	config.GpinitsystemOverrides = request.GpinitsystemOverrides
+	config.UpdateExtensions = request.UpdateExtensions
+	config.UpdateExtensionsAllow = request.UpdateExtensionsAllow
+	config.UpdateExtensionsDeny = request.UpdateExtensionsDeny
+	config.GPHomeManifestAllowlist = request.GphomeManifestAllowlist
+	config.Distribute = request.Distribute
+	config.DistributeTargetGPHome = request.DistributeTargetGPHome
 
 	var ports []int
 	for _, p := range request.Ports {
 		ports = append(ports, int(p))
 	}
 
-	config.TargetInitializeConfig, err = AssignDatadirsAndPorts(config.Source, ports, config.UpgradeID)
+	// Hard links cannot cross filesystems, so placing the target data
+	// directories elsewhere is only possible in copy mode.
+	placement := dataDirPlacement(request.TargetDataDirPlacement)
+	if len(placement) > 0 && config.UseLinkMode {
+		return xerrors.New("placing the target data directories requires copy mode")
+	}
+
+	// The source primaries are copied to the target hosts of a host mapping,
+	// which is only possible in copy mode.
+	config.HostMapping = greenplum.HostMapping(request.HostMapping)
+	if len(config.HostMapping) > 0 {
+		if config.UseLinkMode {
+			return xerrors.New("mapping hosts requires copy mode")
+		}
+
+		if len(config.TablespaceRelocations) > 0 {
+			return xerrors.New("relocating tablespaces is not supported with a host mapping")
+		}
+
+		if err := config.HostMapping.Validate(config.Source); err != nil {
+			return err
+		}
+	}
+
+	config.TargetInitializeConfig, err = AssignDatadirsAndPorts(config.Source, ports, config.UpgradeID, placement, config.HostMapping)
 	if err != nil {
 		return err
 	}
 
+	// Without tablespace support the user defined tablespaces would be left
+	// behind, so the upgrade cannot continue when there are any.
+	if !path.TablespacesSupported {
+		tablespaces, err := greenplum.QueryTablespaces(dbconn)
+		if err != nil {
+			return xerrors.Errorf("extract tablespace information: %w", err)
+		}
+
+		if err := verifyNoUserDefinedTablespaces(tablespaces, path); err != nil {
+			return err
+		}
+	}
+
 	// major version upgrade requires upgrading tablespaces
-	if dbconn.Version.Is("5") {
+	if path.TablespacesSupported {
 		if err := utils.System.MkdirAll(utils.GetTablespaceDir(), 0700); err != nil {
 			return xerrors.Errorf("create tablespace directory %q: %w", utils.GetTablespaceDir(), err)
 		}
@@ -65,6 +144,11 @@ func FillConfiguration(config *Config, conn *sql.DB, _ step.OutStreams, request
 		if err != nil {
 			return xerrors.Errorf("extract tablespace information: %w", err)
 		}
+
+		// The master tablespaces are copied from the hub during execute.
+		if config.Source.RemoteMaster && hasUserDefinedTablespaces(config.Tablespaces.GetMasterTablespaces()) {
+			return xerrors.New("user defined tablespaces are not supported when the hub does not run on the master host")
+		}
 	}
 
 	if err := saveConfig(); err != nil {
@@ -74,7 +158,56 @@ func FillConfiguration(config *Config, conn *sql.DB, _ step.OutStreams, request
 	return nil
 }
 
-func AssignDatadirsAndPorts(source *greenplum.Cluster, ports []int, upgradeID upgrade.ID) (InitializeConfig, error) {
+func verifyNoUserDefinedTablespaces(tablespaces greenplum.Tablespaces, path greenplum.UpgradePath) error {
+	if tablespaces.HasUserDefined() {
+		return xerrors.Errorf("user defined tablespaces are not supported when upgrading from %s. "+
+			"Drop the user defined tablespaces before upgrading.", path)
+	}
+
+	return nil
+}
+
+func hasUserDefinedTablespaces(tablespaces greenplum.SegmentTablespaces) bool {
+	for _, tablespace := range tablespaces {
+		if tablespace.IsUserDefined() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func tablespaceRelocations(relocations []*idl.TablespaceRelocation) greenplum.TablespaceRelocations {
+	var result greenplum.TablespaceRelocations
+	for _, relocation := range relocations {
+		result = append(result, greenplum.TablespaceRelocation{
+			Host:      relocation.GetHost(),
+			OldPrefix: relocation.GetOldPrefix(),
+			NewPrefix: relocation.GetNewPrefix(),
+		})
+	}
+
+	return result
+}
+
+func dataDirPlacement(rules []*idl.DataDirPlacementRule) upgrade.DataDirPlacement {
+	var placement upgrade.DataDirPlacement
+	for _, rule := range rules {
+		placement = append(placement, upgrade.PlacementRule{
+			Host:    rule.GetHost(),
+			Role:    rule.GetRole(),
+			BaseDir: rule.GetBaseDir(),
+		})
+	}
+
+	return placement
+}
+
+// AssignDatadirsAndPorts assigns the target cluster hosts, ports and temporary
+// data directories. The segments of mapped hosts are assigned to their target
+// hosts. The data directories are placed next to the source data directories
+// unless the placement specifies another base directory.
+func AssignDatadirsAndPorts(source *greenplum.Cluster, ports []int, upgradeID upgrade.ID, placement upgrade.DataDirPlacement, mapping greenplum.HostMapping) (InitializeConfig, error) {
 	ports = sanitize(ports)
 
 	targetInitializeConfig := InitializeConfig{}
@@ -98,7 +231,7 @@ func AssignDatadirsAndPorts(source *greenplum.Cluster, ports []int, upgradeID up
 		}
 
 		master.Port = ports[nextPortIndex]
-		master.DataDir = upgrade.TempDataDir(master.DataDir, segPrefix, upgradeID)
+		master.DataDir = placement.PlacedDataDir(master.DataDir, segPrefix, upgradeID, master.Hostname, upgrade.MasterPlacement, master.ContentID)
 		targetInitializeConfig.Master = master
 		nextPortIndex++
 	}
@@ -108,8 +241,9 @@ func AssignDatadirsAndPorts(source *greenplum.Cluster, ports []int, upgradeID up
 		if nextPortIndex > len(ports)-1 {
 			return InitializeConfig{}, errors.New("not enough ports")
 		}
+		standby.Hostname = mapping.TargetHost(standby.Hostname)
 		standby.Port = ports[nextPortIndex]
-		standby.DataDir = upgrade.TempDataDir(standby.DataDir, segPrefix, upgradeID)
+		standby.DataDir = placement.PlacedDataDir(standby.DataDir, segPrefix, upgradeID, standby.Hostname, upgrade.StandbyPlacement, standby.ContentID)
 		targetInitializeConfig.Standby = standby
 		nextPortIndex++
 	}
@@ -123,6 +257,7 @@ func AssignDatadirsAndPorts(source *greenplum.Cluster, ports []int, upgradeID up
 		}
 
 		segment := source.Primaries[content]
+		segment.Hostname = mapping.TargetHost(segment.Hostname)
 
 		if portIndex, ok := portIndexByHost[segment.Hostname]; ok {
 			if portIndex > len(ports)-1 {
@@ -137,7 +272,7 @@ func AssignDatadirsAndPorts(source *greenplum.Cluster, ports []int, upgradeID up
 			segment.Port = ports[nextPortIndex]
 			portIndexByHost[segment.Hostname] = nextPortIndex + 1
 		}
-		segment.DataDir = upgrade.TempDataDir(segment.DataDir, segPrefix, upgradeID)
+		segment.DataDir = placement.PlacedDataDir(segment.DataDir, segPrefix, upgradeID, segment.Hostname, upgrade.PrimaryPlacement, segment.ContentID)
 
 		targetInitializeConfig.Primaries = append(targetInitializeConfig.Primaries, segment)
 	}
@@ -149,6 +284,7 @@ func AssignDatadirsAndPorts(source *greenplum.Cluster, ports []int, upgradeID up
 		}
 
 		if segment, ok := source.Mirrors[content]; ok {
+			segment.Hostname = mapping.TargetHost(segment.Hostname)
 			if portIndex, ok := portIndexByHost[segment.Hostname]; ok {
 				if portIndex > len(ports)-1 {
 					return InitializeConfig{}, errors.New("not enough ports")
@@ -162,7 +298,7 @@ func AssignDatadirsAndPorts(source *greenplum.Cluster, ports []int, upgradeID up
 				segment.Port = ports[nextPortIndex]
 				portIndexByHost[segment.Hostname] = nextPortIndex + 1
 			}
-			segment.DataDir = upgrade.TempDataDir(segment.DataDir, segPrefix, upgradeID)
+			segment.DataDir = placement.PlacedDataDir(segment.DataDir, segPrefix, upgradeID, segment.Hostname, upgrade.MirrorPlacement, segment.ContentID)
 
 			targetInitializeConfig.Mirrors = append(targetInitializeConfig.Mirrors, segment)
 		}
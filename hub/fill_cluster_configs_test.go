@@ -158,7 +158,7 @@ func TestAssignDataDirsAndPorts(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			actual, err := AssignDatadirsAndPorts(c.cluster, c.ports, upgradeID)
+			actual, err := AssignDatadirsAndPorts(c.cluster, c.ports, upgradeID, nil, nil)
 			if err != nil {
 				t.Errorf("returned error %+v", err)
 			}
@@ -169,6 +169,62 @@ func TestAssignDataDirsAndPorts(t *testing.T) {
 		})
 	}
 
+	t.Run("places the data directories in the base directories of the placement", func(t *testing.T) {
+		cluster := MustCreateCluster(t, []greenplum.SegConfig{
+			{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: "p"},
+			{ContentID: -1, DbID: 2, Hostname: "smdw", DataDir: "/data/standby", Role: "m"},
+			{ContentID: 0, DbID: 3, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: "p"},
+			{ContentID: 0, DbID: 4, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: "m"},
+		})
+
+		placement := upgrade.DataDirPlacement{
+			{Role: upgrade.PrimaryPlacement, BaseDir: "/ssd/primary"},
+			{Role: upgrade.MirrorPlacement, BaseDir: "/ssd/mirror"},
+			{Host: "smdw", BaseDir: "/ssd/{role}"},
+		}
+
+		actual, err := AssignDatadirsAndPorts(cluster, []int{1, 2, 3, 4}, upgradeID, placement, nil)
+		if err != nil {
+			t.Errorf("returned error %+v", err)
+		}
+
+		expected := InitializeConfig{
+			Master:    greenplum.SegConfig{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: expectedDataDir("/data/qddir/seg-1"), Role: "p", Port: 1},
+			Standby:   greenplum.SegConfig{ContentID: -1, DbID: 2, Hostname: "smdw", DataDir: expectedDataDir("/ssd/standby/standby"), Role: "m", Port: 2},
+			Primaries: []greenplum.SegConfig{{ContentID: 0, DbID: 3, Hostname: "sdw1", DataDir: expectedDataDir("/ssd/primary/seg1"), Role: "p", Port: 3}},
+			Mirrors:   []greenplum.SegConfig{{ContentID: 0, DbID: 4, Hostname: "sdw2", DataDir: expectedDataDir("/ssd/mirror/seg1"), Role: "m", Port: 3}},
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("got %+v want %+v", actual, expected)
+		}
+	})
+
+	t.Run("assigns the segments of mapped hosts to their target hosts", func(t *testing.T) {
+		cluster := MustCreateCluster(t, []greenplum.SegConfig{
+			{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: "p"},
+			{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: "p"},
+			{ContentID: 0, DbID: 3, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: "m"},
+		})
+
+		mapping := greenplum.HostMapping{"sdw1": "new-sdw1"}
+
+		actual, err := AssignDatadirsAndPorts(cluster, []int{1, 2, 3}, upgradeID, nil, mapping)
+		if err != nil {
+			t.Errorf("returned error %+v", err)
+		}
+
+		expected := InitializeConfig{
+			Master:    greenplum.SegConfig{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: expectedDataDir("/data/qddir/seg-1"), Role: "p", Port: 1},
+			Primaries: []greenplum.SegConfig{{ContentID: 0, DbID: 2, Hostname: "new-sdw1", DataDir: expectedDataDir("/data/dbfast1/seg1"), Role: "p", Port: 2}},
+			Mirrors:   []greenplum.SegConfig{{ContentID: 0, DbID: 3, Hostname: "sdw2", DataDir: expectedDataDir("/data/dbfast_mirror1/seg1"), Role: "m", Port: 2}},
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("got %+v want %+v", actual, expected)
+		}
+	})
+
 	errCases := []struct {
 		name string
 
@@ -235,10 +291,37 @@ func TestAssignDataDirsAndPorts(t *testing.T) {
 
 	for _, c := range errCases {
 		t.Run(c.name, func(t *testing.T) {
-			_, err := AssignDatadirsAndPorts(c.cluster, c.ports, 0)
+			_, err := AssignDatadirsAndPorts(c.cluster, c.ports, 0, nil, nil)
 			if err == nil {
 				t.Errorf("AssignDatadirsAndPorts(<cluster>, %v) returned nil, want error", c.ports)
 			}
 		})
 	}
 }
+
+func TestVerifyNoUserDefinedTablespaces(t *testing.T) {
+	path := greenplum.UpgradePath{Source: 6, Target: 6}
+
+	t.Run("succeeds when there are only system tablespaces", func(t *testing.T) {
+		tablespaces := greenplum.Tablespaces{
+			-1: {1663: {Location: "/data/qddir/seg-1", UserDefined: 0}},
+		}
+
+		err := verifyNoUserDefinedTablespaces(tablespaces, path)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("errors when there are user defined tablespaces", func(t *testing.T) {
+		tablespaces := greenplum.Tablespaces{
+			-1: {1663: {Location: "/data/qddir/seg-1", UserDefined: 0}},
+			0:  {16386: {Location: "/tmp/user_ts/p0", UserDefined: 1}},
+		}
+
+		err := verifyNoUserDefinedTablespaces(tablespaces, path)
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
@@ -36,6 +36,20 @@ func (s *Server) Finalize(_ *idl.FinalizeRequest, stream idl.CliToHub_FinalizeSe
 		}
 	}()
 
+	// Persist the outputs of this step such that they are reported correctly
+	// even when substeps that produce them are skipped on a subsequent run.
+	if s.FinalizeSummary.StartTime.IsZero() {
+		s.FinalizeSummary = FinalizeSummary{
+			TargetVersion:                     s.Target.Version.VersionString,
+			ArchivedSourceMasterDataDirectory: upgrade.ArchiveDataDir(s.Source.MasterDataDir(), s.Config.TargetInitializeConfig.Master.DataDir),
+			StartTime:                         time.Now(),
+		}
+
+		if err := s.SaveConfig(); err != nil {
+			return err
+		}
+	}
+
 	st.Run(idl.Substep_SHUTDOWN_TARGET_CLUSTER, func(streams step.OutStreams) error {
 		err := s.Target.Stop(streams)
 
@@ -50,12 +64,24 @@ func (s *Server) Finalize(_ *idl.FinalizeRequest, stream idl.CliToHub_FinalizeSe
 		return s.UpdateCatalogAndClusterConfig(streams)
 	})
 
+	if s.Quiesce {
+		st.Run(idl.Substep_UNQUIESCE_SOURCE_CLUSTER, func(_ step.OutStreams) error {
+			agentConns, err := s.AgentConns()
+			if err != nil {
+				return err
+			}
+
+			return UnquiesceSourceCluster(agentConns, s.Source)
+		})
+	}
+
 	st.Run(idl.Substep_UPDATE_DATA_DIRECTORIES, func(_ step.OutStreams) error {
 		return s.UpdateDataDirectories()
 	})
 
 	st.Run(idl.Substep_UPDATE_TARGET_CONF_FILES, func(streams step.OutStreams) error {
 		return UpdateConfFiles(streams,
+			remoteMasterHost(s.Target),
 			semver.MustParse(s.Target.Version.SemVer.String()),
 			s.Target.MasterDataDir(),
 			s.TargetInitializeConfig.Master.Port,
@@ -80,13 +106,13 @@ func (s *Server) Finalize(_ *idl.FinalizeRequest, stream idl.CliToHub_FinalizeSe
 			// TODO: once the temporary standby upgrade is fixed, switch to
 			// using the TargetInitializeConfig's temporary assignments, and
 			// move this upgrade step back to before the target shutdown.
-			standby := s.Source.Mirrors[-1]
-			return UpgradeStandby(greenplum.NewRunner(s.Target, streams), StandbyConfig{
+			standby := finalSegment(s.Source.Mirrors[-1], s.TargetInitializeConfig)
+			return UpgradeStandby(streams, s.Connection, s.Target.MasterPort(), greenplum.NewRunner(s.Target, streams), StandbyConfig{
 				Port:            standby.Port,
 				Hostname:        standby.Hostname,
 				DataDirectory:   standby.DataDir,
 				UseHbaHostnames: s.UseHbaHostnames,
-			})
+			}, DefaultStandbySyncTimeout)
 		})
 	}
 
@@ -97,42 +123,96 @@ func (s *Server) Finalize(_ *idl.FinalizeRequest, stream idl.CliToHub_FinalizeSe
 			// TODO: once the temporary mirror upgrade is fixed, switch to using
 			// the TargetInitializeConfig's temporary assignments, and move this
 			// upgrade step back to before the target shutdown.
-			mirrors := func(seg *greenplum.SegConfig) bool {
+			mirrors := s.Source.SelectSegments(func(seg *greenplum.SegConfig) bool {
 				return seg.IsMirror()
+			})
+			for i := range mirrors {
+				mirrors[i] = finalSegment(mirrors[i], s.TargetInitializeConfig)
 			}
 
-			return UpgradeMirrors(s.StateDir, s.Connection, s.Target.MasterPort(),
-				s.Source.SelectSegments(mirrors), greenplum.NewRunner(s.Target, streams), s.UseHbaHostnames)
+			if s.MirrorUpgradeStrategy == RsyncStrategy {
+				// The tablespace symlinks of a copied primary point to the
+				// primary's tablespace locations, so fall back to gpaddmirrors.
+				if !s.Tablespaces.HasUserDefined() {
+					agentConns, err := s.AgentConns()
+					if err != nil {
+						return xerrors.Errorf("connect to gpupgrade agent: %w", err)
+					}
+
+					return UpgradeMirrorsUsingRsync(streams, s.Connection, agentConns, s.Target,
+						mirrors, s.UseHbaHostnames, s.MirrorUpgradeJobs, s.MirrorSyncTimeout)
+				}
+
+				fmt.Fprintln(streams.Stdout(), "The rsync mirror upgrade strategy does not support tablespaces. Upgrading mirrors using gpaddmirrors.")
+			}
+
+			return UpgradeMirrors(streams, s.StateDir, s.Connection, s.Target.MasterPort(),
+				mirrors, greenplum.NewRunner(s.Target, streams), s.UseHbaHostnames, s.MirrorSyncTimeout)
+		})
+	}
+
+	if s.Source.HasStandby() || s.Source.HasMirrors() {
+		st.Run(idl.Substep_CHECK_MIRRORS_AND_STANDBY, func(streams step.OutStreams) error {
+			return CheckMirrorsAndStandby(streams, s.Connection, s.Target.MasterPort(), s.MirrorSyncTimeout)
+		})
+	}
+
+	if s.UpdateExtensions {
+		st.Run(idl.Substep_UPDATE_EXTENSIONS, func(streams step.OutStreams) error {
+			path, err := utils.GetJSONFile(s.StateDir, step.SubstepsFileName)
+			if err != nil {
+				return err
+			}
+
+			return UpdateExtensions(streams, step.NewFileStore(path), s.Connection, s.Target.MasterPort(),
+				s.UpdateExtensionsAllow, s.UpdateExtensionsDeny)
+		})
+	}
+
+	if s.AnalyzeTargetCluster {
+		st.Run(idl.Substep_ANALYZE_TARGET_CLUSTER, func(streams step.OutStreams) error {
+			return AnalyzeTargetCluster(streams, s.StateDir, s.Connection, s.Target.MasterPort(),
+				greenplum.NewRunner(s.Target, streams), s.AnalyzeJobs)
 		})
 	}
 
-	// FIXME: archiveDir is not set unless we actually run this substep; it must be persisted.
-	var archiveDir string
 	st.Run(idl.Substep_ARCHIVE_LOG_DIRECTORIES, func(_ step.OutStreams) error {
 		// Archive log directory on master
 		oldDir, err := utils.GetLogDir()
 		if err != nil {
 			return err
 		}
-		archiveDir = filepath.Join(filepath.Dir(oldDir), upgrade.GetArchiveDirectoryName(s.UpgradeID, time.Now()))
+		archiveDir := filepath.Join(filepath.Dir(oldDir), upgrade.GetArchiveDirectoryName(s.UpgradeID, time.Now()))
 
 		gplog.Debug("moving directory %q to %q", oldDir, archiveDir)
 		if err = utils.Move(oldDir, archiveDir); err != nil {
 			return err
 		}
 
-		return ArchiveSegmentLogDirectories(s.agentConns, s.Config.Target.MasterHostname(), archiveDir)
+		s.FinalizeSummary.LogArchiveDirectory = archiveDir
+		if err := s.SaveConfig(); err != nil {
+			return err
+		}
+
+		return ArchiveSegmentLogDirectories(s.agentConns, s.hubHost(), archiveDir)
 	})
 
 	st.Run(idl.Substep_DELETE_SEGMENT_STATEDIRS, func(_ step.OutStreams) error {
-		return DeleteStateDirectories(s.agentConns, s.Source.MasterHostname())
+		return DeleteStateDirectories(s.agentConns, s.hubHost())
 	})
 
+	if st.Err() == nil {
+		s.FinalizeSummary.EndTime = time.Now()
+		if err := s.SaveConfig(); err != nil {
+			return err
+		}
+	}
+
 	message := &idl.Message{Contents: &idl.Message_Response{Response: &idl.Response{Contents: &idl.Response_FinalizeResponse{
 		FinalizeResponse: &idl.FinalizeResponse{
-			TargetVersion:                     s.Target.Version.VersionString,
-			LogArchiveDirectory:               archiveDir,
-			ArchivedSourceMasterDataDirectory: s.Config.TargetInitializeConfig.Master.DataDir + upgrade.OldSuffix,
+			TargetVersion:                     s.FinalizeSummary.TargetVersion,
+			LogArchiveDirectory:               s.FinalizeSummary.LogArchiveDirectory,
+			ArchivedSourceMasterDataDirectory: s.FinalizeSummary.ArchivedSourceMasterDataDirectory,
 			UpgradeID:                         s.Config.UpgradeID.String(),
 			Target: &idl.Cluster{
 				Port:                int32(s.Target.MasterPort()),
@@ -0,0 +1,95 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// GeneratedInitsystemParameters are written by gpupgrade from the source
+// cluster and cannot be overridden.
+var GeneratedInitsystemParameters = []string{"QD_PRIMARY_ARRAY", "PRIMARY_ARRAY", "MIRROR_ARRAY", "HBA_HOSTNAMES"}
+
+// initsystemLocaleOptions are the gpinitsystem command line options for the
+// locale overrides, which gpinitsystem does not read from its config.
+var initsystemLocaleOptions = map[string]string{
+	"LOCALE":      "--locale",
+	"LC_COLLATE":  "--lc-collate",
+	"LC_CTYPE":    "--lc-ctype",
+	"LC_MESSAGES": "--lc-messages",
+	"LC_MONETARY": "--lc-monetary",
+	"LC_NUMERIC":  "--lc-numeric",
+	"LC_TIME":     "--lc-time",
+}
+
+var initsystemParameterName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// ValidateInitsystemOverride returns an error when the gpinitsystem parameter
+// cannot be overridden.
+func ValidateInitsystemOverride(name string) error {
+	if !initsystemParameterName.MatchString(name) {
+		return xerrors.Errorf("%q is not a gpinitsystem parameter name", name)
+	}
+
+	for _, generated := range GeneratedInitsystemParameters {
+		if name == generated {
+			return xerrors.Errorf("%q is generated by gpupgrade and cannot be overridden", name)
+		}
+	}
+
+	return nil
+}
+
+// MergeInitsystemOverrides replaces the generated gpinitsystem parameters with
+// their overrides, and appends the remaining overrides in sorted order.
+func MergeInitsystemOverrides(gpinitsystemConfig []string, overrides map[string]string) []string {
+	merged := make(map[string]bool)
+
+	var config []string
+	for _, line := range gpinitsystemConfig {
+		name := strings.SplitN(line, "=", 2)[0]
+		if value, ok := overrides[name]; ok {
+			line = name + "=" + value
+			merged[name] = true
+		}
+
+		config = append(config, line)
+	}
+
+	var names []string
+	for name := range overrides {
+		if !merged[name] {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		config = append(config, name+"="+overrides[name])
+	}
+
+	return config
+}
+
+// InitsystemLocaleOptions returns the gpinitsystem command line options for
+// the locale overrides.
+func InitsystemLocaleOptions(overrides map[string]string) []string {
+	var options []string
+	for name, value := range overrides {
+		if option, ok := initsystemLocaleOptions[name]; ok {
+			options = append(options, option+"="+unquoteInitsystemValue(value))
+		}
+	}
+
+	sort.Strings(options)
+	return options
+}
+
+func unquoteInitsystemValue(value string) string {
+	return strings.Trim(value, `"'`)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+)
+
+func TestValidateInitsystemOverride(t *testing.T) {
+	for _, name := range []string{"ENCODING", "HEAP_CHECKSUM", "LC_COLLATE"} {
+		if err := hub.ValidateInitsystemOverride(name); err != nil {
+			t.Errorf("ValidateInitsystemOverride(%q) returned error %+v", name, err)
+		}
+	}
+
+	for _, name := range []string{"encoding", "1ENCODING", "QD_PRIMARY_ARRAY", "HBA_HOSTNAMES"} {
+		if err := hub.ValidateInitsystemOverride(name); err == nil {
+			t.Errorf("expected ValidateInitsystemOverride(%q) to error", name)
+		}
+	}
+}
+
+func TestMergeInitsystemOverrides(t *testing.T) {
+	config := []string{
+		"ARRAY_NAME=gp_upgrade_cluster",
+		"ENCODING=UNICODE",
+		"CHECK_POINT_SEGMENTS=8",
+	}
+
+	overrides := map[string]string{
+		"ENCODING":           "UTF8",
+		"MASTER_MAX_CONNECT": "250",
+		"HEAP_CHECKSUM":      "on",
+	}
+
+	expected := []string{
+		"ARRAY_NAME=gp_upgrade_cluster",
+		"ENCODING=UTF8",
+		"CHECK_POINT_SEGMENTS=8",
+		"HEAP_CHECKSUM=on",
+		"MASTER_MAX_CONNECT=250",
+	}
+
+	merged := hub.MergeInitsystemOverrides(config, overrides)
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("got %q want %q", merged, expected)
+	}
+}
+
+func TestInitsystemLocaleOptions(t *testing.T) {
+	overrides := map[string]string{
+		"LOCALE":      `"en_US.utf8"`,
+		"LC_MONETARY": "C",
+		"ENCODING":    "UTF8",
+	}
+
+	expected := []string{"--lc-monetary=C", "--locale=en_US.utf8"}
+
+	options := hub.InitsystemLocaleOptions(overrides)
+	if !reflect.DeepEqual(options, expected) {
+		t.Errorf("got %q want %q", options, expected)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/greenplum-db/gp-common-go-libs/dbconn"
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/kballard/go-shellquote"
 	"github.com/pkg/errors"
 	"golang.org/x/xerrors"
 
@@ -25,10 +27,10 @@ import (
 	"github.com/greenplum-db/gpupgrade/step"
 )
 
-var ErrUnknownCatalogVersion = errors.New("pg_controldata output is missing catalog version")
+var ErrUnknownCatalogVersion = errors.New("output is missing catalog version")
 
 func (s *Server) GenerateInitsystemConfig() error {
-	sourceDBConn := db.NewDBConn("localhost", int(s.Source.MasterPort()), "template1")
+	sourceDBConn := db.NewDBConnWithSettings(s.Connection, int(s.Source.MasterPort()), "template1")
 	return s.writeConf(sourceDBConn)
 }
 
@@ -48,11 +50,18 @@ func (s *Server) writeConf(sourceDBConn *dbconn.DBConn) error {
 		return err
 	}
 
-	gpinitsystemConfig, err = GetCheckpointSegmentsAndEncoding(gpinitsystemConfig, s.Source.Version, sourceDBConn)
+	targetVersion, err := greenplum.LocalVersion(s.TargetGPHome)
 	if err != nil {
 		return err
 	}
 
+	gpinitsystemConfig, err = GetCheckpointSegmentsAndEncoding(gpinitsystemConfig, targetVersion, sourceDBConn)
+	if err != nil {
+		return err
+	}
+
+	gpinitsystemConfig = MergeInitsystemOverrides(gpinitsystemConfig, s.GpinitsystemOverrides)
+
 	gpinitsystemConfig, err = WriteSegmentArray(gpinitsystemConfig, s.TargetInitializeConfig)
 	if err != nil {
 		return xerrors.Errorf("generating segment array: %w", err)
@@ -77,7 +86,7 @@ func (s *Server) RemoveTargetCluster(streams step.OutStreams) error {
 		}
 	}
 
-	err = DeleteMasterAndPrimaryDataDirectories(streams, s.agentConns, s.TargetInitializeConfig)
+	err = DeleteMasterAndPrimaryDataDirectories(streams, s.agentConns, s.TargetInitializeConfig, s.Source.RemoteMaster)
 	if err != nil {
 		return xerrors.Errorf("deleting target cluster data directories: %w", err)
 	}
@@ -94,7 +103,7 @@ func (s *Server) CreateTargetCluster(stream step.OutStreams) error {
 		return err
 	}
 
-	conn := db.NewDBConn("localhost", s.TargetInitializeConfig.Master.Port, "template1")
+	conn := db.NewDBConnWithSettings(s.Connection, s.TargetInitializeConfig.Master.Port, "template1")
 	defer conn.Close()
 
 	s.Target, err = greenplum.ClusterFromDB(conn, s.TargetGPHome)
@@ -102,6 +111,9 @@ func (s *Server) CreateTargetCluster(stream step.OutStreams) error {
 		return xerrors.Errorf("retrieve target configuration: %w", err)
 	}
 
+	// The target master is created on the source master host.
+	s.Target.RemoteMaster = s.Source.RemoteMaster
+
 	if err := s.SaveConfig(); err != nil {
 		return err
 	}
@@ -115,11 +127,24 @@ func (s *Server) InitTargetCluster(stream step.OutStreams) error {
 		return err
 	}
 
-	return RunInitsystemForTargetCluster(stream,
-		s.TargetGPHome, s.initsystemConfPath(), version)
+	host := remoteMasterHost(s.Source)
+	if host != "" {
+		// gpinitsystem reads its configuration on the master host, within
+		// the state directory shared with the agent there.
+		if err := Copy(stream, s.initsystemConfPath(), []string{s.initsystemConfPath()}, []string{host}); err != nil {
+			return err
+		}
+	}
+
+	return RunInitsystemForTargetCluster(stream, host,
+		s.TargetGPHome, s.initsystemConfPath(), version, InitsystemLocaleOptions(s.GpinitsystemOverrides)...)
 }
 
-func GetCheckpointSegmentsAndEncoding(gpinitsystemConfig []string, version dbconn.GPDBVersion, dbConnector *dbconn.DBConn) ([]string, error) {
+// GetCheckpointSegmentsAndEncoding carries the encoding and checkpoint segments
+// of the source cluster over to the gpinitsystem config of the target cluster.
+// Which parameters are written depends on the target version since
+// gpinitsystem for 7X no longer accepts CHECK_POINT_SEGMENTS.
+func GetCheckpointSegmentsAndEncoding(gpinitsystemConfig []string, targetVersion semver.Version, dbConnector *dbconn.DBConn) ([]string, error) {
 	encoding, err := dbconn.SelectString(dbConnector, "SELECT current_setting('server_encoding') AS string")
 	if err != nil {
 		return gpinitsystemConfig, xerrors.Errorf("retrieve server encoding: %w", err)
@@ -127,7 +152,7 @@ func GetCheckpointSegmentsAndEncoding(gpinitsystemConfig []string, version dbcon
 	gpinitsystemConfig = append(gpinitsystemConfig, fmt.Sprintf("ENCODING=%s", encoding))
 
 	// The 7X guc max_wal_size supersedes checkpoint_segments and its default value is sufficient.
-	if version.Before("7") {
+	if targetVersion.LT(semver.MustParse("7.0.0")) {
 		checkpointSegments, err := dbconn.SelectString(dbConnector, "SELECT current_setting('checkpoint_segments') AS string")
 		if err != nil {
 			return gpinitsystemConfig, xerrors.Errorf("retrieve checkpoint segments: %w", err)
@@ -202,7 +227,9 @@ func WriteSegmentArray(config []string, targetInitializeConfig InitializeConfig)
 	return config, nil
 }
 
-func RunInitsystemForTargetCluster(stream step.OutStreams, gpHome, configPath string, version semver.Version) error {
+// RunInitsystemForTargetCluster runs gpinitsystem on the host, or locally when
+// the host is empty, passing it any additional options.
+func RunInitsystemForTargetCluster(stream step.OutStreams, host, gpHome, configPath string, version semver.Version, options ...string) error {
 	// TODO: migrate this implementation to greenplum.Runner.
 
 	args := "-a -I " + configPath
@@ -212,11 +239,15 @@ func RunInitsystemForTargetCluster(stream step.OutStreams, gpHome, configPath st
 		args += " --ignore-warnings"
 	}
 
+	if len(options) > 0 {
+		args += " " + shellquote.Join(options...)
+	}
+
 	script := fmt.Sprintf("source %[1]s/greenplum_path.sh && %[1]s/bin/gpinitsystem %[2]s",
 		gpHome,
 		args,
 	)
-	cmd := execCommand("bash", "-c", script)
+	cmd := commandOnHost(host, "bash", "-c", script)
 
 	cmd.Stdout = stream.Stdout()
 	cmd.Stderr = stream.Stderr()
@@ -226,12 +257,15 @@ func RunInitsystemForTargetCluster(stream step.OutStreams, gpHome, configPath st
 	// previous installation's ambient environment into the mix.
 	//
 	// gpinitsystem unfortunately relies on a few envvars for logging purposes;
-	// otherwise, we could clear the environment completely.
-	cmd.Env = filterEnv([]string{
-		"HOME",
-		"USER",
-		"LOGNAME",
-	})
+	// otherwise, we could clear the environment completely. Over ssh the
+	// remote login environment is used instead.
+	if host == "" {
+		cmd.Env = filterEnv([]string{
+			"HOME",
+			"USER",
+			"LOGNAME",
+		})
+	}
 
 	err := cmd.Run()
 	if err != nil {
@@ -274,11 +308,20 @@ func GetMasterSegPrefix(datadir string) (string, error) {
 	return segPrefix, nil
 }
 
-func GetCatalogVersion(stream step.OutStreams, gphome, datadir string) (string, error) {
+func GetCatalogVersion(stream step.OutStreams, host, gphome, datadir string) (string, error) {
 	utility := filepath.Join(gphome, "bin", "pg_controldata")
-	cmd := execCommand(utility, datadir)
+	return runCatalogVersion(stream, commandOnHost(host, utility, datadir))
+}
+
+// GetBinaryCatalogVersion returns the catalog version of the postgres of the
+// GPHOME, which unlike GetCatalogVersion needs no data directory.
+func GetBinaryCatalogVersion(stream step.OutStreams, host, gphome string) (string, error) {
+	postgres := filepath.Join(gphome, "bin", "postgres")
+	return runCatalogVersion(stream, commandOnHost(host, postgres, "--catalog-version"))
+}
 
-	// Buffer stdout to parse pg_controldata
+func runCatalogVersion(stream step.OutStreams, cmd *exec.Cmd) (string, error) {
+	// Buffer stdout to parse the catalog version
 	stdout := new(bytes.Buffer)
 	tee := io.MultiWriter(stream.Stdout(), stdout)
 
@@ -290,7 +333,6 @@ func GetCatalogVersion(stream step.OutStreams, gphome, datadir string) (string,
 		return "", err
 	}
 
-	// parse pg_control data
 	var version string
 	prefix := "Catalog version number:"
 
@@ -304,7 +346,7 @@ func GetCatalogVersion(stream step.OutStreams, gphome, datadir string) (string,
 	}
 
 	if err := scanner.Err(); err != nil {
-		return "", xerrors.Errorf("scanning pg_controldata: %w", err)
+		return "", xerrors.Errorf("scanning catalog version: %w", err)
 	}
 
 	if version == "" {
@@ -313,3 +355,32 @@ func GetCatalogVersion(stream step.OutStreams, gphome, datadir string) (string,
 
 	return version, nil
 }
+
+// VerifyTablespaceCatalogVersions ensures the target cluster tablespace
+// directories do not collide with those of a 6X source cluster. Both clusters
+// use the same tablespace locations with directories named by their catalog
+// version, so the catalog versions must differ. The versions are those of the
+// source and target GPHOMEs, such that this is checked before the target
+// cluster is created.
+func VerifyTablespaceCatalogVersions(stream step.OutStreams, source *greenplum.Cluster, targetGPHome string, tablespaces greenplum.Tablespaces) error {
+	if !source.Version.AtLeast("6") || !tablespaces.HasUserDefined() {
+		return nil
+	}
+
+	sourceCatalogVersion, err := GetBinaryCatalogVersion(stream, remoteMasterHost(source), source.GPHome)
+	if err != nil {
+		return err
+	}
+
+	targetCatalogVersion, err := GetBinaryCatalogVersion(stream, remoteMasterHost(source), targetGPHome)
+	if err != nil {
+		return err
+	}
+
+	if sourceCatalogVersion == targetCatalogVersion {
+		return fmt.Errorf("source and target clusters have the same catalog version %s. "+
+			"Upgrading user defined tablespaces requires different catalog versions.", targetCatalogVersion)
+	}
+
+	return nil
+}
@@ -56,6 +56,8 @@ func init() {
 		gpinitsystem,
 		gpinitsystem_Exits1,
 		pg_controldata,
+		postgresCatalogVersion,
+		postgresCatalogVersion_Target,
 	)
 }
 
@@ -91,11 +93,11 @@ func TestGetCheckpointSegmentsAndEncoding(t *testing.T) {
 
 	// the mock query order must match the query order in GetCheckpointSegmentsAndEncoding
 	cases := []struct {
-		version dbconn.GPDBVersion
+		version semver.Version
 		query   []mockQuery
 	}{
 		{
-			dbconn.NewVersion("5.0.0"),
+			semver.MustParse("5.0.0"),
 			[]mockQuery{
 				{
 					"SELECT .*server.*",
@@ -110,7 +112,7 @@ func TestGetCheckpointSegmentsAndEncoding(t *testing.T) {
 			},
 		},
 		{
-			dbconn.NewVersion("6.0.0"),
+			semver.MustParse("6.0.0"),
 			[]mockQuery{
 				{
 					"SELECT .*server.*",
@@ -125,7 +127,7 @@ func TestGetCheckpointSegmentsAndEncoding(t *testing.T) {
 			},
 		},
 		{
-			dbconn.NewVersion("7.0.0"),
+			semver.MustParse("7.0.0"),
 			[]mockQuery{
 				{
 					"SELECT .*server.*",
@@ -137,7 +139,7 @@ func TestGetCheckpointSegmentsAndEncoding(t *testing.T) {
 	}
 
 	for _, c := range cases {
-		t.Run(fmt.Sprintf("successfully get the GUC values for %s", c.version.VersionString), func(t *testing.T) {
+		t.Run(fmt.Sprintf("successfully get the GUC values for a %s target", c.version), func(t *testing.T) {
 			dbConn, sqlMock := testhelper.CreateAndConnectMockDB(1)
 
 			var expected []string
@@ -240,7 +242,7 @@ func TestRunInitsystemForTargetCluster(t *testing.T) {
 				}
 			})
 
-		err := RunInitsystemForTargetCluster(step.DevNullStream, gpHome7, gpinitsystemConfigPath, version7)
+		err := RunInitsystemForTargetCluster(step.DevNullStream, "", gpHome7, gpinitsystemConfigPath, version7)
 		if err != nil {
 			t.Error("gpinitsystem failed")
 		}
@@ -260,7 +262,23 @@ func TestRunInitsystemForTargetCluster(t *testing.T) {
 				}
 			})
 
-		err := RunInitsystemForTargetCluster(step.DevNullStream, gpHome6, gpinitsystemConfigPath, version6)
+		err := RunInitsystemForTargetCluster(step.DevNullStream, "", gpHome6, gpinitsystemConfigPath, version6)
+		if err != nil {
+			t.Error("gpinitsystem failed")
+		}
+	})
+
+	t.Run("passes the quoted gpinitsystem options", func(t *testing.T) {
+		execCommand = exectest.NewCommandWithVerifier(gpinitsystem,
+			func(path string, args ...string) {
+				expected := []string{"-c", "source /usr/local/gpdb7/greenplum_path.sh && " +
+					"/usr/local/gpdb7/bin/gpinitsystem -a -I /dir/.gpupgrade/gpinitsystem_config --lc-collate=C '--locale=en US'"}
+				if !reflect.DeepEqual(args, expected) {
+					t.Errorf("args %q, want %q", args, expected)
+				}
+			})
+
+		err := RunInitsystemForTargetCluster(step.DevNullStream, "", gpHome7, gpinitsystemConfigPath, version7, "--lc-collate=C", "--locale=en US")
 		if err != nil {
 			t.Error("gpinitsystem failed")
 		}
@@ -269,7 +287,7 @@ func TestRunInitsystemForTargetCluster(t *testing.T) {
 	t.Run("returns an error when gpinitsystem fails with --ignore-warnings when upgrading to GPDB6", func(t *testing.T) {
 		execCommand = exectest.NewCommand(gpinitsystem_Exits1)
 
-		err := RunInitsystemForTargetCluster(step.DevNullStream, gpHome6, gpinitsystemConfigPath, version6)
+		err := RunInitsystemForTargetCluster(step.DevNullStream, "", gpHome6, gpinitsystemConfigPath, version6)
 
 		var actual *exec.ExitError
 		if !errors.As(err, &actual) {
@@ -284,7 +302,7 @@ func TestRunInitsystemForTargetCluster(t *testing.T) {
 	t.Run("returns an error when gpinitsystem errors when upgrading to GPDB7 or higher", func(t *testing.T) {
 		execCommand = exectest.NewCommand(gpinitsystem_Exits1)
 
-		err := RunInitsystemForTargetCluster(step.DevNullStream, gpHome7, gpinitsystemConfigPath, version7)
+		err := RunInitsystemForTargetCluster(step.DevNullStream, "", gpHome7, gpinitsystemConfigPath, version7)
 
 		var actual *exec.ExitError
 		if !errors.As(err, &actual) {
@@ -327,7 +345,7 @@ func TestRunInitsystemForTargetCluster(t *testing.T) {
 		defer ResetExecCommand()
 
 		out := &stdoutBuffer{}
-		err := RunInitsystemForTargetCluster(out, gpHome6, gpinitsystemConfigPath, version6)
+		err := RunInitsystemForTargetCluster(out, "", gpHome6, gpinitsystemConfigPath, version6)
 
 		if err != nil {
 			t.Fatalf("got error: %+v", err)
@@ -421,7 +439,7 @@ func TestGetCatalogVersion(t *testing.T) {
 		SetExecCommand(exectest.NewCommand(pg_controldata))
 		defer ResetExecCommand()
 
-		version, err := GetCatalogVersion(step.DevNullStream, gphome, datadir)
+		version, err := GetCatalogVersion(step.DevNullStream, "", gphome, datadir)
 		if err != nil {
 			t.Errorf("GetCatalogVersion returned error %+v", err)
 		}
@@ -436,7 +454,7 @@ func TestGetCatalogVersion(t *testing.T) {
 		SetExecCommand(exectest.NewCommand(Failure))
 		defer ResetExecCommand()
 
-		version, err := GetCatalogVersion(step.DevNullStream, gphome, datadir)
+		version, err := GetCatalogVersion(step.DevNullStream, "", gphome, datadir)
 		var exitErr *exec.ExitError
 		if !errors.As(err, &exitErr) {
 			t.Fatalf("got error %#v want %T", err, exitErr)
@@ -455,7 +473,7 @@ func TestGetCatalogVersion(t *testing.T) {
 		SetExecCommand(exectest.NewCommand(Success))
 		defer ResetExecCommand()
 
-		version, err := GetCatalogVersion(step.DevNullStream, gphome, datadir)
+		version, err := GetCatalogVersion(step.DevNullStream, "", gphome, datadir)
 		if !errors.Is(err, ErrUnknownCatalogVersion) {
 			t.Errorf("got error %#v want %#v", err, ErrUnknownCatalogVersion)
 		}
@@ -466,6 +484,119 @@ func TestGetCatalogVersion(t *testing.T) {
 	})
 }
 
+func postgresCatalogVersion() {
+	fmt.Println("Catalog version number:               301908232")
+}
+
+func postgresCatalogVersion_Target() {
+	fmt.Println("Catalog version number:               302206171")
+}
+
+func TestGetBinaryCatalogVersion(t *testing.T) {
+	testlog.SetupLogger()
+
+	t.Run("returns the catalog version of postgres", func(t *testing.T) {
+		SetExecCommand(exectest.NewCommandWithVerifier(postgresCatalogVersion, func(name string, args ...string) {
+			if name != "/usr/local/target/bin/postgres" {
+				t.Errorf("got %q want the target postgres", name)
+			}
+
+			expected := []string{"--catalog-version"}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+		defer ResetExecCommand()
+
+		version, err := GetBinaryCatalogVersion(step.DevNullStream, "", "/usr/local/target")
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+
+		if version != "301908232" {
+			t.Errorf("got %s want %s", version, "301908232")
+		}
+	})
+
+	t.Run("errors when the catalog version is not found", func(t *testing.T) {
+		SetExecCommand(exectest.NewCommand(Success))
+		defer ResetExecCommand()
+
+		_, err := GetBinaryCatalogVersion(step.DevNullStream, "", "/usr/local/target")
+		if !errors.Is(err, ErrUnknownCatalogVersion) {
+			t.Errorf("got error %#v want %#v", err, ErrUnknownCatalogVersion)
+		}
+	})
+}
+
+func TestVerifyTablespaceCatalogVersions(t *testing.T) {
+	testlog.SetupLogger()
+
+	source := MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+	})
+	source.GPHome = "/usr/local/source"
+	source.Version = dbconn.NewVersion("6.15.0")
+
+	tablespaces := greenplum.Tablespaces{
+		1: {16386: {Location: "/tmp/tblspc", UserDefined: 1}},
+	}
+
+	t.Run("errors when a 6X source with user defined tablespaces has the same catalog version as the target", func(t *testing.T) {
+		var postgres []string
+		SetExecCommand(exectest.NewCommandWithVerifier(postgresCatalogVersion, func(name string, args ...string) {
+			postgres = append(postgres, name)
+		}))
+		defer ResetExecCommand()
+
+		err := VerifyTablespaceCatalogVersions(step.DevNullStream, source, "/usr/local/target", tablespaces)
+		expected := "source and target clusters have the same catalog version 301908232. " +
+			"Upgrading user defined tablespaces requires different catalog versions."
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+
+		expectedPostgres := []string{"/usr/local/source/bin/postgres", "/usr/local/target/bin/postgres"}
+		if !reflect.DeepEqual(postgres, expectedPostgres) {
+			t.Errorf("got %q want %q", postgres, expectedPostgres)
+		}
+	})
+
+	t.Run("succeeds when the catalog versions differ", func(t *testing.T) {
+		SetExecCommand(func(name string, args ...string) *exec.Cmd {
+			if strings.HasPrefix(name, "/usr/local/target") {
+				return exectest.NewCommand(postgresCatalogVersion_Target)(name, args...)
+			}
+
+			return exectest.NewCommand(postgresCatalogVersion)(name, args...)
+		})
+		defer ResetExecCommand()
+
+		err := VerifyTablespaceCatalogVersions(step.DevNullStream, source, "/usr/local/target", tablespaces)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("does not check the catalog version without user defined tablespaces or of 5X sources", func(t *testing.T) {
+		SetExecCommand(exectest.NewCommand(Failure))
+		defer ResetExecCommand()
+
+		err := VerifyTablespaceCatalogVersions(step.DevNullStream, source, "/usr/local/target", greenplum.Tablespaces{})
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+
+		source5X := *source
+		source5X.Version = dbconn.NewVersion("5.28.0")
+
+		err = VerifyTablespaceCatalogVersions(step.DevNullStream, &source5X, "/usr/local/target", tablespaces)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+}
+
 func TestFilterEnv(t *testing.T) {
 	cases := []struct {
 		name       string
@@ -36,6 +36,7 @@ func (s *Server) Initialize(in *idl.InitializeRequest, stream idl.CliToHub_Initi
 		}
 	}()
 
+	var path greenplum.UpgradePath
 	st.RunInternalSubstep(func() error {
 		sourceVersion, err := greenplum.LocalVersion(in.SourceGPHome)
 		if err != nil {
@@ -47,10 +48,34 @@ func (s *Server) Initialize(in *idl.InitializeRequest, stream idl.CliToHub_Initi
 			return err
 		}
 
-		conn := connURI.Connection(sourceVersion, targetVersion)
+		conn := connURI.Connection(sourceVersion, targetVersion,
+			connURI.Host(in.GetSourceMasterHost()),
+			connURI.User(in.GetDbUser()),
+			connURI.PassFile(in.GetPgpassFile()),
+			connURI.PasswordEnv(in.GetDbPasswordEnv()),
+			connURI.SSLMode(in.GetSslMode()),
+			connURI.SSLCert(in.GetSslCert()),
+			connURI.SSLKey(in.GetSslKey()),
+			connURI.SSLRootCert(in.GetSslRootCert()),
+			connURI.ApplicationName(in.GetApplicationName()))
 		s.Connection = conn
 
-		return nil
+		if err := useConnectionEnv(conn); err != nil {
+			return err
+		}
+
+		policy, err := greenplum.LoadInstalledVersionPolicy()
+		if err != nil {
+			return err
+		}
+
+		path, err = policy.Path(sourceVersion, targetVersion)
+		if err != nil && in.GetSkipVersionCheck() {
+			gplog.Warn("skipping version check: %v", err)
+			path, err = greenplum.UnverifiedPath(sourceVersion, targetVersion), nil
+		}
+
+		return err
 	})
 
 	st.Run(idl.Substep_SAVING_SOURCE_CLUSTER_CONFIG, func(stream step.OutStreams) error {
@@ -70,24 +95,71 @@ func (s *Server) Initialize(in *idl.InitializeRequest, stream idl.CliToHub_Initi
 			}
 		}()
 
-		return FillConfiguration(s.Config, conn, stream, in, s.SaveConfig)
+		return FillConfiguration(s.Config, conn, stream, in, path, s.SaveConfig)
+	})
+
+	// Check the catalog versions before the target cluster is created, which
+	// would otherwise need to be reverted.
+	st.RunInternalSubstep(func() error {
+		return VerifyTablespaceCatalogVersions(step.DevNullStream, s.Source, s.TargetGPHome, s.Tablespaces)
 	})
 
+	// Use the request rather than the configuration, which is not filled when
+	// SAVING_SOURCE_CLUSTER_CONFIG is skipped as initialize is rerun.
+	if in.GetDistribute() {
+		st.Run(idl.Substep_DISTRIBUTE, func(stream step.OutStreams) error {
+			return s.DistributeToAgentHosts(stream, in.GetDistributeTargetGPHome())
+		})
+	}
+
 	// we need the cluster information to determine what hosts to check, so we do this check
 	// as early as possible after that information is available
 	st.RunInternalSubstep(func() error {
-		if err := EnsureVersionsMatch(AgentHosts(s.Source), upgrade.NewVersions()); err != nil {
+		if err := EnsureVersionsMatch(s.AgentHosts(), upgrade.NewVersions()); err != nil {
 			return err
 		}
 
-		return EnsureVersionsMatch(AgentHosts(s.Source), greenplum.NewVersions(s.TargetGPHome))
+		return EnsureVersionsMatch(s.AgentHosts(), greenplum.NewVersions(s.TargetGPHome))
 	})
 
 	st.Run(idl.Substep_START_AGENTS, func(_ step.OutStreams) error {
-		_, err := RestartAgents(context.Background(), nil, AgentHosts(s.Source), s.AgentPort, s.StateDir)
+		_, err := RestartAgents(context.Background(), nil, s.AgentHosts(), s.AgentPort, s.StateDir)
 		return err
 	})
 
+	st.Run(idl.Substep_CHECK_TARGET_GPHOME, func(_ step.OutStreams) error {
+		conns, err := s.AgentConns()
+		if err != nil {
+			return err
+		}
+
+		return CheckTargetGPHome(conns, s.hubHost(), s.TargetGPHome, s.GPHomeManifestAllowlist)
+	})
+
+	if len(in.GetTablespaceRelocations()) > 0 {
+		st.Run(idl.Substep_CHECK_TABLESPACE_RELOCATIONS, func(_ step.OutStreams) error {
+			conns, err := s.AgentConns()
+			if err != nil {
+				return err
+			}
+
+			return CheckTablespaceRelocations(conns, s.Source, s.Tablespaces, s.TablespaceRelocations)
+		})
+	}
+
+	st.Run(idl.Substep_CHECK_LOCALE_AND_ENCODING, func(_ step.OutStreams) error {
+		return CheckLocaleAndEncoding(s.Connection, s.Source.MasterPort(), remoteMasterHost(s.Source), s.GpinitsystemOverrides)
+	})
+
+	st.Run(idl.Substep_CHECK_LIBRARIES, func(_ step.OutStreams) error {
+		conns, err := s.AgentConns()
+		if err != nil {
+			return err
+		}
+
+		return CheckLibraries(conns, s.Connection, s.Source, s.TargetGPHome)
+	})
+
 	return st.Err()
 }
 
@@ -125,7 +197,7 @@ func (s *Server) InitializeCreateCluster(in *idl.InitializeCreateClusterRequest,
 		// Persist target catalog version which is needed to revert tablespaces.
 		// We do this right after target cluster creation since during revert the
 		// state of the cluster is unknown.
-		version, err := GetCatalogVersion(stream, s.Target.GPHome, s.Target.MasterDataDir())
+		version, err := GetCatalogVersion(stream, remoteMasterHost(s.Target), s.Target.GPHome, s.Target.MasterDataDir())
 		if err != nil {
 			return err
 		}
@@ -147,6 +219,16 @@ func (s *Server) InitializeCreateCluster(in *idl.InitializeCreateClusterRequest,
 	st.Run(idl.Substep_BACKUP_TARGET_MASTER, func(stream step.OutStreams) error {
 		sourceDir := s.Target.MasterDataDir()
 		targetDir := filepath.Join(s.StateDir, originalMasterBackupName)
+
+		if s.Target.RemoteMaster {
+			conns, err := s.AgentConns()
+			if err != nil {
+				return err
+			}
+
+			return RsyncMasterDataDirOnHost(conns, s.Target, sourceDir, targetDir)
+		}
+
 		return RsyncMasterDataDir(stream, sourceDir, targetDir)
 	})
 
@@ -160,6 +242,22 @@ func (s *Server) InitializeCreateCluster(in *idl.InitializeCreateClusterRequest,
 		return s.CheckUpgrade(stream, conns)
 	})
 
+	if s.Quiesce {
+		st.Run(idl.Substep_QUIESCE_SOURCE_CLUSTER, func(_ step.OutStreams) error {
+			conns, err := s.AgentConns()
+			if err != nil {
+				return err
+			}
+
+			admins, err := quiesceAdmins(s.Connection)
+			if err != nil {
+				return err
+			}
+
+			return QuiesceSourceCluster(conns, s.Source, admins)
+		})
+	}
+
 	message := &idl.Message{Contents: &idl.Message_Response{Response: &idl.Response{Contents: &idl.Response_InitializeResponse{
 		InitializeResponse: &idl.InitializeResponse{
 			HasMirrors: s.Config.Source.HasMirrors(),
@@ -0,0 +1,120 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+// MigrateConfiguration carries the postgresql.conf settings and pg_hba.conf
+// entries of the source master and primaries over to the target, and reports
+// what was changed or skipped. The master is migrated by the hub unless it
+// runs on another host, and the primaries by the agents on the target hosts
+// given by the data directory pairs.
+func MigrateConfiguration(streams step.OutStreams, agentConns []*Connection, source, target *greenplum.Cluster, pairs map[string][]*idl.DataDirPair) error {
+	master := &idl.DataDirPair{
+		SourceDataDir: source.MasterDataDir(),
+		TargetDataDir: target.MasterDataDir(),
+		Content:       -1,
+		DBID:          int32(source.Master().DbID),
+	}
+
+	hostPairs := make(map[string][]*idl.DataDirPair)
+	for host, p := range pairs {
+		hostPairs[host] = p
+	}
+
+	var changes []*idl.ConfigurationChange
+	if source.RemoteMaster {
+		host := source.MasterHostname()
+		hostPairs[host] = append([]*idl.DataDirPair{master}, hostPairs[host]...)
+	} else {
+		targetSettings, err := upgrade.TargetSettingNames(target.GPHome)
+		if err != nil {
+			return err
+		}
+
+		masterChanges, err := upgrade.MigrateConfig(master.SourceDataDir, master.TargetDataDir,
+			semver.MustParse(source.Version.SemVer.String()), semver.MustParse(target.Version.SemVer.String()), targetSettings)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range masterChanges {
+			changes = append(changes, &idl.ConfigurationChange{
+				DataDir: master.TargetDataDir,
+				File:    change.File,
+				Name:    change.Name,
+				Action:  change.Action,
+				Detail:  change.Detail,
+			})
+		}
+	}
+
+	var mu sync.Mutex
+	request := func(conn *Connection) error {
+		if len(hostPairs[conn.Hostname]) == 0 {
+			return nil
+		}
+
+		req := &idl.MigrateConfigurationRequest{
+			SourceVersion: source.Version.SemVer.String(),
+			TargetVersion: target.Version.SemVer.String(),
+			DataDirPairs:  hostPairs[conn.Hostname],
+			TargetGPHome:  target.GPHome,
+		}
+
+		reply, err := conn.AgentClient.MigrateConfiguration(context.Background(), req)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, reply.GetChanges()...)
+
+		return nil
+	}
+
+	if err := ExecuteRPC(agentConns, request); err != nil {
+		return err
+	}
+
+	return reportConfigurationChanges(streams, changes)
+}
+
+func reportConfigurationChanges(streams step.OutStreams, changes []*idl.ConfigurationChange) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(streams.Stdout(), "The target configuration matches the source cluster.")
+		return err
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].GetDataDir() < changes[j].GetDataDir()
+	})
+
+	if _, err := fmt.Fprintln(streams.Stdout(), "Migrated the source cluster configuration to the target cluster:"); err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		_, err := fmt.Fprintf(streams.Stdout(), "  %s %s: %s %q: %s\n",
+			change.GetDataDir(), change.GetFile(), change.GetAction(), change.GetName(), change.GetDetail())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+func TestMigrateConfiguration(t *testing.T) {
+	source := hub.MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+	})
+	source.Version = dbconn.NewVersion("5.28.0")
+	source.RemoteMaster = true
+
+	target := hub.MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1_ABC123-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1_ABC123", Role: greenplum.PrimaryRole},
+	})
+	target.Version = dbconn.NewVersion("6.20.0")
+	target.GPHome = "/usr/local/target"
+
+	primaryPair := &idl.DataDirPair{SourceDataDir: "/data/dbfast1/seg1", TargetDataDir: "/data/dbfast1/seg1_ABC123", Content: 0, DBID: 2}
+	pairs := map[string][]*idl.DataDirPair{"sdw1": {primaryPair}}
+
+	t.Run("migrates the master and primaries through the agents and reports the changes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+		mdw.EXPECT().MigrateConfiguration(
+			gomock.Any(),
+			&idl.MigrateConfigurationRequest{
+				SourceVersion: "5.28.0",
+				TargetVersion: "6.20.0",
+				DataDirPairs: []*idl.DataDirPair{
+					{SourceDataDir: "/data/qddir/seg-1", TargetDataDir: "/data/qddir/seg-1_ABC123-1", Content: -1, DBID: 1},
+				},
+				TargetGPHome: "/usr/local/target",
+			},
+		).Return(&idl.MigrateConfigurationReply{Changes: []*idl.ConfigurationChange{
+			{DataDir: "/data/qddir/seg-1_ABC123-1", File: "postgresql.conf", Name: "max_fsm_pages", Action: upgrade.ConfigSkipped, Detail: "not a setting of GPDB 6"},
+		}}, nil)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().MigrateConfiguration(
+			gomock.Any(),
+			&idl.MigrateConfigurationRequest{
+				SourceVersion: "5.28.0",
+				TargetVersion: "6.20.0",
+				DataDirPairs:  []*idl.DataDirPair{primaryPair},
+				TargetGPHome:  "/usr/local/target",
+			},
+		).Return(&idl.MigrateConfigurationReply{Changes: []*idl.ConfigurationChange{
+			{DataDir: "/data/dbfast1/seg1_ABC123", File: "postgresql.conf", Name: "work_mem", Action: upgrade.ConfigApplied, Detail: "'64MB' replacing the target default"},
+		}}, nil)
+
+		agentConns := []*hub.Connection{
+			{AgentClient: mdw, Hostname: "mdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		streams := new(step.BufferedStreams)
+		err := hub.MigrateConfiguration(streams, agentConns, source, target, pairs)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		expected := `Migrated the source cluster configuration to the target cluster:
+  /data/dbfast1/seg1_ABC123 postgresql.conf: applied "work_mem": '64MB' replacing the target default
+  /data/qddir/seg-1_ABC123-1 postgresql.conf: skipped "max_fsm_pages": not a setting of GPDB 6
+`
+		if streams.StdoutBuf.String() != expected {
+			t.Errorf("got report\n%s\nwant\n%s", streams.StdoutBuf.String(), expected)
+		}
+
+		if len(pairs["mdw"]) != 0 {
+			t.Errorf("expected the data directory pairs to be unchanged, got %v", pairs)
+		}
+	})
+
+	t.Run("returns agent errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().MigrateConfiguration(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(nil, expected)
+
+		agentConns := []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.MigrateConfiguration(step.DevNullStream, agentConns, source, target, pairs)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("reports when there is nothing to migrate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().MigrateConfiguration(gomock.Any(), gomock.Any()).Return(&idl.MigrateConfigurationReply{}, nil)
+
+		streams := new(step.BufferedStreams)
+		err := hub.MigrateConfiguration(streams, []*hub.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}, source, target, pairs)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if !strings.Contains(streams.StdoutBuf.String(), "matches the source cluster") {
+			t.Errorf("got report %q", streams.StdoutBuf.String())
+		}
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// QuiesceSourceCluster blocks client connections to all source segments other
+// than those of the admins, for the duration of the upgrade. The master is
+// quiesced by the hub unless it runs on another host.
+func QuiesceSourceCluster(agentConns []*Connection, source *greenplum.Cluster, admins []string) error {
+	binDir := filepath.Join(source.GPHome, "bin")
+
+	if !source.RemoteMaster {
+		if err := upgrade.Quiesce(binDir, source.MasterDataDir(), admins); err != nil {
+			return xerrors.Errorf("quiescing master: %w", err)
+		}
+	}
+
+	request := func(conn *Connection) error {
+		req := &idl.QuiesceRequest{
+			BinDir:   binDir,
+			DataDirs: quiesceDataDirs(conn.Hostname, source),
+			Admins:   admins,
+		}
+
+		if len(req.DataDirs) == 0 {
+			return nil
+		}
+
+		_, err := conn.AgentClient.QuiesceSegments(context.Background(), req)
+		return err
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
+
+// UnquiesceSourceCluster restores client access to all source segments
+// quiesced by QuiesceSourceCluster.
+func UnquiesceSourceCluster(agentConns []*Connection, source *greenplum.Cluster) error {
+	binDir := filepath.Join(source.GPHome, "bin")
+
+	if !source.RemoteMaster {
+		if err := upgrade.Unquiesce(binDir, source.MasterDataDir()); err != nil {
+			return xerrors.Errorf("unquiescing master: %w", err)
+		}
+	}
+
+	request := func(conn *Connection) error {
+		req := &idl.QuiesceRequest{
+			BinDir:   binDir,
+			DataDirs: quiesceDataDirs(conn.Hostname, source),
+		}
+
+		if len(req.DataDirs) == 0 {
+			return nil
+		}
+
+		_, err := conn.AgentClient.UnquiesceSegments(context.Background(), req)
+		return err
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
+
+func quiesceDataDirs(host string, source *greenplum.Cluster) []string {
+	segments := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+		return seg.IsOnHost(host) && (!seg.IsMaster() || source.RemoteMaster)
+	})
+
+	var dataDirs []string
+	for _, seg := range segments {
+		dataDirs = append(dataDirs, seg.DataDir)
+	}
+
+	return dataDirs
+}
+
+// quiesceAdmins returns the users keeping access to the quiesced source
+// cluster: the configured database user and the user running gpupgrade, which
+// the upgrade utilities connect as.
+func quiesceAdmins(conn *connURI.Conn) ([]string, error) {
+	var admins []string
+	if conn.User() != "" {
+		admins = append(admins, conn.User())
+	}
+
+	currentUser, err := utils.System.CurrentUser()
+	if err != nil {
+		return nil, xerrors.Errorf("looking up current user: %w", err)
+	}
+
+	if currentUser.Username != conn.User() {
+		admins = append(admins, currentUser.Username)
+	}
+
+	return admins, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+)
+
+func TestQuiesceSourceCluster(t *testing.T) {
+	source := hub.MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: -1, DbID: 2, Hostname: "smdw", DataDir: "/data/standby", Role: greenplum.MirrorRole},
+		{ContentID: 0, DbID: 3, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 1, DbID: 4, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg2", Role: greenplum.MirrorRole},
+		{ContentID: 0, DbID: 5, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		{ContentID: 1, DbID: 6, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+	})
+	source.GPHome = "/usr/local/source"
+	source.RemoteMaster = true
+
+	admins := []string{"upgrade_admin", "gpadmin"}
+
+	t.Run("quiesces all source segments through the agents", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+		mdw.EXPECT().QuiesceSegments(
+			gomock.Any(),
+			&idl.QuiesceRequest{BinDir: "/usr/local/source/bin", DataDirs: []string{"/data/qddir/seg-1"}, Admins: admins},
+		).Return(&idl.QuiesceReply{}, nil)
+
+		smdw := mock_idl.NewMockAgentClient(ctrl)
+		smdw.EXPECT().QuiesceSegments(
+			gomock.Any(),
+			&idl.QuiesceRequest{BinDir: "/usr/local/source/bin", DataDirs: []string{"/data/standby"}, Admins: admins},
+		).Return(&idl.QuiesceReply{}, nil)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().QuiesceSegments(
+			gomock.Any(),
+			&idl.QuiesceRequest{BinDir: "/usr/local/source/bin", DataDirs: []string{"/data/dbfast1/seg1", "/data/dbfast_mirror2/seg2"}, Admins: admins},
+		).Return(&idl.QuiesceReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().QuiesceSegments(
+			gomock.Any(),
+			&idl.QuiesceRequest{BinDir: "/usr/local/source/bin", DataDirs: []string{"/data/dbfast_mirror1/seg1", "/data/dbfast2/seg2"}, Admins: admins},
+		).Return(&idl.QuiesceReply{}, nil)
+
+		// Hosts without source segments are not quiesced.
+		sdw3 := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*hub.Connection{
+			{AgentClient: mdw, Hostname: "mdw"},
+			{AgentClient: smdw, Hostname: "smdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: sdw3, Hostname: "sdw3"},
+		}
+
+		err := hub.QuiesceSourceCluster(agentConns, source, admins)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("unquiesces all source segments through the agents", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+		mdw.EXPECT().UnquiesceSegments(
+			gomock.Any(),
+			&idl.QuiesceRequest{BinDir: "/usr/local/source/bin", DataDirs: []string{"/data/qddir/seg-1"}},
+		).Return(&idl.QuiesceReply{}, nil)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().UnquiesceSegments(
+			gomock.Any(),
+			&idl.QuiesceRequest{BinDir: "/usr/local/source/bin", DataDirs: []string{"/data/dbfast1/seg1", "/data/dbfast_mirror2/seg2"}},
+		).Return(&idl.QuiesceReply{}, nil)
+
+		agentConns := []*hub.Connection{
+			{AgentClient: mdw, Hostname: "mdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.UnquiesceSourceCluster(agentConns, source)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("returns agent errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().QuiesceSegments(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(nil, expected)
+
+		agentConns := []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.QuiesceSourceCluster(agentConns, source, admins)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
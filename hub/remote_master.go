@@ -0,0 +1,56 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"os/exec"
+
+	"github.com/kballard/go-shellquote"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+)
+
+// remoteMasterHost returns the master host when the hub does not run on it.
+// Otherwise it returns an empty host such that commands run locally.
+func remoteMasterHost(cluster *greenplum.Cluster) string {
+	if !cluster.RemoteMaster {
+		return ""
+	}
+
+	return cluster.MasterHostname()
+}
+
+// commandOnHost returns a command that runs on the host over ssh, or locally
+// when the host is empty.
+func commandOnHost(host string, name string, args ...string) *exec.Cmd {
+	if host == "" {
+		return execCommand(name, args...)
+	}
+
+	return execCommand("ssh", host, shellquote.Join(append([]string{name}, args...)...))
+}
+
+// masterAgentConn returns the connection to the agent on the master host, which
+// performs the master operations when the hub does not run on the master host.
+func masterAgentConn(agentConns []*Connection, source *greenplum.Cluster) (*Connection, error) {
+	for _, conn := range agentConns {
+		if conn.Hostname == source.MasterHostname() {
+			return conn, nil
+		}
+	}
+
+	return nil, xerrors.Errorf("no agent connection to master host %s", source.MasterHostname())
+}
+
+// hubHost returns the master host when the hub runs on it, such that agent
+// operations duplicating the hub's own are skipped there. Otherwise it returns
+// an empty host as the agent on the master host must perform them.
+func (s *Server) hubHost() string {
+	if s.Source.RemoteMaster {
+		return ""
+	}
+
+	return s.Source.MasterHostname()
+}
@@ -24,7 +24,7 @@ func (s *Server) UpdateDataDirectories() error {
 func UpdateDataDirectories(conf *Config, agentConns []*Connection) error {
 	source := conf.Source.MasterDataDir()
 	target := conf.TargetInitializeConfig.Master.DataDir
-	if err := ArchiveSource(source, target, true); err != nil {
+	if err := archiveMaster(conf.Source, agentConns, source, target); err != nil {
 		return xerrors.Errorf("renaming master data directories: %w", err)
 	}
 
@@ -48,6 +48,23 @@ func UpdateDataDirectories(conf *Config, agentConns []*Connection) error {
 	return nil
 }
 
+// archiveMaster archives the source master data directory and renames the
+// target to take its place. When the hub does not run on the master host this
+// is done by the agent there.
+func archiveMaster(cluster *greenplum.Cluster, agentConns []*Connection, source, target string) error {
+	if !cluster.RemoteMaster {
+		return ArchiveSource(source, target, true)
+	}
+
+	renames := RenameMap{cluster.MasterHostname(): {{
+		Source:       source,
+		Target:       target,
+		RenameTarget: true,
+	}}}
+
+	return RenameSegmentDataDirs(agentConns, renames)
+}
+
 // getRenameMap() returns a map of host to cluster data directories to be renamed.
 // This includes renaming source to archive, and target to source. In link mode
 // the mirrors have been deleted to save disk space, so exclude them from the map.
@@ -72,6 +89,12 @@ func getRenameMap(source *greenplum.Cluster, target InitializeConfig, onlyRename
 
 	targetMirrors := append(target.Mirrors, target.Standby)
 	for _, seg := range targetMirrors {
+		// The source mirrors of mapped hosts remain on their source hosts
+		// and are left untouched.
+		if seg.Hostname != source.Mirrors[seg.ContentID].Hostname {
+			continue
+		}
+
 		m[seg.Hostname] = append(m[seg.Hostname], &idl.RenameDirectories{
 			Source:       source.Mirrors[seg.ContentID].DataDir,
 			Target:       seg.DataDir,
@@ -82,6 +105,42 @@ func getRenameMap(source *greenplum.Cluster, target InitializeConfig, onlyRename
 	return m
 }
 
+// finalSegment returns an upgraded segment once finalize has renamed the target
+// data directories. The segment is on its target host with the data directory
+// described by upgrade.FinalDataDir. Segments without a target data directory
+// are unchanged.
+func finalSegment(seg greenplum.SegConfig, target InitializeConfig) greenplum.SegConfig {
+	targets := append([]greenplum.SegConfig{target.Master, target.Standby}, target.Primaries...)
+	for _, t := range append(targets, target.Mirrors...) {
+		if t.DataDir != "" && t.ContentID == seg.ContentID && t.Role == seg.Role {
+			seg.Hostname = t.Hostname
+			seg.DataDir = upgrade.FinalDataDir(seg.DataDir, t.DataDir)
+			return seg
+		}
+	}
+
+	return seg
+}
+
+// finalDataDir returns the data directory of an upgraded segment once finalize
+// has renamed the target data directories. See finalSegment.
+func finalDataDir(seg greenplum.SegConfig, target InitializeConfig) string {
+	return finalSegment(seg, target).DataDir
+}
+
+// finalDataDirs updates the hosts and data directories of the cluster to those
+// of the upgraded segments once finalize has renamed the target data
+// directories.
+func finalDataDirs(cluster *greenplum.Cluster, target InitializeConfig) {
+	for content, seg := range cluster.Primaries {
+		cluster.Primaries[content] = finalSegment(seg, target)
+	}
+
+	for content, seg := range cluster.Mirrors {
+		cluster.Mirrors[content] = finalSegment(seg, target)
+	}
+}
+
 // e.g. for source /data/dbfast1/demoDataDir0 becomes /data/dbfast1/demoDataDir0_old
 // e.g. for target /data/dbfast1/demoDataDir0_123ABC becomes /data/dbfast1/demoDataDir0
 func RenameSegmentDataDirs(agentConns []*Connection, renames RenameMap) error {
@@ -8,10 +8,14 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"sync"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/kballard/go-shellquote"
 	"github.com/pkg/errors"
+	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
@@ -25,12 +29,101 @@ var RecoversegCmd = exec.Command
 
 var Options = []string{"--archive", "--compress", "--stats"}
 
+// ChecksumOption makes rsync compare file contents rather than size and
+// modification time when deciding which files to transfer.
+const ChecksumOption = "--checksum"
+
+// RsyncOptions returns the options used to restore the source cluster from its
+// mirrors and standby.
+func RsyncOptions(checksum bool) []string {
+	options := append([]string{}, Options...)
+	if checksum {
+		options = append(options, ChecksumOption)
+	}
+
+	return options
+}
+
+// ModifiedContents is the set of source cluster content IDs whose data
+// directories were modified by pg_upgrade in link mode. Only these need to be
+// restored from their mirrors or standby.
+type ModifiedContents map[int]bool
+
+// GetModifiedContents returns the source cluster segments whose data
+// directories must be restored. Once the target cluster has started every
+// segment is modified. Otherwise, such as when execute failed while upgrading
+// the primaries, only the segments whose pg_upgrade was started in link mode
+// are modified. The master status is recorded by the hub, and the primary
+// statuses by the agents on each primary host. When the hub does not run on
+// the master host the master status is recorded by the agent there.
+func GetModifiedContents(agentConns []*Connection, source *greenplum.Cluster, stateDir string, targetStarted bool) (ModifiedContents, error) {
+	modified := make(ModifiedContents)
+
+	if targetStarted {
+		for _, content := range source.ContentIDs {
+			modified[content] = true
+		}
+
+		return modified, nil
+	}
+
+	if !source.RemoteMaster {
+		status, err := upgrade.ReadSegmentStatus(upgrade.MasterWorkingDirectory(stateDir))
+		if err != nil {
+			return nil, err
+		}
+
+		if status.Modified() {
+			modified[-1] = true
+		}
+	}
+
+	var mu sync.Mutex
+	request := func(conn *Connection) error {
+		primaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsOnHost(conn.Hostname) && (seg.IsPrimary() || (source.RemoteMaster && seg.IsMaster()))
+		})
+
+		if len(primaries) == 0 {
+			return nil
+		}
+
+		var contents []int32
+		for _, primary := range primaries {
+			contents = append(contents, int32(primary.ContentID))
+		}
+
+		reply, err := conn.AgentClient.GetSegmentStatuses(context.Background(), &idl.GetSegmentStatusesRequest{Contents: contents})
+		if err != nil {
+			return xerrors.Errorf("get segment statuses on host %s: %w", conn.Hostname, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, status := range reply.GetStatuses() {
+			if status.GetLinkMode() && status.GetStarted() {
+				modified[int(status.GetContent())] = true
+			}
+		}
+
+		return nil
+	}
+
+	if err := ExecuteRPC(agentConns, request); err != nil {
+		return nil, err
+	}
+
+	return modified, nil
+}
+
 var Excludes = []string{
 	"pg_hba.conf", "postmaster.opts", "postgresql.auto.conf", "internal.auto.conf",
 	"gp_dbid", "postgresql.conf", "backup_label.old", "postmaster.pid", "recovery.conf",
 }
 
-func RsyncMasterAndPrimaries(stream step.OutStreams, agentConns []*Connection, source *greenplum.Cluster) error {
+// RsyncMasterAndPrimaries restores the modified master and primaries from the
+// standby and mirrors.
+func RsyncMasterAndPrimaries(stream step.OutStreams, agentConns []*Connection, source *greenplum.Cluster, modified ModifiedContents, options []string) error {
 	if !source.HasAllMirrorsAndStandby() {
 		return errors.New("Source cluster does not have mirrors and/or standby. Cannot restore source cluster. Please contact support.")
 	}
@@ -38,13 +131,17 @@ func RsyncMasterAndPrimaries(stream step.OutStreams, agentConns []*Connection, s
 	var wg sync.WaitGroup
 	errs := make(chan error, 2)
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		errs <- RsyncMaster(stream, source.Standby(), source.Master())
-	}()
+	// When the hub does not run on the master host the master is restored by
+	// the agent on the standby host along with the primaries.
+	if modified[-1] && !source.RemoteMaster {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- RsyncMaster(stream, source.Standby(), source.Master(), options)
+		}()
+	}
 
-	errs <- RsyncPrimaries(agentConns, source)
+	errs <- RsyncPrimaries(agentConns, source, modified, options)
 
 	wg.Wait()
 	close(errs)
@@ -57,7 +154,7 @@ func RsyncMasterAndPrimaries(stream step.OutStreams, agentConns []*Connection, s
 	return err
 }
 
-func RsyncMasterAndPrimariesTablespaces(stream step.OutStreams, agentConns []*Connection, source *greenplum.Cluster, tablespaces greenplum.Tablespaces) error {
+func RsyncMasterAndPrimariesTablespaces(stream step.OutStreams, agentConns []*Connection, source *greenplum.Cluster, tablespaces greenplum.Tablespaces, modified ModifiedContents, options []string) error {
 	if !source.HasAllMirrorsAndStandby() {
 		return ErrMissingMirrorsAndStandby
 	}
@@ -65,13 +162,15 @@ func RsyncMasterAndPrimariesTablespaces(stream step.OutStreams, agentConns []*Co
 	var wg sync.WaitGroup
 	errs := make(chan error, 2)
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		errs <- RsyncMasterTablespaces(stream, source.StandbyHostname(), tablespaces[source.Master().DbID], tablespaces[source.Standby().DbID])
-	}()
+	if modified[-1] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- RsyncMasterTablespaces(stream, source, tablespaces, options)
+		}()
+	}
 
-	errs <- RsyncPrimariesTablespaces(agentConns, source, tablespaces)
+	errs <- RsyncPrimariesTablespaces(agentConns, source, tablespaces, modified, options)
 
 	wg.Wait()
 	close(errs)
@@ -100,7 +199,12 @@ func Recoverseg(stream step.OutStreams, cluster *greenplum.Cluster, useHbaHostna
 
 	script := fmt.Sprintf("source %[1]s/greenplum_path.sh && MASTER_DATA_DIRECTORY=%[2]s PGPORT=%[3]d %[1]s/bin/gprecoverseg -a %[4]s",
 		cluster.GPHome, cluster.MasterDataDir(), cluster.MasterPort(), hbaHostnames)
-	cmd := RecoversegCmd("bash", "-c", script)
+	var cmd *exec.Cmd
+	if cluster.RemoteMaster {
+		cmd = RecoversegCmd("ssh", cluster.MasterHostname(), shellquote.Join("bash", "-c", script))
+	} else {
+		cmd = RecoversegCmd("bash", "-c", script)
+	}
 
 	cmd.Stdout = stream.Stdout()
 	cmd.Stderr = stream.Stderr()
@@ -109,12 +213,12 @@ func Recoverseg(stream step.OutStreams, cluster *greenplum.Cluster, useHbaHostna
 	return cmd.Run()
 }
 
-func RsyncMaster(stream step.OutStreams, standby greenplum.SegConfig, master greenplum.SegConfig) error {
+func RsyncMaster(stream step.OutStreams, standby greenplum.SegConfig, master greenplum.SegConfig, options []string) error {
 	opts := []rsync.Option{
 		rsync.WithSources(standby.DataDir + string(os.PathSeparator)),
 		rsync.WithSourceHost(standby.Hostname),
 		rsync.WithDestination(master.DataDir),
-		rsync.WithOptions(Options...),
+		rsync.WithOptions(options...),
 		rsync.WithExcludedFiles(Excludes...),
 		rsync.WithStream(stream),
 	}
@@ -122,17 +226,33 @@ func RsyncMaster(stream step.OutStreams, standby greenplum.SegConfig, master gre
 	return rsync.Rsync(opts...)
 }
 
-func RsyncMasterTablespaces(stream step.OutStreams, standbyHostname string, masterTablespaces greenplum.SegmentTablespaces, standbyTablespaces greenplum.SegmentTablespaces) error {
-	for oid, masterTsInfo := range masterTablespaces {
+// sourceTablespaceDir returns the tablespace directory of a source cluster
+// segment. In 5X each segment has its own tablespace location, while in 6X
+// the segments share a location and use a subdirectory named by their dbid.
+func sourceTablespaceDir(source *greenplum.Cluster, tsInfo greenplum.TablespaceInfo, dbID int) string {
+	if !source.Version.AtLeast("6") {
+		return tsInfo.Location
+	}
+
+	return filepath.Join(tsInfo.Location, strconv.Itoa(dbID))
+}
+
+func RsyncMasterTablespaces(stream step.OutStreams, source *greenplum.Cluster, tablespaces greenplum.Tablespaces, options []string) error {
+	master := source.Master()
+	standby := source.Standby()
+
+	for oid, masterTsInfo := range tablespaces[master.DbID] {
 		if !masterTsInfo.IsUserDefined() {
 			continue
 		}
 
+		standbyDir := sourceTablespaceDir(source, tablespaces[standby.DbID][oid], standby.DbID)
+
 		opts := []rsync.Option{
-			rsync.WithSourceHost(standbyHostname),
-			rsync.WithSources(standbyTablespaces[oid].Location + string(os.PathSeparator)),
-			rsync.WithDestination(masterTsInfo.Location),
-			rsync.WithOptions(Options...),
+			rsync.WithSourceHost(standby.Hostname),
+			rsync.WithSources(standbyDir + string(os.PathSeparator)),
+			rsync.WithDestination(sourceTablespaceDir(source, masterTsInfo, master.DbID)),
+			rsync.WithOptions(options...),
 			rsync.WithStream(stream),
 		}
 
@@ -145,10 +265,14 @@ func RsyncMasterTablespaces(stream step.OutStreams, standbyHostname string, mast
 	return nil
 }
 
-func RsyncPrimaries(agentConns []*Connection, source *greenplum.Cluster) error {
+// RsyncPrimaries restores the modified primaries from their mirrors. The
+// master is restored from the standby as well when the hub does not run on the
+// master host.
+func RsyncPrimaries(agentConns []*Connection, source *greenplum.Cluster, modified ModifiedContents, options []string) error {
 	request := func(conn *Connection) error {
 		mirrors := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
-			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
+			restorable := seg.IsMirror() || (source.RemoteMaster && seg.IsStandby())
+			return seg.IsOnHost(conn.Hostname) && restorable && modified[seg.ContentID]
 		})
 
 		if len(mirrors) == 0 {
@@ -166,7 +290,7 @@ func RsyncPrimaries(agentConns []*Connection, source *greenplum.Cluster) error {
 		}
 
 		req := &idl.RsyncRequest{
-			Options:  Options,
+			Options:  options,
 			Excludes: Excludes,
 			Pairs:    pairs,
 		}
@@ -178,10 +302,10 @@ func RsyncPrimaries(agentConns []*Connection, source *greenplum.Cluster) error {
 	return ExecuteRPC(agentConns, request)
 }
 
-func RsyncPrimariesTablespaces(agentConns []*Connection, source *greenplum.Cluster, tablespaces greenplum.Tablespaces) error {
+func RsyncPrimariesTablespaces(agentConns []*Connection, source *greenplum.Cluster, tablespaces greenplum.Tablespaces, modified ModifiedContents, options []string) error {
 	request := func(conn *Connection) error {
 		mirrors := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
-			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
+			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror() && modified[seg.ContentID]
 		})
 
 		if len(mirrors) == 0 {
@@ -200,18 +324,19 @@ func RsyncPrimariesTablespaces(agentConns []*Connection, source *greenplum.Clust
 				}
 
 				pair := &idl.RsyncPair{
-					Source:          mirrorTsInfo.Location + string(os.PathSeparator),
+					Source:          sourceTablespaceDir(source, mirrorTsInfo, mirror.DbID) + string(os.PathSeparator),
 					DestinationHost: primary.Hostname,
-					Destination:     primaryTablespaces[oid].Location,
+					Destination:     sourceTablespaceDir(source, primaryTablespaces[oid], primary.DbID),
 				}
 				pairs = append(pairs, pair)
 			}
 		}
 
 		req := &idl.RsyncRequest{
-			Options:  Options,
-			Excludes: Excludes,
-			Pairs:    pairs,
+			Options:       options,
+			Excludes:      Excludes,
+			Pairs:         pairs,
+			SourceVersion: source.Version.SemVer.String(),
 		}
 
 		_, err := conn.AgentClient.RsyncTablespaceDirectories(context.Background(), req)
@@ -225,11 +350,15 @@ func RestoreMasterAndPrimariesPgControl(streams step.OutStreams, agentConns []*C
 	var wg sync.WaitGroup
 	errs := make(chan error, 2)
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		errs <- upgrade.RestorePgControl(source.MasterDataDir(), streams)
-	}()
+	// When the hub does not run on the master host the agent there restores
+	// the master along with any primaries.
+	if !source.RemoteMaster {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- upgrade.RestorePgControl(source.MasterDataDir(), streams)
+		}()
+	}
 
 	errs <- restorePrimariesPgControl(agentConns, source)
 
@@ -247,7 +376,7 @@ func RestoreMasterAndPrimariesPgControl(streams step.OutStreams, agentConns []*C
 func restorePrimariesPgControl(agentConns []*Connection, source *greenplum.Cluster) error {
 	request := func(conn *Connection) error {
 		primaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
-			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsPrimary()
+			return seg.IsOnHost(conn.Hostname) && (seg.IsPrimary() || (source.RemoteMaster && seg.IsMaster()))
 		})
 
 		if len(primaries) == 0 {
@@ -24,6 +24,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
@@ -109,6 +110,8 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 		},
 	}
 
+	allContents := hub.ModifiedContents{-1: true, 0: true, 1: true}
+
 	t.Run("restores master in link mode using correct rsync arguments", func(t *testing.T) {
 		defer rsync.ResetRsyncCommand()
 		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
@@ -140,7 +143,7 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 			}
 		}))
 
-		err := hub.RsyncMaster(&testutils.DevNullWithClose{}, cluster.Standby(), cluster.Master())
+		err := hub.RsyncMaster(&testutils.DevNullWithClose{}, cluster.Standby(), cluster.Master(), hub.Options)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -154,7 +157,7 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 			{ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
 		})
 
-		err := hub.RsyncMasterAndPrimariesTablespaces(&testutils.DevNullWithClose{}, []*hub.Connection{}, cluster, nil)
+		err := hub.RsyncMasterAndPrimariesTablespaces(&testutils.DevNullWithClose{}, []*hub.Connection{}, cluster, nil, nil, hub.Options)
 		if !errors.Is(err, hub.ErrMissingMirrorsAndStandby) {
 			t.Errorf("got error %#v want %#v", err, hub.ErrMissingMirrorsAndStandby)
 		}
@@ -185,7 +188,7 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 			}
 		}))
 
-		err := hub.RsyncMasterTablespaces(&testutils.DevNullWithClose{}, cluster.StandbyHostname(), tablespaces[cluster.Master().DbID], tablespaces[cluster.Standby().DbID])
+		err := hub.RsyncMasterTablespaces(&testutils.DevNullWithClose{}, cluster, tablespaces, hub.Options)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -274,7 +277,49 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 			{nil, standby, "standby", nil},
 		}
 
-		err := hub.RsyncPrimaries(agentConns, cluster)
+		err := hub.RsyncPrimaries(agentConns, cluster, allContents, hub.Options)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("only restores the modified primaries using checksums", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		msdw1 := mock_idl.NewMockAgentClient(ctrl)
+		msdw1.EXPECT().RsyncDataDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options:  append(hub.Options, hub.ChecksumOption),
+				Excludes: hub.Excludes,
+				Pairs: []*idl.RsyncPair{{
+					Source:          "/data/dbfast_mirror1/seg1" + string(os.PathSeparator),
+					DestinationHost: "sdw1",
+					Destination:     "/data/dbfast1/seg1",
+				}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		msdw2 := mock_idl.NewMockAgentClient(ctrl)
+		msdw2.EXPECT().RsyncDataDirectories(gomock.Any(), gomock.Any()).Times(0)
+
+		agentConns := []*hub.Connection{
+			{nil, msdw1, "msdw1", nil},
+			{nil, msdw2, "msdw2", nil},
+		}
+
+		err := hub.RsyncPrimaries(agentConns, cluster, hub.ModifiedContents{0: true}, hub.RsyncOptions(true))
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("does not restore the master when it was not modified", func(t *testing.T) {
+		rsync.SetRsyncCommand(exectest.NewCommand(hub.Failure))
+		defer rsync.ResetRsyncCommand()
+
+		err := hub.RsyncMasterAndPrimaries(&testutils.DevNullWithClose{}, []*hub.Connection{}, cluster, hub.ModifiedContents{}, hub.Options)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -295,6 +340,7 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 					DestinationHost: "sdw1",
 					Destination:     "/tmp/p1/1663",
 				}},
+				SourceVersion: "5.0.0",
 			},
 		).Return(&idl.RsyncReply{}, nil)
 
@@ -309,6 +355,7 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 					DestinationHost: "sdw2",
 					Destination:     "/tmp/p2/1663",
 				}},
+				SourceVersion: "5.0.0",
 			},
 		).Return(&idl.RsyncReply{}, nil)
 
@@ -320,7 +367,59 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 			{nil, standby, "standby", nil},
 		}
 
-		err := hub.RsyncPrimariesTablespaces(agentConns, cluster, tablespaces)
+		err := hub.RsyncPrimariesTablespaces(agentConns, cluster, tablespaces, allContents, hub.Options)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("restores the dbID directories of 6X tablespaces", func(t *testing.T) {
+		cluster6X := *cluster
+		cluster6X.Version = dbconn.NewVersion("6.15.0")
+
+		tablespaces6X := greenplum.Tablespaces{
+			1: {16386: {Location: "/tmp/tblspc", UserDefined: 1}},
+			2: {16386: {Location: "/tmp/tblspc", UserDefined: 1}},
+			3: {16386: {Location: "/tmp/tblspc", UserDefined: 1}},
+			4: {16386: {Location: "/tmp/tblspc", UserDefined: 1}},
+		}
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		msdw1 := mock_idl.NewMockAgentClient(ctrl)
+		msdw1.EXPECT().RsyncTablespaceDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options:  hub.Options,
+				Excludes: hub.Excludes,
+				Pairs: []*idl.RsyncPair{{
+					Source:          "/tmp/tblspc/4" + string(os.PathSeparator),
+					DestinationHost: "sdw1",
+					Destination:     "/tmp/tblspc/3",
+				}},
+				SourceVersion: "6.15.0",
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		agentConns := []*hub.Connection{
+			{nil, msdw1, "msdw1", nil},
+		}
+
+		err := hub.RsyncPrimariesTablespaces(agentConns, &cluster6X, tablespaces6X, hub.ModifiedContents{0: true}, hub.Options)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			expected := []string{"standby:/tmp/tblspc/2/", "/tmp/tblspc/1"}
+			if !reflect.DeepEqual(args[3:5], expected) {
+				t.Errorf("got source and destination %q want %q", args[3:5], expected)
+			}
+		}))
+		defer rsync.ResetRsyncCommand()
+
+		err = hub.RsyncMasterTablespaces(&testutils.DevNullWithClose{}, &cluster6X, tablespaces6X, hub.Options)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -334,7 +433,7 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 			{ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
 		})
 
-		err := hub.RsyncMasterAndPrimaries(&testutils.DevNullWithClose{}, []*hub.Connection{}, cluster)
+		err := hub.RsyncMasterAndPrimaries(&testutils.DevNullWithClose{}, []*hub.Connection{}, cluster, nil, hub.Options)
 		if err == nil {
 			t.Error("unexpected nil error")
 		}
@@ -344,7 +443,7 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 		rsync.SetRsyncCommand(exectest.NewCommand(hub.Failure))
 		defer rsync.ResetRsyncCommand()
 
-		err := hub.RsyncMaster(&testutils.DevNullWithClose{}, cluster.Standby(), cluster.Master())
+		err := hub.RsyncMaster(&testutils.DevNullWithClose{}, cluster.Standby(), cluster.Master(), hub.Options)
 		if err == nil {
 			t.Error("unexpected nil error")
 		}
@@ -354,7 +453,7 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 		rsync.SetRsyncCommand(exectest.NewCommand(hub.Failure))
 		defer rsync.ResetRsyncCommand()
 
-		err := hub.RsyncMasterTablespaces(&testutils.DevNullWithClose{}, cluster.MasterHostname(), tablespaces[greenplum.MasterDbid], tablespaces[cluster.Standby().DbID])
+		err := hub.RsyncMasterTablespaces(&testutils.DevNullWithClose{}, cluster, tablespaces, hub.Options)
 		if err == nil {
 			t.Error("unexpected nil error")
 		}
@@ -393,7 +492,7 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 			{nil, failedClient, "msdw2", nil},
 		}
 
-		err := hub.RsyncPrimaries(agentConns, cluster)
+		err := hub.RsyncPrimaries(agentConns, cluster, allContents, hub.Options)
 
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -422,8 +521,222 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 			{nil, failedClient, "msdw2", nil},
 		}
 
-		err := hub.RsyncPrimariesTablespaces(agentConns, cluster, tablespaces)
+		err := hub.RsyncPrimariesTablespaces(agentConns, cluster, tablespaces, allContents, hub.Options)
+
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v, want %#v", err, expected)
+		}
+	})
+}
+
+func TestGetModifiedContents(t *testing.T) {
+	cluster := hub.MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, Hostname: "master", DataDir: "/data/qddir", Role: greenplum.PrimaryRole},
+		{ContentID: -1, Hostname: "standby", DataDir: "/data/standby", Role: greenplum.MirrorRole},
+		{ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, Hostname: "msdw1", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		{ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+		{ContentID: 1, Hostname: "msdw1", DataDir: "/data/dbfast_mirror2/seg2", Role: greenplum.MirrorRole},
+		{ContentID: 2, Hostname: "sdw2", DataDir: "/data/dbfast3/seg3", Role: greenplum.PrimaryRole},
+		{ContentID: 2, Hostname: "msdw2", DataDir: "/data/dbfast_mirror3/seg3", Role: greenplum.MirrorRole},
+	})
+
+	t.Run("returns the segments whose pg_upgrade was started in link mode", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		wd := upgrade.MasterWorkingDirectory(stateDir)
+		if err := os.MkdirAll(wd, 0700); err != nil {
+			t.Fatalf("creating directory %q: %+v", wd, err)
+		}
+
+		if err := upgrade.MarkUpgradeStarted(wd, true); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetSegmentStatuses(
+			gomock.Any(),
+			&idl.GetSegmentStatusesRequest{Contents: []int32{0, 1}},
+		).Return(&idl.GetSegmentStatusesReply{Statuses: []*idl.SegmentStatus{
+			{Content: 0, LinkMode: true, Started: true, Finished: true, Recorded: true},
+			{Content: 1, LinkMode: true, Started: false, Recorded: true},
+		}}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().GetSegmentStatuses(
+			gomock.Any(),
+			&idl.GetSegmentStatusesRequest{Contents: []int32{2}},
+		).Return(&idl.GetSegmentStatusesReply{Statuses: []*idl.SegmentStatus{
+			{Content: 2, LinkMode: true, Started: true, Recorded: true},
+		}}, nil)
+
+		msdw1 := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*hub.Connection{
+			{nil, sdw1, "sdw1", nil},
+			{nil, sdw2, "sdw2", nil},
+			{nil, msdw1, "msdw1", nil},
+		}
+
+		modified, err := hub.GetModifiedContents(agentConns, cluster, stateDir, false)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		expected := hub.ModifiedContents{-1: true, 0: true, 2: true}
+		if !reflect.DeepEqual(modified, expected) {
+			t.Errorf("got %v want %v", modified, expected)
+		}
+	})
+
+	t.Run("does not restore segments without a recorded status", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetSegmentStatuses(
+			gomock.Any(),
+			&idl.GetSegmentStatusesRequest{Contents: []int32{0, 1}},
+		).Return(&idl.GetSegmentStatusesReply{Statuses: []*idl.SegmentStatus{
+			{Content: 0, Recorded: false},
+			{Content: 1, LinkMode: true, Started: true, Recorded: true},
+		}}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().GetSegmentStatuses(
+			gomock.Any(),
+			&idl.GetSegmentStatusesRequest{Contents: []int32{2}},
+		).Return(&idl.GetSegmentStatusesReply{Statuses: []*idl.SegmentStatus{
+			{Content: 2, LinkMode: false, Started: true, Recorded: true},
+		}}, nil)
+
+		agentConns := []*hub.Connection{
+			{nil, sdw1, "sdw1", nil},
+			{nil, sdw2, "sdw2", nil},
+		}
+
+		modified, err := hub.GetModifiedContents(agentConns, cluster, stateDir, false)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		expected := hub.ModifiedContents{1: true}
+		if !reflect.DeepEqual(modified, expected) {
+			t.Errorf("got %v want %v", modified, expected)
+		}
+	})
+
+	t.Run("returns every segment once the target cluster has started", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetSegmentStatuses(gomock.Any(), gomock.Any()).Times(0)
+
+		agentConns := []*hub.Connection{
+			{nil, sdw1, "sdw1", nil},
+		}
+
+		modified, err := hub.GetModifiedContents(agentConns, cluster, "", true)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		expected := hub.ModifiedContents{-1: true, 0: true, 1: true, 2: true}
+		if !reflect.DeepEqual(modified, expected) {
+			t.Errorf("got %v want %v", modified, expected)
+		}
+	})
+
+	t.Run("only restores the segments whose pg_upgrade was started in link mode", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		// the master was not modified, so restoring it from the standby fails
+		rsync.SetRsyncCommand(exectest.NewCommand(hub.Failure))
+		defer rsync.ResetRsyncCommand()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetSegmentStatuses(
+			gomock.Any(),
+			&idl.GetSegmentStatusesRequest{Contents: []int32{0, 1}},
+		).Return(&idl.GetSegmentStatusesReply{Statuses: []*idl.SegmentStatus{
+			{Content: 0, LinkMode: true, Started: true, Recorded: true},
+			{Content: 1, Recorded: false},
+		}}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().GetSegmentStatuses(
+			gomock.Any(),
+			&idl.GetSegmentStatusesRequest{Contents: []int32{2}},
+		).Return(&idl.GetSegmentStatusesReply{Statuses: []*idl.SegmentStatus{
+			{Content: 2, Recorded: false},
+		}}, nil)
+
+		msdw1 := mock_idl.NewMockAgentClient(ctrl)
+		msdw1.EXPECT().RsyncDataDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options:  hub.Options,
+				Excludes: hub.Excludes,
+				Pairs: []*idl.RsyncPair{{
+					Source:          "/data/dbfast_mirror1/seg1" + string(os.PathSeparator),
+					DestinationHost: "sdw1",
+					Destination:     "/data/dbfast1/seg1",
+				}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		msdw2 := mock_idl.NewMockAgentClient(ctrl)
+		msdw2.EXPECT().RsyncDataDirectories(gomock.Any(), gomock.Any()).Times(0)
+
+		agentConns := []*hub.Connection{
+			{nil, sdw1, "sdw1", nil},
+			{nil, sdw2, "sdw2", nil},
+			{nil, msdw1, "msdw1", nil},
+			{nil, msdw2, "msdw2", nil},
+		}
+
+		modified, err := hub.GetModifiedContents(agentConns, cluster, stateDir, false)
+		if err != nil {
+			t.Fatalf("unexpected err %#v", err)
+		}
+
+		err = hub.RsyncMasterAndPrimaries(&testutils.DevNullWithClose{}, agentConns, cluster, modified, hub.Options)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("errors when getting the segment statuses fails", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		failedClient := mock_idl.NewMockAgentClient(ctrl)
+		failedClient.EXPECT().GetSegmentStatuses(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(nil, expected)
+
+		agentConns := []*hub.Connection{
+			{nil, failedClient, "sdw1", nil},
+		}
 
+		_, err := hub.GetModifiedContents(agentConns, cluster, stateDir, false)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/pkg/errors"
 	"golang.org/x/xerrors"
 
+	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -22,7 +24,7 @@ import (
 
 var ErrMissingMirrorsAndStandby = errors.New("Source cluster does not have mirrors and/or standby. Cannot restore source cluster. Please contact support.")
 
-func (s *Server) Revert(_ *idl.RevertRequest, stream idl.CliToHub_RevertServer) (err error) {
+func (s *Server) Revert(request *idl.RevertRequest, stream idl.CliToHub_RevertServer) (err error) {
 	st, err := step.Begin(s.StateDir, idl.Step_REVERT, stream)
 	if err != nil {
 		return err
@@ -38,8 +40,22 @@ func (s *Server) Revert(_ *idl.RevertRequest, stream idl.CliToHub_RevertServer)
 		}
 	}()
 
-	if !s.Source.HasAllMirrorsAndStandby() {
-		return errors.New("Source cluster does not have mirrors and/or standby. Cannot restore source cluster. Please contact support.")
+	plan, err := s.PlanRevert()
+	if err != nil {
+		return err
+	}
+
+	// Persist the outputs of this step such that they are reported correctly
+	// even when substeps that produce them are skipped on a subsequent run.
+	if s.RevertSummary.StartTime.IsZero() {
+		s.RevertSummary = RevertSummary{
+			SourceVersion: s.Source.Version.VersionString,
+			StartTime:     time.Now(),
+		}
+
+		if err := s.SaveConfig(); err != nil {
+			return err
+		}
 	}
 
 	// ensure that agentConns is populated
@@ -49,7 +65,7 @@ func (s *Server) Revert(_ *idl.RevertRequest, stream idl.CliToHub_RevertServer)
 	}
 
 	// If the target cluster is started, it must be stopped.
-	if s.Target != nil {
+	if plan.Includes(idl.Substep_SHUTDOWN_TARGET_CLUSTER) {
 		st.AlwaysRun(idl.Substep_SHUTDOWN_TARGET_CLUSTER, func(streams step.OutStreams) error {
 			running, err := s.Target.IsMasterRunning(streams)
 			if err != nil {
@@ -68,49 +84,45 @@ func (s *Server) Revert(_ *idl.RevertRequest, stream idl.CliToHub_RevertServer)
 		})
 	}
 
-	if s.TargetInitializeConfig.Primaries != nil && s.TargetInitializeConfig.Master.DataDir != "" {
+	if plan.Includes(idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS) {
 		st.Run(idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS, func(streams step.OutStreams) error {
-			return DeleteMasterAndPrimaryDataDirectories(streams, s.agentConns, s.TargetInitializeConfig)
+			if err := DeleteMasterAndPrimaryDataDirectories(streams, s.agentConns, s.TargetInitializeConfig, s.Source.RemoteMaster); err != nil {
+				return err
+			}
+
+			return DeleteSourcePrimaryCopies(s.agentConns, s.Source, s.Tablespaces, s.HostMapping)
 		})
+	}
 
+	if plan.Includes(idl.Substep_DELETE_TABLESPACES) {
 		st.Run(idl.Substep_DELETE_TABLESPACES, func(streams step.OutStreams) error {
-			return DeleteTargetTablespaces(streams, s.agentConns, s.Config.Target, s.TargetCatalogVersion, s.Tablespaces)
+			return DeleteTargetTablespaces(streams, s.agentConns, s.Config.Target, s.TargetCatalogVersion, s.TargetTablespaces())
 		})
 	}
 
-	if s.UseLinkMode {
-		// For any of the link-mode cases described in the "Reverting to old
-		// cluster" section of https://www.postgresql.org/docs/9.4/pgupgrade.html,
-		// it is correct to restore the pg_control file. Even in the case where
-		// we're going to perform a full rsync restoration, we rely on this
-		// substep to clean up the pg_control.old file, since the rsync will not
-		// remove it.
+	if plan.Includes(idl.Substep_RESTORE_PGCONTROL) {
 		st.Run(idl.Substep_RESTORE_PGCONTROL, func(streams step.OutStreams) error {
 			return RestoreMasterAndPrimariesPgControl(streams, s.agentConns, s.Source)
 		})
+	}
 
-		// if the target cluster has been started at any point, we must restore the source
-		// cluster as its files could have been modified.
-		targetStarted, err := step.HasRun(idl.Step_EXECUTE, idl.Substep_START_TARGET_CLUSTER)
-		if err != nil {
-			return err
-		}
+	if plan.Includes(idl.Substep_RESTORE_SOURCE_CLUSTER) {
+		st.Run(idl.Substep_RESTORE_SOURCE_CLUSTER, func(stream step.OutStreams) error {
+			modified, err := GetModifiedContents(s.agentConns, s.Source, s.StateDir, plan.TargetStarted)
+			if err != nil {
+				return err
+			}
 
-		if targetStarted {
-			st.Run(idl.Substep_RESTORE_SOURCE_CLUSTER, func(stream step.OutStreams) error {
-				if err := RsyncMasterAndPrimaries(stream, s.agentConns, s.Source); err != nil {
-					return err
-				}
+			options := RsyncOptions(request.GetChecksum())
+			if err := RsyncMasterAndPrimaries(stream, s.agentConns, s.Source, modified, options); err != nil {
+				return err
+			}
 
-				return RsyncMasterAndPrimariesTablespaces(stream, s.agentConns, s.Source, s.Tablespaces)
-			})
-		}
+			return RsyncMasterAndPrimariesTablespaces(stream, s.agentConns, s.Source, s.Tablespaces, modified, options)
+		})
 	}
 
-	handleMirrorStartupFailure, err := s.expectMirrorFailure()
-	if err != nil {
-		return err
-	}
+	handleMirrorStartupFailure := plan.Includes(idl.Substep_RECOVERSEG_SOURCE_CLUSTER)
 
 	// If the source cluster is not running, it must be started.
 	st.AlwaysRun(idl.Substep_START_SOURCE_CLUSTER, func(streams step.OutStreams) error {
@@ -150,32 +162,48 @@ func (s *Server) Revert(_ *idl.RevertRequest, stream idl.CliToHub_RevertServer)
 		})
 	}
 
-	// FIXME: archiveDir is not set unless we actually run this substep; it must be persisted.
-	var archiveDir string
+	if plan.Includes(idl.Substep_UNQUIESCE_SOURCE_CLUSTER) {
+		st.Run(idl.Substep_UNQUIESCE_SOURCE_CLUSTER, func(_ step.OutStreams) error {
+			return UnquiesceSourceCluster(s.agentConns, s.Source)
+		})
+	}
+
 	st.Run(idl.Substep_ARCHIVE_LOG_DIRECTORIES, func(_ step.OutStreams) error {
 		// Archive log directory on master
 		oldDir, err := utils.GetLogDir()
 		if err != nil {
 			return err
 		}
-		archiveDir = filepath.Join(filepath.Dir(oldDir), upgrade.GetArchiveDirectoryName(s.UpgradeID, time.Now()))
+		archiveDir := filepath.Join(filepath.Dir(oldDir), upgrade.GetArchiveDirectoryName(s.UpgradeID, time.Now()))
 
 		gplog.Debug("moving directory %q to %q", oldDir, archiveDir)
 		if err = utils.Move(oldDir, archiveDir); err != nil {
 			return err
 		}
 
-		return ArchiveSegmentLogDirectories(s.agentConns, s.Config.Source.MasterHostname(), archiveDir)
+		s.RevertSummary.LogArchiveDirectory = archiveDir
+		if err := s.SaveConfig(); err != nil {
+			return err
+		}
+
+		return ArchiveSegmentLogDirectories(s.agentConns, s.hubHost(), archiveDir)
 	})
 
 	st.Run(idl.Substep_DELETE_SEGMENT_STATEDIRS, func(_ step.OutStreams) error {
-		return DeleteStateDirectories(s.agentConns, s.Source.MasterHostname())
+		return DeleteStateDirectories(s.agentConns, s.hubHost())
 	})
 
+	if st.Err() == nil {
+		s.RevertSummary.EndTime = time.Now()
+		if err := s.SaveConfig(); err != nil {
+			return err
+		}
+	}
+
 	message := &idl.Message{Contents: &idl.Message_Response{Response: &idl.Response{Contents: &idl.Response_RevertResponse{
 		RevertResponse: &idl.RevertResponse{
-			SourceVersion:       s.Source.Version.VersionString,
-			LogArchiveDirectory: archiveDir,
+			SourceVersion:       s.RevertSummary.SourceVersion,
+			LogArchiveDirectory: s.RevertSummary.LogArchiveDirectory,
 			Source: &idl.Cluster{
 				Port:                int32(s.Source.MasterPort()),
 				MasterDataDirectory: s.Source.MasterDataDir(),
@@ -190,21 +218,91 @@ func (s *Server) Revert(_ *idl.RevertRequest, stream idl.CliToHub_RevertServer)
 	return st.Err()
 }
 
+// CheckRevertPossible returns an error if the source cluster cannot be
+// restored to its original state. Whether revert is possible depends on the
+// upgrade mode, whether the target cluster has been started, and whether the
+// source cluster has a mirror for every primary and a standby for the master:
+//
+//	mode | target started | mirrors and standby | revert possible
+//	-----+----------------+---------------------+-----------------------------------
+//	copy | any            | any                 | yes; source files are never modified
+//	link | no             | any                 | yes; restoring pg_control suffices
+//	link | yes            | all present         | yes; rsync from mirrors and standby
+//	link | yes            | any missing         | no; source files have been modified
+//
+// In link mode the source and target clusters share their data files. Once the
+// target cluster has been started those files may have been modified, and the
+// only unmodified copies are on the source mirrors and standby.
+func CheckRevertPossible(source *greenplum.Cluster, useLinkMode bool, targetStarted bool) error {
+	if !useLinkMode || !targetStarted {
+		return nil
+	}
+
+	if source.HasAllMirrorsAndStandby() {
+		return nil
+	}
+
+	return newRevertNotPossibleError(source)
+}
+
+// RevertNotPossibleError is the backing error type for
+// ErrMissingMirrorsAndStandby when reverting a link mode upgrade. It lists the
+// primaries that cannot be restored.
+type RevertNotPossibleError struct {
+	MissingContents []int
+}
+
+func newRevertNotPossibleError(source *greenplum.Cluster) *RevertNotPossibleError {
+	var missing []int
+	for _, content := range source.ContentIDs {
+		if _, ok := source.Mirrors[content]; !ok {
+			missing = append(missing, content)
+		}
+	}
+
+	return &RevertNotPossibleError{MissingContents: missing}
+}
+
+func (r *RevertNotPossibleError) Error() string {
+	var segments []string
+	for _, content := range r.MissingContents {
+		if content == -1 {
+			segments = append(segments, "master (no standby)")
+			continue
+		}
+
+		segments = append(segments, fmt.Sprintf("content %d (no mirror)", content))
+	}
+
+	return fmt.Sprintf(`Cannot revert. The upgrade was run in link mode and the target cluster
+has been started, so the source cluster data files may have been modified.
+Restoring them requires an unmodified copy from a mirror or standby, which
+the following segments do not have: %s.
+Restore the source cluster from a backup.`, strings.Join(segments, ", "))
+}
+
+func (r *RevertNotPossibleError) Is(err error) bool {
+	return err == ErrMissingMirrorsAndStandby
+}
+
 // In 5X, running pg_upgrade on the primaries can cause the mirrors to receive an invalid
 // checkpoint upon starting. There are two ways to resolve this:
 // - Rsync from the corresponding mirrors
 // or
 // - Running recoverseg
-// If the former hasn't run yet, then we do expect mirror failure upon start, so return true.
-func (s *Server) expectMirrorFailure() (bool, error) {
+// If the former is not part of the revert, then we do expect mirror failure upon start, so return true.
+func (s *Server) expectMirrorFailure(restoresSourceCluster bool) (bool, error) {
 	// mirror startup failure is expected only for GPDB 5x
 	if !s.Source.Version.Is("5") {
 		return false, nil
 	}
 
-	hasRestoreRun, err := step.HasRun(idl.Step_REVERT, idl.Substep_RESTORE_SOURCE_CLUSTER)
-	if err != nil {
-		return false, err
+	if !s.Source.HasMirrors() {
+		return false, nil
+	}
+
+	if restoresSourceCluster {
+		return false, nil
 	}
 
 	primariesUpgraded, err := step.HasRun(idl.Step_EXECUTE, idl.Substep_UPGRADE_PRIMARIES)
@@ -212,5 +310,5 @@ func (s *Server) expectMirrorFailure() (bool, error) {
 		return false, err
 	}
 
-	return !hasRestoreRun && primariesUpgraded, nil
+	return primariesUpgraded, nil
 }
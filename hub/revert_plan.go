@@ -0,0 +1,410 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+// RevertPlan lists the substeps revert will run given the current state of the
+// upgrade. Revert runs exactly the substeps in the plan such that the preview
+// shown by "gpupgrade revert --plan" matches what revert does.
+type RevertPlan struct {
+	Substeps []idl.Substep
+
+	// TargetStarted is true when execute started the target cluster, in
+	// which case every source data directory is restored rather than only
+	// those modified by pg_upgrade.
+	TargetStarted bool
+}
+
+func (p *RevertPlan) Includes(substep idl.Substep) bool {
+	for _, s := range p.Substeps {
+		if s == substep {
+			return true
+		}
+	}
+
+	return false
+}
+
+// alwaysRunRevertSubsteps are run even if they completed during a previous
+// revert.
+var alwaysRunRevertSubsteps = map[idl.Substep]bool{
+	idl.Substep_SHUTDOWN_TARGET_CLUSTER: true,
+	idl.Substep_START_SOURCE_CLUSTER:    true,
+}
+
+// PlanRevert determines the revert substeps based on the upgrade mode, the
+// progress of the upgrade, and the source and target cluster configuration.
+func (s *Server) PlanRevert() (*RevertPlan, error) {
+	// if the target cluster has been started at any point in link mode, we
+	// must restore the source cluster as its files could have been modified.
+	targetStarted, err := step.HasRun(idl.Step_EXECUTE, idl.Substep_START_TARGET_CLUSTER)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckRevertPossible(s.Source, s.UseLinkMode, targetStarted); err != nil {
+		return nil, err
+	}
+
+	// If execute failed while upgrading the primaries in link mode, the
+	// segments whose pg_upgrade was started no longer match their mirrors.
+	primariesUpgraded, err := step.HasRun(idl.Step_EXECUTE, idl.Substep_UPGRADE_PRIMARIES)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &RevertPlan{TargetStarted: targetStarted}
+
+	// If the target cluster is started, it must be stopped.
+	if s.Target != nil {
+		plan.Substeps = append(plan.Substeps, idl.Substep_SHUTDOWN_TARGET_CLUSTER)
+	}
+
+	if s.TargetInitializeConfig.Primaries != nil && s.TargetInitializeConfig.Master.DataDir != "" {
+		plan.Substeps = append(plan.Substeps, idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS, idl.Substep_DELETE_TABLESPACES)
+	}
+
+	if s.UseLinkMode {
+		// For any of the link-mode cases described in the "Reverting to old
+		// cluster" section of https://www.postgresql.org/docs/9.4/pgupgrade.html,
+		// it is correct to restore the pg_control file. Even in the case where
+		// we're going to perform a full rsync restoration, we rely on this
+		// substep to clean up the pg_control.old file, since the rsync will not
+		// remove it.
+		plan.Substeps = append(plan.Substeps, idl.Substep_RESTORE_PGCONTROL)
+
+		// Without mirrors and a standby the partially upgraded source cluster
+		// cannot be restored, and is reverted by restoring pg_control alone.
+		if targetStarted || (primariesUpgraded && s.Source.HasAllMirrorsAndStandby()) {
+			plan.Substeps = append(plan.Substeps, idl.Substep_RESTORE_SOURCE_CLUSTER)
+		}
+	}
+
+	plan.Substeps = append(plan.Substeps, idl.Substep_START_SOURCE_CLUSTER)
+
+	handleMirrorStartupFailure, err := s.expectMirrorFailure(plan.Includes(idl.Substep_RESTORE_SOURCE_CLUSTER))
+	if err != nil {
+		return nil, err
+	}
+
+	if handleMirrorStartupFailure {
+		plan.Substeps = append(plan.Substeps, idl.Substep_RECOVERSEG_SOURCE_CLUSTER)
+	}
+
+	if s.Quiesce {
+		plan.Substeps = append(plan.Substeps, idl.Substep_UNQUIESCE_SOURCE_CLUSTER)
+	}
+
+	plan.Substeps = append(plan.Substeps, idl.Substep_ARCHIVE_LOG_DIRECTORIES, idl.Substep_DELETE_SEGMENT_STATEDIRS)
+
+	return plan, nil
+}
+
+func (s *Server) GetRevertPlan(ctx context.Context, _ *idl.RevertPlanRequest) (*idl.RevertPlanReply, error) {
+	plan, err := s.PlanRevert()
+	if err != nil {
+		return nil, err
+	}
+
+	agentConns, err := s.AgentConns()
+	if err != nil {
+		return nil, xerrors.Errorf("connect to gpupgrade agent: %w", err)
+	}
+
+	logDir, err := utils.GetLogDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var modified ModifiedContents
+	if plan.Includes(idl.Substep_RESTORE_SOURCE_CLUSTER) {
+		modified, err = GetModifiedContents(agentConns, s.Source, s.StateDir, plan.TargetStarted)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reply := &idl.RevertPlanReply{}
+	for _, substep := range plan.Substeps {
+		completed := false
+		if !alwaysRunRevertSubsteps[substep] {
+			completed, err = step.HasCompleted(idl.Step_REVERT, substep)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		reply.Substeps = append(reply.Substeps, &idl.RevertPlanSubstep{
+			Substep:   substep,
+			Completed: completed,
+			Actions:   s.revertActions(substep, logDir, modified),
+		})
+	}
+
+	if err := estimateRevertActionSizes(agentConns, s.hubHost(), reply); err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// revertActions returns the directories that the substep deletes, rsyncs,
+// restores or archives on each host. Only the modified segments are restored
+// from their mirrors and standby.
+func (s *Server) revertActions(substep idl.Substep, logDir string, modified ModifiedContents) []*idl.RevertAction {
+	var actions []*idl.RevertAction
+
+	switch substep {
+	case idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS:
+		segments := append([]greenplum.SegConfig{s.TargetInitializeConfig.Master}, s.TargetInitializeConfig.Primaries...)
+		for _, seg := range segments {
+			actions = append(actions, &idl.RevertAction{
+				Operation: idl.RevertAction_DELETE,
+				Host:      seg.Hostname,
+				Directory: seg.DataDir,
+			})
+		}
+
+		for _, seg := range sourcePrimaryCopies(s.Source, s.HostMapping) {
+			actions = append(actions, &idl.RevertAction{
+				Operation: idl.RevertAction_DELETE,
+				Host:      seg.Hostname,
+				Directory: seg.DataDir,
+			})
+
+			for _, oid := range sortedTablespaceOids(s.Tablespaces[seg.DbID]) {
+				tsInfo := s.Tablespaces[seg.DbID][oid]
+				if !tsInfo.IsUserDefined() {
+					continue
+				}
+
+				actions = append(actions, &idl.RevertAction{
+					Operation: idl.RevertAction_DELETE,
+					Host:      seg.Hostname,
+					Directory: sourceTablespaceDir(s.Source, tsInfo, seg.DbID),
+				})
+			}
+		}
+
+	case idl.Substep_DELETE_TABLESPACES:
+		if s.Target == nil || s.TargetCatalogVersion == "" {
+			break
+		}
+
+		primaries := s.Target.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsPrimary()
+		})
+
+		tablespaces := s.TargetTablespaces()
+		for _, seg := range primaries {
+			for _, oid := range sortedTablespaceOids(tablespaces[seg.DbID]) {
+				tsInfo := tablespaces[seg.DbID][oid]
+				if !tsInfo.IsUserDefined() {
+					continue
+				}
+
+				actions = append(actions, &idl.RevertAction{
+					Operation: idl.RevertAction_DELETE,
+					Host:      seg.Hostname,
+					Directory: upgrade.TablespacePath(tsInfo.Location, seg.DbID, s.Target.Version.SemVer.Major, s.TargetCatalogVersion),
+				})
+			}
+		}
+
+	case idl.Substep_RESTORE_PGCONTROL:
+		primaries := s.Source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsPrimary()
+		})
+
+		for _, seg := range primaries {
+			actions = append(actions, &idl.RevertAction{
+				Operation: idl.RevertAction_RESTORE_PGCONTROL,
+				Host:      seg.Hostname,
+				Directory: seg.DataDir,
+			})
+		}
+
+	case idl.Substep_RESTORE_SOURCE_CLUSTER:
+		mirrors := s.Source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return (seg.IsMirror() || seg.IsStandby()) && modified[seg.ContentID]
+		})
+
+		for _, mirror := range mirrors {
+			primary := s.Source.Primaries[mirror.ContentID]
+			actions = append(actions, &idl.RevertAction{
+				Operation:       idl.RevertAction_RSYNC,
+				Host:            primary.Hostname,
+				Directory:       primary.DataDir,
+				SourceHost:      mirror.Hostname,
+				SourceDirectory: mirror.DataDir,
+			})
+
+			for _, oid := range sortedTablespaceOids(s.Tablespaces[mirror.DbID]) {
+				mirrorTsInfo := s.Tablespaces[mirror.DbID][oid]
+				if !mirrorTsInfo.IsUserDefined() {
+					continue
+				}
+
+				actions = append(actions, &idl.RevertAction{
+					Operation:       idl.RevertAction_RSYNC,
+					Host:            primary.Hostname,
+					Directory:       s.Tablespaces[primary.DbID][oid].Location,
+					SourceHost:      mirror.Hostname,
+					SourceDirectory: mirrorTsInfo.Location,
+				})
+			}
+		}
+
+	case idl.Substep_ARCHIVE_LOG_DIRECTORIES:
+		hosts := append([]string{s.hubHostname()}, s.agentOnlyHosts()...)
+		for _, host := range hosts {
+			actions = append(actions, &idl.RevertAction{
+				Operation: idl.RevertAction_ARCHIVE,
+				Host:      host,
+				Directory: logDir,
+			})
+		}
+
+	case idl.Substep_DELETE_SEGMENT_STATEDIRS:
+		for _, host := range s.agentOnlyHosts() {
+			actions = append(actions, &idl.RevertAction{
+				Operation: idl.RevertAction_DELETE,
+				Host:      host,
+				Directory: utils.GetStateDir(),
+			})
+		}
+	}
+
+	return actions
+}
+
+// agentOnlyHosts returns the sorted agent hosts excluding the hub host, whose
+// agent skips what the hub does itself. This includes the target hosts of a
+// host mapping, and the master host when the hub does not run on it.
+func (s *Server) agentOnlyHosts() []string {
+	var hosts []string
+	for _, host := range s.AgentHosts() {
+		if host != s.hubHost() {
+			hosts = append(hosts, host)
+		}
+	}
+
+	sort.Strings(hosts)
+	return hosts
+}
+
+// hubHostname returns the host the hub runs on, which is the master host
+// unless the master is remote.
+func (s *Server) hubHostname() string {
+	if host := s.hubHost(); host != "" {
+		return host
+	}
+
+	hostname, err := utils.System.Hostname()
+	if err != nil {
+		gplog.Debug("getting hub hostname: %v", err)
+		return "localhost"
+	}
+
+	return hostname
+}
+
+func sortedTablespaceOids(tablespaces greenplum.SegmentTablespaces) []int {
+	var oids []int
+	for oid := range tablespaces {
+		oids = append(oids, oid)
+	}
+
+	sort.Ints(oids)
+	return oids
+}
+
+// estimateRevertActionSizes sets the number of bytes each delete or rsync
+// action will remove or copy. Sizes on the hub host are computed by the hub,
+// and sizes on all other hosts, including a remote master host, by their
+// agents. The hub host is empty when the master is remote.
+func estimateRevertActionSizes(agentConns []*Connection, hubHost string, reply *idl.RevertPlanReply) error {
+	// sizedDirectory returns the host and directory whose size is the amount
+	// of data affected by the action.
+	sizedDirectory := func(action *idl.RevertAction) (string, string) {
+		switch action.Operation {
+		case idl.RevertAction_DELETE:
+			return action.Host, action.Directory
+		case idl.RevertAction_RSYNC:
+			return action.SourceHost, action.SourceDirectory
+		default:
+			return "", ""
+		}
+	}
+
+	dirsByHost := make(map[string][]string)
+	for _, substep := range reply.Substeps {
+		for _, action := range substep.Actions {
+			if host, dir := sizedDirectory(action); dir != "" {
+				dirsByHost[host] = append(dirsByHost[host], dir)
+			}
+		}
+	}
+
+	sizes := make(map[string]map[string]uint64)
+	if hubHost != "" {
+		sizes[hubHost] = make(map[string]uint64)
+		for _, dir := range dirsByHost[hubHost] {
+			size, err := disk.DirectorySize(dir)
+			if err != nil {
+				return err
+			}
+
+			sizes[hubHost][dir] = size
+		}
+	}
+
+	var mu sync.Mutex
+	request := func(conn *Connection) error {
+		dirs := dirsByHost[conn.Hostname]
+		if conn.Hostname == hubHost || len(dirs) == 0 {
+			return nil
+		}
+
+		resp, err := conn.AgentClient.GetDirectorySizes(context.Background(), &idl.GetDirectorySizesRequest{Directories: dirs})
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		sizes[conn.Hostname] = resp.GetSizes()
+
+		return nil
+	}
+
+	if err := ExecuteRPC(agentConns, request); err != nil {
+		return err
+	}
+
+	for _, substep := range reply.Substeps {
+		for _, action := range substep.Actions {
+			if host, dir := sizedDirectory(action); dir != "" {
+				action.Bytes = sizes[host][dir]
+			}
+		}
+	}
+
+	return nil
+}
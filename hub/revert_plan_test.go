@@ -0,0 +1,411 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestPlanRevert(t *testing.T) {
+	source := MustCreateCluster(t, []greenplum.SegConfig{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "smdw", DataDir: "/data/standby", Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+	})
+	source.Version = dbconn.NewVersion("6.20.0")
+
+	targetInitializeConfig := InitializeConfig{
+		Master:    greenplum.SegConfig{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir_upgrade"},
+		Primaries: []greenplum.SegConfig{{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1_upgrade"}},
+	}
+
+	cases := []struct {
+		name             string
+		useLinkMode      bool
+		quiesce          bool
+		executeSubsteps  []idl.Substep
+		initializeConfig InitializeConfig
+		expectedSubsteps []idl.Substep
+	}{
+		{
+			name:             "copy mode before the target cluster is created",
+			useLinkMode:      false,
+			initializeConfig: InitializeConfig{},
+			expectedSubsteps: []idl.Substep{
+				idl.Substep_START_SOURCE_CLUSTER,
+				idl.Substep_ARCHIVE_LOG_DIRECTORIES,
+				idl.Substep_DELETE_SEGMENT_STATEDIRS,
+			},
+		},
+		{
+			name:             "copy mode after execute",
+			useLinkMode:      false,
+			executeSubsteps:  []idl.Substep{idl.Substep_UPGRADE_PRIMARIES, idl.Substep_START_TARGET_CLUSTER},
+			initializeConfig: targetInitializeConfig,
+			expectedSubsteps: []idl.Substep{
+				idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS,
+				idl.Substep_DELETE_TABLESPACES,
+				idl.Substep_START_SOURCE_CLUSTER,
+				idl.Substep_ARCHIVE_LOG_DIRECTORIES,
+				idl.Substep_DELETE_SEGMENT_STATEDIRS,
+			},
+		},
+		{
+			name:             "link mode before the primaries are upgraded",
+			useLinkMode:      true,
+			executeSubsteps:  []idl.Substep{idl.Substep_UPGRADE_MASTER},
+			initializeConfig: targetInitializeConfig,
+			expectedSubsteps: []idl.Substep{
+				idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS,
+				idl.Substep_DELETE_TABLESPACES,
+				idl.Substep_RESTORE_PGCONTROL,
+				idl.Substep_START_SOURCE_CLUSTER,
+				idl.Substep_ARCHIVE_LOG_DIRECTORIES,
+				idl.Substep_DELETE_SEGMENT_STATEDIRS,
+			},
+		},
+		{
+			name:             "link mode after upgrading the primaries failed",
+			useLinkMode:      true,
+			executeSubsteps:  []idl.Substep{idl.Substep_UPGRADE_MASTER, idl.Substep_UPGRADE_PRIMARIES},
+			initializeConfig: targetInitializeConfig,
+			expectedSubsteps: []idl.Substep{
+				idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS,
+				idl.Substep_DELETE_TABLESPACES,
+				idl.Substep_RESTORE_PGCONTROL,
+				idl.Substep_RESTORE_SOURCE_CLUSTER,
+				idl.Substep_START_SOURCE_CLUSTER,
+				idl.Substep_ARCHIVE_LOG_DIRECTORIES,
+				idl.Substep_DELETE_SEGMENT_STATEDIRS,
+			},
+		},
+		{
+			name:             "link mode after the target cluster has started",
+			useLinkMode:      true,
+			executeSubsteps:  []idl.Substep{idl.Substep_UPGRADE_PRIMARIES, idl.Substep_START_TARGET_CLUSTER},
+			initializeConfig: targetInitializeConfig,
+			expectedSubsteps: []idl.Substep{
+				idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS,
+				idl.Substep_DELETE_TABLESPACES,
+				idl.Substep_RESTORE_PGCONTROL,
+				idl.Substep_RESTORE_SOURCE_CLUSTER,
+				idl.Substep_START_SOURCE_CLUSTER,
+				idl.Substep_ARCHIVE_LOG_DIRECTORIES,
+				idl.Substep_DELETE_SEGMENT_STATEDIRS,
+			},
+		},
+		{
+			name:             "unquiesces a quiesced source cluster after starting it",
+			useLinkMode:      false,
+			quiesce:          true,
+			initializeConfig: InitializeConfig{},
+			expectedSubsteps: []idl.Substep{
+				idl.Substep_START_SOURCE_CLUSTER,
+				idl.Substep_UNQUIESCE_SOURCE_CLUSTER,
+				idl.Substep_ARCHIVE_LOG_DIRECTORIES,
+				idl.Substep_DELETE_SEGMENT_STATEDIRS,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stateDir := testutils.GetTempDir(t, "")
+			defer testutils.MustRemoveAll(t, stateDir)
+
+			resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+			defer resetEnv()
+
+			path := filepath.Join(stateDir, step.SubstepsFileName)
+			testutils.MustWriteToFile(t, path, "{}")
+
+			store := step.NewFileStore(path)
+			for _, substep := range c.executeSubsteps {
+				if err := store.Write(idl.Step_EXECUTE, substep, idl.Status_COMPLETE); err != nil {
+					t.Fatalf("store.Write returned error %+v", err)
+				}
+			}
+
+			server := New(&Config{
+				Source:                 source,
+				UseLinkMode:            c.useLinkMode,
+				Quiesce:                c.quiesce,
+				TargetInitializeConfig: c.initializeConfig,
+			}, nil, stateDir)
+
+			plan, err := server.PlanRevert()
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+
+			if !reflect.DeepEqual(plan.Substeps, c.expectedSubsteps) {
+				t.Errorf("got substeps %v want %v", plan.Substeps, c.expectedSubsteps)
+			}
+		})
+	}
+
+	t.Run("recovers the 5X source cluster mirrors when the primaries were upgraded in copy mode", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+		defer resetEnv()
+
+		path := filepath.Join(stateDir, step.SubstepsFileName)
+		testutils.MustWriteToFile(t, path, "{}")
+		if err := step.NewFileStore(path).Write(idl.Step_EXECUTE, idl.Substep_UPGRADE_PRIMARIES, idl.Status_COMPLETE); err != nil {
+			t.Fatalf("store.Write returned error %+v", err)
+		}
+
+		source5X := MustCreateCluster(t, []greenplum.SegConfig{
+			{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir", Role: greenplum.PrimaryRole},
+			{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+			{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		})
+		source5X.Version = dbconn.NewVersion("5.28.0")
+
+		server := New(&Config{Source: source5X}, nil, stateDir)
+
+		plan, err := server.PlanRevert()
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if !plan.Includes(idl.Substep_RECOVERSEG_SOURCE_CLUSTER) {
+			t.Errorf("expected plan %v to include %s", plan.Substeps, idl.Substep_RECOVERSEG_SOURCE_CLUSTER)
+		}
+	})
+
+	t.Run("only restores pg_control when upgrading the primaries of a source cluster without mirrors failed", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+		defer resetEnv()
+
+		path := filepath.Join(stateDir, step.SubstepsFileName)
+		testutils.MustWriteToFile(t, path, "{}")
+		if err := step.NewFileStore(path).Write(idl.Step_EXECUTE, idl.Substep_UPGRADE_PRIMARIES, idl.Status_FAILED); err != nil {
+			t.Fatalf("store.Write returned error %+v", err)
+		}
+
+		mirrorless := MustCreateCluster(t, []greenplum.SegConfig{
+			{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir", Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		})
+		mirrorless.Version = dbconn.NewVersion("6.20.0")
+
+		server := New(&Config{Source: mirrorless, UseLinkMode: true}, nil, stateDir)
+
+		plan, err := server.PlanRevert()
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if !plan.Includes(idl.Substep_RESTORE_PGCONTROL) {
+			t.Errorf("expected plan %v to include %s", plan.Substeps, idl.Substep_RESTORE_PGCONTROL)
+		}
+
+		if plan.Includes(idl.Substep_RESTORE_SOURCE_CLUSTER) {
+			t.Errorf("expected plan %v to not include %s", plan.Substeps, idl.Substep_RESTORE_SOURCE_CLUSTER)
+		}
+	})
+
+	t.Run("errors when revert is not possible", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+		defer resetEnv()
+
+		path := filepath.Join(stateDir, step.SubstepsFileName)
+		testutils.MustWriteToFile(t, path, "{}")
+		if err := step.NewFileStore(path).Write(idl.Step_EXECUTE, idl.Substep_START_TARGET_CLUSTER, idl.Status_COMPLETE); err != nil {
+			t.Fatalf("store.Write returned error %+v", err)
+		}
+
+		mirrorless := MustCreateCluster(t, []greenplum.SegConfig{
+			{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir", Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		})
+
+		server := New(&Config{Source: mirrorless, UseLinkMode: true}, nil, stateDir)
+
+		_, err := server.PlanRevert()
+		if !errors.Is(err, ErrMissingMirrorsAndStandby) {
+			t.Errorf("got error %#v want %#v", err, ErrMissingMirrorsAndStandby)
+		}
+	})
+}
+
+func TestRevertActions(t *testing.T) {
+	source := MustCreateCluster(t, []greenplum.SegConfig{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "smdw", DataDir: "/data/standby", Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+	})
+
+	server := New(&Config{
+		Source: source,
+		Tablespaces: greenplum.Tablespaces{
+			3: {16386: {Location: "/tmp/primary1/16386", UserDefined: 1}, 1663: {Location: "/data/dbfast1/seg1", UserDefined: 0}},
+			4: {16386: {Location: "/tmp/mirror1/16386", UserDefined: 1}, 1663: {Location: "/data/dbfast_mirror1/seg1", UserDefined: 0}},
+		},
+	}, nil, "")
+
+	t.Run("rsyncs the mirrors and standby and their tablespaces to the primaries and master", func(t *testing.T) {
+		actions := server.revertActions(idl.Substep_RESTORE_SOURCE_CLUSTER, "", ModifiedContents{-1: true, 0: true})
+
+		expected := []*idl.RevertAction{
+			{Operation: idl.RevertAction_RSYNC, Host: "mdw", Directory: "/data/qddir", SourceHost: "smdw", SourceDirectory: "/data/standby"},
+			{Operation: idl.RevertAction_RSYNC, Host: "sdw1", Directory: "/data/dbfast1/seg1", SourceHost: "sdw2", SourceDirectory: "/data/dbfast_mirror1/seg1"},
+			{Operation: idl.RevertAction_RSYNC, Host: "sdw1", Directory: "/tmp/primary1/16386", SourceHost: "sdw2", SourceDirectory: "/tmp/mirror1/16386"},
+		}
+
+		if !reflect.DeepEqual(actions, expected) {
+			t.Errorf("got actions %v want %v", actions, expected)
+		}
+	})
+
+	t.Run("only rsyncs the modified segments", func(t *testing.T) {
+		actions := server.revertActions(idl.Substep_RESTORE_SOURCE_CLUSTER, "", ModifiedContents{0: true})
+
+		expected := []*idl.RevertAction{
+			{Operation: idl.RevertAction_RSYNC, Host: "sdw1", Directory: "/data/dbfast1/seg1", SourceHost: "sdw2", SourceDirectory: "/data/dbfast_mirror1/seg1"},
+			{Operation: idl.RevertAction_RSYNC, Host: "sdw1", Directory: "/tmp/primary1/16386", SourceHost: "sdw2", SourceDirectory: "/tmp/mirror1/16386"},
+		}
+
+		if !reflect.DeepEqual(actions, expected) {
+			t.Errorf("got actions %v want %v", actions, expected)
+		}
+	})
+
+	t.Run("archives the log directory on all hosts", func(t *testing.T) {
+		actions := server.revertActions(idl.Substep_ARCHIVE_LOG_DIRECTORIES, "/home/gpadmin/gpAdminLogs/gpupgrade", nil)
+
+		var hosts []string
+		for _, action := range actions {
+			hosts = append(hosts, action.Host)
+		}
+
+		expected := []string{"mdw", "sdw1", "sdw2", "smdw"}
+		if !reflect.DeepEqual(hosts, expected) {
+			t.Errorf("got hosts %v want %v", hosts, expected)
+		}
+	})
+	t.Run("archives and deletes the state directory on a remote master host", func(t *testing.T) {
+		utils.System.Hostname = func() (string, error) {
+			return "hub", nil
+		}
+		defer func() {
+			utils.System = utils.InitializeSystemFunctions()
+		}()
+
+		remote := *source
+		remote.RemoteMaster = true
+		server := New(&Config{Source: &remote}, nil, "")
+
+		var hosts []string
+		for _, action := range server.revertActions(idl.Substep_ARCHIVE_LOG_DIRECTORIES, "/home/gpadmin/gpAdminLogs/gpupgrade", nil) {
+			hosts = append(hosts, action.Host)
+		}
+
+		expected := []string{"hub", "mdw", "sdw1", "sdw2", "smdw"}
+		if !reflect.DeepEqual(hosts, expected) {
+			t.Errorf("got hosts %v want %v", hosts, expected)
+		}
+
+		hosts = nil
+		for _, action := range server.revertActions(idl.Substep_DELETE_SEGMENT_STATEDIRS, "", nil) {
+			hosts = append(hosts, action.Host)
+		}
+
+		expected = []string{"mdw", "sdw1", "sdw2", "smdw"}
+		if !reflect.DeepEqual(hosts, expected) {
+			t.Errorf("got hosts %v want %v", hosts, expected)
+		}
+	})
+}
+
+func TestEstimateRevertActionSizes(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	testutils.MustWriteToFile(t, filepath.Join(dir, "file"), "12345")
+
+	newReply := func() *idl.RevertPlanReply {
+		return &idl.RevertPlanReply{Substeps: []*idl.RevertPlanSubstep{{
+			Substep: idl.Substep_DELETE_TARGET_CLUSTER_DATADIRS,
+			Actions: []*idl.RevertAction{
+				{Operation: idl.RevertAction_DELETE, Host: "mdw", Directory: dir},
+				{Operation: idl.RevertAction_DELETE, Host: "sdw1", Directory: "/data/dbfast1/seg1"},
+			},
+		}}}
+	}
+
+	t.Run("sizes the master directories on the hub", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetDirectorySizes(gomock.Any(), &idl.GetDirectorySizesRequest{Directories: []string{"/data/dbfast1/seg1"}}).
+			Return(&idl.GetDirectorySizesReply{Sizes: map[string]uint64{"/data/dbfast1/seg1": 42}}, nil)
+
+		agentConns := []*Connection{{nil, mdw, "mdw", nil}, {nil, sdw1, "sdw1", nil}}
+
+		reply := newReply()
+		err := estimateRevertActionSizes(agentConns, "mdw", reply)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		actions := reply.Substeps[0].Actions
+		if actions[0].Bytes == 0 || actions[1].Bytes != 42 {
+			t.Errorf("got sizes %d and %d want a local size and 42", actions[0].Bytes, actions[1].Bytes)
+		}
+	})
+
+	t.Run("sizes the directories of a remote master host by its agent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+		mdw.EXPECT().GetDirectorySizes(gomock.Any(), &idl.GetDirectorySizesRequest{Directories: []string{dir}}).
+			Return(&idl.GetDirectorySizesReply{Sizes: map[string]uint64{dir: 7}}, nil)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetDirectorySizes(gomock.Any(), gomock.Any()).
+			Return(&idl.GetDirectorySizesReply{Sizes: map[string]uint64{"/data/dbfast1/seg1": 42}}, nil)
+
+		agentConns := []*Connection{{nil, mdw, "mdw", nil}, {nil, sdw1, "sdw1", nil}}
+
+		reply := newReply()
+		err := estimateRevertActionSizes(agentConns, "", reply)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		actions := reply.Substeps[0].Actions
+		if actions[0].Bytes != 7 || actions[1].Bytes != 42 {
+			t.Errorf("got sizes %d and %d want 7 and 42", actions[0].Bytes, actions[1].Bytes)
+		}
+	})
+}
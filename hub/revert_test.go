@@ -0,0 +1,74 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+)
+
+func TestCheckRevertPossible(t *testing.T) {
+	complete := hub.MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, Hostname: "master", DataDir: "/data/qddir", Role: greenplum.PrimaryRole},
+		{ContentID: -1, Hostname: "standby", DataDir: "/data/standby", Role: greenplum.MirrorRole},
+		{ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+	})
+
+	incomplete := hub.MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, Hostname: "master", DataDir: "/data/qddir", Role: greenplum.PrimaryRole},
+		{ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		{ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+	})
+
+	cases := []struct {
+		name          string
+		source        *greenplum.Cluster
+		linkMode      bool
+		targetStarted bool
+		possible      bool
+	}{
+		{"copy mode without mirrors and standby", incomplete, false, true, true},
+		{"link mode before the target cluster has started", incomplete, true, false, true},
+		{"link mode after the target cluster has started with mirrors and standby", complete, true, true, true},
+		{"link mode after the target cluster has started without mirrors and standby", incomplete, true, true, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := hub.CheckRevertPossible(c.source, c.linkMode, c.targetStarted)
+			if c.possible && err != nil {
+				t.Errorf("unexpected error: %+v", err)
+			}
+
+			if !c.possible && !errors.Is(err, hub.ErrMissingMirrorsAndStandby) {
+				t.Errorf("got error %#v want %#v", err, hub.ErrMissingMirrorsAndStandby)
+			}
+		})
+	}
+
+	t.Run("reports the segments that cannot be restored", func(t *testing.T) {
+		err := hub.CheckRevertPossible(incomplete, true, true)
+
+		var revertErr *hub.RevertNotPossibleError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("got error %#v want type %T", err, revertErr)
+		}
+
+		if !reflect.DeepEqual(revertErr.MissingContents, []int{-1, 1}) {
+			t.Errorf("got missing contents %v want %v", revertErr.MissingContents, []int{-1, 1})
+		}
+
+		expected := "master (no standby), content 1 (no mirror)"
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error %q to contain %q", err.Error(), expected)
+		}
+	})
+}
@@ -191,7 +191,7 @@ func (s *Server) Stop(closeAgentConns bool) {
 }
 
 func (s *Server) RestartAgents(ctx context.Context, in *idl.RestartAgentsRequest) (*idl.RestartAgentsReply, error) {
-	restartedHosts, err := RestartAgents(ctx, nil, AgentHosts(s.Source), s.AgentPort, s.StateDir)
+	restartedHosts, err := RestartAgents(ctx, nil, s.AgentHosts(), s.AgentPort, s.StateDir)
 	return &idl.RestartAgentsReply{AgentHosts: restartedHosts}, err
 }
 
@@ -287,7 +287,7 @@ func (s *Server) AgentConns() ([]*Connection, error) {
 		return s.agentConns, nil
 	}
 
-	hostnames := AgentHosts(s.Source)
+	hostnames := s.AgentHosts()
 	for _, host := range hostnames {
 		ctx, cancelFunc := context.WithTimeout(context.Background(), DialTimeout)
 		conn, err := s.grpcDialer(ctx,
@@ -348,6 +348,25 @@ type InitializeConfig struct {
 	Mirrors   []greenplum.SegConfig
 }
 
+// FinalizeSummary contains the outputs of finalize. It is persisted so that
+// they are reported correctly when finalize is run again after a failure.
+type FinalizeSummary struct {
+	TargetVersion                     string
+	LogArchiveDirectory               string
+	ArchivedSourceMasterDataDirectory string
+	StartTime                         time.Time
+	EndTime                           time.Time
+}
+
+// RevertSummary contains the outputs of revert. It is persisted so that they
+// are reported correctly when revert is run again after a failure.
+type RevertSummary struct {
+	SourceVersion       string
+	LogArchiveDirectory string
+	StartTime           time.Time
+	EndTime             time.Time
+}
+
 // Config contains all the information that will be persisted to/loaded from
 // from disk during calls to Save() and Load().
 type Config struct {
@@ -362,7 +381,8 @@ type Config struct {
 
 	// Connection is a utility object that generates connection URIs to the
 	// source or target databases.  It also contains the Source.Version and
-	// Target.Version internally.
+	// Target.Version internally, as well as the master host and authentication
+	// settings.
 	Connection *connURI.Conn
 
 	// TargetInitializeConfig contains all the info needed to initialize the
@@ -381,6 +401,97 @@ type Config struct {
 	Tablespaces                greenplum.Tablespaces
 	TablespacesMappingFilePath string
 	TargetCatalogVersion       string
+
+	// TablespaceRelocations moves the user defined tablespaces of the primary
+	// segments to new locations in the target cluster. See TargetTablespaces.
+	TablespaceRelocations greenplum.TablespaceRelocations
+
+	// HostMapping creates the target segments of the mapped source hosts on
+	// new hosts. The source primaries are copied to their target hosts during
+	// execute, leaving the source cluster untouched.
+	HostMapping greenplum.HostMapping
+
+	// AnalyzeTargetCluster enables regenerating optimizer statistics on the
+	// target cluster during finalize using AnalyzeJobs parallel workers.
+	AnalyzeTargetCluster bool
+	AnalyzeJobs          int
+
+	// MirrorUpgradeStrategy selects how the mirrors are upgraded during
+	// finalize. The rsync strategy copies up to MirrorUpgradeJobs primaries
+	// concurrently on each mirror host.
+	MirrorUpgradeStrategy string
+	MirrorUpgradeJobs     int
+
+	// MirrorSyncTimeout is how long finalize waits for the upgraded mirrors
+	// to synchronize before failing.
+	MirrorSyncTimeout time.Duration
+
+	// ActiveSessionPolicy selects what execute does about sessions connected
+	// to the source cluster before shutting it down. The wait policy waits up
+	// to ActiveSessionWait for them to disconnect.
+	ActiveSessionPolicy string
+	ActiveSessionWait   time.Duration
+
+	// Quiesce blocks client connections to the source cluster other than
+	// those of the admins from initialize until finalize or revert.
+	Quiesce bool
+
+	// GpinitsystemOverrides are gpinitsystem_config parameters merged into
+	// the generated config of the target cluster.
+	GpinitsystemOverrides map[string]string
+
+	// UpdateExtensions enables updating the extensions of the target cluster
+	// to their default versions during finalize. When set, only the
+	// extensions of UpdateExtensionsAllow are updated, and those of
+	// UpdateExtensionsDeny never are.
+	UpdateExtensions      bool
+	UpdateExtensionsAllow []string
+	UpdateExtensionsDeny  []string
+
+	// GPHomeManifestAllowlist are patterns of target GPHOME files expected to
+	// differ across hosts.
+	GPHomeManifestAllowlist []string
+
+	// Distribute copies the gpupgrade binary of the hub, and the target
+	// GPHOME when DistributeTargetGPHome is set, to the agent hosts during
+	// initialize.
+	Distribute             bool
+	DistributeTargetGPHome bool
+
+	FinalizeSummary FinalizeSummary
+	RevertSummary   RevertSummary
+}
+
+// AgentHosts returns the hosts of both the source and target segments, which
+// differ when using a host mapping. The master host is included when the hub
+// does not run on it.
+func (c *Config) AgentHosts() []string {
+	hosts := AgentHosts(c.Source)
+
+	uniqueHosts := make(map[string]bool)
+	for _, host := range hosts {
+		uniqueHosts[host] = true
+	}
+
+	extraHosts := c.HostMapping.TargetHosts()
+	if c.Source.RemoteMaster {
+		extraHosts = append(extraHosts, c.Source.MasterHostname())
+	}
+
+	for _, host := range extraHosts {
+		if !uniqueHosts[host] {
+			uniqueHosts[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts
+}
+
+// TargetTablespaces returns the tablespaces of the target cluster, which are
+// the source tablespaces at their relocated locations.
+func (c *Config) TargetTablespaces() greenplum.Tablespaces {
+	return c.Tablespaces.Relocate(c.Source, c.TablespaceRelocations)
 }
 
 func (c *Config) Load(r io.Reader) error {
@@ -416,6 +527,24 @@ func LoadConfig(conf *Config, path string) error {
 		return xerrors.Errorf("reading configuration file: %w", err)
 	}
 
+	return useConnectionEnv(conf.Connection)
+}
+
+// useConnectionEnv sets the libpq environment variables of the connection
+// settings, such that the driver reads the configured pgpass file and the
+// utilities run by gpupgrade connect the same way as the hub.
+func useConnectionEnv(conn *connURI.Conn) error {
+	if conn == nil {
+		return nil
+	}
+
+	for _, entry := range conn.Env() {
+		pair := strings.SplitN(entry, "=", 2)
+		if err := os.Setenv(pair[0], pair[1]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -5,8 +5,12 @@ package hub
 
 import (
 	"bytes"
+	"os"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
 
 	"github.com/greenplum-db/gpupgrade/db/connURI"
 	"github.com/greenplum-db/gpupgrade/greenplum"
@@ -42,6 +46,48 @@ func TestConfig(t *testing.T) {
 				}}}, // Tablespaces
 			greenplum.TablespacesMappingFile, // TablespacesMappingFilePath
 			"301908232",                      // TargetCatalogVersion
+			greenplum.TablespaceRelocations{{
+				Host:      "sdw1",
+				OldPrefix: "/data/tablespaces",
+				NewPrefix: "/mnt/tablespaces",
+			}}, // TablespaceRelocations
+			greenplum.HostMapping{"sdw2": "new-sdw2"}, // HostMapping
+			true,             // AnalyzeTargetCluster
+			4,                // AnalyzeJobs
+			RsyncStrategy,    // MirrorUpgradeStrategy
+			8,                // MirrorUpgradeJobs
+			5 * time.Minute,  // MirrorSyncTimeout
+			WaitPolicy,       // ActiveSessionPolicy
+			10 * time.Minute, // ActiveSessionWait
+			true,             // Quiesce
+			map[string]string{
+				"HEAP_CHECKSUM": "on",
+			}, // GpinitsystemOverrides
+			true, // UpdateExtensions
+			[]string{
+				"postgis",
+			}, // UpdateExtensionsAllow
+			[]string{
+				"madlib",
+			}, // UpdateExtensionsDeny
+			[]string{
+				"share/postgresql/timezone",
+			}, // GPHomeManifestAllowlist
+			true,  // Distribute
+			false, // DistributeTargetGPHome
+			FinalizeSummary{
+				TargetVersion:                     "6.20.0",
+				LogArchiveDirectory:               "/home/gpadmin/gpAdminLogs/gpupgrade-ID-2021-01-02T03:04",
+				ArchivedSourceMasterDataDirectory: "/data/qddir/demoDataDir-1_old",
+				StartTime:                         time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC),
+				EndTime:                           time.Date(2021, 1, 2, 3, 4, 0, 0, time.UTC),
+			},
+			RevertSummary{
+				SourceVersion:       "5.28.10",
+				LogArchiveDirectory: "/home/gpadmin/gpAdminLogs/gpupgrade-ID-2021-01-02T03:04",
+				StartTime:           time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC),
+				EndTime:             time.Date(2021, 1, 2, 3, 4, 0, 0, time.UTC),
+			},
 		}
 
 		buf := new(bytes.Buffer)
@@ -68,3 +114,25 @@ func TestConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestUseConnectionEnv(t *testing.T) {
+	resetUser := testutils.SetEnv(t, "PGUSER", "")
+	defer resetUser()
+
+	resetSSLMode := testutils.SetEnv(t, "PGSSLMODE", "")
+	defer resetSSLMode()
+
+	conn := connURI.Connection(semver.MustParse("6.0.0"), semver.MustParse("6.0.0"),
+		connURI.User("gpadmin"), connURI.SSLMode("require"))
+
+	err := useConnectionEnv(conn)
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	for key, expected := range map[string]string{"PGUSER": "gpadmin", "PGSSLMODE": "require"} {
+		if actual := os.Getenv(key); actual != expected {
+			t.Errorf("got %s=%q want %q", key, actual, expected)
+		}
+	}
+}
@@ -404,3 +404,36 @@ func TestAgentHosts(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigAgentHosts(t *testing.T) {
+	source := hub.MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, Hostname: "mdw", Role: "p"},
+		{ContentID: 0, Hostname: "sdw1", Role: "p"},
+		{ContentID: 1, Hostname: "sdw2", Role: "p"},
+	})
+
+	t.Run("includes the master host when the hub does not run on it", func(t *testing.T) {
+		source.RemoteMaster = true
+		defer func() { source.RemoteMaster = false }()
+
+		conf := &hub.Config{Source: source}
+		actual := conf.AgentHosts()
+		sort.Strings(actual) // order not guaranteed
+
+		expected := []string{"mdw", "sdw1", "sdw2"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("got %q want %q", actual, expected)
+		}
+	})
+
+	t.Run("includes the target hosts of a host mapping", func(t *testing.T) {
+		conf := &hub.Config{Source: source, HostMapping: greenplum.HostMapping{"sdw2": "new-sdw2"}}
+		actual := conf.AgentHosts()
+		sort.Strings(actual) // order not guaranteed
+
+		expected := []string{"new-sdw2", "sdw1", "sdw2"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("got %q want %q", actual, expected)
+		}
+	})
+}
@@ -37,16 +37,10 @@ func (s *Server) UpdateCatalogAndClusterConfig(streams step.OutStreams) (err err
 	// UpdateCatalogAndClusterConfig mutates the target cluster with the new
 	// data directories which have yet to be reflected on disk in a later substep.
 	master := s.Target.Primaries[-1]
-
-	// XXX We should not have to do this. Put Target back the way it was.
-	segPrefix, err := GetMasterSegPrefix(master.DataDir)
-	if err != nil {
-		return err
-	}
-	master.DataDir = upgrade.TempDataDir(master.DataDir, segPrefix, s.Config.UpgradeID)
+	master.DataDir = s.TargetInitializeConfig.Master.DataDir
 
 	segs := map[int]greenplum.SegConfig{-1: master}
-	oldTarget := &greenplum.Cluster{Primaries: segs, GPHome: s.Target.GPHome}
+	oldTarget := &greenplum.Cluster{Primaries: segs, GPHome: s.Target.GPHome, RemoteMaster: s.Target.RemoteMaster}
 
 	err = oldTarget.StopMasterOnly(streams)
 	if err != nil {
@@ -64,10 +58,10 @@ func WithinDbConnection(conn *connURI.Conn, masterPort int, operation func(conne
 		connURI.AllowSystemTableMods(),
 	}
 
-	connURI := conn.URI(options...)
-	connection, err := sql.Open("pgx", connURI)
+	uri := conn.URI(options...)
+	connection, err := sql.Open("pgx", uri)
 	if err != nil {
-		return xerrors.Errorf("connecting to master on port %d in utility mode with connection URI '%s': %w", masterPort, connURI, err)
+		return xerrors.Errorf("connecting to master on port %d in utility mode with connection URI '%s': %w", masterPort, connURI.Redact(uri), err)
 	}
 
 	defer func() {
@@ -176,7 +170,8 @@ func commitOrRollback(tx *sql.Tx, err error) error {
 }
 
 // UpdateGpSegmentConfiguration will modify the gp_segment_configuration of the passed
-// sql.DB to match the cluster port settings from the source utils.Cluster.
+// sql.DB to match the cluster port settings from the source utils.Cluster, and
+// the data directories the primaries have once finalize renames them.
 //
 // As a reminder to developers, we don't have any mirrors up at this point on
 // the target cluster. We copy only the primary information.
@@ -201,6 +196,7 @@ func (s *Server) UpdateGpSegmentConfiguration(db *sql.DB) (err error) {
 			// TODO: this is out of sync now, as the standby/mirrors are added later.
 			//   replace with one without standby/mirrors
 			s.Target = origConf.Source
+			finalDataDirs(s.Target, s.TargetInitializeConfig)
 			s.Target.GPHome = origConf.Target.GPHome
 			s.Target.Version = origConf.Target.Version
 
@@ -217,7 +213,10 @@ func (s *Server) UpdateGpSegmentConfiguration(db *sql.DB) (err error) {
 	// TODO: Consider iterating over dbids instead which is unique and could
 	//  remove the need for specifying the role when updating the catalog.
 	for _, content := range s.Source.ContentIDs {
-		err := updateConfiguration(tx, s.Source.Primaries[content])
+		seg := s.Source.Primaries[content]
+		seg.DataDir = finalDataDir(seg, s.TargetInitializeConfig)
+
+		err := updateConfiguration(tx, seg)
 		if err != nil {
 			return err
 		}
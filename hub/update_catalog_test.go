@@ -123,6 +123,63 @@ func TestUpdateCatalog(t *testing.T) {
 		}
 	})
 
+	t.Run("updates the catalog of a 7X target cluster", func(t *testing.T) {
+		data := `{
+	"Source": {
+		"GPHome": "/usr/local/gpdb6",
+			"Version": {
+			  "VersionString": "6.15.0",
+			  "SemVer": "6.15.0"
+			}
+		},
+	"Target": {
+		"GPHome": "/usr/local/gpdb7",
+			"Version": {
+			  "VersionString": "7.0.0-alpha.0 build dev",
+			  "SemVer": "7.0.0"
+			}
+		}
+}`
+		testutils.MustWriteToFile(t, config, data)
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		contents := sqlmock.NewRows([]string{"content"})
+		for _, content := range src.ContentIDs {
+			contents.AddRow(content)
+		}
+
+		mock.MatchExpectationsInOrder(false)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT content FROM gp_segment_configuration").
+			WillReturnRows(contents)
+
+		for _, content := range src.ContentIDs {
+			expectCatalogUpdate(mock, src.Primaries[content]).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		mock.ExpectCommit()
+
+		err = server.UpdateGpSegmentConfiguration(db)
+		if err != nil {
+			t.Errorf("returned error %+v", err)
+		}
+
+		if server.Target.GPHome != "/usr/local/gpdb7" {
+			t.Errorf("got target GPHome %q want %q", server.Target.GPHome, "/usr/local/gpdb7")
+		}
+
+		if !server.Target.Version.Is("7") {
+			t.Errorf("got target version %q want 7X", server.Target.Version.VersionString)
+		}
+	})
+
 	// All cases added to this table expect an error to be returned. The core of
 	// the test case is .prepare(), which sets up the Sqlmock appropriately.
 	// The case's .check() method tests that the returned error is what the test
@@ -12,28 +12,30 @@ import (
 	"github.com/greenplum-db/gpupgrade/step"
 )
 
-func UpdateConfFiles(streams step.OutStreams, version semver.Version, masterDataDir string, oldPort, newPort int) error {
+// UpdateConfFiles updates the configuration files of the master on the host,
+// or locally when the host is empty.
+func UpdateConfFiles(streams step.OutStreams, host string, version semver.Version, masterDataDir string, oldPort, newPort int) error {
 	if version.Major < 7 {
-		if err := UpdateGpperfmonConf(streams, masterDataDir); err != nil {
+		if err := UpdateGpperfmonConf(streams, host, masterDataDir); err != nil {
 			return err
 		}
 	}
 
-	if err := UpdatePostgresqlConf(streams, masterDataDir, oldPort, newPort); err != nil {
+	if err := UpdatePostgresqlConf(streams, host, masterDataDir, oldPort, newPort); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func UpdateGpperfmonConf(streams step.OutStreams, masterDataDir string) error {
+func UpdateGpperfmonConf(streams step.OutStreams, host, masterDataDir string) error {
 	logDir := filepath.Join(masterDataDir, "gpperfmon", "logs")
 
 	pattern := `^log_location = .*$`
 	replacement := fmt.Sprintf("log_location = %s", logDir)
 
 	// TODO: allow arbitrary whitespace around the = sign?
-	cmd := execCommand(
+	cmd := commandOnHost(host,
 		"sed",
 		"-i.bak", // in-place substitution with .bak backup extension
 		fmt.Sprintf(`s|%s|%s|`, pattern, replacement),
@@ -44,7 +46,7 @@ func UpdateGpperfmonConf(streams step.OutStreams, masterDataDir string) error {
 	return cmd.Run()
 }
 
-func UpdatePostgresqlConf(streams step.OutStreams, dataDir string, oldPort, newPort int) error {
+func UpdatePostgresqlConf(streams step.OutStreams, host, dataDir string, oldPort, newPort int) error {
 	// NOTE: any additions of forward slashes (/) here require an update to the
 	// sed script below
 	pattern := fmt.Sprintf(`(^port[ \t]*=[ \t]*)%d([^0-9]|$)`, oldPort)
@@ -52,7 +54,7 @@ func UpdatePostgresqlConf(streams step.OutStreams, dataDir string, oldPort, newP
 
 	path := filepath.Join(dataDir, "postgresql.conf")
 
-	cmd := execCommand(
+	cmd := commandOnHost(host,
 		"sed",
 		"-E",     // use POSIX extended regexes
 		"-i.bak", // in-place substitution with .bak backup extension
@@ -44,7 +44,7 @@ other_log_location = /some/directory
 `)
 
 		// Perform the replacement.
-		err = hub.UpdateGpperfmonConf(step.DevNullStream, dir)
+		err = hub.UpdateGpperfmonConf(step.DevNullStream, "", dir)
 		if err != nil {
 			t.Errorf("UpdateGpperfmonConf() returned error %+v", err)
 		}
@@ -77,7 +77,7 @@ port=50000
 `)
 
 		// Perform the replacement.
-		err = hub.UpdatePostgresqlConf(step.DevNullStream, dir, 5000, 6000)
+		err = hub.UpdatePostgresqlConf(step.DevNullStream, "", dir, 5000, 6000)
 		if err != nil {
 			t.Errorf("UpdatePostgresqlConf() returned error %+v", err)
 		}
@@ -0,0 +1,264 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/jackc/pgx"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// The results of updating an extension.
+const (
+	ExtensionUpdated    = "updated"
+	ExtensionNotUpdated = "not updated"
+	ExtensionFailed     = "failed"
+	ExtensionExcluded   = "excluded"
+)
+
+// ExtensionUpdate is an extension of a target database whose installed
+// version is older than the default version of the target GPHOME.
+type ExtensionUpdate struct {
+	Database    string
+	Extension   string
+	FromVersion string
+	ToVersion   string
+	Status      string
+	Error       string `json:",omitempty"`
+}
+
+func (u ExtensionUpdate) String() string {
+	s := fmt.Sprintf("database %q extension %q from %s to %s: %s", u.Database, u.Extension, u.FromVersion, u.ToVersion, u.Status)
+	if u.Error != "" {
+		s += ": " + u.Error
+	}
+
+	return s
+}
+
+// UpdateExtensions runs ALTER EXTENSION ... UPDATE for the extensions of every
+// target database which have a newer default version. An allow list limits
+// the extensions updated, and the deny list excludes extensions from it. All
+// updates are first tried in transactions which are rolled back, such that
+// none are applied unless every update succeeds. They are then committed a
+// database at a time, stopping at the first failure, which leaves the earlier
+// databases updated. The results are recorded in the store as the result of
+// the finalize UPDATE_EXTENSIONS substep and reported.
+func UpdateExtensions(streams step.OutStreams, store step.ResultStore, conn *connURI.Conn, masterPort int, allow, deny []string) (err error) {
+	db, err := utils.System.SqlOpen("pgx", conn.URI(connURI.ToTarget(), connURI.Port(masterPort)))
+	if err != nil {
+		return err
+	}
+
+	databases, err := getConnectableDatabases(db)
+	if cErr := db.Close(); cErr != nil {
+		err = errorlist.Append(err, cErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	var updates []ExtensionUpdate
+	for _, database := range databases {
+		err := withTargetDatabase(conn, masterPort, database, func(db *sql.DB) error {
+			found, err := findExtensionUpdates(db, database)
+			updates = append(updates, found...)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range updates {
+		updates[i].Status = ExtensionNotUpdated
+		if !extensionAllowed(updates[i].Extension, allow, deny) {
+			updates[i].Status = ExtensionExcluded
+		}
+	}
+
+	defer func() {
+		if wErr := store.WriteResult(idl.Step_FINALIZE, idl.Substep_UPDATE_EXTENSIONS, updates); wErr != nil {
+			err = errorlist.Append(err, xerrors.Errorf("write extension updates: %w", wErr))
+		}
+
+		if rErr := reportExtensionUpdates(streams, updates); rErr != nil {
+			err = errorlist.Append(err, rErr)
+		}
+	}()
+
+	// Apply the updates only once all of them succeed, so that a failure
+	// does not leave the cluster partially updated.
+	if err := alterExtensions(conn, masterPort, updates, false); err != nil {
+		return err
+	}
+
+	return alterExtensions(conn, masterPort, updates, true)
+}
+
+// alterExtensions updates the extensions of each database in a transaction,
+// which is rolled back unless commit is set. The status of a failed update is
+// set to ExtensionFailed, and that of the others to ExtensionUpdated once
+// committed. When validating every database is tried such that all failures
+// are reported, whereas committing stops at the first failure.
+func alterExtensions(conn *connURI.Conn, masterPort int, updates []ExtensionUpdate, commit bool) error {
+	byDatabase := make(map[string][]int)
+	var databases []string
+	for i, u := range updates {
+		if u.Status != ExtensionNotUpdated {
+			continue
+		}
+
+		if _, ok := byDatabase[u.Database]; !ok {
+			databases = append(databases, u.Database)
+		}
+		byDatabase[u.Database] = append(byDatabase[u.Database], i)
+	}
+
+	var mErr error
+	for _, database := range databases {
+		err := withTargetDatabase(conn, masterPort, database, func(db *sql.DB) error {
+			failed, err := alterDatabaseExtensions(db, updates, byDatabase[database], commit)
+			if failed >= 0 {
+				updates[failed].Status = ExtensionFailed
+				updates[failed].Error = err.Error()
+			}
+
+			return err
+		})
+		if err != nil {
+			mErr = errorlist.Append(mErr, xerrors.Errorf("update extensions in database %q: %w", database, err))
+			if commit {
+				return mErr
+			}
+
+			continue
+		}
+
+		if commit {
+			for _, i := range byDatabase[database] {
+				updates[i].Status = ExtensionUpdated
+			}
+		}
+	}
+
+	return mErr
+}
+
+// alterDatabaseExtensions returns the index of the update which failed, or -1
+// when the transaction itself failed or no update failed.
+func alterDatabaseExtensions(db *sql.DB, updates []ExtensionUpdate, indexes []int, commit bool) (failed int, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return -1, err
+	}
+
+	for _, i := range indexes {
+		gplog.Info("updating extension %q in database %q from %s to %s", updates[i].Extension, updates[i].Database, updates[i].FromVersion, updates[i].ToVersion)
+
+		_, err := tx.Exec(fmt.Sprintf("ALTER EXTENSION %s UPDATE", pgx.Identifier{updates[i].Extension}.Sanitize()))
+		if err != nil {
+			if rErr := tx.Rollback(); rErr != nil {
+				err = errorlist.Append(err, rErr)
+			}
+
+			return i, err
+		}
+	}
+
+	if !commit {
+		return -1, tx.Rollback()
+	}
+
+	return -1, tx.Commit()
+}
+
+// findExtensionUpdates returns the extensions of the database whose installed
+// version differs from the default version of the target GPHOME.
+func findExtensionUpdates(db *sql.DB, database string) ([]ExtensionUpdate, error) {
+	rows, err := db.Query(`
+		SELECT e.extname, e.extversion, a.default_version
+		FROM pg_extension e JOIN pg_available_extensions a ON a.name = e.extname
+		WHERE a.default_version IS NOT NULL AND a.default_version <> e.extversion
+		ORDER BY e.extname`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying extensions in database %q: %w", database, err)
+	}
+	defer rows.Close()
+
+	var updates []ExtensionUpdate
+	for rows.Next() {
+		u := ExtensionUpdate{Database: database}
+		if err := rows.Scan(&u.Extension, &u.FromVersion, &u.ToVersion); err != nil {
+			return nil, xerrors.Errorf("scanning extensions in database %q: %w", database, err)
+		}
+
+		updates = append(updates, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating extensions in database %q: %w", database, err)
+	}
+
+	return updates, nil
+}
+
+func withTargetDatabase(conn *connURI.Conn, masterPort int, database string, f func(*sql.DB) error) (err error) {
+	db, err := utils.System.SqlOpen("pgx", conn.URI(connURI.ToTarget(), connURI.Port(masterPort), connURI.Database(database)))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return f(db)
+}
+
+func extensionAllowed(extension string, allow, deny []string) bool {
+	for _, name := range deny {
+		if name == extension {
+			return false
+		}
+	}
+
+	if len(allow) == 0 {
+		return true
+	}
+
+	for _, name := range allow {
+		if name == extension {
+			return true
+		}
+	}
+
+	return false
+}
+
+func reportExtensionUpdates(streams step.OutStreams, updates []ExtensionUpdate) error {
+	if len(updates) == 0 {
+		_, err := fmt.Fprintln(streams.Stdout(), "All extensions are at their default version.")
+		return err
+	}
+
+	for _, u := range updates {
+		if _, err := fmt.Fprintln(streams.Stdout(), u.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const alterPostgis = `ALTER EXTENSION "postgis" UPDATE`
+
+// mockDatabases returns a mock for each database opened by the test in the
+// order they are opened.
+func mockDatabases(t *testing.T, conn *connURI.Conn, databases ...string) ([]sqlmock.Sqlmock, func()) {
+	var dbs []*sql.DB
+	var mocks []sqlmock.Sqlmock
+	for range databases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+
+		dbs = append(dbs, db)
+		mocks = append(mocks, mock)
+	}
+
+	opened := 0
+	utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+		if opened == len(dbs) {
+			t.Fatalf("unexpected connection %q", dataSourceName)
+		}
+
+		options := []connURI.Option{connURI.ToTarget(), connURI.Port(15432)}
+		if databases[opened] != "" {
+			options = append(options, connURI.Database(databases[opened]))
+		}
+
+		if expected := conn.URI(options...); dataSourceName != expected {
+			t.Errorf("got connection %q want %q", dataSourceName, expected)
+		}
+
+		opened++
+		return dbs[opened-1], nil
+	}
+
+	return mocks, func() {
+		utils.System = utils.InitializeSystemFunctions()
+		for _, mock := range mocks {
+			testutils.FinishMock(mock, t)
+		}
+	}
+}
+
+func expectExtensionUpdates(databases, gis, postgres sqlmock.Sqlmock) {
+	databases.ExpectQuery("SELECT datname FROM pg_database WHERE datallowconn").
+		WillReturnRows(sqlmock.NewRows([]string{"datname"}).AddRow("gis").AddRow("postgres"))
+	databases.ExpectClose()
+
+	gis.ExpectQuery("SELECT e.extname, e.extversion, a.default_version").
+		WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "default_version"}).
+			AddRow("madlib", "1.17", "1.19").
+			AddRow("postgis", "2.5.4", "3.1.0"))
+	gis.ExpectClose()
+
+	postgres.ExpectQuery("SELECT e.extname, e.extversion, a.default_version").
+		WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "default_version"}))
+	postgres.ExpectClose()
+}
+
+func readExtensionUpdates(t *testing.T, store *step.FileStore) []ExtensionUpdate {
+	var updates []ExtensionUpdate
+	found, err := store.ReadResult(idl.Step_FINALIZE, idl.Substep_UPDATE_EXTENSIONS, &updates)
+	if err != nil {
+		t.Fatalf("read extension updates: %v", err)
+	}
+
+	if !found {
+		t.Fatalf("expected the extension updates to be recorded")
+	}
+
+	return updates
+}
+
+func TestUpdateExtensions(t *testing.T) {
+	testlog.SetupLogger()
+
+	conn := connURI.Connection(semver.MustParse("6.0.0"), semver.MustParse("7.0.0"))
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	path := filepath.Join(stateDir, step.SubstepsFileName)
+	testutils.MustWriteToFile(t, path, "{}")
+	store := step.NewFileStore(path)
+
+	t.Run("updates the allowed extensions once every update succeeds", func(t *testing.T) {
+		mocks, cleanup := mockDatabases(t, conn, "", "gis", "postgres", "gis", "gis")
+		defer cleanup()
+
+		expectExtensionUpdates(mocks[0], mocks[1], mocks[2])
+
+		validate := mocks[3]
+		validate.ExpectBegin()
+		validate.ExpectExec(alterPostgis).WillReturnResult(sqlmock.NewResult(0, 0))
+		validate.ExpectRollback()
+		validate.ExpectClose()
+
+		apply := mocks[4]
+		apply.ExpectBegin()
+		apply.ExpectExec(alterPostgis).WillReturnResult(sqlmock.NewResult(0, 0))
+		apply.ExpectCommit()
+		apply.ExpectClose()
+
+		err := UpdateExtensions(step.DevNullStream, store, conn, 15432, nil, []string{"madlib"})
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []ExtensionUpdate{
+			{Database: "gis", Extension: "madlib", FromVersion: "1.17", ToVersion: "1.19", Status: ExtensionExcluded},
+			{Database: "gis", Extension: "postgis", FromVersion: "2.5.4", ToVersion: "3.1.0", Status: ExtensionUpdated},
+		}
+		if updates := readExtensionUpdates(t, store); !reflect.DeepEqual(updates, expected) {
+			t.Errorf("got %+v want %+v", updates, expected)
+		}
+	})
+
+	t.Run("applies no updates when any update fails", func(t *testing.T) {
+		mocks, cleanup := mockDatabases(t, conn, "", "gis", "postgres", "gis")
+		defer cleanup()
+
+		expectExtensionUpdates(mocks[0], mocks[1], mocks[2])
+
+		expected := errors.New("extension \"postgis\" has no update path from version \"2.5.4\" to version \"3.1.0\"")
+		validate := mocks[3]
+		validate.ExpectBegin()
+		validate.ExpectExec(alterPostgis).WillReturnError(expected)
+		validate.ExpectRollback()
+		validate.ExpectClose()
+
+		err := UpdateExtensions(step.DevNullStream, store, conn, 15432, []string{"postgis"}, nil)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+
+		updates := readExtensionUpdates(t, store)
+		if len(updates) != 2 || updates[0].Status != ExtensionExcluded ||
+			updates[1].Status != ExtensionFailed || updates[1].Error != expected.Error() {
+			t.Errorf("got %+v want madlib excluded and postgis failed", updates)
+		}
+	})
+
+	t.Run("stops committing at the first failure", func(t *testing.T) {
+		mocks, cleanup := mockDatabases(t, conn, "", "gis", "postgres", "gis", "postgres", "gis")
+		defer cleanup()
+
+		mocks[0].ExpectQuery("SELECT datname FROM pg_database WHERE datallowconn").
+			WillReturnRows(sqlmock.NewRows([]string{"datname"}).AddRow("gis").AddRow("postgres"))
+		mocks[0].ExpectClose()
+
+		for _, mock := range mocks[1:3] {
+			mock.ExpectQuery("SELECT e.extname, e.extversion, a.default_version").
+				WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "default_version"}).
+					AddRow("postgis", "2.5.4", "3.1.0"))
+			mock.ExpectClose()
+		}
+
+		for _, mock := range mocks[3:5] {
+			mock.ExpectBegin()
+			mock.ExpectExec(alterPostgis).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectRollback()
+			mock.ExpectClose()
+		}
+
+		// The postgres database is not committed once gis fails.
+		expected := errors.New("connection reset")
+		commit := mocks[5]
+		commit.ExpectBegin()
+		commit.ExpectExec(alterPostgis).WillReturnResult(sqlmock.NewResult(0, 0))
+		commit.ExpectCommit().WillReturnError(expected)
+		commit.ExpectClose()
+
+		err := UpdateExtensions(step.DevNullStream, store, conn, 15432, nil, nil)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+
+		updates := readExtensionUpdates(t, store)
+		if len(updates) != 2 || updates[0].Status != ExtensionNotUpdated || updates[1].Status != ExtensionNotUpdated {
+			t.Errorf("got %+v want neither database updated", updates)
+		}
+	})
+
+	t.Run("errors when the report cannot be written", func(t *testing.T) {
+		mocks, cleanup := mockDatabases(t, conn, "", "gis", "postgres", "gis", "gis")
+		defer cleanup()
+
+		expectExtensionUpdates(mocks[0], mocks[1], mocks[2])
+
+		for i, mock := range mocks[3:] {
+			mock.ExpectBegin()
+			mock.ExpectExec(alterPostgis).WillReturnResult(sqlmock.NewResult(0, 0))
+			if i == 0 {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+			mock.ExpectClose()
+		}
+
+		expected := errors.New("write failed")
+		err := UpdateExtensions(testutils.FailingStreams{Err: expected}, store, conn, 15432, []string{"postgis"}, nil)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestExtensionAllowed(t *testing.T) {
+	cases := []struct {
+		extension string
+		allow     []string
+		deny      []string
+		expected  bool
+	}{
+		{"postgis", nil, nil, true},
+		{"postgis", []string{"postgis"}, nil, true},
+		{"madlib", []string{"postgis"}, nil, false},
+		{"postgis", nil, []string{"postgis"}, false},
+		{"postgis", []string{"postgis"}, []string{"postgis"}, false},
+	}
+
+	for _, c := range cases {
+		if allowed := extensionAllowed(c.extension, c.allow, c.deny); allowed != c.expected {
+			t.Errorf("extensionAllowed(%q, %q, %q) got %t want %t", c.extension, c.allow, c.deny, allowed, c.expected)
+		}
+	}
+}
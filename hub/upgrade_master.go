@@ -6,6 +6,7 @@ package hub
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -18,6 +19,7 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
@@ -37,9 +39,17 @@ type UpgradeMasterArgs struct {
 	Stream      step.OutStreams
 	CheckOnly   bool
 	UseLinkMode bool
+
+	// AgentConns are used to upgrade the master through the agent on the
+	// master host when the hub does not run on it.
+	AgentConns []*Connection
 }
 
 func UpgradeMaster(args UpgradeMasterArgs) error {
+	if args.Source.RemoteMaster {
+		return upgradeRemoteMaster(args)
+	}
+
 	wd := upgrade.MasterWorkingDirectory(args.StateDir)
 	err := utils.System.MkdirAll(wd, 0700)
 	if err != nil {
@@ -75,17 +85,20 @@ func UpgradeMaster(args UpgradeMasterArgs) error {
 		options = append(options, upgrade.WithLinkMode())
 	}
 
-	// When upgrading from 5 the master must be provided with its standby's dbid to allow WAL to sync.
-	if args.Source.Version.Before("6") {
-		if args.Source.HasStandby() {
-			options = append(options, upgrade.WithOldOptions(fmt.Sprintf("-x %d", args.Source.Standby().DbID)))
-		}
+	if oldOptions := masterOldOptions(args.Source); oldOptions != "" {
+		options = append(options, upgrade.WithOldOptions(oldOptions))
 	}
 
 	// FIXME: args.Target.Version comes from gp-common-go-libs, which uses a deprecated version of semver.
 	//   It is not compatible with the semver v4 we use in gpupgrade.
 	targetVersion := semver.MustParse(args.Target.Version.SemVer.String())
 
+	if !args.CheckOnly {
+		if err := upgrade.MarkUpgradeStarted(wd, args.UseLinkMode); err != nil {
+			return xerrors.Errorf("record master upgrade status: %w", err)
+		}
+	}
+
 	err = upgrade.Run(pair, targetVersion, options...)
 	if err != nil {
 		// Error details from stdout are added to any errors containing "fatal"
@@ -119,9 +132,63 @@ func UpgradeMaster(args UpgradeMasterArgs) error {
 		return NewUpgradeMasterError(args.CheckOnly, errText, err)
 	}
 
+	if !args.CheckOnly {
+		if err := upgrade.MarkUpgradeFinished(wd); err != nil {
+			return xerrors.Errorf("record master upgrade status: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// upgradeRemoteMaster upgrades the master through the agent on the master host.
+// The agent restores the target master from the backup taken during
+// initialize, and records the upgrade status in its own state directory.
+func upgradeRemoteMaster(args UpgradeMasterArgs) error {
+	conn, err := masterAgentConn(args.AgentConns, args.Source)
+	if err != nil {
+		return NewUpgradeMasterError(args.CheckOnly, "", err)
+	}
+
+	source := masterSegmentFromCluster(args.Source)
+	target := masterSegmentFromCluster(args.Target)
+
+	req := &idl.UpgradeMasterRequest{
+		SourceBinDir:  source.BinDir,
+		TargetBinDir:  target.BinDir,
+		TargetVersion: args.Target.Version.SemVer.String(),
+		DataDirPair: &idl.DataDirPair{
+			SourceDataDir: source.DataDir,
+			TargetDataDir: target.DataDir,
+			SourcePort:    int32(source.Port),
+			TargetPort:    int32(target.Port),
+			Content:       -1,
+			DBID:          int32(source.DBID),
+		},
+		CheckOnly:       args.CheckOnly,
+		UseLinkMode:     args.UseLinkMode,
+		OldOptions:      masterOldOptions(args.Source),
+		MasterBackupDir: filepath.Join(args.StateDir, originalMasterBackupName),
+	}
+
+	if _, err := conn.AgentClient.UpgradeMaster(context.Background(), req); err != nil {
+		return NewUpgradeMasterError(args.CheckOnly, "", err)
+	}
+
+	return nil
+}
+
+// masterOldOptions returns the pg_upgrade --old-options for the master. When
+// upgrading from 5 the master must be provided with its standby's dbid to
+// allow WAL to sync.
+func masterOldOptions(source *greenplum.Cluster) string {
+	if source.Version.Before("6") && source.HasStandby() {
+		return fmt.Sprintf("-x %d", source.Standby().DbID)
+	}
+
+	return ""
+}
+
 type UpgradeMasterError struct {
 	FailedAction string
 	ErrorText    string
@@ -178,6 +245,27 @@ func fileEntries(root string) ([]string, error) {
 	return files, nil
 }
 
+// RsyncMasterDataDirOnHost copies the master data directory within the master
+// host through its agent, for a hub that does not run on the master host.
+func RsyncMasterDataDirOnHost(agentConns []*Connection, cluster *greenplum.Cluster, sourceDir, targetDir string) error {
+	conn, err := masterAgentConn(agentConns, cluster)
+	if err != nil {
+		return err
+	}
+
+	req := &idl.RsyncRequest{
+		Options:  []string{"--archive", "--delete"},
+		Excludes: []string{"pg_log/*"},
+		Pairs:    []*idl.RsyncPair{{Source: filepath.Clean(sourceDir), Destination: targetDir}},
+	}
+
+	if _, err := conn.AgentClient.RsyncDataDirectories(context.Background(), req); err != nil {
+		return xerrors.Errorf("rsync %q to %q on host %s: %w", sourceDir, targetDir, conn.Hostname, err)
+	}
+
+	return nil
+}
+
 func RsyncMasterDataDir(stream step.OutStreams, sourceDir, targetDir string) error {
 	sourceDirRsync := filepath.Clean(sourceDir) + string(os.PathSeparator)
 
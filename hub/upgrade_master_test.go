@@ -19,6 +19,8 @@ import (
 	"github.com/greenplum-db/gp-common-go-libs/dbconn"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
@@ -492,3 +494,101 @@ func TestRsyncMasterDir(t *testing.T) {
 	})
 
 }
+
+func TestUpgradeRemoteMaster(t *testing.T) {
+	source := MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, Hostname: "mdw", Port: 5432, DataDir: "/data/qddir/seg-1", DbID: 1, Role: "p"},
+		{ContentID: 0, Hostname: "sdw1", Port: 25432, DataDir: "/data/dbfast1/seg1", DbID: 2, Role: "p"},
+	})
+	source.GPHome = "/usr/local/source"
+	source.Version = dbconn.NewVersion("6.14.0")
+	source.RemoteMaster = true
+
+	target := MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, Hostname: "mdw", Port: 6432, DataDir: "/data/qddir/seg-1_ABC123-1", DbID: 1, Role: "p"},
+	})
+	target.GPHome = "/usr/local/target"
+	target.Version = dbconn.NewVersion("6.15.0")
+
+	t.Run("upgrades the master through the agent on the master host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+		mdw.EXPECT().UpgradeMaster(
+			gomock.Any(),
+			&idl.UpgradeMasterRequest{
+				SourceBinDir:  "/usr/local/source/bin",
+				TargetBinDir:  "/usr/local/target/bin",
+				TargetVersion: "6.15.0",
+				DataDirPair: &idl.DataDirPair{
+					SourceDataDir: "/data/qddir/seg-1",
+					TargetDataDir: "/data/qddir/seg-1_ABC123-1",
+					SourcePort:    5432,
+					TargetPort:    6432,
+					Content:       -1,
+					DBID:          1,
+				},
+				UseLinkMode:     true,
+				MasterBackupDir: filepath.Join("/state", originalMasterBackupName),
+			},
+		).Return(&idl.UpgradeMasterReply{}, nil)
+
+		// The other agents are not used to upgrade the master.
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+
+		err := UpgradeMaster(UpgradeMasterArgs{
+			Source:      source,
+			Target:      target,
+			StateDir:    "/state",
+			Stream:      step.DevNullStream,
+			UseLinkMode: true,
+			AgentConns: []*Connection{
+				{AgentClient: sdw1, Hostname: "sdw1"},
+				{AgentClient: mdw, Hostname: "mdw"},
+			},
+		})
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("returns an upgrade master error when the agent fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("pg_upgrade failed")
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+		mdw.EXPECT().UpgradeMaster(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		err := UpgradeMaster(UpgradeMasterArgs{
+			Source:     source,
+			Target:     target,
+			StateDir:   "/state",
+			Stream:     step.DevNullStream,
+			CheckOnly:  true,
+			AgentConns: []*Connection{{AgentClient: mdw, Hostname: "mdw"}},
+		})
+
+		var upgradeErr UpgradeMasterError
+		if !errors.As(err, &upgradeErr) {
+			t.Fatalf("got error %#v want type %T", err, upgradeErr)
+		}
+
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("errors when there is no agent on the master host", func(t *testing.T) {
+		err := UpgradeMaster(UpgradeMasterArgs{
+			Source:   source,
+			Target:   target,
+			StateDir: "/state",
+			Stream:   step.DevNullStream,
+		})
+		if err == nil || !strings.Contains(err.Error(), "no agent connection to master host mdw") {
+			t.Errorf("got error %#v want missing agent connection", err)
+		}
+	})
+}
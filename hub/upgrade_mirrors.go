@@ -5,20 +5,27 @@ package hub
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
+	"strings"
+	"text/tabwriter"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/db/connURI"
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-const defaultFTSTimeout = 2 * time.Minute
+// DefaultMirrorSyncTimeout is how long finalize waits for the upgraded mirrors
+// to synchronize with their primaries.
+const DefaultMirrorSyncTimeout = 2 * time.Minute
 
 func writeGpAddmirrorsConfig(mirrors []greenplum.SegConfig, out io.Writer) error {
 	for _, m := range mirrors {
@@ -39,8 +46,168 @@ func runAddMirrors(r greenplum.Runner, filepath string, useHbaHostnames bool) er
 	return r.Run("gpaddmirrors", args...)
 }
 
-func waitForFTS(db *sql.DB, timeout time.Duration) error {
+// MirrorStatus is the state of a single mirror as reported by
+// gp_segment_configuration and the replication statistics of its primary. The
+// standby master is the mirror of content -1.
+type MirrorStatus struct {
+	Content  int
+	Hostname string
+	Status   string
+	Mode     string
+
+	// Replicating is true when the primary has a WAL sender for the mirror,
+	// in which case State, SyncState and LagBytes describe its replication
+	// and LagBytes is the amount of WAL sent but not yet replayed.
+	Replicating bool
+	State       string
+	SyncState   string
+	LagBytes    int64
+}
+
+// Synchronized returns whether the mirror is in sync with its primary. FTS
+// does not probe the standby master, so the status and mode of content -1 are
+// not maintained and its replication state is used instead.
+func (m MirrorStatus) Synchronized() bool {
+	if m.IsStandby() {
+		return m.Replicating && m.State == "streaming" && m.SyncState == "sync"
+	}
+
+	return m.Status == "u" && m.Mode == "s"
+}
+
+func (m MirrorStatus) IsStandby() bool {
+	return m.Content == -1
+}
+
+func (m MirrorStatus) String() string {
+	if !m.IsStandby() {
+		return fmt.Sprintf("content %d on %s (status %s, mode %s)", m.Content, m.Hostname, m.Status, m.Mode)
+	}
+
+	if !m.Replicating {
+		return fmt.Sprintf("standby master on %s (not connected)", m.Hostname)
+	}
+
+	return fmt.Sprintf("standby master on %s (state %s, sync state %s)", m.Hostname, m.State, m.SyncState)
+}
+
+// mirrorSelection restricts the mirrors whose status is queried.
+type mirrorSelection int
+
+const (
+	segmentMirrors mirrorSelection = iota
+	standbyMaster
+	allMirrors
+)
+
+func (s mirrorSelection) condition() string {
+	switch s {
+	case segmentMirrors:
+		return "AND c.content <> -1"
+	case standbyMaster:
+		return "AND c.content = -1"
+	default:
+		return ""
+	}
+}
+
+var ErrMirrorsNotSynchronized = errors.New("mirrors not synchronized")
+
+type MirrorsNotSynchronizedError struct {
+	Timeout time.Duration
+	Mirrors []MirrorStatus
+}
+
+func (e MirrorsNotSynchronizedError) Error() string {
+	var mirrors []string
+	for _, m := range e.Mirrors {
+		mirrors = append(mirrors, m.String())
+	}
+
+	return fmt.Sprintf("%s timeout exceeded waiting for mirrors to synchronize: %s", e.Timeout, strings.Join(mirrors, ", "))
+}
+
+func (e MirrorsNotSynchronizedError) Is(err error) bool {
+	return err == ErrMirrorsNotSynchronized
+}
+
+// replayLag returns the expression computing the bytes of WAL sent to a
+// replica but not yet replayed. 7X renamed the xlog functions and the
+// replication statistics columns to use wal and lsn.
+func replayLag(version semver.Version) string {
+	if version.LT(semver.MustParse("7.0.0")) {
+		return "pg_xlog_location_diff(r.sent_location, r.replay_location)"
+	}
+
+	return "pg_wal_lsn_diff(r.sent_lsn, r.replay_lsn)"
+}
+
+// mirrorStatusQuery joins each mirror with the replication statistics of its
+// primary. gp_stat_replication gathers its rows from the master and segments,
+// so it must be queried over a dispatching rather than a utility mode
+// connection. Only the WAL senders of mirrors are joined, as the master may
+// also be streaming to a base backup.
+func mirrorStatusQuery(version semver.Version, selection mirrorSelection) string {
+	return fmt.Sprintf(`
+	SELECT c.content, c.hostname, c.status, c.mode,
+		r.gp_segment_id IS NOT NULL,
+		COALESCE(r.state, ''), COALESCE(r.sync_state, ''),
+		COALESCE(%s, 0)::bigint
+	FROM gp_segment_configuration c
+		LEFT JOIN gp_stat_replication r
+			ON r.gp_segment_id = c.content AND r.application_name = 'gp_walreceiver'
+	WHERE c.role = 'm' %s
+	ORDER BY c.content`, replayLag(version), selection.condition())
+}
+
+func queryMirrorStatuses(db *sql.DB, version semver.Version, selection mirrorSelection) ([]MirrorStatus, error) {
+	rows, err := db.Query(mirrorStatusQuery(version, selection))
+	if err != nil {
+		return nil, xerrors.Errorf("querying mirror status: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []MirrorStatus
+	for rows.Next() {
+		var m MirrorStatus
+		if err := rows.Scan(&m.Content, &m.Hostname, &m.Status, &m.Mode, &m.Replicating, &m.State, &m.SyncState, &m.LagBytes); err != nil {
+			return nil, xerrors.Errorf("scanning mirror status: %w", err)
+		}
+
+		statuses = append(statuses, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating mirror status: %w", err)
+	}
+
+	return statuses, nil
+}
+
+func formatMirrorStatuses(statuses []MirrorStatus) string {
+	var b strings.Builder
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTENT\tHOST\tSTATUS\tMODE\tSTATE\tSYNC STATE\tREPLAY LAG")
+	for _, m := range statuses {
+		state, syncState, lag := "-", "-", "-"
+		if m.Replicating {
+			state, syncState, lag = m.State, m.SyncState, fmt.Sprintf("%d bytes", m.LagBytes)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", m.Content, m.Hostname, m.Status, m.Mode, state, syncState, lag)
+	}
+	w.Flush()
+
+	return b.String()
+}
+
+// waitForMirrors requests FTS probes until every selected mirror is up and in
+// sync, writing the status of each mirror to out whenever it changes. If the
+// mirrors do not synchronize within the timeout a MirrorsNotSynchronizedError
+// naming the remaining mirrors is returned.
+func waitForMirrors(out io.Writer, db *sql.DB, version semver.Version, selection mirrorSelection, timeout time.Duration) error {
 	startTime := time.Now()
+	var lastTable string
 	for {
 		rows, err := db.Query("SELECT gp_request_fts_probe_scan();")
 		if err != nil {
@@ -51,52 +218,40 @@ func waitForFTS(db *sql.DB, timeout time.Duration) error {
 			return xerrors.Errorf("closing probe scan results: %w", err)
 		}
 
-		doneWaiting, err := func() (bool, error) {
-			var up bool
-			rows, err = db.Query(`
-				SELECT every(status = 'u' AND mode = 's')
-					FROM gp_segment_configuration
-					WHERE role = 'm'
-			`)
-			if err != nil {
-				return false, xerrors.Errorf("querying mirror status: %w", err)
-			}
+		statuses, err := queryMirrorStatuses(db, version, selection)
+		if err != nil {
+			return err
+		}
 
-			defer rows.Close() // XXX lost error
+		table := formatMirrorStatuses(statuses)
+		if table != lastTable {
+			fmt.Fprintf(out, "\n%s", table)
+			lastTable = table
+		}
 
-			for rows.Next() {
-				if err := rows.Scan(&up); err != nil {
-					return false, xerrors.Errorf("scanning mirror status: %w", err)
-				}
+		var unsynchronized []MirrorStatus
+		for _, m := range statuses {
+			if !m.Synchronized() {
+				unsynchronized = append(unsynchronized, m)
 			}
-			if err := rows.Err(); err != nil {
-				return false, xerrors.Errorf("iterating mirror status: %w", err)
-			}
-
-			return up, nil
-		}()
-
-		if err != nil {
-			return err
 		}
 
-		if doneWaiting {
+		if len(unsynchronized) == 0 {
 			return nil
 		}
 
 		if time.Since(startTime) > timeout {
-			return xerrors.Errorf("%s timeout exceeded waiting for mirrors to come up", timeout)
+			return MirrorsNotSynchronizedError{Timeout: timeout, Mirrors: unsynchronized}
 		}
 
 		time.Sleep(time.Second)
 	}
 }
 
-func UpgradeMirrors(stateDir string, conn *connURI.Conn, masterPort int, mirrors []greenplum.SegConfig, targetRunner greenplum.Runner, useHbaHostnames bool) (err error) {
+func UpgradeMirrors(streams step.OutStreams, stateDir string, conn *connURI.Conn, masterPort int, mirrors []greenplum.SegConfig, targetRunner greenplum.Runner, useHbaHostnames bool, syncTimeout time.Duration) (err error) {
 	options := []connURI.Option{
 		connURI.ToTarget(),
 		connURI.Port(masterPort),
-		connURI.UtilityMode(),
 	}
 
 	db, err := utils.System.SqlOpen("pgx", conn.URI(options...))
@@ -106,10 +261,10 @@ func UpgradeMirrors(stateDir string, conn *connURI.Conn, masterPort int, mirrors
 
 	defer db.Close()
 
-	return doUpgrade(db, stateDir, mirrors, targetRunner, useHbaHostnames)
+	return doUpgrade(streams, db, conn.TargetVersion(), stateDir, mirrors, targetRunner, useHbaHostnames, syncTimeout)
 }
 
-func doUpgrade(db *sql.DB, stateDir string, mirrors []greenplum.SegConfig, targetRunner greenplum.Runner, useHbaHostnames bool) (err error) {
+func doUpgrade(streams step.OutStreams, db *sql.DB, version semver.Version, stateDir string, mirrors []greenplum.SegConfig, targetRunner greenplum.Runner, useHbaHostnames bool, syncTimeout time.Duration) (err error) {
 	path := filepath.Join(stateDir, "add_mirrors_config")
 	// calling Close() on a file twice results in an error
 	// only call Close() in the defer if we haven't yet tried to close it.
@@ -145,5 +300,5 @@ func doUpgrade(db *sql.DB, stateDir string, mirrors []greenplum.SegConfig, targe
 		return err
 	}
 
-	return waitForFTS(db, defaultFTSTimeout)
+	return waitForMirrors(streams.Stdout(), db, version, segmentMirrors, syncTimeout)
 }
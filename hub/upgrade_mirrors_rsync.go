@@ -0,0 +1,363 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// The strategies used to upgrade the mirrors during finalize. The gpaddmirrors
+// strategy creates each mirror with a full base backup of its primary. The
+// rsync strategy has the agents copy the stopped, upgraded primaries to their
+// mirrors, which avoids streaming every primary through the replication
+// protocol.
+const (
+	GpaddmirrorsStrategy = "gpaddmirrors"
+	RsyncStrategy        = "rsync"
+)
+
+var MirrorUpgradeStrategies = []string{GpaddmirrorsStrategy, RsyncStrategy}
+
+const (
+	DefaultMirrorUpgradeJobs = 4
+	MaxMirrorUpgradeJobs     = 32
+)
+
+// ClusterRunner starts and stops a cluster.
+type ClusterRunner interface {
+	Start(cluster *greenplum.Cluster, streams step.OutStreams) error
+	Stop(cluster *greenplum.Cluster, streams step.OutStreams) error
+	IsMasterRunning(cluster *greenplum.Cluster, streams step.OutStreams) (bool, error)
+}
+
+type clusterRunner struct{}
+
+func (clusterRunner) Start(cluster *greenplum.Cluster, streams step.OutStreams) error {
+	return cluster.Start(streams)
+}
+
+func (clusterRunner) Stop(cluster *greenplum.Cluster, streams step.OutStreams) error {
+	return cluster.Stop(streams)
+}
+
+func (clusterRunner) IsMasterRunning(cluster *greenplum.Cluster, streams step.OutStreams) (bool, error) {
+	return cluster.IsMasterRunning(streams)
+}
+
+var runner ClusterRunner = clusterRunner{}
+
+// UpgradeMirrorsUsingRsync registers the mirrors in gp_segment_configuration
+// and sets up replication on their primaries as gpaddmirrors does. It then
+// stops the target cluster, and copies each primary to its mirror with at most
+// jobs copies running concurrently on each mirror host. The target cluster is
+// then restarted and the mirrors are given up to syncTimeout to come up in sync.
+//
+// The target cluster is restarted when copying fails, and is started first
+// when a previous attempt left it stopped, such that the substep can be rerun.
+func UpgradeMirrorsUsingRsync(streams step.OutStreams, conn *connURI.Conn, agentConns []*Connection, target *greenplum.Cluster, mirrors []greenplum.SegConfig, useHbaHostnames bool, jobs int, syncTimeout time.Duration) (err error) {
+	running, err := runner.IsMasterRunning(target, streams)
+	if err != nil {
+		return err
+	}
+
+	if !running {
+		if err := runner.Start(target, streams); err != nil {
+			return xerrors.Errorf("starting target cluster: %w", err)
+		}
+	}
+
+	dbids, err := registerTargetMirrors(conn, target, mirrors)
+	if err != nil {
+		return err
+	}
+
+	user := conn.User()
+	if user == "" {
+		currentUser, err := utils.System.CurrentUser()
+		if err != nil {
+			return err
+		}
+
+		user = currentUser.Username
+	}
+
+	if err := addReplicationEntries(agentConns, target, mirrors, user, useHbaHostnames); err != nil {
+		return err
+	}
+
+	if err := createReplicationSlots(conn, target, mirrors); err != nil {
+		return err
+	}
+
+	if err := runner.Stop(target, streams); err != nil {
+		return xerrors.Errorf("stopping target cluster: %w", err)
+	}
+
+	// Restart the target cluster when copying fails, as a rerun needs it
+	// running.
+	stopped := true
+	defer func() {
+		if !stopped {
+			return
+		}
+
+		if sErr := runner.Start(target, streams); sErr != nil {
+			err = errorlist.Append(err, xerrors.Errorf("starting target cluster: %w", sErr))
+		}
+	}()
+
+	if err := copyPrimariesToMirrors(streams, agentConns, target, mirrors, dbids, user, conn.SSLMode(), jobs); err != nil {
+		return err
+	}
+
+	stopped = false
+	if err := runner.Start(target, streams); err != nil {
+		return xerrors.Errorf("starting target cluster: %w", err)
+	}
+
+	options := []connURI.Option{
+		connURI.ToTarget(),
+		connURI.Port(target.MasterPort()),
+	}
+
+	db, err := utils.System.SqlOpen("pgx", conn.URI(options...))
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	return waitForMirrors(streams.Stdout(), db, conn.TargetVersion(), segmentMirrors, syncTimeout)
+}
+
+func registerTargetMirrors(conn *connURI.Conn, target *greenplum.Cluster, mirrors []greenplum.SegConfig) (_ map[int]int, err error) {
+	options := []connURI.Option{
+		connURI.ToTarget(),
+		connURI.Port(target.MasterPort()),
+		connURI.UtilityMode(),
+		connURI.AllowSystemTableMods(),
+	}
+
+	db, err := utils.System.SqlOpen("pgx", conn.URI(options...))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, xerrors.Errorf("closing connection to target master: %w", cErr))
+		}
+	}()
+
+	return registerMirrors(db, mirrors)
+}
+
+// registerMirrors adds the mirrors to gp_segment_configuration and returns the
+// dbid assigned to each mirror keyed by content ID. Mirrors registered by a
+// previous attempt are not added again.
+func registerMirrors(db *sql.DB, mirrors []greenplum.SegConfig) (map[int]int, error) {
+	dbids := make(map[int]int)
+
+	rows, err := db.Query("SELECT content, dbid FROM gp_segment_configuration WHERE role = 'm'")
+	if err != nil {
+		return nil, xerrors.Errorf("querying registered mirrors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var content, dbid int
+		if err := rows.Scan(&content, &dbid); err != nil {
+			return nil, xerrors.Errorf("scanning registered mirrors: %w", err)
+		}
+
+		dbids[content] = dbid
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating registered mirrors: %w", err)
+	}
+
+	for _, mirror := range mirrors {
+		if _, ok := dbids[mirror.ContentID]; ok {
+			continue
+		}
+
+		var dbid int
+		err := db.QueryRow("SELECT gp_add_segment_mirror($1::int2, $2, $3, $4, $5)",
+			mirror.ContentID, mirror.Hostname, mirror.Hostname, mirror.Port, mirror.DataDir).Scan(&dbid)
+		if err != nil {
+			return nil, xerrors.Errorf("registering mirror for content %d: %w", mirror.ContentID, err)
+		}
+
+		dbids[mirror.ContentID] = dbid
+	}
+
+	return dbids, nil
+}
+
+// addReplicationEntries adds the pg_hba.conf entries allowing each mirror host
+// to replicate from its primary. As the primaries are copied to the mirrors
+// the entries end up on both, such that replication continues after failover.
+func addReplicationEntries(agentConns []*Connection, target *greenplum.Cluster, mirrors []greenplum.SegConfig, user string, useHbaHostnames bool) error {
+	request := func(conn *Connection) error {
+		var entries []*idl.ReplicationEntry
+		for _, mirror := range mirrors {
+			primary := target.Primaries[mirror.ContentID]
+			if !primary.IsOnHost(conn.Hostname) {
+				continue
+			}
+
+			entries = append(entries, &idl.ReplicationEntry{
+				DataDir:    primary.DataDir,
+				MirrorHost: mirror.Hostname,
+			})
+		}
+
+		if len(entries) == 0 {
+			return nil
+		}
+
+		_, err := conn.AgentClient.AddReplicationEntries(context.Background(), &idl.AddReplicationEntriesRequest{
+			Entries:         entries,
+			User:            user,
+			UseHbaHostnames: useHbaHostnames,
+		})
+		if err != nil {
+			return xerrors.Errorf("add replication entries on host %s: %w", conn.Hostname, err)
+		}
+
+		return nil
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
+
+// createReplicationSlots creates the replication slot of each mirrored
+// primary, such that the primary retains the WAL its mirror has yet to
+// receive. Slots created by a previous attempt are kept.
+func createReplicationSlots(conn *connURI.Conn, target *greenplum.Cluster, mirrors []greenplum.SegConfig) error {
+	for _, mirror := range mirrors {
+		primary := target.Primaries[mirror.ContentID]
+
+		options := []connURI.Option{
+			connURI.ToTarget(),
+			connURI.Host(primary.Hostname),
+			connURI.Port(primary.Port),
+			connURI.UtilityMode(),
+		}
+
+		err := createReplicationSlot(conn.URI(options...))
+		if err != nil {
+			return xerrors.Errorf("creating replication slot for content %d on host %s: %w", primary.ContentID, primary.Hostname, err)
+		}
+	}
+
+	return nil
+}
+
+func createReplicationSlot(uri string) (err error) {
+	db, err := utils.System.SqlOpen("pgx", uri)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	_, err = db.Exec(`SELECT pg_create_physical_replication_slot($1)
+WHERE NOT EXISTS (SELECT 1 FROM pg_replication_slots WHERE slot_name = $1)`, upgrade.ReplicationSlot)
+	return err
+}
+
+func copyPrimariesToMirrors(streams step.OutStreams, agentConns []*Connection, target *greenplum.Cluster, mirrors []greenplum.SegConfig, dbids map[int]int, user string, sslMode string, jobs int) error {
+	// mu serializes the progress output from concurrent copies.
+	var mu sync.Mutex
+	upgraded := 0
+
+	upgradeMirror := func(conn *Connection, mirror greenplum.SegConfig) error {
+		primary := target.Primaries[mirror.ContentID]
+
+		mu.Lock()
+		fmt.Fprintf(streams.Stdout(), "Upgrading mirror for content %d on host %s...\n", mirror.ContentID, mirror.Hostname)
+		mu.Unlock()
+
+		_, err := conn.AgentClient.UpgradeMirror(context.Background(), &idl.UpgradeMirrorRequest{
+			Content:        int32(mirror.ContentID),
+			PrimaryHost:    primary.Hostname,
+			PrimaryDataDir: primary.DataDir,
+			PrimaryPort:    int32(primary.Port),
+			MirrorDataDir:  mirror.DataDir,
+			MirrorPort:     int32(mirror.Port),
+			MirrorDbid:     int32(dbids[mirror.ContentID]),
+			TargetVersion:  target.Version.SemVer.String(),
+			User:           user,
+			SslMode:        sslMode,
+		})
+		if err != nil {
+			return xerrors.Errorf("upgrade mirror for content %d on host %s: %w", mirror.ContentID, mirror.Hostname, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		upgraded++
+		fmt.Fprintf(streams.Stdout(), "Upgraded mirror for content %d (%d of %d)\n", mirror.ContentID, upgraded, len(mirrors))
+
+		return nil
+	}
+
+	request := func(conn *Connection) error {
+		var hostMirrors []greenplum.SegConfig
+		for _, mirror := range mirrors {
+			if mirror.IsOnHost(conn.Hostname) {
+				hostMirrors = append(hostMirrors, mirror)
+			}
+		}
+
+		work := make(chan greenplum.SegConfig, len(hostMirrors))
+		for _, mirror := range hostMirrors {
+			work <- mirror
+		}
+		close(work)
+
+		errs := make(chan error, len(hostMirrors))
+
+		var wg sync.WaitGroup
+		for i := 0; i < jobs && i < len(hostMirrors); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for mirror := range work {
+					errs <- upgradeMirror(conn, mirror)
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(errs)
+
+		var err error
+		for e := range errs {
+			err = errorlist.Append(err, e)
+		}
+
+		return err
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
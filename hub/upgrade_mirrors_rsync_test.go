@@ -0,0 +1,466 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func TestRegisterMirrors(t *testing.T) {
+	mirrors := []greenplum.SegConfig{
+		{ContentID: 0, Hostname: "sdw2", Port: 25435, DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		{ContentID: 1, Hostname: "sdw1", Port: 25436, DataDir: "/data/dbfast_mirror2/seg2", Role: greenplum.MirrorRole},
+	}
+
+	t.Run("adds the mirrors that are not yet registered", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT content, dbid FROM gp_segment_configuration WHERE role = 'm'`).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "dbid"}).AddRow(0, 5))
+		mock.ExpectQuery(`SELECT gp_add_segment_mirror\(\$1::int2, \$2, \$3, \$4, \$5\)`).
+			WithArgs(1, "sdw1", "sdw1", 25436, "/data/dbfast_mirror2/seg2").
+			WillReturnRows(sqlmock.NewRows([]string{"gp_add_segment_mirror"}).AddRow(6))
+
+		dbids, err := registerMirrors(db, mirrors)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		expected := map[int]int{0: 5, 1: 6}
+		if !reflect.DeepEqual(dbids, expected) {
+			t.Errorf("got dbids %v want %v", dbids, expected)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("%v", err)
+		}
+	})
+
+	t.Run("errors when registering a mirror fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		expected := errors.New("permission denied")
+		mock.ExpectQuery(`SELECT content, dbid FROM gp_segment_configuration WHERE role = 'm'`).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "dbid"}))
+		mock.ExpectQuery(`SELECT gp_add_segment_mirror`).WillReturnError(expected)
+
+		_, err = registerMirrors(db, mirrors)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestAddReplicationEntries(t *testing.T) {
+	target := MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Hostname: "mdw", Port: 5432, DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Hostname: "sdw1", Port: 25432, DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 1, DbID: 3, Hostname: "sdw2", Port: 25433, DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+	})
+
+	mirrors := []greenplum.SegConfig{
+		{ContentID: 0, Hostname: "sdw2", Port: 25435, DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		{ContentID: 1, Hostname: "sdw1", Port: 25436, DataDir: "/data/dbfast_mirror2/seg2", Role: greenplum.MirrorRole},
+	}
+
+	t.Run("adds the entries of each mirror host to its primaries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// The master has no mirror and so is not requested.
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().AddReplicationEntries(gomock.Any(), &idl.AddReplicationEntriesRequest{
+			Entries:         []*idl.ReplicationEntry{{DataDir: "/data/dbfast1/seg1", MirrorHost: "sdw2"}},
+			User:            "gpadmin",
+			UseHbaHostnames: true,
+		}).Return(&idl.AddReplicationEntriesReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().AddReplicationEntries(gomock.Any(), &idl.AddReplicationEntriesRequest{
+			Entries:         []*idl.ReplicationEntry{{DataDir: "/data/dbfast2/seg2", MirrorHost: "sdw1"}},
+			User:            "gpadmin",
+			UseHbaHostnames: true,
+		}).Return(&idl.AddReplicationEntriesReply{}, nil)
+
+		agentConns := []*Connection{
+			{nil, mdw, "mdw", nil},
+			{nil, sdw1, "sdw1", nil},
+			{nil, sdw2, "sdw2", nil},
+		}
+
+		err := addReplicationEntries(agentConns, target, mirrors, "gpadmin", true)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("errors when adding the entries fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().AddReplicationEntries(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*Connection{{nil, sdw1, "sdw1", nil}}
+
+		err := addReplicationEntries(agentConns, target, mirrors, "gpadmin", false)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestCreateReplicationSlots(t *testing.T) {
+	conn := connURI.Connection(semver.MustParse("6.0.0"), semver.MustParse("7.0.0"))
+
+	target := MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Hostname: "mdw", Port: 5432, DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Hostname: "sdw1", Port: 25432, DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+	})
+
+	mirrors := []greenplum.SegConfig{
+		{ContentID: 0, Hostname: "sdw2", Port: 25435, DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+	}
+
+	mockDB := func(t *testing.T) (sqlmock.Sqlmock, func()) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+
+		utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+			expected := conn.URI(connURI.ToTarget(), connURI.Host("sdw1"), connURI.Port(25432), connURI.UtilityMode())
+			if dataSourceName != expected {
+				t.Errorf("got: %q want: %q", dataSourceName, expected)
+			}
+
+			return db, nil
+		}
+
+		return mock, func() {
+			utils.System = utils.InitializeSystemFunctions()
+			testutils.FinishMock(mock, t)
+		}
+	}
+
+	t.Run("creates the slot on the primary of each mirror", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		mock.ExpectExec(`SELECT pg_create_physical_replication_slot\(\$1\)`).
+			WithArgs(upgrade.ReplicationSlot).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectClose()
+
+		err := createReplicationSlots(conn, target, mirrors)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("errors when creating a slot fails", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expected := errors.New("all replication slots are in use")
+		mock.ExpectExec(`SELECT pg_create_physical_replication_slot`).WillReturnError(expected)
+		mock.ExpectClose()
+
+		err := createReplicationSlots(conn, target, mirrors)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestCopyPrimariesToMirrors(t *testing.T) {
+	target := MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Hostname: "mdw", Port: 5432, DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Hostname: "sdw1", Port: 25432, DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 1, DbID: 3, Hostname: "sdw2", Port: 25433, DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+		{ContentID: 2, DbID: 4, Hostname: "sdw2", Port: 25434, DataDir: "/data/dbfast3/seg3", Role: greenplum.PrimaryRole},
+	})
+	target.Version = dbconn.NewVersion("7.0.0")
+
+	mirrors := []greenplum.SegConfig{
+		{ContentID: 0, Hostname: "sdw2", Port: 25435, DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		{ContentID: 1, Hostname: "sdw1", Port: 25436, DataDir: "/data/dbfast_mirror2/seg2", Role: greenplum.MirrorRole},
+		{ContentID: 2, Hostname: "sdw1", Port: 25437, DataDir: "/data/dbfast_mirror3/seg3", Role: greenplum.MirrorRole},
+	}
+
+	dbids := map[int]int{0: 5, 1: 6, 2: 7}
+
+	t.Run("copies each primary to its mirror and reports progress", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().UpgradeMirror(gomock.Any(), &idl.UpgradeMirrorRequest{
+			Content:        1,
+			PrimaryHost:    "sdw2",
+			PrimaryDataDir: "/data/dbfast2/seg2",
+			PrimaryPort:    25433,
+			MirrorDataDir:  "/data/dbfast_mirror2/seg2",
+			MirrorPort:     25436,
+			MirrorDbid:     6,
+			TargetVersion:  "7.0.0",
+			User:           "gpadmin",
+			SslMode:        "require",
+		}).Return(&idl.UpgradeMirrorReply{}, nil)
+		sdw1.EXPECT().UpgradeMirror(gomock.Any(), &idl.UpgradeMirrorRequest{
+			Content:        2,
+			PrimaryHost:    "sdw2",
+			PrimaryDataDir: "/data/dbfast3/seg3",
+			PrimaryPort:    25434,
+			MirrorDataDir:  "/data/dbfast_mirror3/seg3",
+			MirrorPort:     25437,
+			MirrorDbid:     7,
+			TargetVersion:  "7.0.0",
+			User:           "gpadmin",
+			SslMode:        "require",
+		}).Return(&idl.UpgradeMirrorReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().UpgradeMirror(gomock.Any(), &idl.UpgradeMirrorRequest{
+			Content:        0,
+			PrimaryHost:    "sdw1",
+			PrimaryDataDir: "/data/dbfast1/seg1",
+			PrimaryPort:    25432,
+			MirrorDataDir:  "/data/dbfast_mirror1/seg1",
+			MirrorPort:     25435,
+			MirrorDbid:     5,
+			TargetVersion:  "7.0.0",
+			User:           "gpadmin",
+			SslMode:        "require",
+		}).Return(&idl.UpgradeMirrorReply{}, nil)
+
+		agentConns := []*Connection{
+			{nil, sdw1, "sdw1", nil},
+			{nil, sdw2, "sdw2", nil},
+		}
+
+		streams := &step.BufferedStreams{}
+		err := copyPrimariesToMirrors(streams, agentConns, target, mirrors, dbids, "gpadmin", "require", 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		var started []string
+		for _, line := range strings.Split(strings.TrimSpace(streams.StdoutBuf.String()), "\n") {
+			if strings.HasPrefix(line, "Upgrading mirror") {
+				started = append(started, line)
+			}
+		}
+		sort.Strings(started)
+
+		expected := []string{
+			"Upgrading mirror for content 0 on host sdw2...",
+			"Upgrading mirror for content 1 on host sdw1...",
+			"Upgrading mirror for content 2 on host sdw1...",
+		}
+		if !reflect.DeepEqual(started, expected) {
+			t.Errorf("got progress %q want %q", started, expected)
+		}
+
+		if !strings.Contains(streams.StdoutBuf.String(), "(3 of 3)") {
+			t.Errorf("expected output %q to report the last mirror as 3 of 3", streams.StdoutBuf.String())
+		}
+	})
+
+	t.Run("errors when upgrading a mirror fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().UpgradeMirror(gomock.Any(), gomock.Any()).Return(nil, expected).Times(2)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().UpgradeMirror(gomock.Any(), gomock.Any()).Return(&idl.UpgradeMirrorReply{}, nil)
+
+		agentConns := []*Connection{
+			{nil, sdw1, "sdw1", nil},
+			{nil, sdw2, "sdw2", nil},
+		}
+
+		err := copyPrimariesToMirrors(step.DevNullStream, agentConns, target, mirrors, dbids, "gpadmin", "require", 2)
+
+		var errs errorlist.Errors
+		if !errors.As(err, &errs) {
+			t.Fatalf("got error %#v, want type %T", err, errs)
+		}
+
+		if len(errs) != 2 {
+			t.Errorf("got %d errors, want %d", len(errs), 2)
+		}
+
+		for _, err := range errs {
+			if !errors.Is(err, expected) {
+				t.Errorf("got error %#v want %#v", err, expected)
+			}
+		}
+	})
+}
+
+// fakeRunner records the cluster operations instead of running them.
+type fakeRunner struct {
+	running bool
+	calls   []string
+}
+
+func (f *fakeRunner) Start(*greenplum.Cluster, step.OutStreams) error {
+	f.calls = append(f.calls, "start")
+	return nil
+}
+
+func (f *fakeRunner) Stop(*greenplum.Cluster, step.OutStreams) error {
+	f.calls = append(f.calls, "stop")
+	return nil
+}
+
+func (f *fakeRunner) IsMasterRunning(*greenplum.Cluster, step.OutStreams) (bool, error) {
+	f.calls = append(f.calls, "is master running")
+	return f.running, nil
+}
+
+func TestUpgradeMirrorsUsingRsync(t *testing.T) {
+	conn := connURI.Connection(semver.MustParse("6.0.0"), semver.MustParse("7.0.0"))
+
+	target := MustCreateCluster(t, []greenplum.SegConfig{
+		{ContentID: -1, DbID: 1, Hostname: "mdw", Port: 5432, DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Hostname: "sdw1", Port: 25432, DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+	})
+	target.Version = dbconn.NewVersion("7.0.0")
+
+	mirrors := []greenplum.SegConfig{
+		{ContentID: 0, Hostname: "sdw2", Port: 25435, DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+	}
+
+	// mockDBs returns a mock for each connection opened in order.
+	mockDBs := func(t *testing.T, count int) ([]sqlmock.Sqlmock, func()) {
+		var dbs []*sql.DB
+		var mocks []sqlmock.Sqlmock
+		for i := 0; i < count; i++ {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("couldn't create sqlmock: %v", err)
+			}
+
+			dbs = append(dbs, db)
+			mocks = append(mocks, mock)
+		}
+
+		opened := 0
+		utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+			if opened == len(dbs) {
+				t.Fatalf("unexpected connection %q", dataSourceName)
+			}
+
+			opened++
+			return dbs[opened-1], nil
+		}
+
+		return mocks, func() {
+			utils.System = utils.InitializeSystemFunctions()
+			for _, mock := range mocks {
+				testutils.FinishMock(mock, t)
+			}
+		}
+	}
+
+	t.Run("restarts the target cluster when copying a primary fails", func(t *testing.T) {
+		mocks, cleanup := mockDBs(t, 2)
+		defer cleanup()
+
+		mocks[0].ExpectQuery(`SELECT content, dbid FROM gp_segment_configuration WHERE role = 'm'`).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "dbid"}).AddRow(0, 5))
+		mocks[0].ExpectClose()
+
+		mocks[1].ExpectExec(`SELECT pg_create_physical_replication_slot`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mocks[1].ExpectClose()
+
+		fake := &fakeRunner{running: true}
+		runner = fake
+		defer func() { runner = clusterRunner{} }()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("rsync failed")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().AddReplicationEntries(gomock.Any(), gomock.Any()).Return(&idl.AddReplicationEntriesReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().UpgradeMirror(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*Connection{
+			{nil, sdw1, "sdw1", nil},
+			{nil, sdw2, "sdw2", nil},
+		}
+
+		err := UpgradeMirrorsUsingRsync(step.DevNullStream, conn, agentConns, target, mirrors, false, 1, 0)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+
+		calls := []string{"is master running", "stop", "start"}
+		if !reflect.DeepEqual(fake.calls, calls) {
+			t.Errorf("got cluster calls %q want %q", fake.calls, calls)
+		}
+	})
+
+	t.Run("starts a stopped target cluster before registering the mirrors", func(t *testing.T) {
+		mocks, cleanup := mockDBs(t, 1)
+		defer cleanup()
+
+		expected := errors.New("permission denied")
+		mocks[0].ExpectQuery(`SELECT content, dbid FROM gp_segment_configuration WHERE role = 'm'`).WillReturnError(expected)
+		mocks[0].ExpectClose()
+
+		fake := &fakeRunner{running: false}
+		runner = fake
+		defer func() { runner = clusterRunner{} }()
+
+		err := UpgradeMirrorsUsingRsync(step.DevNullStream, conn, nil, target, mirrors, false, 1, 0)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+
+		calls := []string{"is master running", "start"}
+		if !reflect.DeepEqual(fake.calls, calls) {
+			t.Errorf("got cluster calls %q want %q", fake.calls, calls)
+		}
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/greenplum-db/gpupgrade/db/connURI"
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
@@ -226,9 +228,9 @@ func TestDoUpgrade(t *testing.T) {
 		}}
 
 		expectFtsProbe(mock)
-		expectMirrorsAndReturn(mock, "t")
+		expectMirrorStatuses(mock, segmentMirrors, synchronizedMirror)
 
-		err = doUpgrade(db, stateDir, mirrors, &stub, false)
+		err = doUpgrade(step.DevNullStream, db, semver.MustParse("6.0.0"), stateDir, mirrors, &stub, false, DefaultMirrorSyncTimeout)
 
 		if err != nil {
 			t.Errorf("got unexpected error from UpgradeMirrors %#v", err)
@@ -257,7 +259,7 @@ func TestDoUpgrade(t *testing.T) {
 			return nil, expectedError
 		}
 
-		err = doUpgrade(db, "", []greenplum.SegConfig{}, &greenplumStub{}, false)
+		err = doUpgrade(step.DevNullStream, db, semver.MustParse("6.0.0"), "", []greenplum.SegConfig{}, &greenplumStub{}, false, DefaultMirrorSyncTimeout)
 		if !errors.Is(err, expectedError) {
 			t.Errorf("returned error %#v want %#v", err, expectedError)
 		}
@@ -280,7 +282,7 @@ func TestDoUpgrade(t *testing.T) {
 			return nil
 		}
 
-		err = doUpgrade(db, "/state/dir", mirrors, stub, false)
+		err = doUpgrade(step.DevNullStream, db, semver.MustParse("6.0.0"), "/state/dir", mirrors, stub, false, DefaultMirrorSyncTimeout)
 
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
@@ -309,7 +311,7 @@ func TestDoUpgrade(t *testing.T) {
 			return expected
 		}}
 
-		err = doUpgrade(db, "/state/dir", []greenplum.SegConfig{}, stub, false)
+		err = doUpgrade(step.DevNullStream, db, semver.MustParse("6.0.0"), "/state/dir", []greenplum.SegConfig{}, stub, false, DefaultMirrorSyncTimeout)
 		if !errors.Is(err, expected) {
 			t.Errorf("returned error %#v want %#v", err, expected)
 		}
@@ -342,13 +344,12 @@ func TestUpgradeMirrors(t *testing.T) {
 		}
 
 		expectFtsProbe(mock)
-		expectMirrorsAndReturn(mock, "t")
+		expectMirrorStatuses(mock, segmentMirrors, synchronizedMirror)
 
 		utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
 			options := []connURI.Option{
 				connURI.ToTarget(),
 				connURI.Port(123),
-				connURI.UtilityMode(),
 			}
 
 			expected := conn.URI(options...)
@@ -359,7 +360,7 @@ func TestUpgradeMirrors(t *testing.T) {
 			return db, nil
 		}
 
-		err = UpgradeMirrors("", conn, 123, []greenplum.SegConfig{}, stub, false)
+		err = UpgradeMirrors(step.DevNullStream, "", conn, 123, []greenplum.SegConfig{}, stub, false, DefaultMirrorSyncTimeout)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -371,14 +372,14 @@ func TestUpgradeMirrors(t *testing.T) {
 			return nil, expected
 		}
 
-		err := UpgradeMirrors("", conn, 123, []greenplum.SegConfig{}, stub, false)
+		err := UpgradeMirrors(step.DevNullStream, "", conn, 123, []greenplum.SegConfig{}, stub, false, DefaultMirrorSyncTimeout)
 		if !errors.Is(err, expected) {
 			t.Errorf("got: %#v want: %#v", err, expected)
 		}
 	})
 }
 
-func TestWaitForFTS(t *testing.T) {
+func TestWaitForMirrors(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("couldn't create sqlmock: %v", err)
@@ -387,47 +388,212 @@ func TestWaitForFTS(t *testing.T) {
 
 	t.Run("succeeds", func(t *testing.T) {
 		expectFtsProbe(mock)
-		expectMirrorsAndReturn(mock, "t")
+		expectMirrorStatuses(mock, segmentMirrors, synchronizedMirror)
 
-		err = waitForFTS(db, defaultFTSTimeout)
+		err = waitForMirrors(ioutil.Discard, db, semver.MustParse("6.0.0"), segmentMirrors, DefaultMirrorSyncTimeout)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
 	})
 
-	t.Run("waits for mirrors to come up", func(t *testing.T) {
+	t.Run("waits for mirrors to synchronize and reports their progress", func(t *testing.T) {
 		expectFtsProbe(mock)
-		expectMirrorsAndReturn(mock, "f")
+		expectMirrorStatuses(mock, segmentMirrors,
+			MirrorStatus{Content: 0, Hostname: "sdw2", Status: "u", Mode: "s", Replicating: true, State: "streaming", SyncState: "sync"},
+			MirrorStatus{Content: 1, Hostname: "sdw1", Status: "u", Mode: "c", Replicating: true, State: "catchup", SyncState: "async", LagBytes: 4096})
 		expectFtsProbe(mock)
-		expectMirrorsAndReturn(mock, "t")
+		expectMirrorStatuses(mock, segmentMirrors,
+			MirrorStatus{Content: 0, Hostname: "sdw2", Status: "u", Mode: "s", Replicating: true, State: "streaming", SyncState: "sync"},
+			MirrorStatus{Content: 1, Hostname: "sdw1", Status: "u", Mode: "s", Replicating: true, State: "streaming", SyncState: "sync"})
 
-		err = waitForFTS(db, defaultFTSTimeout)
+		var out bytes.Buffer
+		err = waitForMirrors(&out, db, semver.MustParse("6.0.0"), segmentMirrors, DefaultMirrorSyncTimeout)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		expected := `
+CONTENT  HOST  STATUS  MODE  STATE      SYNC STATE  REPLAY LAG
+0        sdw2  u       s     streaming  sync        0 bytes
+1        sdw1  u       c     catchup    async       4096 bytes
+
+CONTENT  HOST  STATUS  MODE  STATE      SYNC STATE  REPLAY LAG
+0        sdw2  u       s     streaming  sync        0 bytes
+1        sdw1  u       s     streaming  sync        0 bytes
+`
+		if out.String() != expected {
+			t.Errorf("got output %q want %q", out.String(), expected)
+		}
+	})
+
+	t.Run("only reports progress when the mirror status changes", func(t *testing.T) {
+		notStreaming := MirrorStatus{Content: 0, Hostname: "sdw2", Status: "d", Mode: "n"}
+
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, segmentMirrors, notStreaming)
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, segmentMirrors, notStreaming)
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, segmentMirrors, synchronizedMirror)
+
+		var out bytes.Buffer
+		err = waitForMirrors(&out, db, semver.MustParse("6.0.0"), segmentMirrors, DefaultMirrorSyncTimeout)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		expected := `
+CONTENT  HOST  STATUS  MODE  STATE  SYNC STATE  REPLAY LAG
+0        sdw2  d       n     -      -           -
+
+CONTENT  HOST  STATUS  MODE  STATE      SYNC STATE  REPLAY LAG
+0        sdw2  u       s     streaming  sync        0 bytes
+`
+		if out.String() != expected {
+			t.Errorf("got output %q want %q", out.String(), expected)
+		}
+	})
+
+	t.Run("times out naming the mirrors that are not synchronized", func(t *testing.T) {
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, segmentMirrors,
+			synchronizedMirror,
+			MirrorStatus{Content: 1, Hostname: "sdw1", Status: "d", Mode: "n"},
+			MirrorStatus{Content: 2, Hostname: "sdw1", Status: "u", Mode: "c", Replicating: true, State: "catchup", SyncState: "async", LagBytes: 512})
+
+		err = waitForMirrors(ioutil.Discard, db, semver.MustParse("6.0.0"), segmentMirrors, -1*time.Second)
+		if !errors.Is(err, ErrMirrorsNotSynchronized) {
+			t.Fatalf("got error %#v want %#v", err, ErrMirrorsNotSynchronized)
+		}
+
+		expected := "-1s timeout exceeded waiting for mirrors to synchronize: " +
+			"content 1 on sdw1 (status d, mode n), content 2 on sdw1 (status u, mode c)"
+		if err.Error() != expected {
+			t.Errorf("got error %q want %q", err.Error(), expected)
+		}
+	})
+
+	t.Run("waits for the standby to stream in sync regardless of its status and mode", func(t *testing.T) {
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, standbyMaster,
+			MirrorStatus{Content: -1, Hostname: "smdw", Status: "u", Mode: "s"})
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, standbyMaster,
+			MirrorStatus{Content: -1, Hostname: "smdw", Status: "u", Mode: "s", Replicating: true, State: "catchup", SyncState: "async", LagBytes: 8192})
+		expectFtsProbe(mock)
+		expectMirrorStatuses(mock, standbyMaster,
+			MirrorStatus{Content: -1, Hostname: "smdw", Status: "u", Mode: "n", Replicating: true, State: "streaming", SyncState: "sync"})
+
+		var out bytes.Buffer
+		err = waitForMirrors(&out, db, semver.MustParse("6.0.0"), standbyMaster, DefaultMirrorSyncTimeout)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
+
+		expected := `
+CONTENT  HOST  STATUS  MODE  STATE  SYNC STATE  REPLAY LAG
+-1       smdw  u       s     -      -           -
+
+CONTENT  HOST  STATUS  MODE  STATE    SYNC STATE  REPLAY LAG
+-1       smdw  u       s     catchup  async       8192 bytes
+
+CONTENT  HOST  STATUS  MODE  STATE      SYNC STATE  REPLAY LAG
+-1       smdw  u       n     streaming  sync        0 bytes
+`
+		if out.String() != expected {
+			t.Errorf("got output %q want %q", out.String(), expected)
+		}
 	})
 
-	t.Run("times out if the mirrors never come up", func(t *testing.T) {
+	t.Run("times out naming the standby when it is not streaming", func(t *testing.T) {
 		expectFtsProbe(mock)
-		expectMirrorsAndReturn(mock, "f")
+		expectMirrorStatuses(mock, allMirrors,
+			MirrorStatus{Content: -1, Hostname: "smdw", Status: "u", Mode: "s"},
+			synchronizedMirror)
 
-		err = waitForFTS(db, -1*time.Second)
+		err = waitForMirrors(ioutil.Discard, db, semver.MustParse("6.0.0"), allMirrors, -1*time.Second)
+		if !errors.Is(err, ErrMirrorsNotSynchronized) {
+			t.Fatalf("got error %#v want %#v", err, ErrMirrorsNotSynchronized)
+		}
 
-		expected := "-1s timeout exceeded waiting for mirrors to come up"
+		expected := "-1s timeout exceeded waiting for mirrors to synchronize: standby master on smdw (not connected)"
 		if err.Error() != expected {
-			t.Errorf("got: %#v want %s", err, expected)
+			t.Errorf("got error %q want %q", err.Error(), expected)
 		}
 	})
+
+	t.Run("returns errors querying the mirror status", func(t *testing.T) {
+		expected := errors.New("permission denied")
+
+		expectFtsProbe(mock)
+		mock.ExpectQuery(`FROM gp_segment_configuration c`).WillReturnError(expected)
+
+		err = waitForMirrors(ioutil.Discard, db, semver.MustParse("6.0.0"), segmentMirrors, DefaultMirrorSyncTimeout)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestMirrorStatusQuery(t *testing.T) {
+	cases := []struct {
+		version  string
+		expected string
+	}{
+		{"5.28.0", "pg_xlog_location_diff(r.sent_location, r.replay_location)"},
+		{"6.15.0", "pg_xlog_location_diff(r.sent_location, r.replay_location)"},
+		{"7.0.0", "pg_wal_lsn_diff(r.sent_lsn, r.replay_lsn)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.version, func(t *testing.T) {
+			query := mirrorStatusQuery(semver.MustParse(c.version), allMirrors)
+			if !strings.Contains(query, c.expected) {
+				t.Errorf("expected query %q to contain %q", query, c.expected)
+			}
+		})
+	}
+}
+
+func TestMirrorSelection(t *testing.T) {
+	cases := []struct {
+		name      string
+		selection mirrorSelection
+		expected  string
+	}{
+		{"segment mirrors", segmentMirrors, "WHERE c.role = 'm' AND c.content <> -1"},
+		{"standby master", standbyMaster, "WHERE c.role = 'm' AND c.content = -1"},
+		{"all mirrors", allMirrors, "WHERE c.role = 'm' \n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			query := mirrorStatusQuery(semver.MustParse("6.15.0"), c.selection)
+			if !strings.Contains(query, c.expected) {
+				t.Errorf("expected query %q to contain %q", query, c.expected)
+			}
+		})
+	}
 }
 
+var synchronizedMirror = MirrorStatus{Content: 0, Hostname: "sdw2", Status: "u", Mode: "s", Replicating: true, State: "streaming", SyncState: "sync"}
+
 func expectFtsProbe(mock sqlmock.Sqlmock) {
 	mock.ExpectQuery(`SELECT gp_request_fts_probe_scan\(\);`).
 		WillReturnRows(sqlmock.NewRows([]string{"gp_request_fts_probe_scan"}).AddRow("t"))
 }
 
-func expectMirrorsAndReturn(mock sqlmock.Sqlmock, up string) {
-	mock.ExpectQuery(`SELECT every\(status = 'u' AND mode = 's'\)
-							FROM gp_segment_configuration
-							WHERE role = 'm'`).
-		WillReturnRows(sqlmock.NewRows([]string{"every"}).AddRow(up))
+func expectMirrorStatuses(mock sqlmock.Sqlmock, selection mirrorSelection, statuses ...MirrorStatus) {
+	rows := sqlmock.NewRows([]string{"content", "hostname", "status", "mode", "replicating", "state", "sync_state", "lag"})
+	for _, m := range statuses {
+		rows.AddRow(m.Content, m.Hostname, m.Status, m.Mode, m.Replicating, m.State, m.SyncState, m.LagBytes)
+	}
+
+	mock.ExpectQuery(`SELECT c.content, c.hostname, c.status, c.mode,.*
+		FROM gp_segment_configuration c
+			LEFT JOIN gp_stat_replication r
+				ON r.gp_segment_id = c.content AND r.application_name = 'gp_walreceiver'
+		WHERE c.role = 'm' ` + regexp.QuoteMeta(selection.condition()) + `
+		ORDER BY c.content`).
+		WillReturnRows(rows)
 }
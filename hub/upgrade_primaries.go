@@ -79,7 +79,9 @@ func (s *Server) GetDataDirPairs() (map[string][]*idl.DataDirPair, error) {
 		}
 		sourceSeg := s.Source.Primaries[contentID]
 		targetSeg := s.Target.Primaries[contentID]
-		if sourceSeg.Hostname != targetSeg.Hostname {
+		// The source primaries of mapped hosts are copied to the same data
+		// directories on their target hosts.
+		if s.HostMapping.TargetHost(sourceSeg.Hostname) != targetSeg.Hostname {
 			return nil, newInvalidClusterError(
 				"hostnames do not match between source and target cluster with content ID %d. "+
 					"Found source cluster hostname: '%s', and target cluster hostname: '%s'",
@@ -93,10 +95,10 @@ func (s *Server) GetDataDirPairs() (map[string][]*idl.DataDirPair, error) {
 			TargetPort:    int32(targetSeg.Port),
 			Content:       int32(contentID),
 			DBID:          int32(sourceSeg.DbID),
-			Tablespaces:   getProtoTablespaceMap(s.Tablespaces, targetSeg.DbID),
+			Tablespaces:   getProtoTablespaceMap(s.TargetTablespaces(), targetSeg.DbID),
 		}
 
-		dataDirPairMap[sourceSeg.Hostname] = append(dataDirPairMap[sourceSeg.Hostname], dataPair)
+		dataDirPairMap[targetSeg.Hostname] = append(dataDirPairMap[targetSeg.Hostname], dataPair)
 	}
 
 	return dataDirPairMap, nil
@@ -204,6 +204,86 @@ func TestUpgradePrimaries(t *testing.T) {
 }
 
 func TestGetDataDirPairs(t *testing.T) {
+	t.Run("uses the relocated tablespace locations", func(t *testing.T) {
+		source := hub.MustCreateCluster(t, []greenplum.SegConfig{
+			{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+			{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		})
+
+		target := hub.MustCreateCluster(t, []greenplum.SegConfig{
+			{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg.ID.-1", Role: greenplum.PrimaryRole},
+			{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.ID.1", Role: greenplum.PrimaryRole},
+		})
+
+		conf := &hub.Config{
+			Source: source,
+			Target: target,
+			Tablespaces: greenplum.Tablespaces{
+				2: {
+					1663:  {Location: "/data/dbfast1/seg1", UserDefined: 0},
+					16384: {Location: "/data/tablespaces/16384", UserDefined: 1},
+				},
+			},
+			TablespaceRelocations: greenplum.TablespaceRelocations{
+				{Host: "sdw1", OldPrefix: "/data/tablespaces", NewPrefix: "/mnt/tablespaces"},
+			},
+		}
+		server := hub.New(conf, nil, "")
+
+		pairs, err := server.GetDataDirPairs()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		tablespaces := pairs["sdw1"][0].Tablespaces
+		if tablespaces[16384].Location != "/mnt/tablespaces/16384" {
+			t.Errorf("got location %q want %q", tablespaces[16384].Location, "/mnt/tablespaces/16384")
+		}
+
+		if tablespaces[1663].Location != "/data/dbfast1/seg1" {
+			t.Errorf("got location %q want %q", tablespaces[1663].Location, "/data/dbfast1/seg1")
+		}
+	})
+
+	t.Run("upgrades the primaries of mapped hosts on their target hosts", func(t *testing.T) {
+		source := hub.MustCreateCluster(t, []greenplum.SegConfig{
+			{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+			{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+			{ContentID: 1, DbID: 3, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+		})
+
+		target := hub.MustCreateCluster(t, []greenplum.SegConfig{
+			{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg.ID.-1", Role: greenplum.PrimaryRole},
+			{ContentID: 0, DbID: 2, Hostname: "new-sdw1", DataDir: "/data/dbfast1/seg.ID.1", Role: greenplum.PrimaryRole},
+			{ContentID: 1, DbID: 3, Hostname: "sdw2", DataDir: "/data/dbfast2/seg.ID.2", Role: greenplum.PrimaryRole},
+		})
+
+		conf := &hub.Config{
+			Source:      source,
+			Target:      target,
+			HostMapping: greenplum.HostMapping{"sdw1": "new-sdw1"},
+		}
+		server := hub.New(conf, nil, "")
+
+		pairs, err := server.GetDataDirPairs()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if len(pairs["sdw1"]) != 0 {
+			t.Errorf("got pairs %v for the source host, want none", pairs["sdw1"])
+		}
+
+		mapped := pairs["new-sdw1"]
+		if len(mapped) != 1 || mapped[0].SourceDataDir != "/data/dbfast1/seg1" || mapped[0].TargetDataDir != "/data/dbfast1/seg.ID.1" {
+			t.Errorf("got pairs %v for the target host", mapped)
+		}
+
+		if len(pairs["sdw2"]) != 1 {
+			t.Errorf("got pairs %v for the unmapped host, want one", pairs["sdw2"])
+		}
+	})
+
 	t.Run("errors if source and target clusters have different number of segments", func(t *testing.T) {
 		source := hub.MustCreateCluster(t, []greenplum.SegConfig{
 			{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
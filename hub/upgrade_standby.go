@@ -4,14 +4,24 @@
 package hub
 
 import (
+	"database/sql"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
 
+	"github.com/greenplum-db/gpupgrade/db/connURI"
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
 )
 
+// DefaultStandbySyncTimeout is how long finalize waits for the upgraded
+// standby to synchronize with the master.
+const DefaultStandbySyncTimeout = 2 * time.Minute
+
 type StandbyConfig struct {
 	Port            int
 	Hostname        string
@@ -19,22 +29,33 @@ type StandbyConfig struct {
 	UseHbaHostnames bool
 }
 
-//
-// To ensure idempotency, remove any possible existing standby from the cluster
-// before adding a new one.
-//
-// In the happy-path, we expect this to fail as there should not be an existing
-// standby for the cluster.
-//
-func UpgradeStandby(r greenplum.Runner, standbyConfig StandbyConfig) error {
-	gplog.Info("removing any existing standby master on target cluster")
+// UpgradeStandby creates the target standby master and waits up to
+// syncTimeout for it to stream WAL in sync with the master. To ensure
+// idempotency a standby left by a previous attempt is removed first.
+func UpgradeStandby(streams step.OutStreams, conn *connURI.Conn, masterPort int, r greenplum.Runner, standbyConfig StandbyConfig, syncTimeout time.Duration) error {
+	options := []connURI.Option{
+		connURI.ToTarget(),
+		connURI.Port(masterPort),
+	}
+
+	db, err := utils.System.SqlOpen("pgx", conn.URI(options...))
+	if err != nil {
+		return err
+	}
 
-	err := r.Run("gpinitstandby", "-r", "-a")
+	defer db.Close()
 
+	exists, err := standbyExists(db)
 	if err != nil {
-		gplog.Debug(fmt.Sprintf(
-			"error message from removing existing standby master (expected in the happy path): %v",
-			err))
+		return err
+	}
+
+	if exists {
+		gplog.Info("removing existing standby master on target cluster")
+
+		if err := r.Run("gpinitstandby", "-r", "-a"); err != nil {
+			return xerrors.Errorf("remove existing standby master: %w", err)
+		}
 	}
 
 	gplog.Info(fmt.Sprintf("creating target standby master: %#v", standbyConfig))
@@ -50,5 +71,19 @@ func UpgradeStandby(r greenplum.Runner, standbyConfig StandbyConfig) error {
 		args = append(args, "--hba-hostnames")
 	}
 
-	return r.Run("gpinitstandby", args...)
+	if err := r.Run("gpinitstandby", args...); err != nil {
+		return err
+	}
+
+	return waitForMirrors(streams.Stdout(), db, conn.TargetVersion(), standbyMaster, syncTimeout)
+}
+
+func standbyExists(db *sql.DB) (bool, error) {
+	var count int
+	err := db.QueryRow("SELECT count(*) FROM gp_segment_configuration WHERE content = -1 AND role = 'm'").Scan(&count)
+	if err != nil {
+		return false, xerrors.Errorf("querying standby master: %w", err)
+	}
+
+	return count > 0, nil
 }
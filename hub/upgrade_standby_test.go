@@ -4,61 +4,98 @@
 package hub_test
 
 import (
+	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
 	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
 )
 
 func TestUpgradeStandby(t *testing.T) {
 	testlog.SetupLogger()
 
-	t.Run("it upgrades the standby through gpinitstandby", func(t *testing.T) {
-		config := hub.StandbyConfig{
-			Port:            8888,
-			Hostname:        "some-hostname",
-			DataDirectory:   "/some/standby/data/directory",
-			UseHbaHostnames: true,
+	conn := connURI.Connection(semver.MustParse("5.0.0"), semver.MustParse("6.0.0"))
+
+	config := hub.StandbyConfig{
+		Port:            8888,
+		Hostname:        "some-hostname",
+		DataDirectory:   "/some/standby/data/directory",
+		UseHbaHostnames: true,
+	}
+
+	mockDB := func(t *testing.T) (sqlmock.Sqlmock, func()) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
 		}
 
+		utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+			expected := conn.URI(connURI.ToTarget(), connURI.Port(15432))
+			if dataSourceName != expected {
+				t.Errorf("got: %q want: %q", dataSourceName, expected)
+			}
+
+			return db, nil
+		}
+
+		return mock, func() {
+			utils.System = utils.InitializeSystemFunctions()
+			testutils.FinishMock(mock, t)
+		}
+	}
+
+	t.Run("it upgrades the standby through gpinitstandby", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectStandbyExists(mock, 0)
+		expectFtsProbe(mock)
+		expectStandbyReplication(mock, "streaming", "sync", 0)
+
 		runner := newSpyRunner()
-		err := hub.UpgradeStandby(runner, config)
+		streams := &step.BufferedStreams{}
+		err := hub.UpgradeStandby(streams, conn, 15432, runner, config, hub.DefaultStandbySyncTimeout)
 		if err != nil {
 			t.Errorf("unexpected error: %+v", err)
 		}
 
-		if runner.TimesRunWasCalledWith("gpinitstandby") != 2 {
+		if runner.TimesRunWasCalledWith("gpinitstandby") != 1 {
 			t.Errorf("got %v calls to config.Run, wanted %v calls",
 				runner.TimesRunWasCalledWith("gpinitstandby"),
-				2)
+				1)
 		}
 
-		if !runner.Call("gpinitstandby", 1).ArgumentsInclude("-r") {
-			t.Errorf("expected remove to have been called")
-		}
-
-		if !runner.Call("gpinitstandby", 1).ArgumentsInclude("-a") {
-			t.Errorf("expected remove to have been called without user prompt")
+		if runner.Call("gpinitstandby", 1).ArgumentsInclude("-r") {
+			t.Errorf("expected remove to not have been called when there is no standby")
 		}
 
 		portArgument := runner.
-			Call("gpinitstandby", 2).
+			Call("gpinitstandby", 1).
 			ArgumentValue("-P")
 
 		hostnameArgument := runner.
-			Call("gpinitstandby", 2).
+			Call("gpinitstandby", 1).
 			ArgumentValue("-s")
 
 		dataDirectoryArgument := runner.
-			Call("gpinitstandby", 2).
+			Call("gpinitstandby", 1).
 			ArgumentValue("-S")
 
 		automaticArgument := runner.
-			Call("gpinitstandby", 2).
+			Call("gpinitstandby", 1).
 			ArgumentsInclude("-a")
 
 		hbaHostnamesArgument := runner.
-			Call("gpinitstandby", 2).
+			Call("gpinitstandby", 1).
 			ArgumentsInclude("--hba-hostnames")
 
 		if portArgument != "8888" {
@@ -83,9 +120,168 @@ func TestUpgradeStandby(t *testing.T) {
 		if !hbaHostnamesArgument {
 			t.Error("got --hba-hostnames argument to be set, it was not")
 		}
+
+		expected := `
+CONTENT  HOST  STATUS  MODE  STATE      SYNC STATE  REPLAY LAG
+-1       smdw  u       s     streaming  sync        0 bytes
+`
+		if streams.StdoutBuf.String() != expected {
+			t.Errorf("got output %q want %q", streams.StdoutBuf.String(), expected)
+		}
+	})
+
+	t.Run("it removes an existing standby before creating the new one", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectStandbyExists(mock, 1)
+		expectFtsProbe(mock)
+		expectStandbyReplication(mock, "streaming", "sync", 0)
+
+		runner := newSpyRunner()
+		err := hub.UpgradeStandby(step.DevNullStream, conn, 15432, runner, config, hub.DefaultStandbySyncTimeout)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+
+		if runner.TimesRunWasCalledWith("gpinitstandby") != 2 {
+			t.Errorf("got %v calls to config.Run, wanted %v calls",
+				runner.TimesRunWasCalledWith("gpinitstandby"),
+				2)
+		}
+
+		if !runner.Call("gpinitstandby", 1).ArgumentsInclude("-r") {
+			t.Errorf("expected remove to have been called")
+		}
+
+		if !runner.Call("gpinitstandby", 1).ArgumentsInclude("-a") {
+			t.Errorf("expected remove to have been called without user prompt")
+		}
+	})
+
+	t.Run("it waits for the standby to synchronize and reports its progress", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectStandbyExists(mock, 0)
+		expectFtsProbe(mock)
+		mock.ExpectQuery(`FROM gp_segment_configuration c`).
+			WillReturnRows(sqlmock.NewRows(standbyColumns).AddRow(-1, "smdw", "u", "s", false, "", "", 0))
+		expectFtsProbe(mock)
+		expectStandbyReplication(mock, "catchup", "async", 8192)
+		expectFtsProbe(mock)
+		expectStandbyReplication(mock, "streaming", "sync", 0)
+
+		streams := &step.BufferedStreams{}
+		err := hub.UpgradeStandby(streams, conn, 15432, newSpyRunner(), config, hub.DefaultStandbySyncTimeout)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+
+		expected := `
+CONTENT  HOST  STATUS  MODE  STATE  SYNC STATE  REPLAY LAG
+-1       smdw  u       s     -      -           -
+
+CONTENT  HOST  STATUS  MODE  STATE    SYNC STATE  REPLAY LAG
+-1       smdw  u       s     catchup  async       8192 bytes
+
+CONTENT  HOST  STATUS  MODE  STATE      SYNC STATE  REPLAY LAG
+-1       smdw  u       s     streaming  sync        0 bytes
+`
+		if streams.StdoutBuf.String() != expected {
+			t.Errorf("got output %q want %q", streams.StdoutBuf.String(), expected)
+		}
+	})
+
+	t.Run("it measures the replay lag of a 7X standby using the wal functions", func(t *testing.T) {
+		conn := connURI.Connection(semver.MustParse("6.0.0"), semver.MustParse("7.0.0"))
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+
+		utils.System.SqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+			return db, nil
+		}
+		defer func() {
+			utils.System = utils.InitializeSystemFunctions()
+			testutils.FinishMock(mock, t)
+		}()
+
+		expectStandbyExists(mock, 0)
+		expectFtsProbe(mock)
+		mock.ExpectQuery(`COALESCE\(pg_wal_lsn_diff\(r.sent_lsn, r.replay_lsn\), 0\)::bigint\s+FROM gp_segment_configuration c`).
+			WillReturnRows(sqlmock.NewRows(standbyColumns).AddRow(-1, "smdw", "u", "s", true, "streaming", "sync", 0))
+
+		err = hub.UpgradeStandby(step.DevNullStream, conn, 15432, newSpyRunner(), config, hub.DefaultStandbySyncTimeout)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("it errors when the standby does not synchronize in time", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectStandbyExists(mock, 0)
+		expectFtsProbe(mock)
+		expectStandbyReplication(mock, "catchup", "async", 8192)
+
+		err := hub.UpgradeStandby(step.DevNullStream, conn, 15432, newSpyRunner(), config, -1*time.Second)
+		if !errors.Is(err, hub.ErrMirrorsNotSynchronized) {
+			t.Fatalf("got error %#v want %#v", err, hub.ErrMirrorsNotSynchronized)
+		}
+
+		expected := "-1s timeout exceeded waiting for mirrors to synchronize: standby master on smdw (state catchup, sync state async)"
+		if err.Error() != expected {
+			t.Errorf("got error %q want %q", err.Error(), expected)
+		}
+	})
+
+	t.Run("it returns errors from removing an existing standby", func(t *testing.T) {
+		mock, cleanup := mockDB(t)
+		defer cleanup()
+
+		expectStandbyExists(mock, 1)
+
+		expected := errors.New("permission denied")
+		runner := &greenplumStub{run: func(utility string, args ...string) error {
+			return expected
+		}}
+
+		err := hub.UpgradeStandby(step.DevNullStream, conn, 15432, runner, config, hub.DefaultStandbySyncTimeout)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
 	})
 }
 
+func expectStandbyExists(mock sqlmock.Sqlmock, count int) {
+	mock.ExpectQuery(`SELECT count\(\*\) FROM gp_segment_configuration WHERE content = -1 AND role = 'm'`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+var standbyColumns = []string{"content", "hostname", "status", "mode", "replicating", "state", "sync_state", "lag"}
+
+func expectFtsProbe(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT gp_request_fts_probe_scan\(\);`).
+		WillReturnRows(sqlmock.NewRows([]string{"gp_request_fts_probe_scan"}).AddRow("t"))
+}
+
+func expectStandbyReplication(mock sqlmock.Sqlmock, state, syncState string, lag int64) {
+	mock.ExpectQuery(`FROM gp_segment_configuration c.*WHERE c.role = 'm' AND c.content = -1`).
+		WillReturnRows(sqlmock.NewRows(standbyColumns).AddRow(-1, "smdw", "u", "s", true, state, syncState, lag))
+}
+
+type greenplumStub struct {
+	run func(utilityName string, arguments ...string) error
+}
+
+func (g *greenplumStub) Run(utilityName string, arguments ...string) error {
+	return g.run(utilityName, arguments...)
+}
+
 type spyRunner struct {
 	calls map[string][]*spyCall
 }
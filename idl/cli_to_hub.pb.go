@@ -92,6 +92,19 @@ const (
 	Substep_RESTORE_PGCONTROL                        Substep = 28
 	Substep_RECOVERSEG_SOURCE_CLUSTER                Substep = 29
 	Substep_STEP_STATUS                              Substep = 30
+	Substep_ANALYZE_TARGET_CLUSTER                   Substep = 31
+	Substep_CHECK_MIRRORS_AND_STANDBY                Substep = 32
+	Substep_CHECK_TABLESPACE_RELOCATIONS             Substep = 33
+	Substep_COPY_SOURCE_PRIMARIES                    Substep = 34
+	Substep_CHECK_ACTIVE_SESSIONS                    Substep = 35
+	Substep_QUIESCE_SOURCE_CLUSTER                   Substep = 36
+	Substep_UNQUIESCE_SOURCE_CLUSTER                 Substep = 37
+	Substep_MIGRATE_CONFIGURATION                    Substep = 38
+	Substep_CHECK_LOCALE_AND_ENCODING                Substep = 39
+	Substep_CHECK_LIBRARIES                          Substep = 40
+	Substep_UPDATE_EXTENSIONS                        Substep = 41
+	Substep_CHECK_TARGET_GPHOME                      Substep = 42
+	Substep_DISTRIBUTE                               Substep = 43
 )
 
 var Substep_name = map[int32]string{
@@ -126,6 +139,19 @@ var Substep_name = map[int32]string{
 	28: "RESTORE_PGCONTROL",
 	29: "RECOVERSEG_SOURCE_CLUSTER",
 	30: "STEP_STATUS",
+	31: "ANALYZE_TARGET_CLUSTER",
+	32: "CHECK_MIRRORS_AND_STANDBY",
+	33: "CHECK_TABLESPACE_RELOCATIONS",
+	34: "COPY_SOURCE_PRIMARIES",
+	35: "CHECK_ACTIVE_SESSIONS",
+	36: "QUIESCE_SOURCE_CLUSTER",
+	37: "UNQUIESCE_SOURCE_CLUSTER",
+	38: "MIGRATE_CONFIGURATION",
+	39: "CHECK_LOCALE_AND_ENCODING",
+	40: "CHECK_LIBRARIES",
+	41: "UPDATE_EXTENSIONS",
+	42: "CHECK_TARGET_GPHOME",
+	43: "DISTRIBUTE",
 }
 
 var Substep_value = map[string]int32{
@@ -160,6 +186,19 @@ var Substep_value = map[string]int32{
 	"RESTORE_PGCONTROL":                        28,
 	"RECOVERSEG_SOURCE_CLUSTER":                29,
 	"STEP_STATUS":                              30,
+	"ANALYZE_TARGET_CLUSTER":                   31,
+	"CHECK_MIRRORS_AND_STANDBY":                32,
+	"CHECK_TABLESPACE_RELOCATIONS":             33,
+	"COPY_SOURCE_PRIMARIES":                    34,
+	"CHECK_ACTIVE_SESSIONS":                    35,
+	"QUIESCE_SOURCE_CLUSTER":                   36,
+	"UNQUIESCE_SOURCE_CLUSTER":                 37,
+	"MIGRATE_CONFIGURATION":                    38,
+	"CHECK_LOCALE_AND_ENCODING":                39,
+	"CHECK_LIBRARIES":                          40,
+	"UPDATE_EXTENSIONS":                        41,
+	"CHECK_TARGET_GPHOME":                      42,
+	"DISTRIBUTE":                               43,
 }
 
 func (x Substep) String() string {
@@ -229,20 +268,82 @@ func (x Chunk_Type) String() string {
 }
 
 func (Chunk_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{14, 0}
+	return fileDescriptor_631e66a01873be02, []int{16, 0}
+}
+
+type RevertAction_Operation int32
+
+const (
+	RevertAction_UNKNOWN_OPERATION RevertAction_Operation = 0
+	RevertAction_DELETE            RevertAction_Operation = 1
+	RevertAction_RSYNC             RevertAction_Operation = 2
+	RevertAction_RESTORE_PGCONTROL RevertAction_Operation = 3
+	RevertAction_ARCHIVE           RevertAction_Operation = 4
+)
+
+var RevertAction_Operation_name = map[int32]string{
+	0: "UNKNOWN_OPERATION",
+	1: "DELETE",
+	2: "RSYNC",
+	3: "RESTORE_PGCONTROL",
+	4: "ARCHIVE",
+}
+
+var RevertAction_Operation_value = map[string]int32{
+	"UNKNOWN_OPERATION": 0,
+	"DELETE":            1,
+	"RSYNC":             2,
+	"RESTORE_PGCONTROL": 3,
+	"ARCHIVE":           4,
+}
+
+func (x RevertAction_Operation) String() string {
+	return proto.EnumName(RevertAction_Operation_name, int32(x))
+}
+
+func (RevertAction_Operation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_631e66a01873be02, []int{27, 0}
 }
 
 type InitializeRequest struct {
-	AgentPort            int32    `protobuf:"varint,1,opt,name=agentPort,proto3" json:"agentPort,omitempty"`
-	SourceGPHome         string   `protobuf:"bytes,2,opt,name=sourceGPHome,proto3" json:"sourceGPHome,omitempty"`
-	TargetGPHome         string   `protobuf:"bytes,3,opt,name=targetGPHome,proto3" json:"targetGPHome,omitempty"`
-	SourcePort           int32    `protobuf:"varint,4,opt,name=sourcePort,proto3" json:"sourcePort,omitempty"`
-	UseLinkMode          bool     `protobuf:"varint,5,opt,name=useLinkMode,proto3" json:"useLinkMode,omitempty"`
-	UseHbaHostnames      bool     `protobuf:"varint,6,opt,name=useHbaHostnames,proto3" json:"useHbaHostnames,omitempty"`
-	Ports                []uint32 `protobuf:"varint,7,rep,packed,name=ports,proto3" json:"ports,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	AgentPort                int32                   `protobuf:"varint,1,opt,name=agentPort,proto3" json:"agentPort,omitempty"`
+	SourceGPHome             string                  `protobuf:"bytes,2,opt,name=sourceGPHome,proto3" json:"sourceGPHome,omitempty"`
+	TargetGPHome             string                  `protobuf:"bytes,3,opt,name=targetGPHome,proto3" json:"targetGPHome,omitempty"`
+	SourcePort               int32                   `protobuf:"varint,4,opt,name=sourcePort,proto3" json:"sourcePort,omitempty"`
+	UseLinkMode              bool                    `protobuf:"varint,5,opt,name=useLinkMode,proto3" json:"useLinkMode,omitempty"`
+	UseHbaHostnames          bool                    `protobuf:"varint,6,opt,name=useHbaHostnames,proto3" json:"useHbaHostnames,omitempty"`
+	Ports                    []uint32                `protobuf:"varint,7,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	AnalyzeTargetCluster     bool                    `protobuf:"varint,8,opt,name=analyzeTargetCluster,proto3" json:"analyzeTargetCluster,omitempty"`
+	AnalyzeJobs              int32                   `protobuf:"varint,9,opt,name=analyzeJobs,proto3" json:"analyzeJobs,omitempty"`
+	MirrorUpgradeStrategy    string                  `protobuf:"bytes,10,opt,name=mirrorUpgradeStrategy,proto3" json:"mirrorUpgradeStrategy,omitempty"`
+	MirrorUpgradeJobs        int32                   `protobuf:"varint,11,opt,name=mirrorUpgradeJobs,proto3" json:"mirrorUpgradeJobs,omitempty"`
+	MirrorSyncTimeoutSeconds int32                   `protobuf:"varint,12,opt,name=mirrorSyncTimeoutSeconds,proto3" json:"mirrorSyncTimeoutSeconds,omitempty"`
+	TablespaceRelocations    []*TablespaceRelocation `protobuf:"bytes,13,rep,name=tablespaceRelocations,proto3" json:"tablespaceRelocations,omitempty"`
+	TargetDataDirPlacement   []*DataDirPlacementRule `protobuf:"bytes,14,rep,name=targetDataDirPlacement,proto3" json:"targetDataDirPlacement,omitempty"`
+	HostMapping              map[string]string       `protobuf:"bytes,15,rep,name=hostMapping,proto3" json:"hostMapping,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SourceMasterHost         string                  `protobuf:"bytes,16,opt,name=sourceMasterHost,proto3" json:"sourceMasterHost,omitempty"`
+	DbUser                   string                  `protobuf:"bytes,17,opt,name=dbUser,proto3" json:"dbUser,omitempty"`
+	PgpassFile               string                  `protobuf:"bytes,18,opt,name=pgpassFile,proto3" json:"pgpassFile,omitempty"`
+	SslMode                  string                  `protobuf:"bytes,19,opt,name=sslMode,proto3" json:"sslMode,omitempty"`
+	DbPasswordEnv            string                  `protobuf:"bytes,20,opt,name=dbPasswordEnv,proto3" json:"dbPasswordEnv,omitempty"`
+	SslCert                  string                  `protobuf:"bytes,21,opt,name=sslCert,proto3" json:"sslCert,omitempty"`
+	SslKey                   string                  `protobuf:"bytes,22,opt,name=sslKey,proto3" json:"sslKey,omitempty"`
+	SslRootCert              string                  `protobuf:"bytes,23,opt,name=sslRootCert,proto3" json:"sslRootCert,omitempty"`
+	ApplicationName          string                  `protobuf:"bytes,24,opt,name=applicationName,proto3" json:"applicationName,omitempty"`
+	ActiveSessionPolicy      string                  `protobuf:"bytes,25,opt,name=activeSessionPolicy,proto3" json:"activeSessionPolicy,omitempty"`
+	ActiveSessionWaitMinutes int32                   `protobuf:"varint,26,opt,name=activeSessionWaitMinutes,proto3" json:"activeSessionWaitMinutes,omitempty"`
+	Quiesce                  bool                    `protobuf:"varint,27,opt,name=quiesce,proto3" json:"quiesce,omitempty"`
+	GpinitsystemOverrides    map[string]string       `protobuf:"bytes,28,rep,name=gpinitsystemOverrides,proto3" json:"gpinitsystemOverrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	UpdateExtensions         bool                    `protobuf:"varint,29,opt,name=updateExtensions,proto3" json:"updateExtensions,omitempty"`
+	UpdateExtensionsAllow    []string                `protobuf:"bytes,30,rep,name=updateExtensionsAllow,proto3" json:"updateExtensionsAllow,omitempty"`
+	UpdateExtensionsDeny     []string                `protobuf:"bytes,31,rep,name=updateExtensionsDeny,proto3" json:"updateExtensionsDeny,omitempty"`
+	GphomeManifestAllowlist  []string                `protobuf:"bytes,32,rep,name=gphomeManifestAllowlist,proto3" json:"gphomeManifestAllowlist,omitempty"`
+	Distribute               bool                    `protobuf:"varint,33,opt,name=distribute,proto3" json:"distribute,omitempty"`
+	DistributeTargetGPHome   bool                    `protobuf:"varint,34,opt,name=distributeTargetGPHome,proto3" json:"distributeTargetGPHome,omitempty"`
+	SkipVersionCheck         bool                    `protobuf:"varint,35,opt,name=skipVersionCheck,proto3" json:"skipVersionCheck,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{}                `json:"-"`
+	XXX_unrecognized         []byte                  `json:"-"`
+	XXX_sizecache            int32                   `json:"-"`
 }
 
 func (m *InitializeRequest) Reset()         { *m = InitializeRequest{} }
@@ -319,6 +420,312 @@ func (m *InitializeRequest) GetPorts() []uint32 {
 	return nil
 }
 
+func (m *InitializeRequest) GetAnalyzeTargetCluster() bool {
+	if m != nil {
+		return m.AnalyzeTargetCluster
+	}
+	return false
+}
+
+func (m *InitializeRequest) GetAnalyzeJobs() int32 {
+	if m != nil {
+		return m.AnalyzeJobs
+	}
+	return 0
+}
+
+func (m *InitializeRequest) GetMirrorUpgradeStrategy() string {
+	if m != nil {
+		return m.MirrorUpgradeStrategy
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetMirrorUpgradeJobs() int32 {
+	if m != nil {
+		return m.MirrorUpgradeJobs
+	}
+	return 0
+}
+
+func (m *InitializeRequest) GetMirrorSyncTimeoutSeconds() int32 {
+	if m != nil {
+		return m.MirrorSyncTimeoutSeconds
+	}
+	return 0
+}
+
+func (m *InitializeRequest) GetTablespaceRelocations() []*TablespaceRelocation {
+	if m != nil {
+		return m.TablespaceRelocations
+	}
+	return nil
+}
+
+func (m *InitializeRequest) GetTargetDataDirPlacement() []*DataDirPlacementRule {
+	if m != nil {
+		return m.TargetDataDirPlacement
+	}
+	return nil
+}
+
+func (m *InitializeRequest) GetHostMapping() map[string]string {
+	if m != nil {
+		return m.HostMapping
+	}
+	return nil
+}
+
+func (m *InitializeRequest) GetSourceMasterHost() string {
+	if m != nil {
+		return m.SourceMasterHost
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetDbUser() string {
+	if m != nil {
+		return m.DbUser
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetPgpassFile() string {
+	if m != nil {
+		return m.PgpassFile
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetSslMode() string {
+	if m != nil {
+		return m.SslMode
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetDbPasswordEnv() string {
+	if m != nil {
+		return m.DbPasswordEnv
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetSslCert() string {
+	if m != nil {
+		return m.SslCert
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetSslKey() string {
+	if m != nil {
+		return m.SslKey
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetSslRootCert() string {
+	if m != nil {
+		return m.SslRootCert
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetApplicationName() string {
+	if m != nil {
+		return m.ApplicationName
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetActiveSessionPolicy() string {
+	if m != nil {
+		return m.ActiveSessionPolicy
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetActiveSessionWaitMinutes() int32 {
+	if m != nil {
+		return m.ActiveSessionWaitMinutes
+	}
+	return 0
+}
+
+func (m *InitializeRequest) GetQuiesce() bool {
+	if m != nil {
+		return m.Quiesce
+	}
+	return false
+}
+
+func (m *InitializeRequest) GetGpinitsystemOverrides() map[string]string {
+	if m != nil {
+		return m.GpinitsystemOverrides
+	}
+	return nil
+}
+
+func (m *InitializeRequest) GetUpdateExtensions() bool {
+	if m != nil {
+		return m.UpdateExtensions
+	}
+	return false
+}
+
+func (m *InitializeRequest) GetUpdateExtensionsAllow() []string {
+	if m != nil {
+		return m.UpdateExtensionsAllow
+	}
+	return nil
+}
+
+func (m *InitializeRequest) GetUpdateExtensionsDeny() []string {
+	if m != nil {
+		return m.UpdateExtensionsDeny
+	}
+	return nil
+}
+
+func (m *InitializeRequest) GetGphomeManifestAllowlist() []string {
+	if m != nil {
+		return m.GphomeManifestAllowlist
+	}
+	return nil
+}
+
+func (m *InitializeRequest) GetDistribute() bool {
+	if m != nil {
+		return m.Distribute
+	}
+	return false
+}
+
+func (m *InitializeRequest) GetDistributeTargetGPHome() bool {
+	if m != nil {
+		return m.DistributeTargetGPHome
+	}
+	return false
+}
+
+func (m *InitializeRequest) GetSkipVersionCheck() bool {
+	if m != nil {
+		return m.SkipVersionCheck
+	}
+	return false
+}
+
+type TablespaceRelocation struct {
+	Host                 string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	OldPrefix            string   `protobuf:"bytes,2,opt,name=oldPrefix,proto3" json:"oldPrefix,omitempty"`
+	NewPrefix            string   `protobuf:"bytes,3,opt,name=newPrefix,proto3" json:"newPrefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TablespaceRelocation) Reset()         { *m = TablespaceRelocation{} }
+func (m *TablespaceRelocation) String() string { return proto.CompactTextString(m) }
+func (*TablespaceRelocation) ProtoMessage()    {}
+func (*TablespaceRelocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_631e66a01873be02, []int{1}
+}
+
+func (m *TablespaceRelocation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TablespaceRelocation.Unmarshal(m, b)
+}
+func (m *TablespaceRelocation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TablespaceRelocation.Marshal(b, m, deterministic)
+}
+func (m *TablespaceRelocation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TablespaceRelocation.Merge(m, src)
+}
+func (m *TablespaceRelocation) XXX_Size() int {
+	return xxx_messageInfo_TablespaceRelocation.Size(m)
+}
+func (m *TablespaceRelocation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TablespaceRelocation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TablespaceRelocation proto.InternalMessageInfo
+
+func (m *TablespaceRelocation) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *TablespaceRelocation) GetOldPrefix() string {
+	if m != nil {
+		return m.OldPrefix
+	}
+	return ""
+}
+
+func (m *TablespaceRelocation) GetNewPrefix() string {
+	if m != nil {
+		return m.NewPrefix
+	}
+	return ""
+}
+
+type DataDirPlacementRule struct {
+	Host                 string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Role                 string   `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	BaseDir              string   `protobuf:"bytes,3,opt,name=baseDir,proto3" json:"baseDir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataDirPlacementRule) Reset()         { *m = DataDirPlacementRule{} }
+func (m *DataDirPlacementRule) String() string { return proto.CompactTextString(m) }
+func (*DataDirPlacementRule) ProtoMessage()    {}
+func (*DataDirPlacementRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_631e66a01873be02, []int{2}
+}
+
+func (m *DataDirPlacementRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataDirPlacementRule.Unmarshal(m, b)
+}
+func (m *DataDirPlacementRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataDirPlacementRule.Marshal(b, m, deterministic)
+}
+func (m *DataDirPlacementRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataDirPlacementRule.Merge(m, src)
+}
+func (m *DataDirPlacementRule) XXX_Size() int {
+	return xxx_messageInfo_DataDirPlacementRule.Size(m)
+}
+func (m *DataDirPlacementRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataDirPlacementRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataDirPlacementRule proto.InternalMessageInfo
+
+func (m *DataDirPlacementRule) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *DataDirPlacementRule) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *DataDirPlacementRule) GetBaseDir() string {
+	if m != nil {
+		return m.BaseDir
+	}
+	return ""
+}
+
 type InitializeCreateClusterRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -329,7 +736,7 @@ func (m *InitializeCreateClusterRequest) Reset()         { *m = InitializeCreate
 func (m *InitializeCreateClusterRequest) String() string { return proto.CompactTextString(m) }
 func (*InitializeCreateClusterRequest) ProtoMessage()    {}
 func (*InitializeCreateClusterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{1}
+	return fileDescriptor_631e66a01873be02, []int{3}
 }
 
 func (m *InitializeCreateClusterRequest) XXX_Unmarshal(b []byte) error {
@@ -360,7 +767,7 @@ func (m *ExecuteRequest) Reset()         { *m = ExecuteRequest{} }
 func (m *ExecuteRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecuteRequest) ProtoMessage()    {}
 func (*ExecuteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{2}
+	return fileDescriptor_631e66a01873be02, []int{4}
 }
 
 func (m *ExecuteRequest) XXX_Unmarshal(b []byte) error {
@@ -391,7 +798,7 @@ func (m *FinalizeRequest) Reset()         { *m = FinalizeRequest{} }
 func (m *FinalizeRequest) String() string { return proto.CompactTextString(m) }
 func (*FinalizeRequest) ProtoMessage()    {}
 func (*FinalizeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{3}
+	return fileDescriptor_631e66a01873be02, []int{5}
 }
 
 func (m *FinalizeRequest) XXX_Unmarshal(b []byte) error {
@@ -413,6 +820,7 @@ func (m *FinalizeRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_FinalizeRequest proto.InternalMessageInfo
 
 type RevertRequest struct {
+	Checksum             bool     `protobuf:"varint,1,opt,name=checksum,proto3" json:"checksum,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -422,7 +830,7 @@ func (m *RevertRequest) Reset()         { *m = RevertRequest{} }
 func (m *RevertRequest) String() string { return proto.CompactTextString(m) }
 func (*RevertRequest) ProtoMessage()    {}
 func (*RevertRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{4}
+	return fileDescriptor_631e66a01873be02, []int{6}
 }
 
 func (m *RevertRequest) XXX_Unmarshal(b []byte) error {
@@ -443,6 +851,13 @@ func (m *RevertRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_RevertRequest proto.InternalMessageInfo
 
+func (m *RevertRequest) GetChecksum() bool {
+	if m != nil {
+		return m.Checksum
+	}
+	return false
+}
+
 type RestartAgentsRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -453,7 +868,7 @@ func (m *RestartAgentsRequest) Reset()         { *m = RestartAgentsRequest{} }
 func (m *RestartAgentsRequest) String() string { return proto.CompactTextString(m) }
 func (*RestartAgentsRequest) ProtoMessage()    {}
 func (*RestartAgentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{5}
+	return fileDescriptor_631e66a01873be02, []int{7}
 }
 
 func (m *RestartAgentsRequest) XXX_Unmarshal(b []byte) error {
@@ -485,7 +900,7 @@ func (m *RestartAgentsReply) Reset()         { *m = RestartAgentsReply{} }
 func (m *RestartAgentsReply) String() string { return proto.CompactTextString(m) }
 func (*RestartAgentsReply) ProtoMessage()    {}
 func (*RestartAgentsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{6}
+	return fileDescriptor_631e66a01873be02, []int{8}
 }
 
 func (m *RestartAgentsReply) XXX_Unmarshal(b []byte) error {
@@ -523,7 +938,7 @@ func (m *StopServicesRequest) Reset()         { *m = StopServicesRequest{} }
 func (m *StopServicesRequest) String() string { return proto.CompactTextString(m) }
 func (*StopServicesRequest) ProtoMessage()    {}
 func (*StopServicesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{7}
+	return fileDescriptor_631e66a01873be02, []int{9}
 }
 
 func (m *StopServicesRequest) XXX_Unmarshal(b []byte) error {
@@ -554,7 +969,7 @@ func (m *StopServicesReply) Reset()         { *m = StopServicesReply{} }
 func (m *StopServicesReply) String() string { return proto.CompactTextString(m) }
 func (*StopServicesReply) ProtoMessage()    {}
 func (*StopServicesReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{8}
+	return fileDescriptor_631e66a01873be02, []int{10}
 }
 
 func (m *StopServicesReply) XXX_Unmarshal(b []byte) error {
@@ -587,7 +1002,7 @@ func (m *SubstepStatus) Reset()         { *m = SubstepStatus{} }
 func (m *SubstepStatus) String() string { return proto.CompactTextString(m) }
 func (*SubstepStatus) ProtoMessage()    {}
 func (*SubstepStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{9}
+	return fileDescriptor_631e66a01873be02, []int{11}
 }
 
 func (m *SubstepStatus) XXX_Unmarshal(b []byte) error {
@@ -633,7 +1048,7 @@ func (m *CheckDiskSpaceRequest) Reset()         { *m = CheckDiskSpaceRequest{} }
 func (m *CheckDiskSpaceRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckDiskSpaceRequest) ProtoMessage()    {}
 func (*CheckDiskSpaceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{10}
+	return fileDescriptor_631e66a01873be02, []int{12}
 }
 
 func (m *CheckDiskSpaceRequest) XXX_Unmarshal(b []byte) error {
@@ -672,7 +1087,7 @@ func (m *CheckDiskSpaceReply) Reset()         { *m = CheckDiskSpaceReply{} }
 func (m *CheckDiskSpaceReply) String() string { return proto.CompactTextString(m) }
 func (*CheckDiskSpaceReply) ProtoMessage()    {}
 func (*CheckDiskSpaceReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{11}
+	return fileDescriptor_631e66a01873be02, []int{13}
 }
 
 func (m *CheckDiskSpaceReply) XXX_Unmarshal(b []byte) error {
@@ -712,7 +1127,7 @@ func (m *CheckDiskSpaceReply_DiskUsage) Reset()         { *m = CheckDiskSpaceRep
 func (m *CheckDiskSpaceReply_DiskUsage) String() string { return proto.CompactTextString(m) }
 func (*CheckDiskSpaceReply_DiskUsage) ProtoMessage()    {}
 func (*CheckDiskSpaceReply_DiskUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{11, 0}
+	return fileDescriptor_631e66a01873be02, []int{13, 0}
 }
 
 func (m *CheckDiskSpaceReply_DiskUsage) XXX_Unmarshal(b []byte) error {
@@ -757,7 +1172,7 @@ func (m *PrepareInitClusterRequest) Reset()         { *m = PrepareInitClusterReq
 func (m *PrepareInitClusterRequest) String() string { return proto.CompactTextString(m) }
 func (*PrepareInitClusterRequest) ProtoMessage()    {}
 func (*PrepareInitClusterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{12}
+	return fileDescriptor_631e66a01873be02, []int{14}
 }
 
 func (m *PrepareInitClusterRequest) XXX_Unmarshal(b []byte) error {
@@ -788,7 +1203,7 @@ func (m *PrepareInitClusterReply) Reset()         { *m = PrepareInitClusterReply
 func (m *PrepareInitClusterReply) String() string { return proto.CompactTextString(m) }
 func (*PrepareInitClusterReply) ProtoMessage()    {}
 func (*PrepareInitClusterReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{13}
+	return fileDescriptor_631e66a01873be02, []int{15}
 }
 
 func (m *PrepareInitClusterReply) XXX_Unmarshal(b []byte) error {
@@ -821,7 +1236,7 @@ func (m *Chunk) Reset()         { *m = Chunk{} }
 func (m *Chunk) String() string { return proto.CompactTextString(m) }
 func (*Chunk) ProtoMessage()    {}
 func (*Chunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{14}
+	return fileDescriptor_631e66a01873be02, []int{16}
 }
 
 func (m *Chunk) XXX_Unmarshal(b []byte) error {
@@ -871,7 +1286,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{15}
+	return fileDescriptor_631e66a01873be02, []int{17}
 }
 
 func (m *Message) XXX_Unmarshal(b []byte) error {
@@ -967,7 +1382,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{16}
+	return fileDescriptor_631e66a01873be02, []int{18}
 }
 
 func (m *Response) XXX_Unmarshal(b []byte) error {
@@ -1073,7 +1488,7 @@ func (m *InitializeResponse) Reset()         { *m = InitializeResponse{} }
 func (m *InitializeResponse) String() string { return proto.CompactTextString(m) }
 func (*InitializeResponse) ProtoMessage()    {}
 func (*InitializeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{17}
+	return fileDescriptor_631e66a01873be02, []int{19}
 }
 
 func (m *InitializeResponse) XXX_Unmarshal(b []byte) error {
@@ -1120,7 +1535,7 @@ func (m *Cluster) Reset()         { *m = Cluster{} }
 func (m *Cluster) String() string { return proto.CompactTextString(m) }
 func (*Cluster) ProtoMessage()    {}
 func (*Cluster) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{18}
+	return fileDescriptor_631e66a01873be02, []int{20}
 }
 
 func (m *Cluster) XXX_Unmarshal(b []byte) error {
@@ -1166,7 +1581,7 @@ func (m *ExecuteResponse) Reset()         { *m = ExecuteResponse{} }
 func (m *ExecuteResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecuteResponse) ProtoMessage()    {}
 func (*ExecuteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{19}
+	return fileDescriptor_631e66a01873be02, []int{21}
 }
 
 func (m *ExecuteResponse) XXX_Unmarshal(b []byte) error {
@@ -1209,7 +1624,7 @@ func (m *FinalizeResponse) Reset()         { *m = FinalizeResponse{} }
 func (m *FinalizeResponse) String() string { return proto.CompactTextString(m) }
 func (*FinalizeResponse) ProtoMessage()    {}
 func (*FinalizeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{20}
+	return fileDescriptor_631e66a01873be02, []int{22}
 }
 
 func (m *FinalizeResponse) XXX_Unmarshal(b []byte) error {
@@ -1278,7 +1693,7 @@ func (m *RevertResponse) Reset()         { *m = RevertResponse{} }
 func (m *RevertResponse) String() string { return proto.CompactTextString(m) }
 func (*RevertResponse) ProtoMessage()    {}
 func (*RevertResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{21}
+	return fileDescriptor_631e66a01873be02, []int{23}
 }
 
 func (m *RevertResponse) XXX_Unmarshal(b []byte) error {
@@ -1320,6 +1735,213 @@ func (m *RevertResponse) GetLogArchiveDirectory() string {
 	return ""
 }
 
+type RevertPlanRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevertPlanRequest) Reset()         { *m = RevertPlanRequest{} }
+func (m *RevertPlanRequest) String() string { return proto.CompactTextString(m) }
+func (*RevertPlanRequest) ProtoMessage()    {}
+func (*RevertPlanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_631e66a01873be02, []int{24}
+}
+
+func (m *RevertPlanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevertPlanRequest.Unmarshal(m, b)
+}
+func (m *RevertPlanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevertPlanRequest.Marshal(b, m, deterministic)
+}
+func (m *RevertPlanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevertPlanRequest.Merge(m, src)
+}
+func (m *RevertPlanRequest) XXX_Size() int {
+	return xxx_messageInfo_RevertPlanRequest.Size(m)
+}
+func (m *RevertPlanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevertPlanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevertPlanRequest proto.InternalMessageInfo
+
+type RevertPlanReply struct {
+	Substeps             []*RevertPlanSubstep `protobuf:"bytes,1,rep,name=substeps,proto3" json:"substeps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RevertPlanReply) Reset()         { *m = RevertPlanReply{} }
+func (m *RevertPlanReply) String() string { return proto.CompactTextString(m) }
+func (*RevertPlanReply) ProtoMessage()    {}
+func (*RevertPlanReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_631e66a01873be02, []int{25}
+}
+
+func (m *RevertPlanReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevertPlanReply.Unmarshal(m, b)
+}
+func (m *RevertPlanReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevertPlanReply.Marshal(b, m, deterministic)
+}
+func (m *RevertPlanReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevertPlanReply.Merge(m, src)
+}
+func (m *RevertPlanReply) XXX_Size() int {
+	return xxx_messageInfo_RevertPlanReply.Size(m)
+}
+func (m *RevertPlanReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevertPlanReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevertPlanReply proto.InternalMessageInfo
+
+func (m *RevertPlanReply) GetSubsteps() []*RevertPlanSubstep {
+	if m != nil {
+		return m.Substeps
+	}
+	return nil
+}
+
+type RevertPlanSubstep struct {
+	Substep Substep `protobuf:"varint,1,opt,name=substep,proto3,enum=idl.Substep" json:"substep,omitempty"`
+	// completed is true when a previous revert completed the substep, in
+	// which case it will be skipped.
+	Completed            bool            `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	Actions              []*RevertAction `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RevertPlanSubstep) Reset()         { *m = RevertPlanSubstep{} }
+func (m *RevertPlanSubstep) String() string { return proto.CompactTextString(m) }
+func (*RevertPlanSubstep) ProtoMessage()    {}
+func (*RevertPlanSubstep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_631e66a01873be02, []int{26}
+}
+
+func (m *RevertPlanSubstep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevertPlanSubstep.Unmarshal(m, b)
+}
+func (m *RevertPlanSubstep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevertPlanSubstep.Marshal(b, m, deterministic)
+}
+func (m *RevertPlanSubstep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevertPlanSubstep.Merge(m, src)
+}
+func (m *RevertPlanSubstep) XXX_Size() int {
+	return xxx_messageInfo_RevertPlanSubstep.Size(m)
+}
+func (m *RevertPlanSubstep) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevertPlanSubstep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevertPlanSubstep proto.InternalMessageInfo
+
+func (m *RevertPlanSubstep) GetSubstep() Substep {
+	if m != nil {
+		return m.Substep
+	}
+	return Substep_UNKNOWN_SUBSTEP
+}
+
+func (m *RevertPlanSubstep) GetCompleted() bool {
+	if m != nil {
+		return m.Completed
+	}
+	return false
+}
+
+func (m *RevertPlanSubstep) GetActions() []*RevertAction {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+type RevertAction struct {
+	Operation       RevertAction_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=idl.RevertAction_Operation" json:"operation,omitempty"`
+	Host            string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Directory       string                 `protobuf:"bytes,3,opt,name=directory,proto3" json:"directory,omitempty"`
+	SourceHost      string                 `protobuf:"bytes,4,opt,name=sourceHost,proto3" json:"sourceHost,omitempty"`
+	SourceDirectory string                 `protobuf:"bytes,5,opt,name=sourceDirectory,proto3" json:"sourceDirectory,omitempty"`
+	// bytes is the estimated amount of data deleted or copied.
+	Bytes                uint64   `protobuf:"varint,6,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevertAction) Reset()         { *m = RevertAction{} }
+func (m *RevertAction) String() string { return proto.CompactTextString(m) }
+func (*RevertAction) ProtoMessage()    {}
+func (*RevertAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_631e66a01873be02, []int{27}
+}
+
+func (m *RevertAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevertAction.Unmarshal(m, b)
+}
+func (m *RevertAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevertAction.Marshal(b, m, deterministic)
+}
+func (m *RevertAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevertAction.Merge(m, src)
+}
+func (m *RevertAction) XXX_Size() int {
+	return xxx_messageInfo_RevertAction.Size(m)
+}
+func (m *RevertAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevertAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevertAction proto.InternalMessageInfo
+
+func (m *RevertAction) GetOperation() RevertAction_Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return RevertAction_UNKNOWN_OPERATION
+}
+
+func (m *RevertAction) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *RevertAction) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *RevertAction) GetSourceHost() string {
+	if m != nil {
+		return m.SourceHost
+	}
+	return ""
+}
+
+func (m *RevertAction) GetSourceDirectory() string {
+	if m != nil {
+		return m.SourceDirectory
+	}
+	return ""
+}
+
+func (m *RevertAction) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
 type GetConfigRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1331,7 +1953,7 @@ func (m *GetConfigRequest) Reset()         { *m = GetConfigRequest{} }
 func (m *GetConfigRequest) String() string { return proto.CompactTextString(m) }
 func (*GetConfigRequest) ProtoMessage()    {}
 func (*GetConfigRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{22}
+	return fileDescriptor_631e66a01873be02, []int{28}
 }
 
 func (m *GetConfigRequest) XXX_Unmarshal(b []byte) error {
@@ -1370,7 +1992,7 @@ func (m *GetConfigReply) Reset()         { *m = GetConfigReply{} }
 func (m *GetConfigReply) String() string { return proto.CompactTextString(m) }
 func (*GetConfigReply) ProtoMessage()    {}
 func (*GetConfigReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e66a01873be02, []int{23}
+	return fileDescriptor_631e66a01873be02, []int{29}
 }
 
 func (m *GetConfigReply) XXX_Unmarshal(b []byte) error {
@@ -1403,7 +2025,12 @@ func init() {
 	proto.RegisterEnum("idl.Substep", Substep_name, Substep_value)
 	proto.RegisterEnum("idl.Status", Status_name, Status_value)
 	proto.RegisterEnum("idl.Chunk_Type", Chunk_Type_name, Chunk_Type_value)
+	proto.RegisterEnum("idl.RevertAction_Operation", RevertAction_Operation_name, RevertAction_Operation_value)
 	proto.RegisterType((*InitializeRequest)(nil), "idl.InitializeRequest")
+	proto.RegisterMapType((map[string]string)(nil), "idl.InitializeRequest.GpinitsystemOverridesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "idl.InitializeRequest.HostMappingEntry")
+	proto.RegisterType((*TablespaceRelocation)(nil), "idl.TablespaceRelocation")
+	proto.RegisterType((*DataDirPlacementRule)(nil), "idl.DataDirPlacementRule")
 	proto.RegisterType((*InitializeCreateClusterRequest)(nil), "idl.InitializeCreateClusterRequest")
 	proto.RegisterType((*ExecuteRequest)(nil), "idl.ExecuteRequest")
 	proto.RegisterType((*FinalizeRequest)(nil), "idl.FinalizeRequest")
@@ -1427,6 +2054,10 @@ func init() {
 	proto.RegisterType((*ExecuteResponse)(nil), "idl.ExecuteResponse")
 	proto.RegisterType((*FinalizeResponse)(nil), "idl.FinalizeResponse")
 	proto.RegisterType((*RevertResponse)(nil), "idl.RevertResponse")
+	proto.RegisterType((*RevertPlanRequest)(nil), "idl.RevertPlanRequest")
+	proto.RegisterType((*RevertPlanReply)(nil), "idl.RevertPlanReply")
+	proto.RegisterType((*RevertPlanSubstep)(nil), "idl.RevertPlanSubstep")
+	proto.RegisterType((*RevertAction)(nil), "idl.RevertAction")
 	proto.RegisterType((*GetConfigRequest)(nil), "idl.GetConfigRequest")
 	proto.RegisterType((*GetConfigReply)(nil), "idl.GetConfigReply")
 }
@@ -1434,110 +2065,170 @@ func init() {
 func init() { proto.RegisterFile("cli_to_hub.proto", fileDescriptor_631e66a01873be02) }
 
 var fileDescriptor_631e66a01873be02 = []byte{
-	// 1643 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0xef, 0x6e, 0xe2, 0xca,
-	0x15, 0x87, 0x04, 0x08, 0x1c, 0x12, 0x98, 0x0c, 0x24, 0x21, 0xec, 0xde, 0x15, 0xf5, 0xae, 0xae,
-	0xa2, 0xbd, 0xb7, 0xd1, 0x8a, 0x56, 0xed, 0x6d, 0xd5, 0x4a, 0x75, 0xcc, 0x04, 0x5b, 0x01, 0xdb,
-	0x1a, 0x9b, 0xb4, 0xb7, 0x52, 0x85, 0x1c, 0x32, 0xc9, 0x5a, 0xe1, 0x62, 0xd6, 0x36, 0x51, 0xd3,
-	0x87, 0xe8, 0xa7, 0xbe, 0x42, 0xd5, 0x77, 0xe8, 0x9b, 0x55, 0xed, 0x87, 0x6a, 0xc6, 0x63, 0x62,
-	0x1c, 0xa2, 0xf6, 0x7e, 0xf3, 0x9c, 0xf3, 0x9b, 0xdf, 0x9c, 0x7f, 0x73, 0xce, 0x18, 0xd0, 0x6c,
-	0xee, 0x4f, 0xe3, 0x60, 0xfa, 0x79, 0x75, 0x73, 0xbe, 0x0c, 0x83, 0x38, 0xc0, 0xbb, 0xfe, 0xed,
-	0x5c, 0xf9, 0x77, 0x11, 0x0e, 0x8d, 0x85, 0x1f, 0xfb, 0xde, 0xdc, 0xff, 0x0b, 0xa3, 0xec, 0xcb,
-	0x8a, 0x45, 0x31, 0x7e, 0x0b, 0x35, 0xef, 0x9e, 0x2d, 0x62, 0x3b, 0x08, 0xe3, 0x4e, 0xb1, 0x57,
-	0x3c, 0x2b, 0xd3, 0x67, 0x01, 0x56, 0x60, 0x3f, 0x0a, 0x56, 0xe1, 0x8c, 0x0d, 0x6d, 0x3d, 0xf8,
-	0x81, 0x75, 0x76, 0x7a, 0xc5, 0xb3, 0x1a, 0xdd, 0x90, 0x71, 0x4c, 0xec, 0x85, 0xf7, 0x2c, 0x96,
-	0x98, 0xdd, 0x04, 0x93, 0x95, 0xe1, 0x77, 0x00, 0xc9, 0x1e, 0x71, 0x4c, 0x49, 0x1c, 0x93, 0x91,
-	0xe0, 0x1e, 0xd4, 0x57, 0x11, 0x1b, 0xf9, 0x8b, 0x87, 0x71, 0x70, 0xcb, 0x3a, 0xe5, 0x5e, 0xf1,
-	0xac, 0x4a, 0xb3, 0x22, 0x7c, 0x06, 0xcd, 0x55, 0xc4, 0xf4, 0x1b, 0x4f, 0x0f, 0xa2, 0x78, 0xe1,
-	0xfd, 0xc0, 0xa2, 0x4e, 0x45, 0xa0, 0xf2, 0x62, 0xdc, 0x86, 0xf2, 0x32, 0x08, 0xe3, 0xa8, 0xb3,
-	0xd7, 0xdb, 0x3d, 0x3b, 0xa0, 0xc9, 0x42, 0xe9, 0xc1, 0xbb, 0x67, 0xe7, 0xb5, 0x90, 0x79, 0x31,
-	0xd3, 0xe6, 0xab, 0x28, 0x66, 0xa1, 0x8c, 0x84, 0x82, 0xa0, 0x41, 0xfe, 0xcc, 0x66, 0xab, 0x38,
-	0x8d, 0x8d, 0x72, 0x08, 0xcd, 0x4b, 0x7f, 0x91, 0x0d, 0x97, 0xd2, 0x84, 0x03, 0xca, 0x1e, 0x59,
-	0x18, 0xa7, 0x82, 0x63, 0x68, 0x53, 0x16, 0xc5, 0x5e, 0x18, 0xab, 0x3c, 0x6a, 0x51, 0x2a, 0xff,
-	0x39, 0xe0, 0x9c, 0x7c, 0x39, 0x7f, 0xe2, 0x71, 0x10, 0xc1, 0xe5, 0xd6, 0x46, 0x9d, 0x62, 0x6f,
-	0xf7, 0xac, 0x46, 0x33, 0x12, 0xe5, 0x08, 0x5a, 0x4e, 0x1c, 0x2c, 0x1d, 0x16, 0x3e, 0xfa, 0x33,
-	0xb6, 0x26, 0x6b, 0xc1, 0xe1, 0xa6, 0x78, 0x39, 0x7f, 0x52, 0xae, 0xe1, 0xc0, 0x59, 0xdd, 0x44,
-	0x31, 0x5b, 0x3a, 0xb1, 0x17, 0xaf, 0x22, 0xdc, 0x83, 0x12, 0x5f, 0x89, 0x2c, 0x36, 0xfa, 0xfb,
-	0xe7, 0xfe, 0xed, 0xfc, 0x5c, 0x22, 0xa8, 0xd0, 0xe0, 0xf7, 0x50, 0x89, 0x04, 0x56, 0x24, 0xb2,
-	0xd1, 0xaf, 0x27, 0x18, 0x21, 0xa2, 0x52, 0xa5, 0xfc, 0x14, 0x8e, 0xb4, 0xcf, 0x6c, 0xf6, 0x30,
-	0xf0, 0xa3, 0x07, 0x67, 0xe9, 0xcd, 0xd6, 0xa5, 0xd2, 0x86, 0x72, 0xe8, 0xc5, 0x7e, 0x20, 0x0e,
-	0x28, 0xd2, 0x64, 0xa1, 0xfc, 0xab, 0x08, 0xad, 0x3c, 0x9e, 0xbb, 0xfa, 0x1b, 0xa8, 0xdc, 0x79,
-	0xfe, 0x9c, 0xdd, 0x0a, 0x37, 0xeb, 0xfd, 0x0f, 0xe2, 0xac, 0x2d, 0xc8, 0xf3, 0x4b, 0x01, 0x23,
-	0x8b, 0x38, 0x7c, 0xa2, 0x72, 0x4f, 0x97, 0x40, 0x8d, 0xa3, 0x26, 0x91, 0x77, 0xcf, 0x44, 0x8d,
-	0x3e, 0x7a, 0xfe, 0xdc, 0xbb, 0x99, 0x33, 0x71, 0x78, 0x89, 0x3e, 0x0b, 0x70, 0x17, 0xaa, 0x21,
-	0xfb, 0xb2, 0xf2, 0x43, 0x76, 0x2b, 0xdc, 0x2a, 0xd1, 0xf5, 0xba, 0xfb, 0x27, 0xa8, 0x67, 0xd8,
-	0x31, 0x82, 0xdd, 0x07, 0xf6, 0x24, 0x28, 0x6a, 0x94, 0x7f, 0xe2, 0xef, 0xa0, 0xfc, 0xe8, 0xcd,
-	0x57, 0x49, 0x65, 0xd7, 0xfb, 0xca, 0xab, 0x46, 0xae, 0xad, 0xa1, 0xc9, 0x86, 0x5f, 0xef, 0x7c,
-	0x57, 0x54, 0xde, 0xc0, 0xa9, 0x1d, 0xb2, 0xa5, 0x17, 0x32, 0x5e, 0x5b, 0xb9, 0x7a, 0x3a, 0x85,
-	0x93, 0x6d, 0x4a, 0x9e, 0xba, 0x2f, 0x50, 0xd6, 0x3e, 0xaf, 0x16, 0x0f, 0xf8, 0x18, 0x2a, 0x37,
-	0xab, 0xbb, 0x3b, 0x16, 0x0a, 0x9b, 0xf6, 0xa9, 0x5c, 0xe1, 0xf7, 0x50, 0x8a, 0x9f, 0x96, 0x4c,
-	0xa6, 0xa9, 0x29, 0xad, 0x5a, 0x2d, 0x1e, 0xce, 0xdd, 0xa7, 0x25, 0xa3, 0x42, 0xa9, 0x7c, 0x03,
-	0x25, 0xbe, 0xc2, 0x75, 0xd8, 0x9b, 0x98, 0x57, 0xa6, 0xf5, 0x7b, 0x13, 0x15, 0x30, 0x40, 0xc5,
-	0x71, 0x07, 0xd6, 0xc4, 0x45, 0x45, 0xf9, 0x4d, 0x28, 0x45, 0x3b, 0xca, 0xdf, 0x8a, 0xb0, 0x37,
-	0x66, 0x91, 0x88, 0xa7, 0x02, 0xe5, 0x19, 0x27, 0x13, 0x87, 0xd6, 0xfb, 0xf0, 0x4c, 0xaf, 0x17,
-	0x68, 0xa2, 0xc2, 0xdf, 0x6e, 0x94, 0x4a, 0xbd, 0x8f, 0xb3, 0xe5, 0x94, 0x54, 0x8c, 0x5e, 0x48,
-	0x6b, 0x06, 0x7f, 0xc3, 0x73, 0x10, 0x2d, 0x83, 0x45, 0x94, 0xdc, 0xff, 0x7a, 0xff, 0x40, 0xe0,
-	0xa9, 0x14, 0xea, 0x05, 0xba, 0x06, 0x5c, 0x00, 0x54, 0x67, 0xc1, 0x22, 0xe6, 0xb7, 0x42, 0xf9,
-	0xc7, 0x0e, 0x54, 0x53, 0x10, 0x36, 0x00, 0xfb, 0x99, 0x06, 0xb5, 0xc1, 0x77, 0x22, 0xf8, 0x8c,
-	0x17, 0x6a, 0xbd, 0x40, 0xb7, 0x6c, 0xc2, 0xbf, 0x83, 0x26, 0x4b, 0x2f, 0xb3, 0xe4, 0x29, 0x09,
-	0x9e, 0xb6, 0xe0, 0x21, 0x9b, 0x3a, 0xbd, 0x40, 0xf3, 0x70, 0xac, 0x01, 0xba, 0x5b, 0x5f, 0x7e,
-	0x49, 0x51, 0x16, 0x14, 0x47, 0x82, 0xe2, 0x32, 0xa7, 0xd4, 0x0b, 0xf4, 0xc5, 0x06, 0xfc, 0x5b,
-	0x68, 0x84, 0xb2, 0x5d, 0x48, 0x8a, 0x8a, 0xa0, 0x68, 0xc9, 0xe8, 0x64, 0x55, 0x7a, 0x81, 0xe6,
-	0xc0, 0x1b, 0x91, 0x72, 0x01, 0xbf, 0xf4, 0x9e, 0x37, 0x14, 0xdd, 0x8b, 0xc6, 0x7e, 0x18, 0x06,
-	0x61, 0x24, 0xf2, 0x59, 0xa5, 0x19, 0x89, 0xd4, 0x3b, 0xb1, 0xb7, 0xb8, 0xbd, 0x79, 0x12, 0xa9,
-	0x4c, 0xf4, 0x52, 0xa2, 0x58, 0xb0, 0x27, 0x2b, 0x13, 0x63, 0x28, 0x65, 0x86, 0x80, 0xf8, 0xc6,
-	0x9f, 0xa0, 0x35, 0xf6, 0xb8, 0x76, 0xe0, 0xc5, 0xde, 0xc0, 0x0f, 0xd9, 0x2c, 0x0e, 0xc2, 0x27,
-	0x39, 0x06, 0xb6, 0xa9, 0x94, 0x5f, 0x42, 0x33, 0x17, 0x5c, 0xfc, 0x01, 0x2a, 0xc9, 0x30, 0x90,
-	0xf5, 0x96, 0x74, 0xa6, 0xf4, 0x42, 0x48, 0x9d, 0xf2, 0x9f, 0x22, 0xa0, 0x7c, 0x4c, 0xff, 0xbf,
-	0xad, 0xf8, 0x03, 0x1c, 0xb8, 0xe2, 0xeb, 0x9a, 0x85, 0x91, 0x1f, 0x2c, 0xa4, 0x7d, 0x9b, 0x42,
-	0xee, 0xcb, 0x28, 0xb8, 0x57, 0xc3, 0xd9, 0x67, 0xff, 0x91, 0x3d, 0xfb, 0x92, 0x8c, 0xab, 0x6d,
-	0x2a, 0x3c, 0x82, 0x9f, 0x48, 0xd9, 0xad, 0x23, 0x66, 0xd5, 0xb6, 0x58, 0x94, 0xc4, 0xfe, 0xff,
-	0x0d, 0xe4, 0x5d, 0x6c, 0xb2, 0xbc, 0x0f, 0xbd, 0x5b, 0x66, 0x0c, 0x44, 0x25, 0xd5, 0xe8, 0xb3,
-	0x40, 0xf9, 0x6b, 0x11, 0x1a, 0x9b, 0xf5, 0xc0, 0x9d, 0x4f, 0x46, 0xe4, 0x76, 0xe7, 0x13, 0x1d,
-	0x77, 0x3e, 0x39, 0x33, 0xe7, 0xfc, 0x86, 0xf0, 0xc7, 0x3b, 0xaf, 0x7c, 0x0d, 0x68, 0xc8, 0x62,
-	0x2d, 0x58, 0xdc, 0xf9, 0xf7, 0xe9, 0x04, 0xc0, 0x50, 0xe2, 0x33, 0x56, 0x36, 0x50, 0xf1, 0xad,
-	0x7c, 0x0d, 0x8d, 0x0c, 0x8e, 0x77, 0xfe, 0x76, 0xda, 0x53, 0x13, 0x58, 0xb2, 0xf8, 0x68, 0x41,
-	0xc9, 0xe1, 0x33, 0x08, 0xc1, 0xbe, 0xec, 0x56, 0x53, 0xc7, 0x25, 0x36, 0x2a, 0xe0, 0x06, 0x80,
-	0x61, 0x1a, 0xae, 0xa1, 0x8e, 0x8c, 0x3f, 0x12, 0x54, 0xe4, 0xfd, 0x8c, 0xfc, 0x81, 0x68, 0x13,
-	0x97, 0xa0, 0x1d, 0xbc, 0x0f, 0xd5, 0x4b, 0xc3, 0x4c, 0x54, 0xbb, 0xbc, 0xa3, 0x51, 0x72, 0x4d,
-	0xa8, 0x8b, 0x4a, 0x1f, 0xff, 0x5e, 0x81, 0x3d, 0xd9, 0x8f, 0x70, 0x0b, 0x9a, 0x6b, 0xd2, 0xc9,
-	0x85, 0xe4, 0xed, 0xc1, 0x5b, 0x47, 0xbd, 0x36, 0xcc, 0xe1, 0xd4, 0xb1, 0x26, 0x54, 0x23, 0x53,
-	0x6d, 0x34, 0x71, 0x5c, 0x42, 0xa7, 0x9a, 0x65, 0x5e, 0x1a, 0x43, 0x54, 0xc4, 0x07, 0x50, 0x73,
-	0x5c, 0x95, 0xba, 0x53, 0x7d, 0x72, 0x81, 0x76, 0xb8, 0x69, 0xc9, 0x52, 0x1d, 0x12, 0xd3, 0x75,
-	0xd0, 0x2e, 0x6e, 0x03, 0xd2, 0x74, 0xa2, 0x5d, 0x4d, 0x07, 0x86, 0x73, 0x35, 0x75, 0x6c, 0x55,
-	0x23, 0xa8, 0x84, 0xbb, 0x70, 0x3c, 0x24, 0x26, 0xa1, 0xaa, 0x4b, 0xa6, 0xae, 0x4a, 0x87, 0xc4,
-	0x4d, 0x29, 0xcb, 0xf8, 0x04, 0x5a, 0xdc, 0x99, 0xb5, 0x3c, 0x39, 0x12, 0x55, 0xf0, 0x1b, 0x38,
-	0x71, 0xf4, 0x89, 0x3b, 0xe0, 0x36, 0xe6, 0x94, 0x7b, 0xb8, 0x03, 0xed, 0x0b, 0x55, 0xbb, 0x9a,
-	0xd8, 0xa9, 0x6a, 0xac, 0x0a, 0x4d, 0x15, 0x1f, 0xc2, 0x41, 0x62, 0xc1, 0xc4, 0x1e, 0x52, 0x75,
-	0x40, 0x50, 0x6d, 0x83, 0x69, 0xd3, 0x33, 0x04, 0x18, 0x43, 0x43, 0x22, 0x53, 0x8e, 0x3a, 0x6e,
-	0x42, 0x5d, 0xb3, 0xec, 0xef, 0x53, 0xc1, 0x3e, 0x3e, 0x82, 0xc3, 0x14, 0x64, 0x53, 0x63, 0xac,
-	0x52, 0x83, 0x38, 0xe8, 0x80, 0x5b, 0x91, 0xf8, 0x9f, 0xb3, 0xaf, 0x81, 0xbf, 0x85, 0xb3, 0x89,
-	0x3d, 0xc8, 0xfa, 0xab, 0xba, 0xea, 0xc8, 0x1a, 0x4e, 0x55, 0x73, 0x90, 0x0f, 0x6b, 0x93, 0x1b,
-	0x28, 0xd1, 0x03, 0xd5, 0x55, 0xa7, 0x03, 0x83, 0x12, 0xcd, 0xb5, 0xc4, 0x21, 0x08, 0xbf, 0x85,
-	0x4e, 0x8e, 0xca, 0x32, 0x2f, 0xa7, 0x97, 0xc6, 0x88, 0x38, 0xe8, 0x50, 0x24, 0x52, 0x5a, 0xe6,
-	0xb8, 0xaa, 0x39, 0xb8, 0xf8, 0x1e, 0xe1, 0xac, 0x70, 0x6c, 0x50, 0x6a, 0x51, 0x07, 0xb5, 0xf0,
-	0x31, 0xe0, 0x01, 0x19, 0x11, 0xc1, 0x73, 0x31, 0x22, 0x22, 0x37, 0x0e, 0x6a, 0x63, 0x05, 0xde,
-	0xad, 0xe5, 0x59, 0x2f, 0x84, 0x2d, 0x03, 0x83, 0x3a, 0xe8, 0x88, 0xdb, 0x20, 0x31, 0x0e, 0x19,
-	0x8e, 0x89, 0xe9, 0xf2, 0xc3, 0x5c, 0x22, 0xb4, 0xc7, 0x3c, 0x85, 0x8e, 0x6b, 0xd9, 0xbc, 0x28,
-	0x84, 0x7f, 0xb2, 0x1a, 0x4e, 0x78, 0xde, 0xe5, 0xb6, 0x24, 0x92, 0xeb, 0x5d, 0xa8, 0xc3, 0x7d,
-	0x56, 0xa9, 0xa6, 0x1b, 0xd7, 0x64, 0xca, 0xe3, 0x92, 0xf5, 0xf9, 0x94, 0x6f, 0xa4, 0xc4, 0x71,
-	0x2d, 0x4a, 0xf2, 0x09, 0xeb, 0x3e, 0x07, 0x3d, 0xa7, 0x79, 0xc3, 0xb3, 0x94, 0xee, 0xb2, 0x87,
-	0x9a, 0x65, 0xba, 0xd4, 0x1a, 0xa1, 0xb7, 0xf8, 0x2b, 0x38, 0xa5, 0x44, 0xb3, 0xae, 0x09, 0x75,
-	0x48, 0xbe, 0xb4, 0xd1, 0x57, 0x3c, 0xd9, 0xbc, 0xfe, 0x85, 0x6d, 0x13, 0x07, 0xbd, 0xfb, 0x68,
-	0x43, 0x45, 0x3e, 0x10, 0x79, 0x6d, 0xac, 0xaf, 0x9e, 0xd0, 0x16, 0xf8, 0x65, 0xa3, 0x13, 0xd3,
-	0x34, 0x4c, 0x7e, 0x1f, 0xf6, 0xa1, 0xaa, 0x59, 0x63, 0x9b, 0xbb, 0x88, 0x76, 0xf8, 0x65, 0xbb,
-	0x54, 0x8d, 0x11, 0x19, 0xa0, 0x5d, 0x0e, 0x73, 0xae, 0x0c, 0xdb, 0x26, 0x03, 0x54, 0xea, 0xff,
-	0xb3, 0x04, 0x55, 0x6d, 0xee, 0xbb, 0x81, 0xbe, 0xba, 0xc1, 0x3a, 0x34, 0x36, 0xdf, 0x4b, 0xb8,
-	0xbb, 0xf5, 0x11, 0x25, 0x3a, 0x48, 0xb7, 0xf3, 0xda, 0x03, 0x4b, 0x29, 0xe0, 0x5f, 0x00, 0x3c,
-	0x4f, 0x38, 0x7c, 0xfc, 0x62, 0xe0, 0x27, 0x0c, 0x49, 0x17, 0x94, 0x4f, 0x19, 0xa5, 0xf0, 0xa9,
-	0x88, 0x6d, 0x38, 0x79, 0xe5, 0x69, 0x8f, 0xdf, 0xe7, 0x48, 0xb6, 0x3d, 0xfc, 0xb7, 0x30, 0x7e,
-	0x82, 0x3d, 0x39, 0xc4, 0x70, 0x6b, 0xf3, 0xbd, 0xf0, 0xda, 0x8e, 0x3e, 0x54, 0xd3, 0xe1, 0x85,
-	0xdb, 0xb9, 0xf7, 0xc1, 0x6b, 0x7b, 0xce, 0xa1, 0x92, 0x74, 0x7c, 0x8c, 0x37, 0x9e, 0x03, 0xaf,
-	0xe1, 0x7f, 0x05, 0xb5, 0x75, 0xa7, 0xc5, 0xc9, 0x23, 0x24, 0xdf, 0xa1, 0xbb, 0xad, 0xbc, 0x38,
-	0x09, 0x2d, 0xe1, 0xbf, 0x2d, 0x99, 0xbf, 0x11, 0x7c, 0x9a, 0x3e, 0xcf, 0x5e, 0xfc, 0xb9, 0x74,
-	0x4f, 0xb6, 0xa9, 0x12, 0x9a, 0x0b, 0xd8, 0xcf, 0xfe, 0x87, 0xe0, 0x8e, 0xfc, 0x7f, 0x78, 0xf1,
-	0xc7, 0xd2, 0x3d, 0xde, 0xa2, 0x11, 0x1c, 0x37, 0x15, 0xf1, 0x4b, 0xfa, 0xb3, 0xff, 0x06, 0x00,
-	0x00, 0xff, 0xff, 0x11, 0x54, 0x31, 0x02, 0xa6, 0x0e, 0x00, 0x00,
+	// 2606 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x59, 0xdb, 0x72, 0xe3, 0xc6,
+	0xd1, 0x16, 0x25, 0xea, 0xc0, 0xa6, 0x0e, 0xd0, 0x88, 0x92, 0x20, 0xee, 0x7a, 0x2d, 0x63, 0xf7,
+	0x5f, 0xeb, 0xdf, 0x75, 0x54, 0x8e, 0xe2, 0x72, 0x6c, 0x57, 0x4e, 0x10, 0x08, 0x91, 0xc8, 0xf2,
+	0xe4, 0x01, 0xb8, 0xf6, 0xba, 0xca, 0xc5, 0x02, 0xc9, 0x91, 0x16, 0xb5, 0x10, 0xc0, 0xc5, 0x80,
+	0xb2, 0xe9, 0xbb, 0xdc, 0xe4, 0x32, 0x57, 0x79, 0x87, 0xbc, 0x43, 0xde, 0x22, 0x8f, 0x93, 0x4a,
+	0x2e, 0x52, 0x73, 0x00, 0x09, 0x82, 0x60, 0x25, 0xbe, 0xe3, 0xf4, 0xd7, 0xdd, 0xd3, 0xdd, 0xd3,
+	0xd3, 0xdd, 0x03, 0x82, 0x32, 0xf4, 0xbd, 0x7e, 0x1c, 0xf6, 0xdf, 0x4e, 0x06, 0x97, 0xe3, 0x28,
+	0x8c, 0x43, 0xb4, 0xe1, 0x8d, 0x7c, 0xed, 0x1f, 0x7b, 0x70, 0x68, 0x05, 0x5e, 0xec, 0xb9, 0xbe,
+	0xf7, 0x13, 0xc1, 0xe4, 0xfd, 0x84, 0xd0, 0x18, 0x3d, 0x86, 0x92, 0x7b, 0x47, 0x82, 0xb8, 0x1b,
+	0x46, 0xb1, 0x5a, 0x38, 0x2f, 0x5c, 0x6c, 0xe2, 0x39, 0x01, 0x69, 0xb0, 0x4b, 0xc3, 0x49, 0x34,
+	0x24, 0xf5, 0x6e, 0x23, 0xbc, 0x27, 0xea, 0xfa, 0x79, 0xe1, 0xa2, 0x84, 0x17, 0x68, 0x8c, 0x27,
+	0x76, 0xa3, 0x3b, 0x12, 0x4b, 0x9e, 0x0d, 0xc1, 0x93, 0xa6, 0xa1, 0x27, 0x00, 0x42, 0x86, 0x6f,
+	0x53, 0xe4, 0xdb, 0xa4, 0x28, 0xe8, 0x1c, 0xca, 0x13, 0x4a, 0x9a, 0x5e, 0xf0, 0xae, 0x15, 0x8e,
+	0x88, 0xba, 0x79, 0x5e, 0xb8, 0xd8, 0xc1, 0x69, 0x12, 0xba, 0x80, 0x83, 0x09, 0x25, 0x8d, 0x81,
+	0xdb, 0x08, 0x69, 0x1c, 0xb8, 0xf7, 0x84, 0xaa, 0x5b, 0x9c, 0x2b, 0x4b, 0x46, 0x15, 0xd8, 0x1c,
+	0x87, 0x51, 0x4c, 0xd5, 0xed, 0xf3, 0x8d, 0x8b, 0x3d, 0x2c, 0x16, 0xe8, 0x0a, 0x2a, 0x6e, 0xe0,
+	0xfa, 0xd3, 0x9f, 0x88, 0xc3, 0x0d, 0x33, 0xfc, 0x09, 0x8d, 0x49, 0xa4, 0xee, 0x70, 0x25, 0xb9,
+	0x18, 0xb3, 0x4a, 0xd2, 0xff, 0x18, 0x0e, 0xa8, 0x5a, 0xe2, 0x66, 0xa7, 0x49, 0xe8, 0x33, 0x38,
+	0xbe, 0xf7, 0xa2, 0x28, 0x8c, 0x7a, 0xe3, 0xbb, 0xc8, 0x1d, 0x11, 0x3b, 0x8e, 0xdc, 0x98, 0xdc,
+	0x4d, 0x55, 0xe0, 0x41, 0xc8, 0x07, 0xd1, 0x27, 0x70, 0xb8, 0x00, 0x70, 0xed, 0x65, 0xae, 0x7d,
+	0x19, 0x40, 0x5f, 0x81, 0x2a, 0x88, 0xf6, 0x34, 0x18, 0x3a, 0xde, 0x3d, 0x09, 0x27, 0xb1, 0x4d,
+	0x86, 0x61, 0x30, 0xa2, 0xea, 0x2e, 0x17, 0x5a, 0x89, 0xa3, 0x0e, 0x1c, 0xc7, 0xee, 0xc0, 0x27,
+	0x74, 0xec, 0x0e, 0x09, 0x26, 0x7e, 0x38, 0x74, 0x63, 0x2f, 0x0c, 0xa8, 0xba, 0x77, 0xbe, 0x71,
+	0x51, 0xbe, 0x3a, 0xbb, 0xf4, 0x46, 0xfe, 0xa5, 0x93, 0xc3, 0x81, 0xf3, 0xe5, 0xd0, 0xd7, 0x70,
+	0x22, 0x0e, 0xb6, 0xe6, 0xc6, 0x6e, 0xcd, 0x8b, 0xba, 0xbe, 0x3b, 0x24, 0xf7, 0x24, 0x88, 0xd5,
+	0xfd, 0x94, 0xc6, 0x2c, 0x88, 0x27, 0x3e, 0xc1, 0x2b, 0x04, 0x91, 0x05, 0xe5, 0xb7, 0x21, 0x8d,
+	0x5b, 0xee, 0x78, 0xec, 0x05, 0x77, 0xea, 0x01, 0xd7, 0xf3, 0x31, 0xd7, 0xb3, 0x94, 0xae, 0x97,
+	0x8d, 0x39, 0xa7, 0x19, 0xc4, 0xd1, 0x14, 0xa7, 0x65, 0xd1, 0x0b, 0x50, 0x44, 0x52, 0xb5, 0x5c,
+	0x76, 0x80, 0x8c, 0x59, 0x55, 0xf8, 0x49, 0x2c, 0xd1, 0xd1, 0x09, 0x6c, 0x8d, 0x06, 0x3d, 0x4a,
+	0x22, 0xf5, 0x90, 0x73, 0xc8, 0x15, 0x4b, 0xd5, 0xf1, 0xdd, 0xd8, 0xa5, 0xf4, 0xc6, 0xf3, 0x89,
+	0x8a, 0x38, 0x96, 0xa2, 0x20, 0x15, 0xb6, 0x29, 0xf5, 0x79, 0x9a, 0x1e, 0x71, 0x30, 0x59, 0xa2,
+	0x67, 0xb0, 0x37, 0x1a, 0x74, 0x5d, 0x4a, 0x7f, 0x08, 0xa3, 0x91, 0x19, 0x3c, 0xa8, 0x15, 0x8e,
+	0x2f, 0x12, 0xa5, 0xbc, 0x41, 0xa2, 0x58, 0x3d, 0x9e, 0xc9, 0xb3, 0x25, 0xb3, 0x88, 0x52, 0xff,
+	0x15, 0x99, 0xaa, 0x27, 0xc2, 0x22, 0xb1, 0x62, 0x69, 0x48, 0xa9, 0x8f, 0xc3, 0x30, 0xe6, 0x52,
+	0xa7, 0x1c, 0x4c, 0x93, 0xd8, 0xe5, 0x70, 0xc7, 0x63, 0xdf, 0x13, 0xa7, 0xd4, 0x76, 0xef, 0x89,
+	0xaa, 0x72, 0xae, 0x2c, 0x19, 0x7d, 0x0a, 0x47, 0xee, 0x30, 0xf6, 0x1e, 0x88, 0x4d, 0x28, 0xf5,
+	0xc2, 0xa0, 0x1b, 0xfa, 0xde, 0x70, 0xaa, 0x9e, 0x71, 0xee, 0x3c, 0x88, 0xa5, 0xdf, 0x02, 0xf9,
+	0x1b, 0xd7, 0x8b, 0x5b, 0x5e, 0x30, 0x89, 0x09, 0x55, 0xab, 0x22, 0xfd, 0x56, 0xe1, 0xcc, 0xd7,
+	0xf7, 0x13, 0x8f, 0xd0, 0x21, 0x51, 0x1f, 0xf1, 0x7b, 0x96, 0x2c, 0xd1, 0x1d, 0x1c, 0xdf, 0x8d,
+	0xbd, 0xc0, 0x8b, 0xe9, 0x94, 0xc6, 0xe4, 0xbe, 0xf3, 0x40, 0xa2, 0xc8, 0x1b, 0x11, 0xaa, 0x3e,
+	0xe6, 0xc7, 0xff, 0xcb, 0x15, 0xc7, 0x5f, 0xcf, 0x93, 0x11, 0x89, 0x90, 0xaf, 0x8f, 0xa5, 0xc4,
+	0x64, 0x3c, 0x72, 0x63, 0x62, 0xfe, 0x18, 0x93, 0x80, 0xf2, 0xe4, 0xff, 0x80, 0xdb, 0xb2, 0x44,
+	0x67, 0xb7, 0x39, 0x4b, 0xd3, 0x7d, 0x3f, 0xfc, 0x41, 0x7d, 0x72, 0xbe, 0xc1, 0x6e, 0x73, 0x2e,
+	0xc8, 0x2a, 0x4b, 0x16, 0xa8, 0x91, 0x60, 0xaa, 0x7e, 0xc8, 0x85, 0x72, 0x31, 0xf4, 0x05, 0x9c,
+	0xde, 0x8d, 0xdf, 0x86, 0xf7, 0xa4, 0xe5, 0x06, 0xde, 0x2d, 0xa1, 0x31, 0x57, 0xe5, 0x7b, 0x34,
+	0x56, 0xcf, 0xb9, 0xd8, 0x2a, 0x98, 0xa5, 0xe7, 0xc8, 0xa3, 0x71, 0xe4, 0x0d, 0x26, 0x31, 0x51,
+	0x3f, 0xe2, 0x9e, 0xa4, 0x28, 0xe8, 0x73, 0x38, 0x99, 0xaf, 0x9c, 0x74, 0x5d, 0xd6, 0x38, 0xef,
+	0x0a, 0x94, 0x5f, 0x9d, 0x77, 0xde, 0xf8, 0x35, 0x89, 0x98, 0x95, 0xc6, 0x5b, 0x32, 0x7c, 0xa7,
+	0x3e, 0x15, 0x71, 0xca, 0xd2, 0xab, 0xbf, 0x03, 0x25, 0x7b, 0x0f, 0x91, 0x02, 0x1b, 0xef, 0xc8,
+	0x94, 0x77, 0x90, 0x12, 0x66, 0x3f, 0x59, 0x1d, 0x7e, 0x70, 0xfd, 0x49, 0xd2, 0x34, 0xc4, 0xe2,
+	0xab, 0xf5, 0x2f, 0x0a, 0xd5, 0x06, 0x54, 0x57, 0x1f, 0xe4, 0xcf, 0xd1, 0xa4, 0xdd, 0x42, 0x25,
+	0xaf, 0x7a, 0x21, 0x04, 0x45, 0x56, 0x17, 0xa4, 0x12, 0xfe, 0x9b, 0x75, 0xba, 0xd0, 0x1f, 0x75,
+	0x23, 0x72, 0xeb, 0xfd, 0x28, 0x35, 0xcd, 0x09, 0x0c, 0x0d, 0xc8, 0x0f, 0x12, 0x15, 0x2d, 0x6c,
+	0x4e, 0xd0, 0xbe, 0x85, 0x4a, 0x5e, 0x4d, 0xcb, 0xdd, 0x07, 0x41, 0x31, 0x0a, 0xfd, 0xc4, 0x58,
+	0xfe, 0x9b, 0x5d, 0x84, 0x81, 0x4b, 0x49, 0xcd, 0x8b, 0xa4, 0xee, 0x64, 0xa9, 0x9d, 0xc3, 0x93,
+	0x79, 0x9a, 0x1b, 0x11, 0x71, 0x63, 0x22, 0xdb, 0x8f, 0xcc, 0x79, 0x4d, 0x81, 0x7d, 0xf3, 0x47,
+	0x32, 0x9c, 0xc4, 0xc9, 0x2d, 0xd0, 0x0e, 0xe1, 0xe0, 0xc6, 0x0b, 0xd2, 0x17, 0x43, 0x7b, 0x09,
+	0x7b, 0x98, 0x3c, 0x90, 0x28, 0x96, 0x04, 0x54, 0x85, 0x9d, 0x21, 0x3b, 0x2c, 0x3a, 0xb9, 0xe7,
+	0xd6, 0xed, 0xe0, 0xd9, 0x5a, 0x3b, 0x81, 0x0a, 0x26, 0x34, 0x76, 0xa3, 0x58, 0x67, 0x9d, 0x9e,
+	0x26, 0x4a, 0x3e, 0x03, 0x94, 0xa1, 0x8f, 0xfd, 0x29, 0xcb, 0x38, 0x3e, 0x10, 0xb0, 0x23, 0xa7,
+	0x6a, 0x81, 0xa7, 0x67, 0x8a, 0xa2, 0x1d, 0xc3, 0x91, 0x1d, 0x87, 0x63, 0x9b, 0x44, 0x0f, 0xde,
+	0x90, 0xcc, 0x94, 0x1d, 0xc1, 0xe1, 0x22, 0x79, 0xec, 0x4f, 0xb5, 0xd7, 0xb0, 0x67, 0x4f, 0x06,
+	0x34, 0x26, 0x63, 0x3b, 0x76, 0xe3, 0x09, 0x45, 0xe7, 0x50, 0x64, 0x2b, 0x6e, 0xe2, 0xfe, 0xd5,
+	0x2e, 0xbf, 0xf6, 0x92, 0x03, 0x73, 0x04, 0x3d, 0x85, 0x2d, 0xca, 0x79, 0x79, 0x40, 0xf7, 0xaf,
+	0xca, 0x82, 0x87, 0x93, 0xb0, 0x84, 0xb4, 0x5f, 0xc0, 0x31, 0x4f, 0xcd, 0x9a, 0x47, 0xdf, 0xd9,
+	0x22, 0x17, 0x44, 0x18, 0x2a, 0xb0, 0x19, 0xb1, 0x94, 0xe0, 0x1b, 0x14, 0xb0, 0x58, 0x68, 0xff,
+	0x2c, 0xc0, 0x51, 0x96, 0x9f, 0xb9, 0xfa, 0x1b, 0xd8, 0xba, 0x75, 0x3d, 0x9f, 0x8c, 0xb8, 0x9b,
+	0xe5, 0xab, 0x67, 0x7c, 0xaf, 0x1c, 0xce, 0xcb, 0x1b, 0xce, 0x26, 0x2a, 0x8f, 0x94, 0xa9, 0x9a,
+	0x50, 0x62, 0x5c, 0x3d, 0xea, 0xde, 0x11, 0x3e, 0x57, 0x3d, 0xb8, 0x9e, 0xcf, 0xb2, 0x93, 0x6f,
+	0x5e, 0xc4, 0x73, 0x02, 0x3b, 0x9d, 0x88, 0xbc, 0x9f, 0x78, 0x11, 0x19, 0x71, 0xb7, 0x8a, 0x78,
+	0xb6, 0xae, 0x7e, 0x0f, 0xe5, 0x94, 0xf6, 0x9c, 0xeb, 0xf0, 0x45, 0xfa, 0x3a, 0x94, 0xaf, 0xb4,
+	0x95, 0x46, 0xce, 0xac, 0x49, 0x5f, 0x99, 0x47, 0x70, 0xd6, 0x8d, 0xc8, 0xd8, 0x8d, 0x08, 0xcb,
+	0xbb, 0x4c, 0xae, 0x9d, 0xc1, 0x69, 0x1e, 0xc8, 0x8e, 0xee, 0x3d, 0x6c, 0x1a, 0x6f, 0x27, 0xc1,
+	0x3b, 0xd6, 0xa6, 0x06, 0x93, 0xdb, 0x5b, 0x12, 0x71, 0x9b, 0x76, 0xb1, 0x5c, 0xa1, 0xa7, 0x50,
+	0x8c, 0xa7, 0x63, 0x22, 0x8f, 0xe9, 0x40, 0x5a, 0x35, 0x09, 0xde, 0x5d, 0x3a, 0xd3, 0x31, 0xc1,
+	0x1c, 0xd4, 0x5e, 0x42, 0x91, 0xad, 0x50, 0x19, 0xb6, 0x7b, 0xed, 0x57, 0xed, 0xce, 0x37, 0x6d,
+	0x65, 0x0d, 0x01, 0x6c, 0xd9, 0x4e, 0xad, 0xd3, 0x73, 0x94, 0x82, 0xfc, 0x6d, 0x62, 0xac, 0xac,
+	0x6b, 0x7f, 0x2d, 0xc0, 0x76, 0x8b, 0x50, 0x1e, 0x4f, 0x0d, 0x36, 0x87, 0x4c, 0x19, 0xdf, 0xb4,
+	0x7c, 0x05, 0x73, 0xf5, 0x8d, 0x35, 0x2c, 0x20, 0xf4, 0xc9, 0x42, 0xaa, 0x94, 0xaf, 0x50, 0x3a,
+	0x9d, 0x44, 0xc6, 0x34, 0xd6, 0x92, 0x9c, 0x41, 0x2f, 0xd9, 0x19, 0xd0, 0x71, 0x18, 0x50, 0x31,
+	0xb3, 0x96, 0xaf, 0xf6, 0x38, 0x3f, 0x96, 0xc4, 0xc6, 0x1a, 0x9e, 0x31, 0x5c, 0x03, 0xec, 0x0c,
+	0xc3, 0x20, 0x66, 0xb7, 0x42, 0xfb, 0xdb, 0x3a, 0xec, 0x24, 0x4c, 0xc8, 0x02, 0xe4, 0xa5, 0xda,
+	0xd4, 0x82, 0xbe, 0xd3, 0xa5, 0x2e, 0x36, 0xd3, 0x9c, 0x23, 0x84, 0xfe, 0x00, 0x07, 0x24, 0xb9,
+	0xe8, 0x52, 0x4f, 0x91, 0xeb, 0xa9, 0x70, 0x3d, 0xe6, 0x22, 0xd6, 0x58, 0xc3, 0x59, 0x76, 0x64,
+	0x80, 0x72, 0x3b, 0x2b, 0x0c, 0x52, 0xc5, 0x26, 0x57, 0x71, 0xcc, 0x55, 0xdc, 0x64, 0xc0, 0xc6,
+	0x1a, 0x5e, 0x12, 0x40, 0xbf, 0x85, 0xfd, 0x48, 0x96, 0x12, 0xa9, 0x62, 0x8b, 0xab, 0x38, 0x92,
+	0xd1, 0x49, 0x43, 0x8d, 0x35, 0x9c, 0x61, 0x5e, 0x88, 0x94, 0x03, 0x68, 0xd9, 0x7b, 0x56, 0x50,
+	0x1a, 0x2e, 0x6d, 0xf1, 0x99, 0x95, 0xca, 0xe2, 0x94, 0xa2, 0x48, 0xdc, 0x8e, 0xdd, 0x60, 0x34,
+	0x98, 0xaa, 0xeb, 0x33, 0x5c, 0x52, 0xb4, 0x0e, 0x6c, 0x27, 0x13, 0x3a, 0x82, 0x62, 0xea, 0xe1,
+	0xc2, 0x7f, 0xb3, 0x11, 0x47, 0x8c, 0x79, 0xb2, 0x62, 0x93, 0x61, 0x1c, 0x46, 0x53, 0x59, 0x8e,
+	0xf3, 0x20, 0xed, 0xd7, 0x70, 0x90, 0x09, 0x2e, 0x7a, 0x06, 0x5b, 0x62, 0x5c, 0x95, 0xf9, 0x26,
+	0x2a, 0x53, 0x72, 0x21, 0x24, 0xa6, 0xfd, 0xbb, 0x00, 0x4a, 0x36, 0xa6, 0xff, 0x9b, 0x28, 0x1b,
+	0x16, 0x45, 0xff, 0x95, 0x9d, 0x55, 0xda, 0xb7, 0x48, 0x64, 0xbe, 0x34, 0xc3, 0x3b, 0x3d, 0x1a,
+	0xbe, 0xf5, 0x1e, 0xc8, 0xdc, 0x17, 0xd1, 0x43, 0xf2, 0x20, 0xd4, 0x84, 0x8f, 0x24, 0x6d, 0x64,
+	0xa7, 0x46, 0xde, 0xc5, 0x58, 0x14, 0xb9, 0xfc, 0x7f, 0x67, 0x64, 0x55, 0x4c, 0x3e, 0x45, 0xac,
+	0x1a, 0xcf, 0xa4, 0x12, 0x9e, 0x13, 0xb4, 0xbf, 0x14, 0x60, 0x7f, 0x31, 0x1f, 0x98, 0xf3, 0x62,
+	0xd2, 0xce, 0x77, 0x5e, 0x60, 0xcc, 0x79, 0xb1, 0x67, 0xc6, 0xf9, 0x05, 0xe2, 0xcf, 0x77, 0x9e,
+	0xf5, 0x1c, 0x61, 0x4f, 0xd7, 0x77, 0x83, 0xa4, 0xa6, 0x99, 0x70, 0x90, 0x26, 0xb2, 0x3a, 0x7f,
+	0x05, 0x3b, 0x54, 0x54, 0x05, 0x2a, 0x2b, 0xfd, 0x49, 0x2a, 0xb9, 0x19, 0x5f, 0xd2, 0x83, 0x66,
+	0x7c, 0xda, 0x9f, 0x0b, 0x70, 0xb8, 0x84, 0xa3, 0xe7, 0xb0, 0x2d, 0x39, 0x72, 0x5b, 0x58, 0x02,
+	0xb2, 0x40, 0x0e, 0xc3, 0xfb, 0xb1, 0x4f, 0x62, 0x59, 0xf1, 0x77, 0xf0, 0x9c, 0x80, 0x5e, 0xc2,
+	0x36, 0x9b, 0xa1, 0xd9, 0x6c, 0xba, 0xc1, 0xcd, 0x39, 0x4c, 0x99, 0xa3, 0x73, 0x04, 0x27, 0x1c,
+	0xda, 0xdf, 0xd7, 0x61, 0x37, 0x8d, 0xa0, 0x2f, 0xa1, 0x14, 0x8e, 0x09, 0xef, 0x6c, 0x81, 0xb4,
+	0xe2, 0xd1, 0x92, 0xfc, 0x65, 0x27, 0x61, 0xc1, 0x73, 0xee, 0xd9, 0xfc, 0xb2, 0xbe, 0x38, 0x27,
+	0x8d, 0x32, 0xc1, 0x9e, 0x13, 0xe6, 0x2f, 0x79, 0xfe, 0xb8, 0x12, 0x89, 0x94, 0xa2, 0xb0, 0xa7,
+	0x88, 0x58, 0xcd, 0x0f, 0x4c, 0xe4, 0x4d, 0x96, 0xcc, 0x5a, 0xf3, 0x60, 0x1a, 0xcb, 0x77, 0x7c,
+	0x11, 0x8b, 0x85, 0xf6, 0x3d, 0x94, 0x66, 0x96, 0xa2, 0x63, 0x38, 0x94, 0x5d, 0xa2, 0xdf, 0xe9,
+	0x9a, 0x58, 0x77, 0xac, 0x8e, 0xec, 0x17, 0x35, 0xb3, 0x69, 0x3a, 0xa6, 0x52, 0x40, 0x25, 0xd8,
+	0xc4, 0xf6, 0x9b, 0xb6, 0xa1, 0xac, 0x33, 0x6e, 0x6c, 0xda, 0x4e, 0x07, 0x9b, 0xfd, 0x6e, 0xdd,
+	0xe8, 0xb4, 0x1d, 0xdc, 0x69, 0x2a, 0x1b, 0xac, 0xd5, 0xe8, 0xd8, 0x68, 0x58, 0xaf, 0x4d, 0xa5,
+	0xa8, 0x3d, 0x07, 0xa5, 0x4e, 0x62, 0x23, 0x0c, 0x6e, 0xbd, 0xbb, 0x64, 0x46, 0x40, 0x50, 0x64,
+	0x5f, 0x0e, 0x92, 0x21, 0x8e, 0xfd, 0xd6, 0x9e, 0xc3, 0x7e, 0x8a, 0x6f, 0xec, 0xa7, 0x86, 0xd0,
+	0x42, 0x6a, 0x08, 0x7d, 0xd1, 0x81, 0xa2, 0xcd, 0xce, 0x57, 0x81, 0xdd, 0xc4, 0x52, 0xdb, 0x31,
+	0xbb, 0xca, 0x1a, 0xda, 0x07, 0xb0, 0xda, 0x96, 0x63, 0xe9, 0x4d, 0xeb, 0x3b, 0x66, 0x68, 0x19,
+	0xb6, 0xcd, 0x6f, 0x4d, 0xa3, 0xe7, 0x98, 0xca, 0x3a, 0xda, 0x85, 0x9d, 0x1b, 0xab, 0x2d, 0xa0,
+	0x0d, 0xe6, 0x0f, 0x36, 0x5f, 0x9b, 0xd8, 0x51, 0x8a, 0x2f, 0xfe, 0x54, 0x82, 0xed, 0x24, 0xb9,
+	0x8e, 0xe0, 0x60, 0xa6, 0xb4, 0x77, 0x2d, 0xf5, 0x9e, 0xc3, 0x63, 0x5b, 0x7f, 0x6d, 0xb5, 0xeb,
+	0x7d, 0xbb, 0xd3, 0xc3, 0x86, 0xd9, 0x37, 0x9a, 0x3d, 0xdb, 0x31, 0x71, 0xdf, 0xe8, 0xb4, 0x6f,
+	0xac, 0xba, 0x52, 0x40, 0x7b, 0x50, 0xb2, 0x1d, 0x1d, 0x3b, 0xfd, 0x46, 0xef, 0x5a, 0x59, 0x67,
+	0xa6, 0x89, 0xa5, 0x5e, 0x37, 0xdb, 0x8e, 0xad, 0x6c, 0xa0, 0x0a, 0x28, 0x46, 0xc3, 0x34, 0x5e,
+	0xf5, 0x6b, 0x96, 0xfd, 0xaa, 0x6f, 0x77, 0x75, 0xc3, 0x54, 0x8a, 0xa8, 0x0a, 0x27, 0x75, 0xb3,
+	0xcd, 0xa2, 0x6c, 0xf6, 0x1d, 0x1d, 0xd7, 0x4d, 0x27, 0x51, 0xb9, 0x89, 0x4e, 0xe1, 0x88, 0x39,
+	0x33, 0xa3, 0x8b, 0x2d, 0x95, 0x2d, 0xf4, 0x08, 0x4e, 0xed, 0x46, 0xcf, 0xa9, 0x31, 0x1b, 0x33,
+	0xe0, 0x36, 0x52, 0xa1, 0x72, 0xad, 0x1b, 0xaf, 0x7a, 0xdd, 0x04, 0x6a, 0xe9, 0x1c, 0xd9, 0x41,
+	0x87, 0xb0, 0x27, 0x2c, 0xe8, 0x75, 0xeb, 0x58, 0xaf, 0x99, 0x4a, 0x69, 0x41, 0xd3, 0xa2, 0x67,
+	0x0a, 0x20, 0x04, 0xfb, 0x92, 0x33, 0xd1, 0x51, 0x46, 0x07, 0x50, 0x36, 0x3a, 0xdd, 0x37, 0x09,
+	0x61, 0x97, 0x67, 0x8b, 0x64, 0xea, 0x62, 0xab, 0xa5, 0x63, 0xcb, 0xb4, 0x95, 0x3d, 0x66, 0x85,
+	0xf0, 0x3f, 0x63, 0xdf, 0x3e, 0xfa, 0x04, 0x2e, 0x7a, 0xdd, 0x5a, 0xda, 0x5f, 0xdd, 0xd1, 0x9b,
+	0x9d, 0x7a, 0x5f, 0x6f, 0xd7, 0xb2, 0x61, 0x3d, 0x60, 0x06, 0x4a, 0xee, 0x9a, 0xee, 0xe8, 0xfd,
+	0x9a, 0x85, 0x4d, 0xc3, 0xe9, 0xf0, 0x4d, 0x14, 0xf4, 0x18, 0xd4, 0x8c, 0xaa, 0x4e, 0xfb, 0xa6,
+	0x7f, 0x63, 0x35, 0x4d, 0x5b, 0x39, 0xe4, 0x07, 0x29, 0x2d, 0xb3, 0x1d, 0xbd, 0x5d, 0xbb, 0x7e,
+	0xa3, 0xa0, 0x34, 0xb1, 0x65, 0x61, 0xdc, 0xc1, 0xb6, 0x72, 0x84, 0x4e, 0x00, 0x89, 0xd4, 0xee,
+	0x3b, 0xfa, 0x75, 0xd3, 0xe4, 0x67, 0x63, 0x2b, 0x15, 0xa4, 0xc1, 0x93, 0x19, 0x3d, 0xed, 0x05,
+	0xb7, 0xa5, 0x66, 0x61, 0x5b, 0x39, 0x66, 0x36, 0x48, 0x1e, 0xdb, 0xac, 0xb7, 0xcc, 0xb6, 0xc3,
+	0x36, 0x73, 0x4c, 0x8e, 0x9e, 0xb0, 0x23, 0xb4, 0x9d, 0x4e, 0x97, 0x25, 0x05, 0xf7, 0x4f, 0x66,
+	0xc3, 0x29, 0x3b, 0x77, 0x29, 0x26, 0x22, 0x39, 0x93, 0x52, 0x54, 0xe6, 0xb3, 0xbc, 0x3b, 0x7d,
+	0x16, 0x97, 0xb4, 0xcf, 0x67, 0x4c, 0x30, 0xb9, 0x6f, 0x99, 0x03, 0xab, 0xce, 0x83, 0x9e, 0x41,
+	0x1e, 0xe5, 0xdf, 0xd2, 0xc7, 0xe8, 0x03, 0x38, 0xc3, 0xa6, 0xd1, 0x79, 0x6d, 0x62, 0xdb, 0xcc,
+	0xa6, 0xb6, 0xf2, 0x01, 0x3b, 0x6c, 0x96, 0xff, 0xdc, 0xb6, 0x9e, 0xad, 0x3c, 0x61, 0x9b, 0xeb,
+	0x6d, 0xbd, 0xf9, 0xe6, 0xbb, 0x6c, 0x44, 0x94, 0x0f, 0x99, 0x2e, 0x91, 0x5d, 0x32, 0xae, 0xdc,
+	0xdf, 0x24, 0xf0, 0xe7, 0xec, 0x06, 0x09, 0x78, 0x1e, 0xe2, 0x3e, 0x36, 0x9b, 0x1d, 0x83, 0xd7,
+	0x17, 0x5b, 0xf9, 0x08, 0x9d, 0xc1, 0x31, 0x4f, 0x2d, 0x69, 0xc6, 0x3c, 0x9b, 0x34, 0x0e, 0x71,
+	0x61, 0xdd, 0x70, 0x58, 0x58, 0x6c, 0xd3, 0xb6, 0xb9, 0xd4, 0x53, 0x66, 0xd2, 0xd7, 0x3d, 0xcb,
+	0xb4, 0x8d, 0xa5, 0x78, 0x3c, 0xe3, 0xf9, 0xd1, 0x5e, 0x81, 0xfe, 0x1f, 0x53, 0xda, 0xb2, 0xea,
+	0xfc, 0xe6, 0x89, 0x74, 0xeb, 0xc9, 0x5a, 0xf7, 0x7c, 0xee, 0x0b, 0xb3, 0xaf, 0x69, 0x72, 0x57,
+	0xcc, 0xb6, 0xd1, 0xa9, 0x59, 0xed, 0xba, 0xf2, 0x31, 0x4b, 0x22, 0x09, 0x5b, 0xd7, 0x58, 0xd8,
+	0x78, 0x21, 0x2e, 0x02, 0x4f, 0x46, 0xf3, 0x5b, 0xc7, 0x6c, 0x0b, 0xfb, 0xfe, 0x9f, 0x65, 0x40,
+	0xe2, 0x37, 0x0f, 0x58, 0xbd, 0xdb, 0xe8, 0xb4, 0x4c, 0xe5, 0x05, 0x2b, 0x55, 0x35, 0xcb, 0x76,
+	0xb0, 0x75, 0xcd, 0xaa, 0xd3, 0xcb, 0x17, 0x5d, 0xd8, 0x92, 0xcf, 0x33, 0x76, 0xef, 0x66, 0x65,
+	0x8d, 0x47, 0x7e, 0x8d, 0x15, 0x32, 0xdc, 0x6b, 0xb7, 0xd9, 0xfe, 0x05, 0x56, 0xc8, 0x8c, 0x4e,
+	0xab, 0xcb, 0x8b, 0xf1, 0x3a, 0x2b, 0x64, 0x37, 0xba, 0xd5, 0x34, 0x6b, 0xa2, 0xec, 0xda, 0xaf,
+	0xac, 0x6e, 0xd7, 0xac, 0x29, 0xc5, 0xab, 0x7f, 0x15, 0x61, 0xc7, 0xf0, 0x3d, 0x27, 0x6c, 0x4c,
+	0x06, 0xa8, 0x01, 0xfb, 0x8b, 0xaf, 0x15, 0x54, 0xcd, 0x7d, 0xc2, 0xf0, 0xea, 0x5c, 0x55, 0x57,
+	0x3d, 0x6f, 0xb4, 0x35, 0xf4, 0x39, 0xc0, 0x7c, 0xbe, 0x44, 0x27, 0xf9, 0x1f, 0x8d, 0xaa, 0xa2,
+	0x25, 0xcb, 0x87, 0x84, 0xb6, 0xf6, 0x69, 0x01, 0x75, 0xe1, 0x74, 0xc5, 0xa3, 0x1b, 0x3d, 0xcd,
+	0x28, 0xc9, 0x7b, 0x92, 0xe7, 0x68, 0xfc, 0x14, 0xb6, 0xe5, 0x08, 0x89, 0x8e, 0x16, 0xa7, 0xf5,
+	0x55, 0x12, 0x57, 0xb0, 0x93, 0x8c, 0x8e, 0xa8, 0x92, 0x99, 0xce, 0x57, 0xc9, 0x5c, 0xc2, 0x96,
+	0xe8, 0xe9, 0x08, 0x2d, 0x0c, 0xe3, 0xab, 0xf8, 0x7f, 0x0f, 0x7b, 0x75, 0x12, 0xcf, 0xa7, 0x16,
+	0x94, 0x1d, 0x73, 0x12, 0xd1, 0xca, 0x12, 0x5d, 0x04, 0xf8, 0x4b, 0x28, 0xcd, 0xda, 0x20, 0x12,
+	0x6f, 0x88, 0x6c, 0xfb, 0xac, 0x1e, 0x65, 0xc9, 0x42, 0xd4, 0x84, 0xbd, 0x85, 0x8f, 0x09, 0xe8,
+	0x4c, 0xee, 0xb1, 0xfc, 0xe1, 0xa1, 0x7a, 0x9a, 0x07, 0x09, 0x35, 0xd7, 0xb0, 0x9b, 0xfe, 0x8c,
+	0x80, 0x54, 0xf9, 0xfc, 0x5f, 0xfa, 0xe0, 0x50, 0x3d, 0xc9, 0x41, 0xb8, 0x8e, 0xc1, 0x16, 0xff,
+	0x17, 0xe4, 0x57, 0xff, 0x19, 0x00, 0x02, 0x02, 0x19, 0x44, 0x19, 0x19, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1558,6 +2249,7 @@ type CliToHubClient interface {
 	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (CliToHub_ExecuteClient, error)
 	Finalize(ctx context.Context, in *FinalizeRequest, opts ...grpc.CallOption) (CliToHub_FinalizeClient, error)
 	Revert(ctx context.Context, in *RevertRequest, opts ...grpc.CallOption) (CliToHub_RevertClient, error)
+	GetRevertPlan(ctx context.Context, in *RevertPlanRequest, opts ...grpc.CallOption) (*RevertPlanReply, error)
 	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigReply, error)
 	RestartAgents(ctx context.Context, in *RestartAgentsRequest, opts ...grpc.CallOption) (*RestartAgentsReply, error)
 	StopServices(ctx context.Context, in *StopServicesRequest, opts ...grpc.CallOption) (*StopServicesReply, error)
@@ -1740,6 +2432,15 @@ func (x *cliToHubRevertClient) Recv() (*Message, error) {
 	return m, nil
 }
 
+func (c *cliToHubClient) GetRevertPlan(ctx context.Context, in *RevertPlanRequest, opts ...grpc.CallOption) (*RevertPlanReply, error) {
+	out := new(RevertPlanReply)
+	err := c.cc.Invoke(ctx, "/idl.CliToHub/GetRevertPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cliToHubClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigReply, error) {
 	out := new(GetConfigReply)
 	err := c.cc.Invoke(ctx, "/idl.CliToHub/GetConfig", in, out, opts...)
@@ -1775,6 +2476,7 @@ type CliToHubServer interface {
 	Execute(*ExecuteRequest, CliToHub_ExecuteServer) error
 	Finalize(*FinalizeRequest, CliToHub_FinalizeServer) error
 	Revert(*RevertRequest, CliToHub_RevertServer) error
+	GetRevertPlan(context.Context, *RevertPlanRequest) (*RevertPlanReply, error)
 	GetConfig(context.Context, *GetConfigRequest) (*GetConfigReply, error)
 	RestartAgents(context.Context, *RestartAgentsRequest) (*RestartAgentsReply, error)
 	StopServices(context.Context, *StopServicesRequest) (*StopServicesReply, error)
@@ -1802,6 +2504,9 @@ func (*UnimplementedCliToHubServer) Finalize(req *FinalizeRequest, srv CliToHub_
 func (*UnimplementedCliToHubServer) Revert(req *RevertRequest, srv CliToHub_RevertServer) error {
 	return status.Errorf(codes.Unimplemented, "method Revert not implemented")
 }
+func (*UnimplementedCliToHubServer) GetRevertPlan(ctx context.Context, req *RevertPlanRequest) (*RevertPlanReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevertPlan not implemented")
+}
 func (*UnimplementedCliToHubServer) GetConfig(ctx context.Context, req *GetConfigRequest) (*GetConfigReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
 }
@@ -1939,6 +2644,24 @@ func (x *cliToHubRevertServer) Send(m *Message) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CliToHub_GetRevertPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliToHubServer).GetRevertPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.CliToHub/GetRevertPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliToHubServer).GetRevertPlan(ctx, req.(*RevertPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CliToHub_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetConfigRequest)
 	if err := dec(in); err != nil {
@@ -2001,6 +2724,10 @@ var _CliToHub_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CheckDiskSpace",
 			Handler:    _CliToHub_CheckDiskSpace_Handler,
 		},
+		{
+			MethodName: "GetRevertPlan",
+			Handler:    _CliToHub_GetRevertPlan_Handler,
+		},
 		{
 			MethodName: "GetConfig",
 			Handler:    _CliToHub_GetConfig_Handler,
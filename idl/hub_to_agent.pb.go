@@ -292,6 +292,132 @@ func (m *UpgradePrimariesReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_UpgradePrimariesReply proto.InternalMessageInfo
 
+type UpgradeMasterRequest struct {
+	SourceBinDir         string       `protobuf:"bytes,1,opt,name=SourceBinDir,proto3" json:"SourceBinDir,omitempty"`
+	TargetBinDir         string       `protobuf:"bytes,2,opt,name=TargetBinDir,proto3" json:"TargetBinDir,omitempty"`
+	TargetVersion        string       `protobuf:"bytes,3,opt,name=TargetVersion,proto3" json:"TargetVersion,omitempty"`
+	DataDirPair          *DataDirPair `protobuf:"bytes,4,opt,name=DataDirPair,proto3" json:"DataDirPair,omitempty"`
+	CheckOnly            bool         `protobuf:"varint,5,opt,name=CheckOnly,proto3" json:"CheckOnly,omitempty"`
+	UseLinkMode          bool         `protobuf:"varint,6,opt,name=UseLinkMode,proto3" json:"UseLinkMode,omitempty"`
+	OldOptions           string       `protobuf:"bytes,7,opt,name=OldOptions,proto3" json:"OldOptions,omitempty"`
+	MasterBackupDir      string       `protobuf:"bytes,8,opt,name=MasterBackupDir,proto3" json:"MasterBackupDir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UpgradeMasterRequest) Reset()         { *m = UpgradeMasterRequest{} }
+func (m *UpgradeMasterRequest) String() string { return proto.CompactTextString(m) }
+func (*UpgradeMasterRequest) ProtoMessage()    {}
+func (*UpgradeMasterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{4}
+}
+
+func (m *UpgradeMasterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpgradeMasterRequest.Unmarshal(m, b)
+}
+func (m *UpgradeMasterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpgradeMasterRequest.Marshal(b, m, deterministic)
+}
+func (m *UpgradeMasterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpgradeMasterRequest.Merge(m, src)
+}
+func (m *UpgradeMasterRequest) XXX_Size() int {
+	return xxx_messageInfo_UpgradeMasterRequest.Size(m)
+}
+func (m *UpgradeMasterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpgradeMasterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpgradeMasterRequest proto.InternalMessageInfo
+
+func (m *UpgradeMasterRequest) GetSourceBinDir() string {
+	if m != nil {
+		return m.SourceBinDir
+	}
+	return ""
+}
+
+func (m *UpgradeMasterRequest) GetTargetBinDir() string {
+	if m != nil {
+		return m.TargetBinDir
+	}
+	return ""
+}
+
+func (m *UpgradeMasterRequest) GetTargetVersion() string {
+	if m != nil {
+		return m.TargetVersion
+	}
+	return ""
+}
+
+func (m *UpgradeMasterRequest) GetDataDirPair() *DataDirPair {
+	if m != nil {
+		return m.DataDirPair
+	}
+	return nil
+}
+
+func (m *UpgradeMasterRequest) GetCheckOnly() bool {
+	if m != nil {
+		return m.CheckOnly
+	}
+	return false
+}
+
+func (m *UpgradeMasterRequest) GetUseLinkMode() bool {
+	if m != nil {
+		return m.UseLinkMode
+	}
+	return false
+}
+
+func (m *UpgradeMasterRequest) GetOldOptions() string {
+	if m != nil {
+		return m.OldOptions
+	}
+	return ""
+}
+
+func (m *UpgradeMasterRequest) GetMasterBackupDir() string {
+	if m != nil {
+		return m.MasterBackupDir
+	}
+	return ""
+}
+
+type UpgradeMasterReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpgradeMasterReply) Reset()         { *m = UpgradeMasterReply{} }
+func (m *UpgradeMasterReply) String() string { return proto.CompactTextString(m) }
+func (*UpgradeMasterReply) ProtoMessage()    {}
+func (*UpgradeMasterReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{5}
+}
+
+func (m *UpgradeMasterReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpgradeMasterReply.Unmarshal(m, b)
+}
+func (m *UpgradeMasterReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpgradeMasterReply.Marshal(b, m, deterministic)
+}
+func (m *UpgradeMasterReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpgradeMasterReply.Merge(m, src)
+}
+func (m *UpgradeMasterReply) XXX_Size() int {
+	return xxx_messageInfo_UpgradeMasterReply.Size(m)
+}
+func (m *UpgradeMasterReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpgradeMasterReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpgradeMasterReply proto.InternalMessageInfo
+
 type DeleteDataDirectoriesRequest struct {
 	Datadirs             []string `protobuf:"bytes,1,rep,name=datadirs,proto3" json:"datadirs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -303,7 +429,7 @@ func (m *DeleteDataDirectoriesRequest) Reset()         { *m = DeleteDataDirector
 func (m *DeleteDataDirectoriesRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteDataDirectoriesRequest) ProtoMessage()    {}
 func (*DeleteDataDirectoriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{4}
+	return fileDescriptor_9e73bb06acc917d8, []int{6}
 }
 
 func (m *DeleteDataDirectoriesRequest) XXX_Unmarshal(b []byte) error {
@@ -341,7 +467,7 @@ func (m *DeleteDataDirectoriesReply) Reset()         { *m = DeleteDataDirectorie
 func (m *DeleteDataDirectoriesReply) String() string { return proto.CompactTextString(m) }
 func (*DeleteDataDirectoriesReply) ProtoMessage()    {}
 func (*DeleteDataDirectoriesReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{5}
+	return fileDescriptor_9e73bb06acc917d8, []int{7}
 }
 
 func (m *DeleteDataDirectoriesReply) XXX_Unmarshal(b []byte) error {
@@ -372,7 +498,7 @@ func (m *DeleteStateDirectoryRequest) Reset()         { *m = DeleteStateDirector
 func (m *DeleteStateDirectoryRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteStateDirectoryRequest) ProtoMessage()    {}
 func (*DeleteStateDirectoryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{6}
+	return fileDescriptor_9e73bb06acc917d8, []int{8}
 }
 
 func (m *DeleteStateDirectoryRequest) XXX_Unmarshal(b []byte) error {
@@ -403,7 +529,7 @@ func (m *DeleteStateDirectoryReply) Reset()         { *m = DeleteStateDirectoryR
 func (m *DeleteStateDirectoryReply) String() string { return proto.CompactTextString(m) }
 func (*DeleteStateDirectoryReply) ProtoMessage()    {}
 func (*DeleteStateDirectoryReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{7}
+	return fileDescriptor_9e73bb06acc917d8, []int{9}
 }
 
 func (m *DeleteStateDirectoryReply) XXX_Unmarshal(b []byte) error {
@@ -435,7 +561,7 @@ func (m *DeleteTablespaceRequest) Reset()         { *m = DeleteTablespaceRequest
 func (m *DeleteTablespaceRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteTablespaceRequest) ProtoMessage()    {}
 func (*DeleteTablespaceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{8}
+	return fileDescriptor_9e73bb06acc917d8, []int{10}
 }
 
 func (m *DeleteTablespaceRequest) XXX_Unmarshal(b []byte) error {
@@ -473,7 +599,7 @@ func (m *DeleteTablespaceReply) Reset()         { *m = DeleteTablespaceReply{} }
 func (m *DeleteTablespaceReply) String() string { return proto.CompactTextString(m) }
 func (*DeleteTablespaceReply) ProtoMessage()    {}
 func (*DeleteTablespaceReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{9}
+	return fileDescriptor_9e73bb06acc917d8, []int{11}
 }
 
 func (m *DeleteTablespaceReply) XXX_Unmarshal(b []byte) error {
@@ -505,7 +631,7 @@ func (m *ArchiveLogDirectoryRequest) Reset()         { *m = ArchiveLogDirectoryR
 func (m *ArchiveLogDirectoryRequest) String() string { return proto.CompactTextString(m) }
 func (*ArchiveLogDirectoryRequest) ProtoMessage()    {}
 func (*ArchiveLogDirectoryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{10}
+	return fileDescriptor_9e73bb06acc917d8, []int{12}
 }
 
 func (m *ArchiveLogDirectoryRequest) XXX_Unmarshal(b []byte) error {
@@ -543,7 +669,7 @@ func (m *ArchiveLogDirectoryReply) Reset()         { *m = ArchiveLogDirectoryRep
 func (m *ArchiveLogDirectoryReply) String() string { return proto.CompactTextString(m) }
 func (*ArchiveLogDirectoryReply) ProtoMessage()    {}
 func (*ArchiveLogDirectoryReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{11}
+	return fileDescriptor_9e73bb06acc917d8, []int{13}
 }
 
 func (m *ArchiveLogDirectoryReply) XXX_Unmarshal(b []byte) error {
@@ -577,7 +703,7 @@ func (m *RenameDirectories) Reset()         { *m = RenameDirectories{} }
 func (m *RenameDirectories) String() string { return proto.CompactTextString(m) }
 func (*RenameDirectories) ProtoMessage()    {}
 func (*RenameDirectories) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{12}
+	return fileDescriptor_9e73bb06acc917d8, []int{14}
 }
 
 func (m *RenameDirectories) XXX_Unmarshal(b []byte) error {
@@ -630,7 +756,7 @@ func (m *RenameDirectoriesRequest) Reset()         { *m = RenameDirectoriesReque
 func (m *RenameDirectoriesRequest) String() string { return proto.CompactTextString(m) }
 func (*RenameDirectoriesRequest) ProtoMessage()    {}
 func (*RenameDirectoriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{13}
+	return fileDescriptor_9e73bb06acc917d8, []int{15}
 }
 
 func (m *RenameDirectoriesRequest) XXX_Unmarshal(b []byte) error {
@@ -668,7 +794,7 @@ func (m *RenameDirectoriesReply) Reset()         { *m = RenameDirectoriesReply{}
 func (m *RenameDirectoriesReply) String() string { return proto.CompactTextString(m) }
 func (*RenameDirectoriesReply) ProtoMessage()    {}
 func (*RenameDirectoriesReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{14}
+	return fileDescriptor_9e73bb06acc917d8, []int{16}
 }
 
 func (m *RenameDirectoriesReply) XXX_Unmarshal(b []byte) error {
@@ -699,7 +825,7 @@ func (m *StopAgentRequest) Reset()         { *m = StopAgentRequest{} }
 func (m *StopAgentRequest) String() string { return proto.CompactTextString(m) }
 func (*StopAgentRequest) ProtoMessage()    {}
 func (*StopAgentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{15}
+	return fileDescriptor_9e73bb06acc917d8, []int{17}
 }
 
 func (m *StopAgentRequest) XXX_Unmarshal(b []byte) error {
@@ -730,7 +856,7 @@ func (m *StopAgentReply) Reset()         { *m = StopAgentReply{} }
 func (m *StopAgentReply) String() string { return proto.CompactTextString(m) }
 func (*StopAgentReply) ProtoMessage()    {}
 func (*StopAgentReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{16}
+	return fileDescriptor_9e73bb06acc917d8, []int{18}
 }
 
 func (m *StopAgentReply) XXX_Unmarshal(b []byte) error {
@@ -763,7 +889,7 @@ func (m *CheckSegmentDiskSpaceRequest) Reset()         { *m = CheckSegmentDiskSp
 func (m *CheckSegmentDiskSpaceRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckSegmentDiskSpaceRequest) ProtoMessage()    {}
 func (*CheckSegmentDiskSpaceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{17}
+	return fileDescriptor_9e73bb06acc917d8, []int{19}
 }
 
 func (m *CheckSegmentDiskSpaceRequest) XXX_Unmarshal(b []byte) error {
@@ -811,7 +937,7 @@ func (m *RsyncPair) Reset()         { *m = RsyncPair{} }
 func (m *RsyncPair) String() string { return proto.CompactTextString(m) }
 func (*RsyncPair) ProtoMessage()    {}
 func (*RsyncPair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{18}
+	return fileDescriptor_9e73bb06acc917d8, []int{20}
 }
 
 func (m *RsyncPair) XXX_Unmarshal(b []byte) error {
@@ -857,6 +983,7 @@ type RsyncRequest struct {
 	Options              []string     `protobuf:"bytes,1,rep,name=Options,proto3" json:"Options,omitempty"`
 	Excludes             []string     `protobuf:"bytes,2,rep,name=Excludes,proto3" json:"Excludes,omitempty"`
 	Pairs                []*RsyncPair `protobuf:"bytes,3,rep,name=Pairs,proto3" json:"Pairs,omitempty"`
+	SourceVersion        string       `protobuf:"bytes,4,opt,name=SourceVersion,proto3" json:"SourceVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -866,7 +993,7 @@ func (m *RsyncRequest) Reset()         { *m = RsyncRequest{} }
 func (m *RsyncRequest) String() string { return proto.CompactTextString(m) }
 func (*RsyncRequest) ProtoMessage()    {}
 func (*RsyncRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{19}
+	return fileDescriptor_9e73bb06acc917d8, []int{21}
 }
 
 func (m *RsyncRequest) XXX_Unmarshal(b []byte) error {
@@ -908,6 +1035,13 @@ func (m *RsyncRequest) GetPairs() []*RsyncPair {
 	return nil
 }
 
+func (m *RsyncRequest) GetSourceVersion() string {
+	if m != nil {
+		return m.SourceVersion
+	}
+	return ""
+}
+
 type RsyncReply struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -918,7 +1052,7 @@ func (m *RsyncReply) Reset()         { *m = RsyncReply{} }
 func (m *RsyncReply) String() string { return proto.CompactTextString(m) }
 func (*RsyncReply) ProtoMessage()    {}
 func (*RsyncReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{20}
+	return fileDescriptor_9e73bb06acc917d8, []int{22}
 }
 
 func (m *RsyncReply) XXX_Unmarshal(b []byte) error {
@@ -950,7 +1084,7 @@ func (m *RestorePgControlRequest) Reset()         { *m = RestorePgControlRequest
 func (m *RestorePgControlRequest) String() string { return proto.CompactTextString(m) }
 func (*RestorePgControlRequest) ProtoMessage()    {}
 func (*RestorePgControlRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{21}
+	return fileDescriptor_9e73bb06acc917d8, []int{23}
 }
 
 func (m *RestorePgControlRequest) XXX_Unmarshal(b []byte) error {
@@ -988,7 +1122,7 @@ func (m *RestorePgControlReply) Reset()         { *m = RestorePgControlReply{} }
 func (m *RestorePgControlReply) String() string { return proto.CompactTextString(m) }
 func (*RestorePgControlReply) ProtoMessage()    {}
 func (*RestorePgControlReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9e73bb06acc917d8, []int{22}
+	return fileDescriptor_9e73bb06acc917d8, []int{24}
 }
 
 func (m *RestorePgControlReply) XXX_Unmarshal(b []byte) error {
@@ -1009,209 +1143,1380 @@ func (m *RestorePgControlReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_RestorePgControlReply proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*TablespaceInfo)(nil), "idl.TablespaceInfo")
-	proto.RegisterType((*UpgradePrimariesRequest)(nil), "idl.UpgradePrimariesRequest")
-	proto.RegisterType((*DataDirPair)(nil), "idl.DataDirPair")
-	proto.RegisterMapType((map[int32]*TablespaceInfo)(nil), "idl.DataDirPair.TablespacesEntry")
-	proto.RegisterType((*UpgradePrimariesReply)(nil), "idl.UpgradePrimariesReply")
-	proto.RegisterType((*DeleteDataDirectoriesRequest)(nil), "idl.DeleteDataDirectoriesRequest")
-	proto.RegisterType((*DeleteDataDirectoriesReply)(nil), "idl.DeleteDataDirectoriesReply")
-	proto.RegisterType((*DeleteStateDirectoryRequest)(nil), "idl.DeleteStateDirectoryRequest")
-	proto.RegisterType((*DeleteStateDirectoryReply)(nil), "idl.DeleteStateDirectoryReply")
-	proto.RegisterType((*DeleteTablespaceRequest)(nil), "idl.DeleteTablespaceRequest")
-	proto.RegisterType((*DeleteTablespaceReply)(nil), "idl.DeleteTablespaceReply")
-	proto.RegisterType((*ArchiveLogDirectoryRequest)(nil), "idl.ArchiveLogDirectoryRequest")
-	proto.RegisterType((*ArchiveLogDirectoryReply)(nil), "idl.ArchiveLogDirectoryReply")
-	proto.RegisterType((*RenameDirectories)(nil), "idl.RenameDirectories")
-	proto.RegisterType((*RenameDirectoriesRequest)(nil), "idl.RenameDirectoriesRequest")
-	proto.RegisterType((*RenameDirectoriesReply)(nil), "idl.RenameDirectoriesReply")
-	proto.RegisterType((*StopAgentRequest)(nil), "idl.StopAgentRequest")
-	proto.RegisterType((*StopAgentReply)(nil), "idl.StopAgentReply")
-	proto.RegisterType((*CheckSegmentDiskSpaceRequest)(nil), "idl.CheckSegmentDiskSpaceRequest")
-	proto.RegisterType((*RsyncPair)(nil), "idl.RsyncPair")
-	proto.RegisterType((*RsyncRequest)(nil), "idl.RsyncRequest")
-	proto.RegisterType((*RsyncReply)(nil), "idl.RsyncReply")
-	proto.RegisterType((*RestorePgControlRequest)(nil), "idl.RestorePgControlRequest")
-	proto.RegisterType((*RestorePgControlReply)(nil), "idl.RestorePgControlReply")
+type GetDirectorySizesRequest struct {
+	Directories          []string `protobuf:"bytes,1,rep,name=directories,proto3" json:"directories,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("hub_to_agent.proto", fileDescriptor_9e73bb06acc917d8) }
-
-var fileDescriptor_9e73bb06acc917d8 = []byte{
-	// 1003 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4b, 0x6f, 0xdb, 0x46,
-	0x10, 0x8e, 0x5e, 0x96, 0x3d, 0x72, 0x6d, 0x65, 0x1d, 0x5b, 0x0c, 0x2d, 0xa7, 0x32, 0x91, 0x83,
-	0x5a, 0xa0, 0x3e, 0xa8, 0x2e, 0xd0, 0x06, 0x45, 0x81, 0xd8, 0x4c, 0xd0, 0x00, 0x7e, 0xa8, 0x54,
-	0xd2, 0xa0, 0x05, 0x0a, 0x63, 0x45, 0x6d, 0x24, 0x56, 0x34, 0xc9, 0x2e, 0x57, 0x69, 0xf5, 0x73,
-	0x73, 0xea, 0xdf, 0x28, 0xf6, 0x25, 0xae, 0x28, 0xd2, 0xc8, 0xa1, 0x37, 0xce, 0xcc, 0x37, 0xb3,
-	0xf3, 0xfc, 0x24, 0x40, 0xb3, 0xc5, 0xf8, 0x8e, 0xc5, 0x77, 0x78, 0x4a, 0x22, 0x76, 0x96, 0xd0,
-	0x98, 0xc5, 0xa8, 0x16, 0x4c, 0x42, 0xbb, 0xed, 0x87, 0x01, 0x37, 0xcc, 0x16, 0x63, 0xa9, 0x76,
-	0xc6, 0xb0, 0xf7, 0x16, 0x8f, 0x43, 0x92, 0x26, 0xd8, 0x27, 0x6f, 0xa2, 0x0f, 0x31, 0x42, 0x50,
-	0xbf, 0xc1, 0xf7, 0xc4, 0xaa, 0xf5, 0x2a, 0xfd, 0x1d, 0x4f, 0x7c, 0x23, 0x1b, 0xb6, 0xaf, 0x62,
-	0x1f, 0xb3, 0x20, 0x8e, 0xac, 0xba, 0xd0, 0xaf, 0x64, 0xd4, 0x83, 0xd6, 0xbb, 0x94, 0x50, 0x97,
-	0x7c, 0x08, 0x22, 0x32, 0xb1, 0x1a, 0xbd, 0x4a, 0x7f, 0xdb, 0x33, 0x55, 0xce, 0xbf, 0x55, 0xe8,
-	0xbc, 0x4b, 0xa6, 0x14, 0x4f, 0xc8, 0x90, 0x06, 0xf7, 0x98, 0x06, 0x24, 0xf5, 0xc8, 0x5f, 0x0b,
-	0x92, 0x32, 0xe4, 0xc0, 0xee, 0x28, 0x5e, 0x50, 0x9f, 0x5c, 0x04, 0x91, 0x1b, 0x50, 0xab, 0x22,
-	0xa2, 0xaf, 0xe9, 0x38, 0xe6, 0x2d, 0xa6, 0x53, 0xc2, 0x14, 0xa6, 0x2a, 0x31, 0xa6, 0x0e, 0x3d,
-	0x87, 0x2f, 0xa4, 0xfc, 0x2b, 0xa1, 0x29, 0x4f, 0x53, 0xa6, 0xbf, 0xae, 0x44, 0xe7, 0xb0, 0xeb,
-	0x62, 0x86, 0xdd, 0x80, 0x0e, 0x71, 0x40, 0x53, 0xab, 0xde, 0xab, 0xf5, 0x5b, 0x83, 0xf6, 0x59,
-	0x30, 0x09, 0xcf, 0x0c, 0x83, 0xb7, 0x86, 0x42, 0x5d, 0xd8, 0xb9, 0x9c, 0x11, 0x7f, 0x7e, 0x1b,
-	0x85, 0x4b, 0x55, 0x5f, 0xa6, 0x50, 0xf5, 0x5f, 0x05, 0xd1, 0xfc, 0x3a, 0x9e, 0x10, 0x6b, 0x6b,
-	0x55, 0xbf, 0x56, 0xa1, 0x3e, 0xec, 0x5f, 0xe3, 0x94, 0x11, 0x7a, 0x81, 0xfd, 0xf9, 0x22, 0xe1,
-	0x25, 0x34, 0x45, 0x76, 0x79, 0x35, 0xfa, 0x09, 0xec, 0x6c, 0x1a, 0xe9, 0x35, 0x4e, 0x92, 0x20,
-	0x9a, 0xbe, 0x0e, 0x42, 0x32, 0xc4, 0x6c, 0x66, 0x6d, 0x0b, 0xa7, 0x07, 0x10, 0xce, 0xa7, 0x2a,
-	0xb4, 0x8c, 0xd4, 0x79, 0x57, 0x64, 0x27, 0x95, 0x52, 0xb5, 0x77, 0x5d, 0x99, 0xf5, 0x4e, 0xa3,
-	0xaa, 0x66, 0xef, 0x34, 0xea, 0x19, 0x80, 0x74, 0x1b, 0xc6, 0x94, 0x89, 0xf6, 0x36, 0x3c, 0x43,
-	0xc3, 0xed, 0xd2, 0x41, 0xd8, 0xeb, 0xd2, 0x9e, 0x69, 0x90, 0x05, 0xcd, 0xcb, 0x38, 0x62, 0x24,
-	0x62, 0xa2, 0x87, 0x0d, 0x4f, 0x8b, 0x7c, 0xe3, 0xdc, 0x8b, 0x37, 0xae, 0x68, 0x5d, 0xc3, 0x13,
-	0xdf, 0xe8, 0x12, 0x5a, 0x46, 0x9d, 0x56, 0x53, 0x0c, 0xea, 0x34, 0x3f, 0xa8, 0x33, 0x03, 0xf3,
-	0x2a, 0x62, 0x74, 0xe9, 0x99, 0x5e, 0xf6, 0x08, 0xda, 0x79, 0x00, 0x6a, 0x43, 0x6d, 0x4e, 0x96,
-	0xa2, 0x11, 0x0d, 0x8f, 0x7f, 0xa2, 0xaf, 0xa0, 0xf1, 0x11, 0x87, 0x0b, 0x22, 0xca, 0x6e, 0x0d,
-	0x0e, 0xc4, 0x23, 0xeb, 0x47, 0xe1, 0x49, 0xc4, 0x8b, 0xea, 0xf7, 0x15, 0xa7, 0x03, 0x87, 0x9b,
-	0xcb, 0x9c, 0x84, 0x4b, 0xe7, 0x05, 0x74, 0x5d, 0x12, 0x12, 0xa6, 0xfb, 0x4a, 0x7c, 0x16, 0x9b,
-	0xab, 0x6e, 0xc3, 0xf6, 0x04, 0x33, 0x3c, 0xe1, 0x8b, 0x57, 0xe9, 0xd5, 0xf8, 0x11, 0x69, 0xd9,
-	0xe9, 0x82, 0x5d, 0xe2, 0xcb, 0x23, 0x9f, 0xc0, 0xb1, 0xb4, 0x8e, 0x18, 0x66, 0x44, 0x9b, 0x97,
-	0x2a, 0xb0, 0x73, 0x0c, 0x4f, 0x8b, 0xcd, 0xdc, 0xf7, 0x1b, 0xe8, 0x48, 0x63, 0x56, 0x91, 0x4e,
-	0x08, 0x41, 0xdd, 0x48, 0x46, 0x7c, 0xf3, 0xea, 0x36, 0xe1, 0x3c, 0xce, 0x39, 0xd8, 0x2f, 0xa9,
-	0x3f, 0x0b, 0x3e, 0x92, 0xab, 0x78, 0x9a, 0x4f, 0x01, 0x1d, 0xc1, 0xd6, 0x0d, 0xf9, 0x3b, 0xdb,
-	0x30, 0x25, 0x39, 0x36, 0x58, 0x85, 0x5e, 0x3c, 0xe2, 0x14, 0x1e, 0x7b, 0x24, 0xc2, 0xf7, 0xc4,
-	0xa8, 0x97, 0x07, 0x92, 0x3b, 0xa5, 0x03, 0x49, 0x89, 0xeb, 0xe5, 0x2e, 0xa9, 0xe5, 0x54, 0x12,
-	0xe7, 0x06, 0x19, 0x44, 0x59, 0x6b, 0xe2, 0xfc, 0xd6, 0x74, 0xce, 0x6b, 0xb0, 0x36, 0x1e, 0xd2,
-	0x89, 0x7f, 0x0d, 0x75, 0x57, 0xf7, 0xa0, 0x35, 0x38, 0x12, 0xb3, 0xdf, 0x04, 0x0b, 0x8c, 0x63,
-	0xc1, 0x51, 0x41, 0x1c, 0x5e, 0x0a, 0x82, 0xf6, 0x88, 0xc5, 0xc9, 0x4b, 0xce, 0xb7, 0x7a, 0x2a,
-	0x6d, 0xd8, 0x33, 0x74, 0x1c, 0x95, 0x40, 0x57, 0xd0, 0xc6, 0x88, 0x4c, 0xef, 0x49, 0xc4, 0xdc,
-	0x20, 0x9d, 0x8f, 0xcc, 0x79, 0x9c, 0x43, 0x93, 0xca, 0x4f, 0x51, 0x7c, 0x6b, 0x60, 0x8b, 0x74,
-	0x84, 0x4f, 0x1e, 0xec, 0x69, 0xe8, 0xda, 0x5a, 0x55, 0x73, 0x6b, 0x15, 0xc3, 0x8e, 0x97, 0x2e,
-	0x23, 0x5f, 0x90, 0x41, 0x59, 0x6b, 0xfb, 0xb0, 0xef, 0x92, 0x94, 0x05, 0x91, 0xe0, 0xf3, 0x9f,
-	0xe3, 0x54, 0xf7, 0x38, 0xaf, 0xe6, 0x54, 0x67, 0xa8, 0x14, 0xc5, 0x9a, 0x2a, 0xe7, 0x4f, 0xd8,
-	0x15, 0x0f, 0xea, 0x92, 0x2c, 0x68, 0xde, 0x26, 0xdc, 0xa2, 0xb7, 0x4c, 0x8b, 0x3c, 0xed, 0x57,
-	0xff, 0xf8, 0xe1, 0x62, 0x42, 0x56, 0x69, 0x6b, 0x19, 0x3d, 0x87, 0x86, 0xe4, 0xe7, 0x9a, 0x98,
-	0xca, 0x9e, 0x9c, 0x8a, 0x2e, 0xc4, 0x93, 0x46, 0x67, 0x17, 0x40, 0xbd, 0xc5, 0x9b, 0xfb, 0x1d,
-	0x74, 0x3c, 0x92, 0xb2, 0x98, 0x92, 0xe1, 0x94, 0x13, 0x0b, 0x8d, 0xc3, 0xcf, 0x39, 0xbc, 0x0e,
-	0x1c, 0x6e, 0xba, 0x25, 0xe1, 0x72, 0xf0, 0xa9, 0x09, 0x0d, 0x31, 0x3b, 0x74, 0x0b, 0x7b, 0xeb,
-	0x23, 0x40, 0xa7, 0xd9, 0x5c, 0x4a, 0x66, 0x69, 0x5b, 0x85, 0xa3, 0xe3, 0x89, 0x3e, 0x42, 0x37,
-	0xd0, 0xce, 0x33, 0x08, 0xea, 0x0a, 0x7c, 0xc9, 0xaf, 0xa4, 0x6d, 0x97, 0x58, 0x65, 0xbc, 0x5f,
-	0x8a, 0x0e, 0xe9, 0xa4, 0x64, 0x95, 0x55, 0xc4, 0xe3, 0x32, 0xb3, 0x0c, 0xf9, 0x03, 0xec, 0xac,
-	0x96, 0x17, 0x1d, 0x0a, 0x6c, 0x7e, 0xc1, 0xed, 0x83, 0xbc, 0x5a, 0xba, 0xfe, 0xa1, 0x19, 0x24,
-	0x47, 0x65, 0xaa, 0x6b, 0x0f, 0x51, 0xa4, 0xfd, 0xe5, 0x43, 0x10, 0x19, 0xfe, 0x77, 0x78, 0x52,
-	0x44, 0x76, 0xa8, 0x67, 0xb8, 0x16, 0xd2, 0xa4, 0xfd, 0xec, 0x01, 0x84, 0x8c, 0xfd, 0x9b, 0xe6,
-	0xd9, 0x8c, 0xfc, 0xcc, 0x02, 0xba, 0x46, 0x80, 0x0d, 0x36, 0x55, 0x33, 0x2a, 0x26, 0xcf, 0x47,
-	0xe8, 0x0e, 0x4e, 0xd5, 0xcb, 0xe2, 0xe8, 0xfe, 0xff, 0x07, 0xde, 0xc3, 0x41, 0x01, 0xd3, 0x22,
-	0xd9, 0xd1, 0x72, 0xe6, 0xb6, 0x4f, 0xca, 0x01, 0x32, 0xf0, 0x8f, 0xf0, 0x44, 0x9c, 0x59, 0x7e,
-	0x9c, 0x8f, 0xb3, 0xab, 0xd4, 0xb1, 0xf6, 0x4d, 0x95, 0xf4, 0xbe, 0x00, 0x5b, 0xc8, 0xc5, 0x05,
-	0x7f, 0x5e, 0x8c, 0xf7, 0xf0, 0x54, 0xdf, 0xa8, 0x5e, 0xfd, 0xd5, 0xb1, 0xaa, 0x9e, 0x95, 0x9c,
-	0xbe, 0xea, 0x59, 0xe1, 0x85, 0x3b, 0x8f, 0xc6, 0x5b, 0xe2, 0x3f, 0xf0, 0xb7, 0xff, 0x05, 0x00,
-	0x00, 0xff, 0xff, 0xa6, 0xba, 0x70, 0x1c, 0x30, 0x0b, 0x00, 0x00,
+func (m *GetDirectorySizesRequest) Reset()         { *m = GetDirectorySizesRequest{} }
+func (m *GetDirectorySizesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDirectorySizesRequest) ProtoMessage()    {}
+func (*GetDirectorySizesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{25}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *GetDirectorySizesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDirectorySizesRequest.Unmarshal(m, b)
+}
+func (m *GetDirectorySizesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDirectorySizesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDirectorySizesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDirectorySizesRequest.Merge(m, src)
+}
+func (m *GetDirectorySizesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDirectorySizesRequest.Size(m)
+}
+func (m *GetDirectorySizesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDirectorySizesRequest.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_GetDirectorySizesRequest proto.InternalMessageInfo
 
-// AgentClient is the client API for Agent service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AgentClient interface {
-	CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*CheckDiskSpaceReply, error)
-	UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error)
-	RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error)
-	StopAgent(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error)
-	DeleteDataDirectories(ctx context.Context, in *DeleteDataDirectoriesRequest, opts ...grpc.CallOption) (*DeleteDataDirectoriesReply, error)
-	DeleteStateDirectory(ctx context.Context, in *DeleteStateDirectoryRequest, opts ...grpc.CallOption) (*DeleteStateDirectoryReply, error)
-	DeleteTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error)
-	DeleteSourceTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error)
-	ArchiveLogDirectory(ctx context.Context, in *ArchiveLogDirectoryRequest, opts ...grpc.CallOption) (*ArchiveLogDirectoryReply, error)
-	RsyncDataDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error)
-	RsyncTablespaceDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error)
-	RestorePrimariesPgControl(ctx context.Context, in *RestorePgControlRequest, opts ...grpc.CallOption) (*RestorePgControlReply, error)
+func (m *GetDirectorySizesRequest) GetDirectories() []string {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
 }
 
-type agentClient struct {
-	cc *grpc.ClientConn
+type GetDirectorySizesReply struct {
+	Sizes                map[string]uint64 `protobuf:"bytes,1,rep,name=sizes,proto3" json:"sizes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func NewAgentClient(cc *grpc.ClientConn) AgentClient {
-	return &agentClient{cc}
+func (m *GetDirectorySizesReply) Reset()         { *m = GetDirectorySizesReply{} }
+func (m *GetDirectorySizesReply) String() string { return proto.CompactTextString(m) }
+func (*GetDirectorySizesReply) ProtoMessage()    {}
+func (*GetDirectorySizesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{26}
 }
 
-func (c *agentClient) CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*CheckDiskSpaceReply, error) {
-	out := new(CheckDiskSpaceReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/CheckDiskSpace", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetDirectorySizesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDirectorySizesReply.Unmarshal(m, b)
+}
+func (m *GetDirectorySizesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDirectorySizesReply.Marshal(b, m, deterministic)
+}
+func (m *GetDirectorySizesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDirectorySizesReply.Merge(m, src)
+}
+func (m *GetDirectorySizesReply) XXX_Size() int {
+	return xxx_messageInfo_GetDirectorySizesReply.Size(m)
+}
+func (m *GetDirectorySizesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDirectorySizesReply.DiscardUnknown(m)
 }
 
-func (c *agentClient) UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error) {
-	out := new(UpgradePrimariesReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/UpgradePrimaries", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetDirectorySizesReply proto.InternalMessageInfo
+
+func (m *GetDirectorySizesReply) GetSizes() map[string]uint64 {
+	if m != nil {
+		return m.Sizes
 	}
-	return out, nil
+	return nil
 }
 
-func (c *agentClient) RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error) {
-	out := new(RenameDirectoriesReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/RenameDirectories", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type CheckTablespaceRelocationsRequest struct {
+	Relocations          []*CheckTablespaceRelocationsRequest_Relocation `protobuf:"bytes,1,rep,name=relocations,proto3" json:"relocations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                        `json:"-"`
+	XXX_unrecognized     []byte                                          `json:"-"`
+	XXX_sizecache        int32                                           `json:"-"`
 }
 
-func (c *agentClient) StopAgent(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error) {
-	out := new(StopAgentReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/StopAgent", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CheckTablespaceRelocationsRequest) Reset()         { *m = CheckTablespaceRelocationsRequest{} }
+func (m *CheckTablespaceRelocationsRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckTablespaceRelocationsRequest) ProtoMessage()    {}
+func (*CheckTablespaceRelocationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{27}
 }
 
-func (c *agentClient) DeleteDataDirectories(ctx context.Context, in *DeleteDataDirectoriesRequest, opts ...grpc.CallOption) (*DeleteDataDirectoriesReply, error) {
-	out := new(DeleteDataDirectoriesReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteDataDirectories", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CheckTablespaceRelocationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckTablespaceRelocationsRequest.Unmarshal(m, b)
+}
+func (m *CheckTablespaceRelocationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckTablespaceRelocationsRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckTablespaceRelocationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckTablespaceRelocationsRequest.Merge(m, src)
+}
+func (m *CheckTablespaceRelocationsRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckTablespaceRelocationsRequest.Size(m)
+}
+func (m *CheckTablespaceRelocationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckTablespaceRelocationsRequest.DiscardUnknown(m)
 }
 
-func (c *agentClient) DeleteStateDirectory(ctx context.Context, in *DeleteStateDirectoryRequest, opts ...grpc.CallOption) (*DeleteStateDirectoryReply, error) {
-	out := new(DeleteStateDirectoryReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteStateDirectory", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_CheckTablespaceRelocationsRequest proto.InternalMessageInfo
+
+func (m *CheckTablespaceRelocationsRequest) GetRelocations() []*CheckTablespaceRelocationsRequest_Relocation {
+	if m != nil {
+		return m.Relocations
 	}
-	return out, nil
+	return nil
 }
 
-func (c *agentClient) DeleteTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error) {
-	out := new(DeleteTablespaceReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteTablespaceDirectories", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type CheckTablespaceRelocationsRequest_Relocation struct {
+	SourceDir            string   `protobuf:"bytes,1,opt,name=sourceDir,proto3" json:"sourceDir,omitempty"`
+	TargetDir            string   `protobuf:"bytes,2,opt,name=targetDir,proto3" json:"targetDir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *agentClient) DeleteSourceTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error) {
-	out := new(DeleteTablespaceReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteSourceTablespaceDirectories", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CheckTablespaceRelocationsRequest_Relocation) Reset() {
+	*m = CheckTablespaceRelocationsRequest_Relocation{}
+}
+func (m *CheckTablespaceRelocationsRequest_Relocation) String() string {
+	return proto.CompactTextString(m)
+}
+func (*CheckTablespaceRelocationsRequest_Relocation) ProtoMessage() {}
+func (*CheckTablespaceRelocationsRequest_Relocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{27, 0}
 }
 
-func (c *agentClient) ArchiveLogDirectory(ctx context.Context, in *ArchiveLogDirectoryRequest, opts ...grpc.CallOption) (*ArchiveLogDirectoryReply, error) {
+func (m *CheckTablespaceRelocationsRequest_Relocation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckTablespaceRelocationsRequest_Relocation.Unmarshal(m, b)
+}
+func (m *CheckTablespaceRelocationsRequest_Relocation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckTablespaceRelocationsRequest_Relocation.Marshal(b, m, deterministic)
+}
+func (m *CheckTablespaceRelocationsRequest_Relocation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckTablespaceRelocationsRequest_Relocation.Merge(m, src)
+}
+func (m *CheckTablespaceRelocationsRequest_Relocation) XXX_Size() int {
+	return xxx_messageInfo_CheckTablespaceRelocationsRequest_Relocation.Size(m)
+}
+func (m *CheckTablespaceRelocationsRequest_Relocation) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckTablespaceRelocationsRequest_Relocation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckTablespaceRelocationsRequest_Relocation proto.InternalMessageInfo
+
+func (m *CheckTablespaceRelocationsRequest_Relocation) GetSourceDir() string {
+	if m != nil {
+		return m.SourceDir
+	}
+	return ""
+}
+
+func (m *CheckTablespaceRelocationsRequest_Relocation) GetTargetDir() string {
+	if m != nil {
+		return m.TargetDir
+	}
+	return ""
+}
+
+type CheckTablespaceRelocationsReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckTablespaceRelocationsReply) Reset()         { *m = CheckTablespaceRelocationsReply{} }
+func (m *CheckTablespaceRelocationsReply) String() string { return proto.CompactTextString(m) }
+func (*CheckTablespaceRelocationsReply) ProtoMessage()    {}
+func (*CheckTablespaceRelocationsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{28}
+}
+
+func (m *CheckTablespaceRelocationsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckTablespaceRelocationsReply.Unmarshal(m, b)
+}
+func (m *CheckTablespaceRelocationsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckTablespaceRelocationsReply.Marshal(b, m, deterministic)
+}
+func (m *CheckTablespaceRelocationsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckTablespaceRelocationsReply.Merge(m, src)
+}
+func (m *CheckTablespaceRelocationsReply) XXX_Size() int {
+	return xxx_messageInfo_CheckTablespaceRelocationsReply.Size(m)
+}
+func (m *CheckTablespaceRelocationsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckTablespaceRelocationsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckTablespaceRelocationsReply proto.InternalMessageInfo
+
+type GetSegmentStatusesRequest struct {
+	Contents             []int32  `protobuf:"varint,1,rep,packed,name=contents,proto3" json:"contents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSegmentStatusesRequest) Reset()         { *m = GetSegmentStatusesRequest{} }
+func (m *GetSegmentStatusesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSegmentStatusesRequest) ProtoMessage()    {}
+func (*GetSegmentStatusesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{29}
+}
+
+func (m *GetSegmentStatusesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSegmentStatusesRequest.Unmarshal(m, b)
+}
+func (m *GetSegmentStatusesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSegmentStatusesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSegmentStatusesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSegmentStatusesRequest.Merge(m, src)
+}
+func (m *GetSegmentStatusesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSegmentStatusesRequest.Size(m)
+}
+func (m *GetSegmentStatusesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSegmentStatusesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSegmentStatusesRequest proto.InternalMessageInfo
+
+func (m *GetSegmentStatusesRequest) GetContents() []int32 {
+	if m != nil {
+		return m.Contents
+	}
+	return nil
+}
+
+type SegmentStatus struct {
+	Content              int32    `protobuf:"varint,1,opt,name=content,proto3" json:"content,omitempty"`
+	LinkMode             bool     `protobuf:"varint,2,opt,name=linkMode,proto3" json:"linkMode,omitempty"`
+	Started              bool     `protobuf:"varint,3,opt,name=started,proto3" json:"started,omitempty"`
+	Finished             bool     `protobuf:"varint,4,opt,name=finished,proto3" json:"finished,omitempty"`
+	Recorded             bool     `protobuf:"varint,5,opt,name=recorded,proto3" json:"recorded,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentStatus) Reset()         { *m = SegmentStatus{} }
+func (m *SegmentStatus) String() string { return proto.CompactTextString(m) }
+func (*SegmentStatus) ProtoMessage()    {}
+func (*SegmentStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{30}
+}
+
+func (m *SegmentStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentStatus.Unmarshal(m, b)
+}
+func (m *SegmentStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentStatus.Marshal(b, m, deterministic)
+}
+func (m *SegmentStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentStatus.Merge(m, src)
+}
+func (m *SegmentStatus) XXX_Size() int {
+	return xxx_messageInfo_SegmentStatus.Size(m)
+}
+func (m *SegmentStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentStatus proto.InternalMessageInfo
+
+func (m *SegmentStatus) GetContent() int32 {
+	if m != nil {
+		return m.Content
+	}
+	return 0
+}
+
+func (m *SegmentStatus) GetLinkMode() bool {
+	if m != nil {
+		return m.LinkMode
+	}
+	return false
+}
+
+func (m *SegmentStatus) GetStarted() bool {
+	if m != nil {
+		return m.Started
+	}
+	return false
+}
+
+func (m *SegmentStatus) GetFinished() bool {
+	if m != nil {
+		return m.Finished
+	}
+	return false
+}
+
+func (m *SegmentStatus) GetRecorded() bool {
+	if m != nil {
+		return m.Recorded
+	}
+	return false
+}
+
+type GetSegmentStatusesReply struct {
+	Statuses             []*SegmentStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetSegmentStatusesReply) Reset()         { *m = GetSegmentStatusesReply{} }
+func (m *GetSegmentStatusesReply) String() string { return proto.CompactTextString(m) }
+func (*GetSegmentStatusesReply) ProtoMessage()    {}
+func (*GetSegmentStatusesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{31}
+}
+
+func (m *GetSegmentStatusesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSegmentStatusesReply.Unmarshal(m, b)
+}
+func (m *GetSegmentStatusesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSegmentStatusesReply.Marshal(b, m, deterministic)
+}
+func (m *GetSegmentStatusesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSegmentStatusesReply.Merge(m, src)
+}
+func (m *GetSegmentStatusesReply) XXX_Size() int {
+	return xxx_messageInfo_GetSegmentStatusesReply.Size(m)
+}
+func (m *GetSegmentStatusesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSegmentStatusesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSegmentStatusesReply proto.InternalMessageInfo
+
+func (m *GetSegmentStatusesReply) GetStatuses() []*SegmentStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+type UpgradeMirrorRequest struct {
+	Content              int32    `protobuf:"varint,1,opt,name=content,proto3" json:"content,omitempty"`
+	PrimaryHost          string   `protobuf:"bytes,2,opt,name=primaryHost,proto3" json:"primaryHost,omitempty"`
+	PrimaryDataDir       string   `protobuf:"bytes,3,opt,name=primaryDataDir,proto3" json:"primaryDataDir,omitempty"`
+	PrimaryPort          int32    `protobuf:"varint,4,opt,name=primaryPort,proto3" json:"primaryPort,omitempty"`
+	MirrorDataDir        string   `protobuf:"bytes,5,opt,name=mirrorDataDir,proto3" json:"mirrorDataDir,omitempty"`
+	MirrorPort           int32    `protobuf:"varint,6,opt,name=mirrorPort,proto3" json:"mirrorPort,omitempty"`
+	MirrorDbid           int32    `protobuf:"varint,7,opt,name=mirrorDbid,proto3" json:"mirrorDbid,omitempty"`
+	TargetVersion        string   `protobuf:"bytes,8,opt,name=targetVersion,proto3" json:"targetVersion,omitempty"`
+	User                 string   `protobuf:"bytes,9,opt,name=user,proto3" json:"user,omitempty"`
+	SslMode              string   `protobuf:"bytes,10,opt,name=sslMode,proto3" json:"sslMode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpgradeMirrorRequest) Reset()         { *m = UpgradeMirrorRequest{} }
+func (m *UpgradeMirrorRequest) String() string { return proto.CompactTextString(m) }
+func (*UpgradeMirrorRequest) ProtoMessage()    {}
+func (*UpgradeMirrorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{32}
+}
+
+func (m *UpgradeMirrorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpgradeMirrorRequest.Unmarshal(m, b)
+}
+func (m *UpgradeMirrorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpgradeMirrorRequest.Marshal(b, m, deterministic)
+}
+func (m *UpgradeMirrorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpgradeMirrorRequest.Merge(m, src)
+}
+func (m *UpgradeMirrorRequest) XXX_Size() int {
+	return xxx_messageInfo_UpgradeMirrorRequest.Size(m)
+}
+func (m *UpgradeMirrorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpgradeMirrorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpgradeMirrorRequest proto.InternalMessageInfo
+
+func (m *UpgradeMirrorRequest) GetContent() int32 {
+	if m != nil {
+		return m.Content
+	}
+	return 0
+}
+
+func (m *UpgradeMirrorRequest) GetPrimaryHost() string {
+	if m != nil {
+		return m.PrimaryHost
+	}
+	return ""
+}
+
+func (m *UpgradeMirrorRequest) GetPrimaryDataDir() string {
+	if m != nil {
+		return m.PrimaryDataDir
+	}
+	return ""
+}
+
+func (m *UpgradeMirrorRequest) GetPrimaryPort() int32 {
+	if m != nil {
+		return m.PrimaryPort
+	}
+	return 0
+}
+
+func (m *UpgradeMirrorRequest) GetMirrorDataDir() string {
+	if m != nil {
+		return m.MirrorDataDir
+	}
+	return ""
+}
+
+func (m *UpgradeMirrorRequest) GetMirrorPort() int32 {
+	if m != nil {
+		return m.MirrorPort
+	}
+	return 0
+}
+
+func (m *UpgradeMirrorRequest) GetMirrorDbid() int32 {
+	if m != nil {
+		return m.MirrorDbid
+	}
+	return 0
+}
+
+func (m *UpgradeMirrorRequest) GetTargetVersion() string {
+	if m != nil {
+		return m.TargetVersion
+	}
+	return ""
+}
+
+func (m *UpgradeMirrorRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *UpgradeMirrorRequest) GetSslMode() string {
+	if m != nil {
+		return m.SslMode
+	}
+	return ""
+}
+
+type UpgradeMirrorReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpgradeMirrorReply) Reset()         { *m = UpgradeMirrorReply{} }
+func (m *UpgradeMirrorReply) String() string { return proto.CompactTextString(m) }
+func (*UpgradeMirrorReply) ProtoMessage()    {}
+func (*UpgradeMirrorReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{33}
+}
+
+func (m *UpgradeMirrorReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpgradeMirrorReply.Unmarshal(m, b)
+}
+func (m *UpgradeMirrorReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpgradeMirrorReply.Marshal(b, m, deterministic)
+}
+func (m *UpgradeMirrorReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpgradeMirrorReply.Merge(m, src)
+}
+func (m *UpgradeMirrorReply) XXX_Size() int {
+	return xxx_messageInfo_UpgradeMirrorReply.Size(m)
+}
+func (m *UpgradeMirrorReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpgradeMirrorReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpgradeMirrorReply proto.InternalMessageInfo
+
+type ReplicationEntry struct {
+	DataDir              string   `protobuf:"bytes,1,opt,name=dataDir,proto3" json:"dataDir,omitempty"`
+	MirrorHost           string   `protobuf:"bytes,2,opt,name=mirrorHost,proto3" json:"mirrorHost,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplicationEntry) Reset()         { *m = ReplicationEntry{} }
+func (m *ReplicationEntry) String() string { return proto.CompactTextString(m) }
+func (*ReplicationEntry) ProtoMessage()    {}
+func (*ReplicationEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{34}
+}
+
+func (m *ReplicationEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplicationEntry.Unmarshal(m, b)
+}
+func (m *ReplicationEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplicationEntry.Marshal(b, m, deterministic)
+}
+func (m *ReplicationEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicationEntry.Merge(m, src)
+}
+func (m *ReplicationEntry) XXX_Size() int {
+	return xxx_messageInfo_ReplicationEntry.Size(m)
+}
+func (m *ReplicationEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicationEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicationEntry proto.InternalMessageInfo
+
+func (m *ReplicationEntry) GetDataDir() string {
+	if m != nil {
+		return m.DataDir
+	}
+	return ""
+}
+
+func (m *ReplicationEntry) GetMirrorHost() string {
+	if m != nil {
+		return m.MirrorHost
+	}
+	return ""
+}
+
+type AddReplicationEntriesRequest struct {
+	Entries              []*ReplicationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	User                 string              `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	UseHbaHostnames      bool                `protobuf:"varint,3,opt,name=useHbaHostnames,proto3" json:"useHbaHostnames,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *AddReplicationEntriesRequest) Reset()         { *m = AddReplicationEntriesRequest{} }
+func (m *AddReplicationEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*AddReplicationEntriesRequest) ProtoMessage()    {}
+func (*AddReplicationEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{35}
+}
+
+func (m *AddReplicationEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddReplicationEntriesRequest.Unmarshal(m, b)
+}
+func (m *AddReplicationEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddReplicationEntriesRequest.Marshal(b, m, deterministic)
+}
+func (m *AddReplicationEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddReplicationEntriesRequest.Merge(m, src)
+}
+func (m *AddReplicationEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_AddReplicationEntriesRequest.Size(m)
+}
+func (m *AddReplicationEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddReplicationEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddReplicationEntriesRequest proto.InternalMessageInfo
+
+func (m *AddReplicationEntriesRequest) GetEntries() []*ReplicationEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *AddReplicationEntriesRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *AddReplicationEntriesRequest) GetUseHbaHostnames() bool {
+	if m != nil {
+		return m.UseHbaHostnames
+	}
+	return false
+}
+
+type AddReplicationEntriesReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddReplicationEntriesReply) Reset()         { *m = AddReplicationEntriesReply{} }
+func (m *AddReplicationEntriesReply) String() string { return proto.CompactTextString(m) }
+func (*AddReplicationEntriesReply) ProtoMessage()    {}
+func (*AddReplicationEntriesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{36}
+}
+
+func (m *AddReplicationEntriesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddReplicationEntriesReply.Unmarshal(m, b)
+}
+func (m *AddReplicationEntriesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddReplicationEntriesReply.Marshal(b, m, deterministic)
+}
+func (m *AddReplicationEntriesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddReplicationEntriesReply.Merge(m, src)
+}
+func (m *AddReplicationEntriesReply) XXX_Size() int {
+	return xxx_messageInfo_AddReplicationEntriesReply.Size(m)
+}
+func (m *AddReplicationEntriesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddReplicationEntriesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddReplicationEntriesReply proto.InternalMessageInfo
+
+type QuiesceRequest struct {
+	BinDir               string   `protobuf:"bytes,1,opt,name=binDir,proto3" json:"binDir,omitempty"`
+	DataDirs             []string `protobuf:"bytes,2,rep,name=dataDirs,proto3" json:"dataDirs,omitempty"`
+	Admins               []string `protobuf:"bytes,3,rep,name=admins,proto3" json:"admins,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuiesceRequest) Reset()         { *m = QuiesceRequest{} }
+func (m *QuiesceRequest) String() string { return proto.CompactTextString(m) }
+func (*QuiesceRequest) ProtoMessage()    {}
+func (*QuiesceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{37}
+}
+
+func (m *QuiesceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuiesceRequest.Unmarshal(m, b)
+}
+func (m *QuiesceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuiesceRequest.Marshal(b, m, deterministic)
+}
+func (m *QuiesceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuiesceRequest.Merge(m, src)
+}
+func (m *QuiesceRequest) XXX_Size() int {
+	return xxx_messageInfo_QuiesceRequest.Size(m)
+}
+func (m *QuiesceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuiesceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuiesceRequest proto.InternalMessageInfo
+
+func (m *QuiesceRequest) GetBinDir() string {
+	if m != nil {
+		return m.BinDir
+	}
+	return ""
+}
+
+func (m *QuiesceRequest) GetDataDirs() []string {
+	if m != nil {
+		return m.DataDirs
+	}
+	return nil
+}
+
+func (m *QuiesceRequest) GetAdmins() []string {
+	if m != nil {
+		return m.Admins
+	}
+	return nil
+}
+
+type QuiesceReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuiesceReply) Reset()         { *m = QuiesceReply{} }
+func (m *QuiesceReply) String() string { return proto.CompactTextString(m) }
+func (*QuiesceReply) ProtoMessage()    {}
+func (*QuiesceReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{38}
+}
+
+func (m *QuiesceReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuiesceReply.Unmarshal(m, b)
+}
+func (m *QuiesceReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuiesceReply.Marshal(b, m, deterministic)
+}
+func (m *QuiesceReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuiesceReply.Merge(m, src)
+}
+func (m *QuiesceReply) XXX_Size() int {
+	return xxx_messageInfo_QuiesceReply.Size(m)
+}
+func (m *QuiesceReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuiesceReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuiesceReply proto.InternalMessageInfo
+
+type MigrateConfigurationRequest struct {
+	SourceVersion        string         `protobuf:"bytes,1,opt,name=sourceVersion,proto3" json:"sourceVersion,omitempty"`
+	TargetVersion        string         `protobuf:"bytes,2,opt,name=targetVersion,proto3" json:"targetVersion,omitempty"`
+	DataDirPairs         []*DataDirPair `protobuf:"bytes,3,rep,name=dataDirPairs,proto3" json:"dataDirPairs,omitempty"`
+	TargetGPHome         string         `protobuf:"bytes,4,opt,name=targetGPHome,proto3" json:"targetGPHome,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *MigrateConfigurationRequest) Reset()         { *m = MigrateConfigurationRequest{} }
+func (m *MigrateConfigurationRequest) String() string { return proto.CompactTextString(m) }
+func (*MigrateConfigurationRequest) ProtoMessage()    {}
+func (*MigrateConfigurationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{39}
+}
+
+func (m *MigrateConfigurationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigrateConfigurationRequest.Unmarshal(m, b)
+}
+func (m *MigrateConfigurationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigrateConfigurationRequest.Marshal(b, m, deterministic)
+}
+func (m *MigrateConfigurationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrateConfigurationRequest.Merge(m, src)
+}
+func (m *MigrateConfigurationRequest) XXX_Size() int {
+	return xxx_messageInfo_MigrateConfigurationRequest.Size(m)
+}
+func (m *MigrateConfigurationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrateConfigurationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrateConfigurationRequest proto.InternalMessageInfo
+
+func (m *MigrateConfigurationRequest) GetSourceVersion() string {
+	if m != nil {
+		return m.SourceVersion
+	}
+	return ""
+}
+
+func (m *MigrateConfigurationRequest) GetTargetVersion() string {
+	if m != nil {
+		return m.TargetVersion
+	}
+	return ""
+}
+
+func (m *MigrateConfigurationRequest) GetDataDirPairs() []*DataDirPair {
+	if m != nil {
+		return m.DataDirPairs
+	}
+	return nil
+}
+
+func (m *MigrateConfigurationRequest) GetTargetGPHome() string {
+	if m != nil {
+		return m.TargetGPHome
+	}
+	return ""
+}
+
+type ConfigurationChange struct {
+	DataDir              string   `protobuf:"bytes,1,opt,name=dataDir,proto3" json:"dataDir,omitempty"`
+	File                 string   `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Action               string   `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Detail               string   `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigurationChange) Reset()         { *m = ConfigurationChange{} }
+func (m *ConfigurationChange) String() string { return proto.CompactTextString(m) }
+func (*ConfigurationChange) ProtoMessage()    {}
+func (*ConfigurationChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{40}
+}
+
+func (m *ConfigurationChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigurationChange.Unmarshal(m, b)
+}
+func (m *ConfigurationChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigurationChange.Marshal(b, m, deterministic)
+}
+func (m *ConfigurationChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigurationChange.Merge(m, src)
+}
+func (m *ConfigurationChange) XXX_Size() int {
+	return xxx_messageInfo_ConfigurationChange.Size(m)
+}
+func (m *ConfigurationChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigurationChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigurationChange proto.InternalMessageInfo
+
+func (m *ConfigurationChange) GetDataDir() string {
+	if m != nil {
+		return m.DataDir
+	}
+	return ""
+}
+
+func (m *ConfigurationChange) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *ConfigurationChange) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ConfigurationChange) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ConfigurationChange) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+type MigrateConfigurationReply struct {
+	Changes              []*ConfigurationChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *MigrateConfigurationReply) Reset()         { *m = MigrateConfigurationReply{} }
+func (m *MigrateConfigurationReply) String() string { return proto.CompactTextString(m) }
+func (*MigrateConfigurationReply) ProtoMessage()    {}
+func (*MigrateConfigurationReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{41}
+}
+
+func (m *MigrateConfigurationReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigrateConfigurationReply.Unmarshal(m, b)
+}
+func (m *MigrateConfigurationReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigrateConfigurationReply.Marshal(b, m, deterministic)
+}
+func (m *MigrateConfigurationReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrateConfigurationReply.Merge(m, src)
+}
+func (m *MigrateConfigurationReply) XXX_Size() int {
+	return xxx_messageInfo_MigrateConfigurationReply.Size(m)
+}
+func (m *MigrateConfigurationReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrateConfigurationReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrateConfigurationReply proto.InternalMessageInfo
+
+func (m *MigrateConfigurationReply) GetChanges() []*ConfigurationChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+type CheckLibrariesRequest struct {
+	GpHome               string   `protobuf:"bytes,1,opt,name=gpHome,proto3" json:"gpHome,omitempty"`
+	Libraries            []string `protobuf:"bytes,2,rep,name=libraries,proto3" json:"libraries,omitempty"`
+	Extensions           []string `protobuf:"bytes,3,rep,name=extensions,proto3" json:"extensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckLibrariesRequest) Reset()         { *m = CheckLibrariesRequest{} }
+func (m *CheckLibrariesRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckLibrariesRequest) ProtoMessage()    {}
+func (*CheckLibrariesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{42}
+}
+
+func (m *CheckLibrariesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckLibrariesRequest.Unmarshal(m, b)
+}
+func (m *CheckLibrariesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckLibrariesRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckLibrariesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckLibrariesRequest.Merge(m, src)
+}
+func (m *CheckLibrariesRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckLibrariesRequest.Size(m)
+}
+func (m *CheckLibrariesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckLibrariesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckLibrariesRequest proto.InternalMessageInfo
+
+func (m *CheckLibrariesRequest) GetGpHome() string {
+	if m != nil {
+		return m.GpHome
+	}
+	return ""
+}
+
+func (m *CheckLibrariesRequest) GetLibraries() []string {
+	if m != nil {
+		return m.Libraries
+	}
+	return nil
+}
+
+func (m *CheckLibrariesRequest) GetExtensions() []string {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
+}
+
+type CheckLibrariesReply struct {
+	MissingLibraries     []string `protobuf:"bytes,1,rep,name=missingLibraries,proto3" json:"missingLibraries,omitempty"`
+	MissingExtensions    []string `protobuf:"bytes,2,rep,name=missingExtensions,proto3" json:"missingExtensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckLibrariesReply) Reset()         { *m = CheckLibrariesReply{} }
+func (m *CheckLibrariesReply) String() string { return proto.CompactTextString(m) }
+func (*CheckLibrariesReply) ProtoMessage()    {}
+func (*CheckLibrariesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{43}
+}
+
+func (m *CheckLibrariesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckLibrariesReply.Unmarshal(m, b)
+}
+func (m *CheckLibrariesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckLibrariesReply.Marshal(b, m, deterministic)
+}
+func (m *CheckLibrariesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckLibrariesReply.Merge(m, src)
+}
+func (m *CheckLibrariesReply) XXX_Size() int {
+	return xxx_messageInfo_CheckLibrariesReply.Size(m)
+}
+func (m *CheckLibrariesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckLibrariesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckLibrariesReply proto.InternalMessageInfo
+
+func (m *CheckLibrariesReply) GetMissingLibraries() []string {
+	if m != nil {
+		return m.MissingLibraries
+	}
+	return nil
+}
+
+func (m *CheckLibrariesReply) GetMissingExtensions() []string {
+	if m != nil {
+		return m.MissingExtensions
+	}
+	return nil
+}
+
+type GetGPHomeManifestRequest struct {
+	GpHome               string   `protobuf:"bytes,1,opt,name=gpHome,proto3" json:"gpHome,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGPHomeManifestRequest) Reset()         { *m = GetGPHomeManifestRequest{} }
+func (m *GetGPHomeManifestRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGPHomeManifestRequest) ProtoMessage()    {}
+func (*GetGPHomeManifestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{44}
+}
+
+func (m *GetGPHomeManifestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGPHomeManifestRequest.Unmarshal(m, b)
+}
+func (m *GetGPHomeManifestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGPHomeManifestRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGPHomeManifestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGPHomeManifestRequest.Merge(m, src)
+}
+func (m *GetGPHomeManifestRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGPHomeManifestRequest.Size(m)
+}
+func (m *GetGPHomeManifestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGPHomeManifestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGPHomeManifestRequest proto.InternalMessageInfo
+
+func (m *GetGPHomeManifestRequest) GetGpHome() string {
+	if m != nil {
+		return m.GpHome
+	}
+	return ""
+}
+
+// GetGPHomeManifestReply is a chunk of the manifest, which is streamed to
+// stay within the message size limit for large installations.
+type GetGPHomeManifestReply struct {
+	Checksums            map[string][]byte `protobuf:"bytes,1,rep,name=checksums,proto3" json:"checksums,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetGPHomeManifestReply) Reset()         { *m = GetGPHomeManifestReply{} }
+func (m *GetGPHomeManifestReply) String() string { return proto.CompactTextString(m) }
+func (*GetGPHomeManifestReply) ProtoMessage()    {}
+func (*GetGPHomeManifestReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{45}
+}
+
+func (m *GetGPHomeManifestReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGPHomeManifestReply.Unmarshal(m, b)
+}
+func (m *GetGPHomeManifestReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGPHomeManifestReply.Marshal(b, m, deterministic)
+}
+func (m *GetGPHomeManifestReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGPHomeManifestReply.Merge(m, src)
+}
+func (m *GetGPHomeManifestReply) XXX_Size() int {
+	return xxx_messageInfo_GetGPHomeManifestReply.Size(m)
+}
+func (m *GetGPHomeManifestReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGPHomeManifestReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGPHomeManifestReply proto.InternalMessageInfo
+
+func (m *GetGPHomeManifestReply) GetChecksums() map[string][]byte {
+	if m != nil {
+		return m.Checksums
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*TablespaceInfo)(nil), "idl.TablespaceInfo")
+	proto.RegisterType((*UpgradePrimariesRequest)(nil), "idl.UpgradePrimariesRequest")
+	proto.RegisterType((*DataDirPair)(nil), "idl.DataDirPair")
+	proto.RegisterMapType((map[int32]*TablespaceInfo)(nil), "idl.DataDirPair.TablespacesEntry")
+	proto.RegisterType((*UpgradePrimariesReply)(nil), "idl.UpgradePrimariesReply")
+	proto.RegisterType((*UpgradeMasterRequest)(nil), "idl.UpgradeMasterRequest")
+	proto.RegisterType((*UpgradeMasterReply)(nil), "idl.UpgradeMasterReply")
+	proto.RegisterType((*DeleteDataDirectoriesRequest)(nil), "idl.DeleteDataDirectoriesRequest")
+	proto.RegisterType((*DeleteDataDirectoriesReply)(nil), "idl.DeleteDataDirectoriesReply")
+	proto.RegisterType((*DeleteStateDirectoryRequest)(nil), "idl.DeleteStateDirectoryRequest")
+	proto.RegisterType((*DeleteStateDirectoryReply)(nil), "idl.DeleteStateDirectoryReply")
+	proto.RegisterType((*DeleteTablespaceRequest)(nil), "idl.DeleteTablespaceRequest")
+	proto.RegisterType((*DeleteTablespaceReply)(nil), "idl.DeleteTablespaceReply")
+	proto.RegisterType((*ArchiveLogDirectoryRequest)(nil), "idl.ArchiveLogDirectoryRequest")
+	proto.RegisterType((*ArchiveLogDirectoryReply)(nil), "idl.ArchiveLogDirectoryReply")
+	proto.RegisterType((*RenameDirectories)(nil), "idl.RenameDirectories")
+	proto.RegisterType((*RenameDirectoriesRequest)(nil), "idl.RenameDirectoriesRequest")
+	proto.RegisterType((*RenameDirectoriesReply)(nil), "idl.RenameDirectoriesReply")
+	proto.RegisterType((*StopAgentRequest)(nil), "idl.StopAgentRequest")
+	proto.RegisterType((*StopAgentReply)(nil), "idl.StopAgentReply")
+	proto.RegisterType((*CheckSegmentDiskSpaceRequest)(nil), "idl.CheckSegmentDiskSpaceRequest")
+	proto.RegisterType((*RsyncPair)(nil), "idl.RsyncPair")
+	proto.RegisterType((*RsyncRequest)(nil), "idl.RsyncRequest")
+	proto.RegisterType((*RsyncReply)(nil), "idl.RsyncReply")
+	proto.RegisterType((*RestorePgControlRequest)(nil), "idl.RestorePgControlRequest")
+	proto.RegisterType((*RestorePgControlReply)(nil), "idl.RestorePgControlReply")
+	proto.RegisterType((*GetDirectorySizesRequest)(nil), "idl.GetDirectorySizesRequest")
+	proto.RegisterType((*GetDirectorySizesReply)(nil), "idl.GetDirectorySizesReply")
+	proto.RegisterMapType((map[string]uint64)(nil), "idl.GetDirectorySizesReply.SizesEntry")
+	proto.RegisterType((*CheckTablespaceRelocationsRequest)(nil), "idl.CheckTablespaceRelocationsRequest")
+	proto.RegisterType((*CheckTablespaceRelocationsRequest_Relocation)(nil), "idl.CheckTablespaceRelocationsRequest.Relocation")
+	proto.RegisterType((*CheckTablespaceRelocationsReply)(nil), "idl.CheckTablespaceRelocationsReply")
+	proto.RegisterType((*GetSegmentStatusesRequest)(nil), "idl.GetSegmentStatusesRequest")
+	proto.RegisterType((*SegmentStatus)(nil), "idl.SegmentStatus")
+	proto.RegisterType((*GetSegmentStatusesReply)(nil), "idl.GetSegmentStatusesReply")
+	proto.RegisterType((*UpgradeMirrorRequest)(nil), "idl.UpgradeMirrorRequest")
+	proto.RegisterType((*UpgradeMirrorReply)(nil), "idl.UpgradeMirrorReply")
+	proto.RegisterType((*ReplicationEntry)(nil), "idl.ReplicationEntry")
+	proto.RegisterType((*AddReplicationEntriesRequest)(nil), "idl.AddReplicationEntriesRequest")
+	proto.RegisterType((*AddReplicationEntriesReply)(nil), "idl.AddReplicationEntriesReply")
+	proto.RegisterType((*QuiesceRequest)(nil), "idl.QuiesceRequest")
+	proto.RegisterType((*QuiesceReply)(nil), "idl.QuiesceReply")
+	proto.RegisterType((*MigrateConfigurationRequest)(nil), "idl.MigrateConfigurationRequest")
+	proto.RegisterType((*ConfigurationChange)(nil), "idl.ConfigurationChange")
+	proto.RegisterType((*MigrateConfigurationReply)(nil), "idl.MigrateConfigurationReply")
+	proto.RegisterType((*CheckLibrariesRequest)(nil), "idl.CheckLibrariesRequest")
+	proto.RegisterType((*CheckLibrariesReply)(nil), "idl.CheckLibrariesReply")
+	proto.RegisterType((*GetGPHomeManifestRequest)(nil), "idl.GetGPHomeManifestRequest")
+	proto.RegisterType((*GetGPHomeManifestReply)(nil), "idl.GetGPHomeManifestReply")
+	proto.RegisterMapType((map[string][]byte)(nil), "idl.GetGPHomeManifestReply.ChecksumsEntry")
+}
+
+func init() { proto.RegisterFile("hub_to_agent.proto", fileDescriptor_9e73bb06acc917d8) }
+
+var fileDescriptor_9e73bb06acc917d8 = []byte{
+	// 1974 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0xdd, 0x6e, 0xdb, 0xc8,
+	0x15, 0xae, 0x64, 0xc9, 0xb2, 0x8f, 0x1c, 0x59, 0x1e, 0x3b, 0x36, 0x3d, 0x76, 0x1c, 0x9b, 0x08,
+	0x02, 0x37, 0x68, 0xdd, 0x56, 0x4d, 0xd1, 0xed, 0x36, 0x2d, 0x90, 0x58, 0xd9, 0x38, 0x80, 0x1d,
+	0x7b, 0xa9, 0xa4, 0x8b, 0x2e, 0x5a, 0x04, 0x94, 0x38, 0x96, 0x07, 0xa6, 0x48, 0x2d, 0x49, 0x6d,
+	0xd7, 0xfb, 0x02, 0xbd, 0x2a, 0xd0, 0xbb, 0xde, 0xf6, 0x59, 0xb6, 0xef, 0xd0, 0xab, 0xde, 0xf4,
+	0xaa, 0xaf, 0x51, 0x9c, 0xf9, 0x21, 0x87, 0x14, 0xa9, 0x04, 0x6d, 0x81, 0xde, 0x71, 0xbe, 0xf3,
+	0x33, 0x67, 0xce, 0x9c, 0x39, 0x3f, 0x12, 0x90, 0x9b, 0xd9, 0xf0, 0x7d, 0x12, 0xbe, 0x77, 0xc7,
+	0x2c, 0x48, 0x4e, 0xa6, 0x51, 0x98, 0x84, 0x64, 0x89, 0x7b, 0x3e, 0xed, 0x8e, 0x7c, 0x8e, 0x84,
+	0x9b, 0xd9, 0x50, 0xc2, 0xf6, 0x10, 0x3a, 0x6f, 0xdd, 0xa1, 0xcf, 0xe2, 0xa9, 0x3b, 0x62, 0xaf,
+	0x83, 0xeb, 0x90, 0x10, 0x68, 0xbc, 0x71, 0x27, 0xcc, 0x5a, 0x3a, 0xac, 0x1d, 0xaf, 0x3a, 0xe2,
+	0x9b, 0x50, 0x58, 0x39, 0x0f, 0x47, 0x6e, 0xc2, 0xc3, 0xc0, 0x6a, 0x08, 0x3c, 0x5d, 0x93, 0x43,
+	0x68, 0xbf, 0x8b, 0x59, 0xd4, 0x67, 0xd7, 0x3c, 0x60, 0x9e, 0xd5, 0x3c, 0xac, 0x1d, 0xaf, 0x38,
+	0x26, 0x64, 0xff, 0xab, 0x0e, 0x3b, 0xef, 0xa6, 0xe3, 0xc8, 0xf5, 0xd8, 0x55, 0xc4, 0x27, 0x6e,
+	0xc4, 0x59, 0xec, 0xb0, 0xaf, 0x66, 0x2c, 0x4e, 0x88, 0x0d, 0x6b, 0x83, 0x70, 0x16, 0x8d, 0xd8,
+	0x0b, 0x1e, 0xf4, 0x79, 0x64, 0xd5, 0x84, 0xf6, 0x1c, 0x86, 0x3c, 0x6f, 0xdd, 0x68, 0xcc, 0x12,
+	0xc5, 0x53, 0x97, 0x3c, 0x26, 0x46, 0x1e, 0xc1, 0x3d, 0xb9, 0xfe, 0x0d, 0x8b, 0x62, 0x34, 0x53,
+	0x9a, 0x9f, 0x07, 0xc9, 0x53, 0x58, 0xeb, 0xbb, 0x89, 0xdb, 0xe7, 0xd1, 0x95, 0xcb, 0xa3, 0xd8,
+	0x6a, 0x1c, 0x2e, 0x1d, 0xb7, 0x7b, 0xdd, 0x13, 0xee, 0xf9, 0x27, 0x06, 0xc1, 0xc9, 0x71, 0x91,
+	0x7d, 0x58, 0x3d, 0xbd, 0x61, 0xa3, 0xdb, 0xcb, 0xc0, 0xbf, 0x53, 0xe7, 0xcb, 0x00, 0x75, 0xfe,
+	0x73, 0x1e, 0xdc, 0x5e, 0x84, 0x1e, 0xb3, 0x96, 0xd3, 0xf3, 0x6b, 0x88, 0x1c, 0xc3, 0xfa, 0x85,
+	0x1b, 0x27, 0x2c, 0x7a, 0xe1, 0x8e, 0x6e, 0x67, 0x53, 0x3c, 0x42, 0x4b, 0x58, 0x57, 0x84, 0xc9,
+	0xaf, 0x81, 0x66, 0xb7, 0x11, 0x5f, 0xb8, 0xd3, 0x29, 0x0f, 0xc6, 0x9f, 0x71, 0x9f, 0x5d, 0xb9,
+	0xc9, 0x8d, 0xb5, 0x22, 0x84, 0x16, 0x70, 0xd8, 0xff, 0xac, 0x43, 0xdb, 0x30, 0x1d, 0xbd, 0x22,
+	0x3d, 0xa9, 0x40, 0xe5, 0xde, 0x3c, 0x98, 0xf9, 0x4e, 0x73, 0xd5, 0x4d, 0xdf, 0x69, 0xae, 0x03,
+	0x00, 0x29, 0x76, 0x15, 0x46, 0x89, 0x70, 0x6f, 0xd3, 0x31, 0x10, 0xa4, 0x4b, 0x01, 0x41, 0x6f,
+	0x48, 0x7a, 0x86, 0x10, 0x0b, 0x5a, 0xa7, 0x61, 0x90, 0xb0, 0x20, 0x11, 0x3e, 0x6c, 0x3a, 0x7a,
+	0x89, 0x11, 0xd7, 0x7f, 0xf1, 0xba, 0x2f, 0x5c, 0xd7, 0x74, 0xc4, 0x37, 0x39, 0x85, 0xb6, 0x71,
+	0x4e, 0xab, 0x25, 0x2e, 0xea, 0xa8, 0x78, 0x51, 0x27, 0x06, 0xcf, 0xcb, 0x20, 0x89, 0xee, 0x1c,
+	0x53, 0x8a, 0x0e, 0xa0, 0x5b, 0x64, 0x20, 0x5d, 0x58, 0xba, 0x65, 0x77, 0xc2, 0x11, 0x4d, 0x07,
+	0x3f, 0xc9, 0xf7, 0xa1, 0xf9, 0xb5, 0xeb, 0xcf, 0x98, 0x38, 0x76, 0xbb, 0xb7, 0x29, 0x36, 0xc9,
+	0x3f, 0x0a, 0x47, 0x72, 0x7c, 0x5a, 0xff, 0xa4, 0x66, 0xef, 0xc0, 0xfd, 0xf9, 0x60, 0x9e, 0xfa,
+	0x77, 0xf6, 0x77, 0x75, 0xd8, 0x52, 0x14, 0x79, 0xaf, 0xff, 0x9f, 0x18, 0xef, 0xe5, 0x42, 0x40,
+	0x5c, 0x44, 0x59, 0x88, 0xe7, 0xe2, 0xe4, 0xbf, 0x8d, 0xf0, 0x03, 0x80, 0x4b, 0xdf, 0xbb, 0x9c,
+	0x62, 0x42, 0x88, 0x55, 0x70, 0x1b, 0x48, 0xd9, 0x0b, 0x58, 0x29, 0x7d, 0x01, 0xf6, 0x16, 0x90,
+	0x82, 0x0f, 0xd1, 0xb5, 0x9f, 0xc2, 0x7e, 0x9f, 0xf9, 0x2c, 0xd1, 0x21, 0xcb, 0x46, 0x49, 0x68,
+	0x66, 0x11, 0x0a, 0x2b, 0x9e, 0x9b, 0xb8, 0x1e, 0xbe, 0xe9, 0xda, 0xe1, 0x12, 0xe6, 0x27, 0xbd,
+	0xb6, 0xf7, 0x81, 0x56, 0xc8, 0xa2, 0xe6, 0x07, 0xb0, 0x27, 0xa9, 0x83, 0xc4, 0x4d, 0x98, 0x26,
+	0xdf, 0x29, 0xc5, 0xf6, 0x1e, 0xec, 0x96, 0x93, 0x51, 0xf6, 0x87, 0xb0, 0x23, 0x89, 0x59, 0xb0,
+	0x68, 0x83, 0x08, 0x34, 0x0c, 0x63, 0xc4, 0x37, 0x06, 0xce, 0x3c, 0x3b, 0xea, 0x79, 0x0a, 0xf4,
+	0x79, 0x34, 0xba, 0xe1, 0x5f, 0xb3, 0xf3, 0x70, 0x5c, 0x34, 0x81, 0x6c, 0xc3, 0xf2, 0x1b, 0xf6,
+	0x87, 0x2c, 0x6e, 0xd4, 0xca, 0xa6, 0x60, 0x95, 0x4a, 0xa1, 0xc6, 0x31, 0x6c, 0x38, 0x2c, 0x70,
+	0x27, 0xcc, 0x38, 0x2f, 0x2a, 0x92, 0x21, 0xa7, 0x15, 0xc9, 0x15, 0xe2, 0x32, 0x82, 0x54, 0xd0,
+	0xa9, 0x15, 0x86, 0xa4, 0x54, 0xa2, 0xa8, 0x4b, 0xe2, 0xde, 0x73, 0x98, 0xfd, 0x19, 0x58, 0x73,
+	0x1b, 0x69, 0xc3, 0x9f, 0x40, 0xa3, 0xaf, 0x7d, 0xd0, 0xee, 0x6d, 0x8b, 0x08, 0x9c, 0x67, 0x16,
+	0x3c, 0xb6, 0x05, 0xdb, 0xf3, 0x24, 0x71, 0x14, 0x02, 0xdd, 0x41, 0x12, 0x4e, 0x9f, 0x63, 0x29,
+	0xd3, 0xb7, 0xd2, 0x85, 0x8e, 0x81, 0x21, 0xd7, 0x14, 0xf6, 0x45, 0xbc, 0x0e, 0xd8, 0x78, 0xc2,
+	0x82, 0xa4, 0xcf, 0xe3, 0xdb, 0x81, 0x79, 0x1f, 0x4f, 0xa1, 0x15, 0xc9, 0x4f, 0x71, 0xf8, 0x76,
+	0x8f, 0x0a, 0x73, 0x84, 0x4c, 0x91, 0xd9, 0x69, 0x45, 0x25, 0x61, 0x55, 0x2f, 0x84, 0x55, 0x08,
+	0xab, 0x4e, 0x7c, 0x17, 0x8c, 0xc4, 0xfb, 0xa9, 0x72, 0xed, 0x31, 0xac, 0xf7, 0x59, 0x9c, 0xf0,
+	0x40, 0x94, 0xca, 0xb3, 0x30, 0xd6, 0x3e, 0x2e, 0xc2, 0xf8, 0xc6, 0x0c, 0x48, 0xbd, 0x6c, 0x13,
+	0xb2, 0xff, 0x5c, 0x83, 0x35, 0xb1, 0xa3, 0x3e, 0x93, 0x05, 0x2d, 0xfd, 0xe2, 0x64, 0x98, 0xe9,
+	0x25, 0xda, 0xfd, 0xf2, 0x9b, 0x91, 0x3f, 0xf3, 0x58, 0x6a, 0xb7, 0x5e, 0x93, 0x47, 0xd0, 0x94,
+	0xb5, 0x6f, 0x49, 0x5c, 0x4b, 0x47, 0x5e, 0x8b, 0x3e, 0x89, 0x23, 0x89, 0x59, 0xe1, 0xd0, 0xa9,
+	0xa6, 0x61, 0x16, 0x0e, 0x05, 0xda, 0x6b, 0x00, 0xca, 0x22, 0xbc, 0x83, 0x9f, 0xc1, 0x8e, 0xc3,
+	0xe2, 0x24, 0x8c, 0xd8, 0xd5, 0x18, 0x53, 0x7b, 0x14, 0xfa, 0x1f, 0xf3, 0x3e, 0x77, 0xe0, 0xfe,
+	0xbc, 0x18, 0xea, 0x7b, 0x06, 0xd6, 0x2b, 0x96, 0xa4, 0x91, 0x3d, 0xe0, 0xdf, 0x66, 0xb1, 0x75,
+	0x08, 0x6d, 0x2f, 0x8b, 0x14, 0xa5, 0xd3, 0x84, 0xd0, 0x5d, 0xdb, 0x25, 0xe2, 0x53, 0xff, 0x8e,
+	0x3c, 0x83, 0x66, 0xcc, 0xbf, 0x55, 0x62, 0xed, 0xde, 0x63, 0xe1, 0x82, 0x72, 0xde, 0x13, 0xf1,
+	0x29, 0x4b, 0x8b, 0x14, 0xa2, 0x9f, 0x00, 0x64, 0xa0, 0x59, 0x4e, 0x56, 0x65, 0x39, 0xd9, 0x32,
+	0xcb, 0x49, 0xc3, 0xac, 0x1c, 0xdf, 0xd5, 0xe0, 0x48, 0x44, 0x9c, 0x99, 0x00, 0x7c, 0xd5, 0x47,
+	0xa5, 0x47, 0x1b, 0x40, 0x3b, 0xca, 0x50, 0x65, 0xe3, 0x4f, 0xb2, 0x70, 0x5d, 0x24, 0x7c, 0x92,
+	0x41, 0x8e, 0xa9, 0x85, 0x9e, 0x01, 0x64, 0x24, 0x4c, 0xf7, 0xb1, 0xec, 0x00, 0xd2, 0xac, 0x92,
+	0x01, 0x48, 0x4d, 0x64, 0xe5, 0x4f, 0xeb, 0x50, 0x06, 0xd8, 0x47, 0xf0, 0x70, 0x91, 0x19, 0x78,
+	0x71, 0x3f, 0x87, 0xdd, 0x57, 0x2c, 0x51, 0x4f, 0x11, 0x13, 0xe7, 0x2c, 0xce, 0xa5, 0xea, 0x91,
+	0xac, 0xfb, 0xf2, 0x6c, 0x4d, 0x27, 0x5d, 0xdb, 0x7f, 0xa9, 0xc1, 0xbd, 0x9c, 0x18, 0xc6, 0xb8,
+	0xa2, 0xaa, 0x8a, 0xad, 0x97, 0xa8, 0xc7, 0xd7, 0x15, 0xa9, 0x2e, 0x32, 0x53, 0xba, 0x46, 0xa9,
+	0x38, 0x71, 0xa3, 0x84, 0x79, 0x2a, 0x69, 0xe9, 0x25, 0x4a, 0x5d, 0xf3, 0x80, 0xc7, 0x37, 0xcc,
+	0x13, 0x21, 0xbd, 0xe2, 0xa4, 0x6b, 0xa4, 0x45, 0x6c, 0x14, 0x46, 0x5e, 0xda, 0xc5, 0xa6, 0x6b,
+	0xfb, 0x35, 0xec, 0x94, 0x1d, 0x09, 0xa3, 0xe9, 0x04, 0x56, 0x62, 0x05, 0xa8, 0xcb, 0x22, 0xe2,
+	0xb2, 0x72, 0xcc, 0x4e, 0xca, 0x63, 0xff, 0xdd, 0x68, 0x13, 0x78, 0x14, 0x85, 0x91, 0xf1, 0x9e,
+	0x2b, 0xce, 0x7a, 0x08, 0xed, 0xa9, 0xe8, 0x35, 0xee, 0x8c, 0x14, 0x62, 0x42, 0xe4, 0x31, 0x74,
+	0xd4, 0x52, 0xf7, 0x70, 0x32, 0x83, 0x14, 0x50, 0x43, 0x93, 0xd1, 0xa5, 0x99, 0x10, 0xbe, 0xfc,
+	0x89, 0x30, 0x4b, 0x2b, 0x6a, 0xca, 0x97, 0x9f, 0x03, 0xb1, 0xe0, 0x4b, 0x40, 0xa8, 0x91, 0x8d,
+	0x9b, 0x81, 0x64, 0xf4, 0xfe, 0x90, 0x7b, 0x56, 0xcb, 0xa4, 0x23, 0x82, 0xbb, 0x24, 0xb9, 0x56,
+	0x46, 0xb6, 0x03, 0x79, 0x10, 0xab, 0xe8, 0x2c, 0x66, 0x91, 0xb5, 0x2a, 0x47, 0x11, 0xfc, 0x16,
+	0x77, 0x1b, 0xfb, 0xe2, 0xda, 0x41, 0xc0, 0x7a, 0x69, 0xb6, 0x0e, 0xca, 0xaf, 0x18, 0x8c, 0xe7,
+	0xd0, 0xc5, 0x0f, 0x2e, 0x23, 0x54, 0x3e, 0x5a, 0x0b, 0x5a, 0x5e, 0xae, 0x21, 0xd6, 0xcb, 0xcc,
+	0x6e, 0xc3, 0xd1, 0x06, 0x62, 0xff, 0xa9, 0x06, 0xfb, 0xcf, 0x3d, 0xaf, 0xa0, 0xd1, 0x28, 0x7a,
+	0x3f, 0x82, 0x16, 0x93, 0x88, 0x0a, 0x86, 0xfb, 0xaa, 0xee, 0xe5, 0x4d, 0x70, 0x34, 0x57, 0x7a,
+	0xc6, 0xba, 0x71, 0xc6, 0x63, 0x58, 0x9f, 0xc5, 0xec, 0x6c, 0xe8, 0xe2, 0x9e, 0x58, 0x15, 0x63,
+	0x15, 0xc7, 0x45, 0x18, 0x9b, 0x9b, 0x0a, 0x73, 0xf0, 0xec, 0xbf, 0x83, 0xce, 0xe7, 0x33, 0xce,
+	0xe2, 0xac, 0x0e, 0x6e, 0xc3, 0xf2, 0xd0, 0x6c, 0x42, 0xd5, 0x4a, 0x27, 0xe8, 0x7e, 0xa1, 0xd2,
+	0xe1, 0x1a, 0x65, 0x5c, 0x6f, 0xc2, 0x03, 0x59, 0x32, 0x56, 0x1d, 0xb5, 0xb2, 0x3b, 0xb0, 0x96,
+	0x6a, 0xc7, 0xdd, 0xfe, 0x56, 0x83, 0xbd, 0x0b, 0x3e, 0x8e, 0xdc, 0x84, 0x9d, 0x86, 0xc1, 0x35,
+	0x1f, 0xcf, 0x22, 0x99, 0x89, 0xd4, 0xde, 0x8f, 0xe0, 0x5e, 0x9c, 0xab, 0x29, 0x6a, 0x18, 0xc9,
+	0x81, 0xf3, 0x91, 0x51, 0x2f, 0x8b, 0x8c, 0xa7, 0xb0, 0xe6, 0x99, 0x83, 0xdc, 0x52, 0xd5, 0x20,
+	0x67, 0x72, 0x61, 0x47, 0x23, 0xd5, 0xbc, 0xba, 0x3a, 0x0b, 0x27, 0x4c, 0x15, 0xb5, 0x1c, 0x66,
+	0xff, 0xb1, 0x06, 0x9b, 0x39, 0xf3, 0x4f, 0x6f, 0xdc, 0x60, 0xcc, 0x16, 0xc4, 0x0c, 0x81, 0xc6,
+	0x35, 0xf7, 0x99, 0xbe, 0x41, 0xfc, 0x46, 0x2c, 0x30, 0x86, 0x68, 0xfc, 0x16, 0x7e, 0x1c, 0x19,
+	0x23, 0xb4, 0x5a, 0x21, 0xee, 0xb1, 0xc4, 0xe5, 0xbe, 0x7a, 0x6a, 0x6a, 0x65, 0x5f, 0xc2, 0x6e,
+	0xb9, 0x3b, 0x31, 0xeb, 0xf4, 0xa0, 0x35, 0x12, 0x86, 0xe9, 0x38, 0xb3, 0x64, 0x85, 0x98, 0xb7,
+	0xdc, 0xd1, 0x8c, 0xf6, 0x04, 0xee, 0x8b, 0xd4, 0x7d, 0xce, 0x87, 0x51, 0x6e, 0x08, 0xdf, 0x86,
+	0xe5, 0xf1, 0x54, 0x78, 0x44, 0x45, 0x85, 0x5c, 0x61, 0x25, 0xf0, 0x35, 0xaf, 0x0a, 0x8b, 0x0c,
+	0xc0, 0xb7, 0xc2, 0xbe, 0x49, 0x58, 0x10, 0x8b, 0x3a, 0x25, 0x63, 0xc3, 0x40, 0xec, 0x10, 0x36,
+	0x8b, 0xdb, 0xa1, 0xe5, 0x4f, 0xa0, 0x3b, 0xe1, 0x71, 0xcc, 0x83, 0x71, 0x4a, 0x50, 0xf5, 0x7b,
+	0x0e, 0x27, 0x3f, 0x80, 0x0d, 0x85, 0xbd, 0xcc, 0x76, 0x92, 0x86, 0xcc, 0x13, 0xec, 0x9e, 0x68,
+	0x18, 0xe4, 0x3d, 0x5e, 0xb8, 0x01, 0xbf, 0x66, 0x71, 0xf2, 0x81, 0x23, 0xda, 0x7f, 0x95, 0x6d,
+	0x42, 0x51, 0x08, 0x0d, 0x3d, 0x83, 0xd5, 0x11, 0xda, 0x1f, 0xcf, 0x26, 0xda, 0xc9, 0x4f, 0x74,
+	0xab, 0x50, 0xc2, 0x7f, 0x72, 0xaa, 0x99, 0xe5, 0x0b, 0xcf, 0x84, 0xe9, 0x33, 0xe8, 0xe4, 0x89,
+	0x1f, 0x6a, 0x1b, 0xd6, 0x8c, 0xb6, 0xa1, 0xf7, 0x8f, 0x0e, 0x34, 0x45, 0xab, 0x4b, 0x2e, 0xa1,
+	0x93, 0xef, 0x58, 0xc9, 0x51, 0xd6, 0x17, 0x54, 0xb4, 0xbe, 0xd4, 0x2a, 0xed, 0x74, 0xf1, 0xc1,
+	0x7e, 0x8f, 0xbc, 0x81, 0x6e, 0x71, 0x96, 0x25, 0xfb, 0x82, 0xbf, 0xe2, 0xf7, 0x1a, 0x4a, 0x2b,
+	0xa8, 0x52, 0xdf, 0x4b, 0xb8, 0x97, 0x9b, 0xde, 0xc8, 0xae, 0xc9, 0x9e, 0x9b, 0x8a, 0xe9, 0x4e,
+	0x19, 0x49, 0xaa, 0xf9, 0xbc, 0x6c, 0x7c, 0x79, 0x50, 0x31, 0x40, 0x28, 0x75, 0x7b, 0x55, 0x64,
+	0xa9, 0xf2, 0x17, 0xb0, 0x9a, 0x8e, 0x0c, 0x44, 0xe6, 0xe4, 0xe2, 0x58, 0x41, 0x37, 0x8b, 0xb0,
+	0x14, 0xfd, 0xbd, 0x9e, 0xdb, 0x0a, 0x03, 0xa4, 0x72, 0xfe, 0xa2, 0xc1, 0x94, 0x3e, 0x5c, 0xc4,
+	0x22, 0xd5, 0x7f, 0x09, 0x5b, 0x65, 0x23, 0x26, 0x39, 0x34, 0x44, 0x4b, 0x87, 0x53, 0x7a, 0xb0,
+	0x80, 0x43, 0xea, 0xfe, 0xad, 0x9e, 0x6e, 0xb3, 0x6e, 0xcd, 0x3c, 0xc0, 0xbe, 0xa1, 0x60, 0x6e,
+	0x86, 0xa5, 0xb4, 0x82, 0x2a, 0x55, 0xbf, 0x87, 0x23, 0xb5, 0xb3, 0x48, 0xdf, 0xff, 0xfb, 0x0d,
+	0xbe, 0x80, 0xcd, 0x92, 0xf9, 0x96, 0x48, 0x8f, 0x56, 0xcf, 0xcb, 0xf4, 0x41, 0x35, 0x83, 0x54,
+	0xfc, 0x0c, 0xb6, 0xc4, 0xd4, 0x52, 0xbc, 0xce, 0x8d, 0x6c, 0x14, 0xd2, 0xba, 0xd6, 0x4d, 0x48,
+	0x4a, 0xbf, 0x00, 0x2a, 0xd6, 0xe5, 0x07, 0xfe, 0x38, 0x1d, 0x5f, 0xc0, 0xae, 0x1e, 0x79, 0xf4,
+	0x0b, 0x4a, 0x67, 0x1f, 0xe5, 0xb3, 0x8a, 0x49, 0x8a, 0xd2, 0x0a, 0x6a, 0xfa, 0x70, 0xe6, 0xe6,
+	0x18, 0xf5, 0x70, 0xaa, 0x46, 0x29, 0xba, 0x57, 0x45, 0x96, 0x2a, 0xdf, 0x02, 0x99, 0xef, 0x7c,
+	0xc9, 0x81, 0x16, 0x2a, 0xef, 0xf2, 0xe9, 0x7e, 0x25, 0x7d, 0x2e, 0x51, 0x88, 0xe6, 0xaa, 0x90,
+	0x28, 0xcc, 0xbe, 0x98, 0xee, 0x94, 0x91, 0xa4, 0x1a, 0x1f, 0x68, 0xf5, 0x30, 0x42, 0x1e, 0x7f,
+	0xdc, 0xd0, 0x44, 0x1f, 0x7d, 0x90, 0x4f, 0xee, 0xf6, 0x4b, 0x58, 0x57, 0x0d, 0x8f, 0x3a, 0x55,
+	0x4c, 0x64, 0xca, 0xc8, 0x37, 0x59, 0x74, 0x23, 0x0f, 0x4a, 0xe1, 0x5f, 0xc1, 0xc6, 0xbb, 0xe0,
+	0xab, 0xff, 0x58, 0xfc, 0x4b, 0xd8, 0x2a, 0x6b, 0x06, 0x54, 0x96, 0x58, 0xd0, 0x76, 0xd1, 0x83,
+	0x05, 0x1c, 0x52, 0xf7, 0x99, 0x2a, 0x2b, 0x59, 0xdd, 0x35, 0x7e, 0x1d, 0x29, 0x36, 0x0b, 0xd4,
+	0x2a, 0xa5, 0x49, 0x4d, 0x03, 0xd8, 0x98, 0x2b, 0x8e, 0x59, 0xfc, 0x95, 0x56, 0x66, 0xba, 0x57,
+	0x45, 0x16, 0x2a, 0x7f, 0x5c, 0xc3, 0xfc, 0x5b, 0xda, 0xe3, 0xaa, 0xfc, 0xbb, 0xa8, 0x1d, 0xa7,
+	0x0f, 0x17, 0xb1, 0x88, 0x0d, 0x86, 0xcb, 0xe2, 0x8f, 0x90, 0x9f, 0xfe, 0x7b, 0x00, 0xb1, 0xd2,
+	0xda, 0x60, 0x35, 0x19, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AgentClient is the client API for Agent service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AgentClient interface {
+	CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*CheckDiskSpaceReply, error)
+	UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error)
+	UpgradeMaster(ctx context.Context, in *UpgradeMasterRequest, opts ...grpc.CallOption) (*UpgradeMasterReply, error)
+	RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error)
+	StopAgent(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error)
+	DeleteDataDirectories(ctx context.Context, in *DeleteDataDirectoriesRequest, opts ...grpc.CallOption) (*DeleteDataDirectoriesReply, error)
+	DeleteStateDirectory(ctx context.Context, in *DeleteStateDirectoryRequest, opts ...grpc.CallOption) (*DeleteStateDirectoryReply, error)
+	DeleteTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error)
+	DeleteSourceTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error)
+	ArchiveLogDirectory(ctx context.Context, in *ArchiveLogDirectoryRequest, opts ...grpc.CallOption) (*ArchiveLogDirectoryReply, error)
+	RsyncDataDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error)
+	RsyncTablespaceDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error)
+	RestorePrimariesPgControl(ctx context.Context, in *RestorePgControlRequest, opts ...grpc.CallOption) (*RestorePgControlReply, error)
+	GetDirectorySizes(ctx context.Context, in *GetDirectorySizesRequest, opts ...grpc.CallOption) (*GetDirectorySizesReply, error)
+	GetSegmentStatuses(ctx context.Context, in *GetSegmentStatusesRequest, opts ...grpc.CallOption) (*GetSegmentStatusesReply, error)
+	UpgradeMirror(ctx context.Context, in *UpgradeMirrorRequest, opts ...grpc.CallOption) (*UpgradeMirrorReply, error)
+	CheckTablespaceRelocations(ctx context.Context, in *CheckTablespaceRelocationsRequest, opts ...grpc.CallOption) (*CheckTablespaceRelocationsReply, error)
+	QuiesceSegments(ctx context.Context, in *QuiesceRequest, opts ...grpc.CallOption) (*QuiesceReply, error)
+	UnquiesceSegments(ctx context.Context, in *QuiesceRequest, opts ...grpc.CallOption) (*QuiesceReply, error)
+	MigrateConfiguration(ctx context.Context, in *MigrateConfigurationRequest, opts ...grpc.CallOption) (*MigrateConfigurationReply, error)
+	CheckLibraries(ctx context.Context, in *CheckLibrariesRequest, opts ...grpc.CallOption) (*CheckLibrariesReply, error)
+	GetGPHomeManifest(ctx context.Context, in *GetGPHomeManifestRequest, opts ...grpc.CallOption) (Agent_GetGPHomeManifestClient, error)
+	AddReplicationEntries(ctx context.Context, in *AddReplicationEntriesRequest, opts ...grpc.CallOption) (*AddReplicationEntriesReply, error)
+}
+
+type agentClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAgentClient(cc *grpc.ClientConn) AgentClient {
+	return &agentClient{cc}
+}
+
+func (c *agentClient) CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*CheckDiskSpaceReply, error) {
+	out := new(CheckDiskSpaceReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/CheckDiskSpace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error) {
+	out := new(UpgradePrimariesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/UpgradePrimaries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UpgradeMaster(ctx context.Context, in *UpgradeMasterRequest, opts ...grpc.CallOption) (*UpgradeMasterReply, error) {
+	out := new(UpgradeMasterReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/UpgradeMaster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error) {
+	out := new(RenameDirectoriesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/RenameDirectories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) StopAgent(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error) {
+	out := new(StopAgentReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/StopAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteDataDirectories(ctx context.Context, in *DeleteDataDirectoriesRequest, opts ...grpc.CallOption) (*DeleteDataDirectoriesReply, error) {
+	out := new(DeleteDataDirectoriesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteDataDirectories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteStateDirectory(ctx context.Context, in *DeleteStateDirectoryRequest, opts ...grpc.CallOption) (*DeleteStateDirectoryReply, error) {
+	out := new(DeleteStateDirectoryReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteStateDirectory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error) {
+	out := new(DeleteTablespaceReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteTablespaceDirectories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteSourceTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error) {
+	out := new(DeleteTablespaceReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteSourceTablespaceDirectories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ArchiveLogDirectory(ctx context.Context, in *ArchiveLogDirectoryRequest, opts ...grpc.CallOption) (*ArchiveLogDirectoryReply, error) {
 	out := new(ArchiveLogDirectoryReply)
 	err := c.cc.Invoke(ctx, "/idl.Agent/ArchiveLogDirectory", in, out, opts...)
 	if err != nil {
@@ -1247,10 +2552,124 @@ func (c *agentClient) RestorePrimariesPgControl(ctx context.Context, in *Restore
 	return out, nil
 }
 
+func (c *agentClient) GetDirectorySizes(ctx context.Context, in *GetDirectorySizesRequest, opts ...grpc.CallOption) (*GetDirectorySizesReply, error) {
+	out := new(GetDirectorySizesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/GetDirectorySizes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetSegmentStatuses(ctx context.Context, in *GetSegmentStatusesRequest, opts ...grpc.CallOption) (*GetSegmentStatusesReply, error) {
+	out := new(GetSegmentStatusesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/GetSegmentStatuses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UpgradeMirror(ctx context.Context, in *UpgradeMirrorRequest, opts ...grpc.CallOption) (*UpgradeMirrorReply, error) {
+	out := new(UpgradeMirrorReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/UpgradeMirror", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) CheckTablespaceRelocations(ctx context.Context, in *CheckTablespaceRelocationsRequest, opts ...grpc.CallOption) (*CheckTablespaceRelocationsReply, error) {
+	out := new(CheckTablespaceRelocationsReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/CheckTablespaceRelocations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) QuiesceSegments(ctx context.Context, in *QuiesceRequest, opts ...grpc.CallOption) (*QuiesceReply, error) {
+	out := new(QuiesceReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/QuiesceSegments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UnquiesceSegments(ctx context.Context, in *QuiesceRequest, opts ...grpc.CallOption) (*QuiesceReply, error) {
+	out := new(QuiesceReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/UnquiesceSegments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) MigrateConfiguration(ctx context.Context, in *MigrateConfigurationRequest, opts ...grpc.CallOption) (*MigrateConfigurationReply, error) {
+	out := new(MigrateConfigurationReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/MigrateConfiguration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) CheckLibraries(ctx context.Context, in *CheckLibrariesRequest, opts ...grpc.CallOption) (*CheckLibrariesReply, error) {
+	out := new(CheckLibrariesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/CheckLibraries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetGPHomeManifest(ctx context.Context, in *GetGPHomeManifestRequest, opts ...grpc.CallOption) (Agent_GetGPHomeManifestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[0], "/idl.Agent/GetGPHomeManifest", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentGetGPHomeManifestClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_GetGPHomeManifestClient interface {
+	Recv() (*GetGPHomeManifestReply, error)
+	grpc.ClientStream
+}
+
+type agentGetGPHomeManifestClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentGetGPHomeManifestClient) Recv() (*GetGPHomeManifestReply, error) {
+	m := new(GetGPHomeManifestReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) AddReplicationEntries(ctx context.Context, in *AddReplicationEntriesRequest, opts ...grpc.CallOption) (*AddReplicationEntriesReply, error) {
+	out := new(AddReplicationEntriesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/AddReplicationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServer is the server API for Agent service.
 type AgentServer interface {
 	CheckDiskSpace(context.Context, *CheckSegmentDiskSpaceRequest) (*CheckDiskSpaceReply, error)
 	UpgradePrimaries(context.Context, *UpgradePrimariesRequest) (*UpgradePrimariesReply, error)
+	UpgradeMaster(context.Context, *UpgradeMasterRequest) (*UpgradeMasterReply, error)
 	RenameDirectories(context.Context, *RenameDirectoriesRequest) (*RenameDirectoriesReply, error)
 	StopAgent(context.Context, *StopAgentRequest) (*StopAgentReply, error)
 	DeleteDataDirectories(context.Context, *DeleteDataDirectoriesRequest) (*DeleteDataDirectoriesReply, error)
@@ -1261,6 +2680,16 @@ type AgentServer interface {
 	RsyncDataDirectories(context.Context, *RsyncRequest) (*RsyncReply, error)
 	RsyncTablespaceDirectories(context.Context, *RsyncRequest) (*RsyncReply, error)
 	RestorePrimariesPgControl(context.Context, *RestorePgControlRequest) (*RestorePgControlReply, error)
+	GetDirectorySizes(context.Context, *GetDirectorySizesRequest) (*GetDirectorySizesReply, error)
+	GetSegmentStatuses(context.Context, *GetSegmentStatusesRequest) (*GetSegmentStatusesReply, error)
+	UpgradeMirror(context.Context, *UpgradeMirrorRequest) (*UpgradeMirrorReply, error)
+	CheckTablespaceRelocations(context.Context, *CheckTablespaceRelocationsRequest) (*CheckTablespaceRelocationsReply, error)
+	QuiesceSegments(context.Context, *QuiesceRequest) (*QuiesceReply, error)
+	UnquiesceSegments(context.Context, *QuiesceRequest) (*QuiesceReply, error)
+	MigrateConfiguration(context.Context, *MigrateConfigurationRequest) (*MigrateConfigurationReply, error)
+	CheckLibraries(context.Context, *CheckLibrariesRequest) (*CheckLibrariesReply, error)
+	GetGPHomeManifest(*GetGPHomeManifestRequest, Agent_GetGPHomeManifestServer) error
+	AddReplicationEntries(context.Context, *AddReplicationEntriesRequest) (*AddReplicationEntriesReply, error)
 }
 
 // UnimplementedAgentServer can be embedded to have forward compatible implementations.
@@ -1273,6 +2702,9 @@ func (*UnimplementedAgentServer) CheckDiskSpace(ctx context.Context, req *CheckS
 func (*UnimplementedAgentServer) UpgradePrimaries(ctx context.Context, req *UpgradePrimariesRequest) (*UpgradePrimariesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpgradePrimaries not implemented")
 }
+func (*UnimplementedAgentServer) UpgradeMaster(ctx context.Context, req *UpgradeMasterRequest) (*UpgradeMasterReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpgradeMaster not implemented")
+}
 func (*UnimplementedAgentServer) RenameDirectories(ctx context.Context, req *RenameDirectoriesRequest) (*RenameDirectoriesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RenameDirectories not implemented")
 }
@@ -1303,6 +2735,36 @@ func (*UnimplementedAgentServer) RsyncTablespaceDirectories(ctx context.Context,
 func (*UnimplementedAgentServer) RestorePrimariesPgControl(ctx context.Context, req *RestorePgControlRequest) (*RestorePgControlReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RestorePrimariesPgControl not implemented")
 }
+func (*UnimplementedAgentServer) GetDirectorySizes(ctx context.Context, req *GetDirectorySizesRequest) (*GetDirectorySizesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDirectorySizes not implemented")
+}
+func (*UnimplementedAgentServer) GetSegmentStatuses(ctx context.Context, req *GetSegmentStatusesRequest) (*GetSegmentStatusesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSegmentStatuses not implemented")
+}
+func (*UnimplementedAgentServer) UpgradeMirror(ctx context.Context, req *UpgradeMirrorRequest) (*UpgradeMirrorReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpgradeMirror not implemented")
+}
+func (*UnimplementedAgentServer) CheckTablespaceRelocations(ctx context.Context, req *CheckTablespaceRelocationsRequest) (*CheckTablespaceRelocationsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckTablespaceRelocations not implemented")
+}
+func (*UnimplementedAgentServer) QuiesceSegments(ctx context.Context, req *QuiesceRequest) (*QuiesceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuiesceSegments not implemented")
+}
+func (*UnimplementedAgentServer) UnquiesceSegments(ctx context.Context, req *QuiesceRequest) (*QuiesceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnquiesceSegments not implemented")
+}
+func (*UnimplementedAgentServer) MigrateConfiguration(ctx context.Context, req *MigrateConfigurationRequest) (*MigrateConfigurationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateConfiguration not implemented")
+}
+func (*UnimplementedAgentServer) CheckLibraries(ctx context.Context, req *CheckLibrariesRequest) (*CheckLibrariesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckLibraries not implemented")
+}
+func (*UnimplementedAgentServer) GetGPHomeManifest(req *GetGPHomeManifestRequest, srv Agent_GetGPHomeManifestServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetGPHomeManifest not implemented")
+}
+func (*UnimplementedAgentServer) AddReplicationEntries(ctx context.Context, req *AddReplicationEntriesRequest) (*AddReplicationEntriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReplicationEntries not implemented")
+}
 
 func RegisterAgentServer(s *grpc.Server, srv AgentServer) {
 	s.RegisterService(&_Agent_serviceDesc, srv)
@@ -1344,6 +2806,24 @@ func _Agent_UpgradePrimaries_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_UpgradeMaster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeMasterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).UpgradeMaster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/UpgradeMaster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).UpgradeMaster(ctx, req.(*UpgradeMasterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Agent_RenameDirectories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RenameDirectoriesRequest)
 	if err := dec(in); err != nil {
@@ -1524,6 +3004,189 @@ func _Agent_RestorePrimariesPgControl_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_GetDirectorySizes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDirectorySizesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetDirectorySizes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/GetDirectorySizes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetDirectorySizes(ctx, req.(*GetDirectorySizesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetSegmentStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSegmentStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetSegmentStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/GetSegmentStatuses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetSegmentStatuses(ctx, req.(*GetSegmentStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_UpgradeMirror_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeMirrorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).UpgradeMirror(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/UpgradeMirror",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).UpgradeMirror(ctx, req.(*UpgradeMirrorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_CheckTablespaceRelocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckTablespaceRelocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).CheckTablespaceRelocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/CheckTablespaceRelocations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).CheckTablespaceRelocations(ctx, req.(*CheckTablespaceRelocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_QuiesceSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuiesceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).QuiesceSegments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/QuiesceSegments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).QuiesceSegments(ctx, req.(*QuiesceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_UnquiesceSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuiesceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).UnquiesceSegments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/UnquiesceSegments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).UnquiesceSegments(ctx, req.(*QuiesceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_MigrateConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateConfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).MigrateConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/MigrateConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).MigrateConfiguration(ctx, req.(*MigrateConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_CheckLibraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckLibrariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).CheckLibraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/CheckLibraries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).CheckLibraries(ctx, req.(*CheckLibrariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetGPHomeManifest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetGPHomeManifestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).GetGPHomeManifest(m, &agentGetGPHomeManifestServer{stream})
+}
+
+type Agent_GetGPHomeManifestServer interface {
+	Send(*GetGPHomeManifestReply) error
+	grpc.ServerStream
+}
+
+type agentGetGPHomeManifestServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentGetGPHomeManifestServer) Send(m *GetGPHomeManifestReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_AddReplicationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReplicationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).AddReplicationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/AddReplicationEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).AddReplicationEntries(ctx, req.(*AddReplicationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Agent_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "idl.Agent",
 	HandlerType: (*AgentServer)(nil),
@@ -1536,6 +3199,10 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpgradePrimaries",
 			Handler:    _Agent_UpgradePrimaries_Handler,
 		},
+		{
+			MethodName: "UpgradeMaster",
+			Handler:    _Agent_UpgradeMaster_Handler,
+		},
 		{
 			MethodName: "RenameDirectories",
 			Handler:    _Agent_RenameDirectories_Handler,
@@ -1576,7 +3243,49 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RestorePrimariesPgControl",
 			Handler:    _Agent_RestorePrimariesPgControl_Handler,
 		},
+		{
+			MethodName: "GetDirectorySizes",
+			Handler:    _Agent_GetDirectorySizes_Handler,
+		},
+		{
+			MethodName: "GetSegmentStatuses",
+			Handler:    _Agent_GetSegmentStatuses_Handler,
+		},
+		{
+			MethodName: "UpgradeMirror",
+			Handler:    _Agent_UpgradeMirror_Handler,
+		},
+		{
+			MethodName: "CheckTablespaceRelocations",
+			Handler:    _Agent_CheckTablespaceRelocations_Handler,
+		},
+		{
+			MethodName: "QuiesceSegments",
+			Handler:    _Agent_QuiesceSegments_Handler,
+		},
+		{
+			MethodName: "UnquiesceSegments",
+			Handler:    _Agent_UnquiesceSegments_Handler,
+		},
+		{
+			MethodName: "MigrateConfiguration",
+			Handler:    _Agent_MigrateConfiguration_Handler,
+		},
+		{
+			MethodName: "CheckLibraries",
+			Handler:    _Agent_CheckLibraries_Handler,
+		},
+		{
+			MethodName: "AddReplicationEntries",
+			Handler:    _Agent_AddReplicationEntries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetGPHomeManifest",
+			Handler:       _Agent_GetGPHomeManifest_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "hub_to_agent.proto",
 }
@@ -116,6 +116,26 @@ func (mr *MockCliToHubClientMockRecorder) GetConfig(arg0, arg1 interface{}, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockCliToHubClient)(nil).GetConfig), varargs...)
 }
 
+// GetRevertPlan mocks base method
+func (m *MockCliToHubClient) GetRevertPlan(arg0 context.Context, arg1 *idl.RevertPlanRequest, arg2 ...grpc.CallOption) (*idl.RevertPlanReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRevertPlan", varargs...)
+	ret0, _ := ret[0].(*idl.RevertPlanReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevertPlan indicates an expected call of GetRevertPlan
+func (mr *MockCliToHubClientMockRecorder) GetRevertPlan(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevertPlan", reflect.TypeOf((*MockCliToHubClient)(nil).GetRevertPlan), varargs...)
+}
+
 // Initialize mocks base method
 func (m *MockCliToHubClient) Initialize(arg0 context.Context, arg1 *idl.InitializeRequest, arg2 ...grpc.CallOption) (idl.CliToHub_InitializeClient, error) {
 	m.ctrl.T.Helper()
@@ -297,6 +317,21 @@ func (mr *MockCliToHubServerMockRecorder) GetConfig(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockCliToHubServer)(nil).GetConfig), arg0, arg1)
 }
 
+// GetRevertPlan mocks base method
+func (m *MockCliToHubServer) GetRevertPlan(arg0 context.Context, arg1 *idl.RevertPlanRequest) (*idl.RevertPlanReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevertPlan", arg0, arg1)
+	ret0, _ := ret[0].(*idl.RevertPlanReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevertPlan indicates an expected call of GetRevertPlan
+func (mr *MockCliToHubServerMockRecorder) GetRevertPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevertPlan", reflect.TypeOf((*MockCliToHubServer)(nil).GetRevertPlan), arg0, arg1)
+}
+
 // Initialize mocks base method
 func (m *MockCliToHubServer) Initialize(arg0 *idl.InitializeRequest, arg1 idl.CliToHub_InitializeServer) error {
 	m.ctrl.T.Helper()
@@ -9,6 +9,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	idl "github.com/greenplum-db/gpupgrade/idl"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 	reflect "reflect"
 )
 
@@ -75,6 +76,26 @@ func (mr *MockAgentClientMockRecorder) UpgradePrimaries(ctx, in interface{}, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradePrimaries", reflect.TypeOf((*MockAgentClient)(nil).UpgradePrimaries), varargs...)
 }
 
+// UpgradeMaster mocks base method
+func (m *MockAgentClient) UpgradeMaster(ctx context.Context, in *idl.UpgradeMasterRequest, opts ...grpc.CallOption) (*idl.UpgradeMasterReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpgradeMaster", varargs...)
+	ret0, _ := ret[0].(*idl.UpgradeMasterReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeMaster indicates an expected call of UpgradeMaster
+func (mr *MockAgentClientMockRecorder) UpgradeMaster(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeMaster", reflect.TypeOf((*MockAgentClient)(nil).UpgradeMaster), varargs...)
+}
+
 // RenameDirectories mocks base method
 func (m *MockAgentClient) RenameDirectories(ctx context.Context, in *idl.RenameDirectoriesRequest, opts ...grpc.CallOption) (*idl.RenameDirectoriesReply, error) {
 	m.ctrl.T.Helper()
@@ -275,6 +296,329 @@ func (mr *MockAgentClientMockRecorder) RestorePrimariesPgControl(ctx, in interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePrimariesPgControl", reflect.TypeOf((*MockAgentClient)(nil).RestorePrimariesPgControl), varargs...)
 }
 
+// GetDirectorySizes mocks base method
+func (m *MockAgentClient) GetDirectorySizes(ctx context.Context, in *idl.GetDirectorySizesRequest, opts ...grpc.CallOption) (*idl.GetDirectorySizesReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDirectorySizes", varargs...)
+	ret0, _ := ret[0].(*idl.GetDirectorySizesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirectorySizes indicates an expected call of GetDirectorySizes
+func (mr *MockAgentClientMockRecorder) GetDirectorySizes(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectorySizes", reflect.TypeOf((*MockAgentClient)(nil).GetDirectorySizes), varargs...)
+}
+
+// GetSegmentStatuses mocks base method
+func (m *MockAgentClient) GetSegmentStatuses(ctx context.Context, in *idl.GetSegmentStatusesRequest, opts ...grpc.CallOption) (*idl.GetSegmentStatusesReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSegmentStatuses", varargs...)
+	ret0, _ := ret[0].(*idl.GetSegmentStatusesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSegmentStatuses indicates an expected call of GetSegmentStatuses
+func (mr *MockAgentClientMockRecorder) GetSegmentStatuses(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentStatuses", reflect.TypeOf((*MockAgentClient)(nil).GetSegmentStatuses), varargs...)
+}
+
+// UpgradeMirror mocks base method
+func (m *MockAgentClient) UpgradeMirror(ctx context.Context, in *idl.UpgradeMirrorRequest, opts ...grpc.CallOption) (*idl.UpgradeMirrorReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpgradeMirror", varargs...)
+	ret0, _ := ret[0].(*idl.UpgradeMirrorReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeMirror indicates an expected call of UpgradeMirror
+func (mr *MockAgentClientMockRecorder) UpgradeMirror(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeMirror", reflect.TypeOf((*MockAgentClient)(nil).UpgradeMirror), varargs...)
+}
+
+// CheckTablespaceRelocations mocks base method
+func (m *MockAgentClient) CheckTablespaceRelocations(ctx context.Context, in *idl.CheckTablespaceRelocationsRequest, opts ...grpc.CallOption) (*idl.CheckTablespaceRelocationsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckTablespaceRelocations", varargs...)
+	ret0, _ := ret[0].(*idl.CheckTablespaceRelocationsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckTablespaceRelocations indicates an expected call of CheckTablespaceRelocations
+func (mr *MockAgentClientMockRecorder) CheckTablespaceRelocations(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTablespaceRelocations", reflect.TypeOf((*MockAgentClient)(nil).CheckTablespaceRelocations), varargs...)
+}
+
+// QuiesceSegments mocks base method
+func (m *MockAgentClient) QuiesceSegments(ctx context.Context, in *idl.QuiesceRequest, opts ...grpc.CallOption) (*idl.QuiesceReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QuiesceSegments", varargs...)
+	ret0, _ := ret[0].(*idl.QuiesceReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuiesceSegments indicates an expected call of QuiesceSegments
+func (mr *MockAgentClientMockRecorder) QuiesceSegments(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuiesceSegments", reflect.TypeOf((*MockAgentClient)(nil).QuiesceSegments), varargs...)
+}
+
+// UnquiesceSegments mocks base method
+func (m *MockAgentClient) UnquiesceSegments(ctx context.Context, in *idl.QuiesceRequest, opts ...grpc.CallOption) (*idl.QuiesceReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnquiesceSegments", varargs...)
+	ret0, _ := ret[0].(*idl.QuiesceReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnquiesceSegments indicates an expected call of UnquiesceSegments
+func (mr *MockAgentClientMockRecorder) UnquiesceSegments(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnquiesceSegments", reflect.TypeOf((*MockAgentClient)(nil).UnquiesceSegments), varargs...)
+}
+
+// MigrateConfiguration mocks base method
+func (m *MockAgentClient) MigrateConfiguration(ctx context.Context, in *idl.MigrateConfigurationRequest, opts ...grpc.CallOption) (*idl.MigrateConfigurationReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MigrateConfiguration", varargs...)
+	ret0, _ := ret[0].(*idl.MigrateConfigurationReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateConfiguration indicates an expected call of MigrateConfiguration
+func (mr *MockAgentClientMockRecorder) MigrateConfiguration(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateConfiguration", reflect.TypeOf((*MockAgentClient)(nil).MigrateConfiguration), varargs...)
+}
+
+// CheckLibraries mocks base method
+func (m *MockAgentClient) CheckLibraries(ctx context.Context, in *idl.CheckLibrariesRequest, opts ...grpc.CallOption) (*idl.CheckLibrariesReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckLibraries", varargs...)
+	ret0, _ := ret[0].(*idl.CheckLibrariesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLibraries indicates an expected call of CheckLibraries
+func (mr *MockAgentClientMockRecorder) CheckLibraries(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLibraries", reflect.TypeOf((*MockAgentClient)(nil).CheckLibraries), varargs...)
+}
+
+// GetGPHomeManifest mocks base method
+func (m *MockAgentClient) GetGPHomeManifest(ctx context.Context, in *idl.GetGPHomeManifestRequest, opts ...grpc.CallOption) (idl.Agent_GetGPHomeManifestClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetGPHomeManifest", varargs...)
+	ret0, _ := ret[0].(idl.Agent_GetGPHomeManifestClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGPHomeManifest indicates an expected call of GetGPHomeManifest
+func (mr *MockAgentClientMockRecorder) GetGPHomeManifest(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGPHomeManifest", reflect.TypeOf((*MockAgentClient)(nil).GetGPHomeManifest), varargs...)
+}
+
+// AddReplicationEntries mocks base method
+func (m *MockAgentClient) AddReplicationEntries(ctx context.Context, in *idl.AddReplicationEntriesRequest, opts ...grpc.CallOption) (*idl.AddReplicationEntriesReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddReplicationEntries", varargs...)
+	ret0, _ := ret[0].(*idl.AddReplicationEntriesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReplicationEntries indicates an expected call of AddReplicationEntries
+func (mr *MockAgentClientMockRecorder) AddReplicationEntries(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReplicationEntries", reflect.TypeOf((*MockAgentClient)(nil).AddReplicationEntries), varargs...)
+}
+
+// MockAgent_GetGPHomeManifestClient is a mock of Agent_GetGPHomeManifestClient interface
+type MockAgent_GetGPHomeManifestClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_GetGPHomeManifestClientMockRecorder
+}
+
+// MockAgent_GetGPHomeManifestClientMockRecorder is the mock recorder for MockAgent_GetGPHomeManifestClient
+type MockAgent_GetGPHomeManifestClientMockRecorder struct {
+	mock *MockAgent_GetGPHomeManifestClient
+}
+
+// NewMockAgent_GetGPHomeManifestClient creates a new mock instance
+func NewMockAgent_GetGPHomeManifestClient(ctrl *gomock.Controller) *MockAgent_GetGPHomeManifestClient {
+	mock := &MockAgent_GetGPHomeManifestClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_GetGPHomeManifestClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAgent_GetGPHomeManifestClient) EXPECT() *MockAgent_GetGPHomeManifestClientMockRecorder {
+	return m.recorder
+}
+
+// Recv mocks base method
+func (m *MockAgent_GetGPHomeManifestClient) Recv() (*idl.GetGPHomeManifestReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.GetGPHomeManifestReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv
+func (mr *MockAgent_GetGPHomeManifestClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_GetGPHomeManifestClient)(nil).Recv))
+}
+
+// Header mocks base method
+func (m *MockAgent_GetGPHomeManifestClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header
+func (mr *MockAgent_GetGPHomeManifestClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_GetGPHomeManifestClient)(nil).Header))
+}
+
+// Trailer mocks base method
+func (m *MockAgent_GetGPHomeManifestClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer
+func (mr *MockAgent_GetGPHomeManifestClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_GetGPHomeManifestClient)(nil).Trailer))
+}
+
+// CloseSend mocks base method
+func (m *MockAgent_GetGPHomeManifestClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend
+func (mr *MockAgent_GetGPHomeManifestClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_GetGPHomeManifestClient)(nil).CloseSend))
+}
+
+// Context mocks base method
+func (m *MockAgent_GetGPHomeManifestClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockAgent_GetGPHomeManifestClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_GetGPHomeManifestClient)(nil).Context))
+}
+
+// SendMsg mocks base method
+func (m_2 *MockAgent_GetGPHomeManifestClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockAgent_GetGPHomeManifestClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_GetGPHomeManifestClient)(nil).SendMsg), m)
+}
+
+// RecvMsg mocks base method
+func (m_2 *MockAgent_GetGPHomeManifestClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockAgent_GetGPHomeManifestClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_GetGPHomeManifestClient)(nil).RecvMsg), m)
+}
+
 // MockAgentServer is a mock of AgentServer interface
 type MockAgentServer struct {
 	ctrl     *gomock.Controller
@@ -328,6 +672,21 @@ func (mr *MockAgentServerMockRecorder) UpgradePrimaries(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradePrimaries", reflect.TypeOf((*MockAgentServer)(nil).UpgradePrimaries), arg0, arg1)
 }
 
+// UpgradeMaster mocks base method
+func (m *MockAgentServer) UpgradeMaster(arg0 context.Context, arg1 *idl.UpgradeMasterRequest) (*idl.UpgradeMasterReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeMaster", arg0, arg1)
+	ret0, _ := ret[0].(*idl.UpgradeMasterReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeMaster indicates an expected call of UpgradeMaster
+func (mr *MockAgentServerMockRecorder) UpgradeMaster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeMaster", reflect.TypeOf((*MockAgentServer)(nil).UpgradeMaster), arg0, arg1)
+}
+
 // RenameDirectories mocks base method
 func (m *MockAgentServer) RenameDirectories(arg0 context.Context, arg1 *idl.RenameDirectoriesRequest) (*idl.RenameDirectoriesReply, error) {
 	m.ctrl.T.Helper()
@@ -477,3 +836,271 @@ func (mr *MockAgentServerMockRecorder) RestorePrimariesPgControl(arg0, arg1 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePrimariesPgControl", reflect.TypeOf((*MockAgentServer)(nil).RestorePrimariesPgControl), arg0, arg1)
 }
+
+// GetDirectorySizes mocks base method
+func (m *MockAgentServer) GetDirectorySizes(arg0 context.Context, arg1 *idl.GetDirectorySizesRequest) (*idl.GetDirectorySizesReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectorySizes", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetDirectorySizesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirectorySizes indicates an expected call of GetDirectorySizes
+func (mr *MockAgentServerMockRecorder) GetDirectorySizes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectorySizes", reflect.TypeOf((*MockAgentServer)(nil).GetDirectorySizes), arg0, arg1)
+}
+
+// GetSegmentStatuses mocks base method
+func (m *MockAgentServer) GetSegmentStatuses(arg0 context.Context, arg1 *idl.GetSegmentStatusesRequest) (*idl.GetSegmentStatusesReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSegmentStatuses", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetSegmentStatusesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSegmentStatuses indicates an expected call of GetSegmentStatuses
+func (mr *MockAgentServerMockRecorder) GetSegmentStatuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentStatuses", reflect.TypeOf((*MockAgentServer)(nil).GetSegmentStatuses), arg0, arg1)
+}
+
+// UpgradeMirror mocks base method
+func (m *MockAgentServer) UpgradeMirror(arg0 context.Context, arg1 *idl.UpgradeMirrorRequest) (*idl.UpgradeMirrorReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeMirror", arg0, arg1)
+	ret0, _ := ret[0].(*idl.UpgradeMirrorReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeMirror indicates an expected call of UpgradeMirror
+func (mr *MockAgentServerMockRecorder) UpgradeMirror(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeMirror", reflect.TypeOf((*MockAgentServer)(nil).UpgradeMirror), arg0, arg1)
+}
+
+// CheckTablespaceRelocations mocks base method
+func (m *MockAgentServer) CheckTablespaceRelocations(arg0 context.Context, arg1 *idl.CheckTablespaceRelocationsRequest) (*idl.CheckTablespaceRelocationsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTablespaceRelocations", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CheckTablespaceRelocationsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckTablespaceRelocations indicates an expected call of CheckTablespaceRelocations
+func (mr *MockAgentServerMockRecorder) CheckTablespaceRelocations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTablespaceRelocations", reflect.TypeOf((*MockAgentServer)(nil).CheckTablespaceRelocations), arg0, arg1)
+}
+
+// QuiesceSegments mocks base method
+func (m *MockAgentServer) QuiesceSegments(arg0 context.Context, arg1 *idl.QuiesceRequest) (*idl.QuiesceReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuiesceSegments", arg0, arg1)
+	ret0, _ := ret[0].(*idl.QuiesceReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuiesceSegments indicates an expected call of QuiesceSegments
+func (mr *MockAgentServerMockRecorder) QuiesceSegments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuiesceSegments", reflect.TypeOf((*MockAgentServer)(nil).QuiesceSegments), arg0, arg1)
+}
+
+// UnquiesceSegments mocks base method
+func (m *MockAgentServer) UnquiesceSegments(arg0 context.Context, arg1 *idl.QuiesceRequest) (*idl.QuiesceReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnquiesceSegments", arg0, arg1)
+	ret0, _ := ret[0].(*idl.QuiesceReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnquiesceSegments indicates an expected call of UnquiesceSegments
+func (mr *MockAgentServerMockRecorder) UnquiesceSegments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnquiesceSegments", reflect.TypeOf((*MockAgentServer)(nil).UnquiesceSegments), arg0, arg1)
+}
+
+// MigrateConfiguration mocks base method
+func (m *MockAgentServer) MigrateConfiguration(arg0 context.Context, arg1 *idl.MigrateConfigurationRequest) (*idl.MigrateConfigurationReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateConfiguration", arg0, arg1)
+	ret0, _ := ret[0].(*idl.MigrateConfigurationReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateConfiguration indicates an expected call of MigrateConfiguration
+func (mr *MockAgentServerMockRecorder) MigrateConfiguration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateConfiguration", reflect.TypeOf((*MockAgentServer)(nil).MigrateConfiguration), arg0, arg1)
+}
+
+// CheckLibraries mocks base method
+func (m *MockAgentServer) CheckLibraries(arg0 context.Context, arg1 *idl.CheckLibrariesRequest) (*idl.CheckLibrariesReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLibraries", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CheckLibrariesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLibraries indicates an expected call of CheckLibraries
+func (mr *MockAgentServerMockRecorder) CheckLibraries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLibraries", reflect.TypeOf((*MockAgentServer)(nil).CheckLibraries), arg0, arg1)
+}
+
+// GetGPHomeManifest mocks base method
+func (m *MockAgentServer) GetGPHomeManifest(arg0 *idl.GetGPHomeManifestRequest, arg1 idl.Agent_GetGPHomeManifestServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGPHomeManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetGPHomeManifest indicates an expected call of GetGPHomeManifest
+func (mr *MockAgentServerMockRecorder) GetGPHomeManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGPHomeManifest", reflect.TypeOf((*MockAgentServer)(nil).GetGPHomeManifest), arg0, arg1)
+}
+
+// AddReplicationEntries mocks base method
+func (m *MockAgentServer) AddReplicationEntries(arg0 context.Context, arg1 *idl.AddReplicationEntriesRequest) (*idl.AddReplicationEntriesReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReplicationEntries", arg0, arg1)
+	ret0, _ := ret[0].(*idl.AddReplicationEntriesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReplicationEntries indicates an expected call of AddReplicationEntries
+func (mr *MockAgentServerMockRecorder) AddReplicationEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReplicationEntries", reflect.TypeOf((*MockAgentServer)(nil).AddReplicationEntries), arg0, arg1)
+}
+
+// MockAgent_GetGPHomeManifestServer is a mock of Agent_GetGPHomeManifestServer interface
+type MockAgent_GetGPHomeManifestServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_GetGPHomeManifestServerMockRecorder
+}
+
+// MockAgent_GetGPHomeManifestServerMockRecorder is the mock recorder for MockAgent_GetGPHomeManifestServer
+type MockAgent_GetGPHomeManifestServerMockRecorder struct {
+	mock *MockAgent_GetGPHomeManifestServer
+}
+
+// NewMockAgent_GetGPHomeManifestServer creates a new mock instance
+func NewMockAgent_GetGPHomeManifestServer(ctrl *gomock.Controller) *MockAgent_GetGPHomeManifestServer {
+	mock := &MockAgent_GetGPHomeManifestServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_GetGPHomeManifestServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAgent_GetGPHomeManifestServer) EXPECT() *MockAgent_GetGPHomeManifestServerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method
+func (m *MockAgent_GetGPHomeManifestServer) Send(arg0 *idl.GetGPHomeManifestReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockAgent_GetGPHomeManifestServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_GetGPHomeManifestServer)(nil).Send), arg0)
+}
+
+// SetHeader mocks base method
+func (m *MockAgent_GetGPHomeManifestServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader
+func (mr *MockAgent_GetGPHomeManifestServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_GetGPHomeManifestServer)(nil).SetHeader), arg0)
+}
+
+// SendHeader mocks base method
+func (m *MockAgent_GetGPHomeManifestServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader
+func (mr *MockAgent_GetGPHomeManifestServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_GetGPHomeManifestServer)(nil).SendHeader), arg0)
+}
+
+// SetTrailer mocks base method
+func (m *MockAgent_GetGPHomeManifestServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer
+func (mr *MockAgent_GetGPHomeManifestServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_GetGPHomeManifestServer)(nil).SetTrailer), arg0)
+}
+
+// Context mocks base method
+func (m *MockAgent_GetGPHomeManifestServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockAgent_GetGPHomeManifestServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_GetGPHomeManifestServer)(nil).Context))
+}
+
+// SendMsg mocks base method
+func (m_2 *MockAgent_GetGPHomeManifestServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockAgent_GetGPHomeManifestServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_GetGPHomeManifestServer)(nil).SendMsg), m)
+}
+
+// RecvMsg mocks base method
+func (m_2 *MockAgent_GetGPHomeManifestServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockAgent_GetGPHomeManifestServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_GetGPHomeManifestServer)(nil).RecvMsg), m)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package integrations_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/db/connURI"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/step"
+)
+
+// TestCheckActiveSessions runs the active session check against the 5X or 6X
+// source cluster given by GPHOME_SOURCE and PGPORT, as used by the end-to-end
+// tests.
+func TestCheckActiveSessions(t *testing.T) {
+	gphome := os.Getenv("GPHOME_SOURCE")
+	if gphome == "" {
+		gphome = os.Getenv("GPHOME")
+	}
+
+	port, err := strconv.Atoi(os.Getenv("PGPORT"))
+	if gphome == "" || err != nil {
+		t.Skip("this test requires an active GPDB source cluster (set GPHOME_SOURCE and PGPORT)")
+	}
+
+	version, err := greenplum.LocalVersion(gphome)
+	if err != nil {
+		t.Fatalf("getting source version: %+v", err)
+	}
+
+	conn := connURI.Connection(version, version)
+
+	t.Run("succeeds when there are no prepared transactions", func(t *testing.T) {
+		err := hub.CheckActiveSessions(step.DevNullStream, conn, port, hub.ProceedPolicy, 0)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("reports the prepared transactions of the primaries", func(t *testing.T) {
+		db, err := sql.Open("pgx", conn.URI(connURI.ToSource(), connURI.Port(port)))
+		if err != nil {
+			t.Fatalf("opening source master: %+v", err)
+		}
+		defer db.Close()
+
+		var host string
+		var primaryPort int
+		err = db.QueryRow("SELECT hostname, port FROM gp_segment_configuration WHERE content = 0 AND role = 'p'").Scan(&host, &primaryPort)
+		if err != nil {
+			t.Fatalf("querying primary: %+v", err)
+		}
+
+		// Transactions can only be prepared directly on a primary.
+		primary, err := sql.Open("pgx", conn.URI(connURI.ToSource(), connURI.Host(host), connURI.Port(primaryPort), connURI.UtilityMode()))
+		if err != nil {
+			t.Fatalf("opening primary: %+v", err)
+		}
+		defer primary.Close()
+
+		session, err := primary.Conn(context.Background())
+		if err != nil {
+			t.Fatalf("connecting to primary: %+v", err)
+		}
+		defer session.Close()
+
+		const gid = "gpupgrade_check_active_sessions"
+		for _, query := range []string{"BEGIN", "PREPARE TRANSACTION '" + gid + "'"} {
+			if _, err := session.ExecContext(context.Background(), query); err != nil {
+				t.Fatalf("%s: %+v", query, err)
+			}
+		}
+		defer func() {
+			if _, err := primary.Exec("ROLLBACK PREPARED '" + gid + "'"); err != nil {
+				t.Errorf("rolling back prepared transaction: %+v", err)
+			}
+		}()
+
+		err = hub.CheckActiveSessions(step.DevNullStream, conn, port, hub.ProceedPolicy, 0)
+		if !errors.Is(err, hub.ErrActiveSessions) {
+			t.Fatalf("got error %#v want %#v", err, hub.ErrActiveSessions)
+		}
+
+		expected := `content 0 gid "` + gid + `"`
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error %q to contain %q", err.Error(), expected)
+		}
+	})
+}
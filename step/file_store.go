@@ -17,7 +17,15 @@ type Store interface {
 	Write(idl.Step, idl.Substep, idl.Status) error
 }
 
-// FileStore implements step.Store by providing persistent storage on disk.
+// ResultStore persists what a substep did, such as a report of each action,
+// alongside its status.
+type ResultStore interface {
+	ReadResult(idl.Step, idl.Substep, interface{}) (bool, error)
+	WriteResult(idl.Step, idl.Substep, interface{}) error
+}
+
+// FileStore implements step.Store and step.ResultStore by providing
+// persistent storage on disk.
 type FileStore struct {
 	path string
 }
@@ -28,6 +36,12 @@ func NewFileStore(path string) *FileStore {
 
 type prettyMap = map[string]map[string]PrettyStatus
 
+// resultsKey is the section holding the substep results by step and substep.
+// It cannot collide with a step as their names are upper case.
+const resultsKey = "results"
+
+type resultMap = map[string]map[string]json.RawMessage
+
 // PrettyStatus exists only to write a string description of idl.Status to
 // the JSON representation, instead of an integer.
 type PrettyStatus struct {
@@ -50,23 +64,60 @@ func (p *PrettyStatus) UnmarshalText(buf []byte) error {
 	return nil
 }
 
-func (f *FileStore) load() (prettyMap, error) {
+func (f *FileStore) load() (prettyMap, resultMap, error) {
 	data, err := ioutil.ReadFile(f.path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	var sections map[string]json.RawMessage
+	err = json.Unmarshal(data, &sections)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	substeps := make(prettyMap)
+	results := make(resultMap)
+	for name, section := range sections {
+		if name == resultsKey {
+			if err := json.Unmarshal(section, &results); err != nil {
+				return nil, nil, err
+			}
+
+			continue
+		}
+
+		var statuses map[string]PrettyStatus
+		if err := json.Unmarshal(section, &statuses); err != nil {
+			return nil, nil, err
+		}
+
+		substeps[name] = statuses
+	}
+
+	return substeps, results, nil
+}
+
+func (f *FileStore) save(substeps prettyMap, results resultMap) error {
+	sections := make(map[string]interface{})
+	for name, statuses := range substeps {
+		sections[name] = statuses
+	}
+
+	if len(results) > 0 {
+		sections[resultsKey] = results
 	}
 
-	var substeps prettyMap
-	err = json.Unmarshal(data, &substeps)
+	data, err := json.MarshalIndent(sections, "", "  ") // pretty print JSON
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return substeps, nil
+	return utils.AtomicallyWrite(f.path, data)
 }
 
 func (f *FileStore) Read(step idl.Step, substep idl.Substep) (idl.Status, error) {
-	steps, err := f.load()
+	steps, _, err := f.load()
 	if err != nil {
 		return idl.Status_UNKNOWN_STATUS, err
 	}
@@ -88,7 +139,7 @@ func (f *FileStore) Read(step idl.Step, substep idl.Substep) (idl.Status, error)
 // Load the latest values from the filesystem, rather than storing
 // in-memory on a struct to avoid having two sources of truth.
 func (f *FileStore) Write(step idl.Step, substep idl.Substep, status idl.Status) (err error) {
-	steps, err := f.load()
+	steps, results, err := f.load()
 	if err != nil {
 		return err
 	}
@@ -98,10 +149,46 @@ func (f *FileStore) Write(step idl.Step, substep idl.Substep, status idl.Status)
 	}
 	steps[step.String()][substep.String()] = PrettyStatus{status}
 
-	data, err := json.MarshalIndent(steps, "", "  ") // pretty print JSON
+	return f.save(steps, results)
+}
+
+// ReadResult unmarshals the result written for the substep into result, and
+// returns false when none was written.
+func (f *FileStore) ReadResult(step idl.Step, substep idl.Substep, result interface{}) (bool, error) {
+	_, results, err := f.load()
+	if err != nil {
+		return false, err
+	}
+
+	data, ok := results[step.String()][substep.String()]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(data, result); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// WriteResult atomically replaces the result of the substep with the JSON
+// representation of result.
+func (f *FileStore) WriteResult(step idl.Step, substep idl.Substep, result interface{}) error {
+	steps, results, err := f.load()
 	if err != nil {
 		return err
 	}
 
-	return utils.AtomicallyWrite(f.path, data)
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := results[step.String()]; !ok {
+		results[step.String()] = make(map[string]json.RawMessage)
+	}
+	results[step.String()][substep.String()] = data
+
+	return f.save(steps, results)
 }
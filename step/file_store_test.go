@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/greenplum-db/gpupgrade/idl"
@@ -167,6 +168,73 @@ func TestFileStore(t *testing.T) {
 			t.Errorf("status[%q][%q] = %q, want %q", section, key, raw[section.String()][key], status.String())
 		}
 	})
+
+	t.Run("reads the same result that was written", func(t *testing.T) {
+		clear(t, path)
+
+		substep := idl.Substep_UPDATE_EXTENSIONS
+		expected := []string{"postgis updated"}
+
+		if err := fs.WriteResult(idl.Step_FINALIZE, substep, expected); err != nil {
+			t.Fatalf("WriteResult() returned error %+v", err)
+		}
+
+		var result []string
+		found, err := fs.ReadResult(idl.Step_FINALIZE, substep, &result)
+		if err != nil {
+			t.Errorf("ReadResult() returned error %#v", err)
+		}
+		if !found || !reflect.DeepEqual(result, expected) {
+			t.Errorf("read %q (found %t), want %q", result, found, expected)
+		}
+	})
+
+	t.Run("keeps the statuses and results when writing either", func(t *testing.T) {
+		clear(t, path)
+
+		substep := idl.Substep_UPDATE_EXTENSIONS
+		if err := fs.Write(idl.Step_FINALIZE, substep, idl.Status_RUNNING); err != nil {
+			t.Fatalf("Write() returned error %+v", err)
+		}
+
+		if err := fs.WriteResult(idl.Step_FINALIZE, substep, "result"); err != nil {
+			t.Fatalf("WriteResult() returned error %+v", err)
+		}
+
+		if err := fs.Write(idl.Step_FINALIZE, substep, idl.Status_COMPLETE); err != nil {
+			t.Fatalf("Write() returned error %+v", err)
+		}
+
+		status, err := fs.Read(idl.Step_FINALIZE, substep)
+		if err != nil {
+			t.Errorf("Read() returned error %#v", err)
+		}
+		if status != idl.Status_COMPLETE {
+			t.Errorf("read %v, want %v", status, idl.Status_COMPLETE)
+		}
+
+		var result string
+		found, err := fs.ReadResult(idl.Step_FINALIZE, substep, &result)
+		if err != nil {
+			t.Errorf("ReadResult() returned error %#v", err)
+		}
+		if !found || result != "result" {
+			t.Errorf("read %q (found %t), want %q", result, found, "result")
+		}
+	})
+
+	t.Run("returns false if no result was written for the substep", func(t *testing.T) {
+		clear(t, path)
+
+		var result string
+		found, err := fs.ReadResult(idl.Step_FINALIZE, idl.Substep_UPDATE_EXTENSIONS, &result)
+		if err != nil {
+			t.Errorf("ReadResult() returned error %#v", err)
+		}
+		if found {
+			t.Errorf("read %q, want no result", result)
+		}
+	})
 }
 
 // clear writes an empty JSON map to the given FileStore backing path.
@@ -67,13 +67,7 @@ func Begin(stateDir string, step idl.Step, sender idl.MessageSender) (*Step, err
 }
 
 func HasRun(step idl.Step, substep idl.Substep) (bool, error) {
-	path, err := utils.GetJSONFile(utils.GetStateDir(), SubstepsFileName)
-	if err != nil {
-		return false, xerrors.Errorf("read %q: %w", SubstepsFileName, err)
-	}
-
-	store := NewFileStore(path)
-	status, err := store.Read(step, substep)
+	status, err := readStatus(step, substep)
 	if err != nil {
 		return false, err
 	}
@@ -85,6 +79,27 @@ func HasRun(step idl.Step, substep idl.Substep) (bool, error) {
 	return false, nil
 }
 
+// HasCompleted returns true if the substep has completed, in which case it
+// will be skipped when the step is run again.
+func HasCompleted(step idl.Step, substep idl.Substep) (bool, error) {
+	status, err := readStatus(step, substep)
+	if err != nil {
+		return false, err
+	}
+
+	return status == idl.Status_COMPLETE, nil
+}
+
+func readStatus(step idl.Step, substep idl.Substep) (idl.Status, error) {
+	path, err := utils.GetJSONFile(utils.GetStateDir(), SubstepsFileName)
+	if err != nil {
+		return idl.Status_UNKNOWN_STATUS, xerrors.Errorf("read %q: %w", SubstepsFileName, err)
+	}
+
+	store := NewFileStore(path)
+	return store.Read(step, substep)
+}
+
 func (s *Step) Streams() OutStreams {
 	return s.streams
 }
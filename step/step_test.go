@@ -5,6 +5,7 @@ package step_test
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -380,6 +381,47 @@ func TestHasRun(t *testing.T) {
 	})
 }
 
+func TestHasCompleted(t *testing.T) {
+	cases := []struct {
+		status    idl.Status
+		completed bool
+	}{
+		{idl.Status_UNKNOWN_STATUS, false},
+		{idl.Status_RUNNING, false},
+		{idl.Status_FAILED, false},
+		{idl.Status_COMPLETE, true},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("returns %t when substep is %s", c.completed, c.status), func(t *testing.T) {
+			dir := testutils.GetTempDir(t, "")
+			defer testutils.MustRemoveAll(t, dir)
+
+			resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", dir)
+			defer resetEnv()
+
+			path := filepath.Join(dir, step.SubstepsFileName)
+			testutils.MustWriteToFile(t, path, "{}")
+			if c.status != idl.Status_UNKNOWN_STATUS {
+				store := step.NewFileStore(path)
+				err := store.Write(idl.Step_REVERT, idl.Substep_RESTORE_SOURCE_CLUSTER, c.status)
+				if err != nil {
+					t.Errorf("store.Write returned error %+v", err)
+				}
+			}
+
+			completed, err := step.HasCompleted(idl.Step_REVERT, idl.Substep_RESTORE_SOURCE_CLUSTER)
+			if err != nil {
+				t.Errorf("HasCompleted returned error %+v", err)
+			}
+
+			if completed != c.completed {
+				t.Errorf("got %t want %t", completed, c.completed)
+			}
+		})
+	}
+}
+
 func TestStepFinish(t *testing.T) {
 	t.Run("closes the output streams", func(t *testing.T) {
 		streams := &testutils.DevNullWithClose{}
@@ -83,6 +83,11 @@ func (m *MockAgentServer) UpgradePrimaries(ctx context.Context, in *idl.UpgradeP
 	return &idl.UpgradePrimariesReply{}, err
 }
 
+func (m *MockAgentServer) UpgradeMaster(context.Context, *idl.UpgradeMasterRequest) (*idl.UpgradeMasterReply, error) {
+	m.increaseCalls()
+	return &idl.UpgradeMasterReply{}, nil
+}
+
 func (m *MockAgentServer) RenameDirectories(context.Context, *idl.RenameDirectoriesRequest) (*idl.RenameDirectoriesReply, error) {
 	m.increaseCalls()
 	return &idl.RenameDirectoriesReply{}, nil
@@ -148,3 +153,53 @@ func (m *MockAgentServer) DeleteSourceTablespaceDirectories(context.Context, *id
 	m.increaseCalls()
 	return &idl.DeleteTablespaceReply{}, nil
 }
+
+func (m *MockAgentServer) GetDirectorySizes(context.Context, *idl.GetDirectorySizesRequest) (*idl.GetDirectorySizesReply, error) {
+	m.increaseCalls()
+	return &idl.GetDirectorySizesReply{}, nil
+}
+
+func (m *MockAgentServer) GetSegmentStatuses(context.Context, *idl.GetSegmentStatusesRequest) (*idl.GetSegmentStatusesReply, error) {
+	m.increaseCalls()
+	return &idl.GetSegmentStatusesReply{}, nil
+}
+
+func (m *MockAgentServer) UpgradeMirror(context.Context, *idl.UpgradeMirrorRequest) (*idl.UpgradeMirrorReply, error) {
+	m.increaseCalls()
+	return &idl.UpgradeMirrorReply{}, nil
+}
+
+func (m *MockAgentServer) CheckTablespaceRelocations(context.Context, *idl.CheckTablespaceRelocationsRequest) (*idl.CheckTablespaceRelocationsReply, error) {
+	m.increaseCalls()
+	return &idl.CheckTablespaceRelocationsReply{}, nil
+}
+
+func (m *MockAgentServer) QuiesceSegments(context.Context, *idl.QuiesceRequest) (*idl.QuiesceReply, error) {
+	m.increaseCalls()
+	return &idl.QuiesceReply{}, nil
+}
+
+func (m *MockAgentServer) UnquiesceSegments(context.Context, *idl.QuiesceRequest) (*idl.QuiesceReply, error) {
+	m.increaseCalls()
+	return &idl.QuiesceReply{}, nil
+}
+
+func (m *MockAgentServer) MigrateConfiguration(context.Context, *idl.MigrateConfigurationRequest) (*idl.MigrateConfigurationReply, error) {
+	m.increaseCalls()
+	return &idl.MigrateConfigurationReply{}, nil
+}
+
+func (m *MockAgentServer) CheckLibraries(context.Context, *idl.CheckLibrariesRequest) (*idl.CheckLibrariesReply, error) {
+	m.increaseCalls()
+	return &idl.CheckLibrariesReply{}, nil
+}
+
+func (m *MockAgentServer) GetGPHomeManifest(*idl.GetGPHomeManifestRequest, idl.Agent_GetGPHomeManifestServer) error {
+	m.increaseCalls()
+	return nil
+}
+
+func (m *MockAgentServer) AddReplicationEntries(context.Context, *idl.AddReplicationEntriesRequest) (*idl.AddReplicationEntriesReply, error) {
+	m.increaseCalls()
+	return &idl.AddReplicationEntriesReply{}, nil
+}
@@ -150,7 +150,7 @@ func VerifyRename(t *testing.T, source, target string) {
 		t.Errorf("expected source %q to exist", source)
 	}
 
-	archive := target + upgrade.OldSuffix
+	archive := upgrade.ArchiveDataDir(source, target)
 	if !upgrade.PathExists(archive) {
 		t.Errorf("expected archive %q to exist", archive)
 	}
@@ -283,6 +283,27 @@ func MustMake5XTablespaceDir(t *testing.T, tablespaceOID int) (string, string) {
 	return dbOID, location
 }
 
+// MustMake6XTablespaceDir creates a tablespace dbID directory with the 6X
+// layout and returns it.
+func MustMake6XTablespaceDir(t *testing.T, dbID int) string {
+	t.Helper()
+
+	// ex: /tmp/tablespace/2
+	location := GetTempDir(t, "")
+	dbIDDir := filepath.Join(location, strconv.Itoa(dbID))
+
+	// ex: /tmp/tablespace/2/GPDB_6_301908232/12094
+	dbOID := filepath.Join(dbIDDir, "GPDB_6_301908232", "12094")
+	err := os.MkdirAll(dbOID, 0700)
+	if err != nil {
+		t.Fatalf("creating 6x tablespace dbOID directory: %v", err)
+	}
+
+	MustWriteToFile(t, filepath.Join(dbOID, "16384"), "")
+
+	return dbIDDir
+}
+
 func MustGetExecutablePath(t *testing.T) string {
 	t.Helper()
 
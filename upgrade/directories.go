@@ -93,6 +93,11 @@ func GetArchiveDirectoryName(id ID, t time.Time) string {
 // source to target. For example:
 //   source '/data/dbfast1/demoDataDir0' becomes archive '/data/dbfast1/demoDataDir.123ABC.0.old'
 //   target '/data/dbfast1/demoDataDir.123ABC.0' becomes source '/data/dbfast1/demoDataDir0'
+// When the target was placed in a different base directory than the source,
+// the archive stays next to the source and the target is renamed within its
+// own base directory, such that neither rename crosses filesystems:
+//   source '/data/dbfast1/demoDataDir0' becomes archive '/data/dbfast1/demoDataDir.123ABC.0.old'
+//   target '/ssd/demoDataDir.123ABC.0' becomes '/ssd/demoDataDir0'
 // When renameTarget is false just the source directory is archived. This is
 // useful in link mode when the mirrors have been deleted to save disk space and
 // will upgraded later to their correct location. Thus, renameTarget is false in
@@ -100,7 +105,7 @@ func GetArchiveDirectoryName(id ID, t time.Time) string {
 func ArchiveSource(source, target string, renameTarget bool) error {
 	// Instead of manipulating the source to create the archive we append the
 	// old suffix to the target to achieve the same result.
-	archive := target + OldSuffix
+	archive := ArchiveDataDir(source, target)
 	if alreadyRenamed(archive, target) {
 		return nil
 	}
@@ -119,7 +124,7 @@ func ArchiveSource(source, target string, renameTarget bool) error {
 		return nil
 	}
 
-	if err := renameDataDirectory(target, source); err != nil {
+	if err := renameDataDirectory(target, FinalDataDir(source, target)); err != nil {
 		return err
 	}
 
@@ -400,6 +405,34 @@ func Verify5XTablespaceDirectories(tsLocations []string) error {
 	return mErr
 }
 
+// Verify6XTablespaceDirectories checks the dbID directories of tablespace
+// locations with the following format: DIR/<tablespaceLocation>/<dbID>
+// It ensures each contains a GPDB_6_<catalogVersion> directory.
+func Verify6XTablespaceDirectories(dbIDDirs []string) error {
+	var mErr error
+	for _, dbIDDir := range dbIDDirs {
+		entries, err := ioutil.ReadDir(dbIDDir)
+		if err != nil {
+			mErr = errorlist.Append(mErr, xerrors.Errorf("reading 6X tablespace directory: %w", err))
+			continue
+		}
+
+		found := false
+		for _, entry := range entries {
+			if entry.IsDir() && strings.HasPrefix(entry.Name(), "GPDB_6_") {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			mErr = errorlist.Append(mErr, newTablespaceDirectoryError("6X source cluster", dbIDDir+` missing "GPDB_6_" directory`))
+		}
+	}
+
+	return mErr
+}
+
 func TablespacePath(tablespaceLocation string, dbID int, majorVersion uint64, catalogVersion string) string {
 	return filepath.Join(
 		tablespaceLocation,
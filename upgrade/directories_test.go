@@ -93,6 +93,40 @@ func TestArchiveSource(t *testing.T) {
 		testutils.VerifyRename(t, source, target)
 	})
 
+	t.Run("renames a target placed in another base directory within that directory", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		source := filepath.Join(dir, "data", "seg0")
+		target := filepath.Join(dir, "ssd", "seg.AAAAAAAAAAA.0")
+		for _, datadir := range []string{source, target} {
+			if err := os.MkdirAll(datadir, 0700); err != nil {
+				t.Fatalf("creating %q: %v", datadir, err)
+			}
+
+			for _, f := range upgrade.PostgresFiles {
+				testutils.MustWriteToFile(t, filepath.Join(datadir, f), "")
+			}
+		}
+
+		err := upgrade.ArchiveSource(source, target, true)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		for _, path := range []string{filepath.Join(dir, "data", "seg.AAAAAAAAAAA.0.old"), filepath.Join(dir, "ssd", "seg0")} {
+			if !upgrade.PathExists(path) {
+				t.Errorf("expected %q to exist", path)
+			}
+		}
+
+		for _, path := range []string{source, target} {
+			if upgrade.PathExists(path) {
+				t.Errorf("expected %q to not exist", path)
+			}
+		}
+	})
+
 	t.Run("returns early if already renamed", func(t *testing.T) {
 		source, target, cleanup := testutils.MustCreateDataDirs(t)
 		defer cleanup(t)
@@ -849,6 +883,68 @@ func TestVerify5XTablespaceDirectories(t *testing.T) {
 	})
 }
 
+func TestVerify6XTablespaceDirectories(t *testing.T) {
+	t.Run("succeeds when given multiple 6X tablespace dbID directories", func(t *testing.T) {
+		var dirs []string
+		for _, dbID := range []int{2, 3, 4} {
+			dbIDDir := testutils.MustMake6XTablespaceDir(t, dbID)
+			defer testutils.MustRemoveAll(t, filepath.Dir(dbIDDir))
+
+			dirs = append(dirs, dbIDDir)
+		}
+
+		err := upgrade.Verify6XTablespaceDirectories(dirs)
+		if err != nil {
+			t.Errorf("Verify6XTablespaceDirectories returned error %+v", err)
+		}
+	})
+
+	t.Run("errors when the dbID directory does not exist", func(t *testing.T) {
+		err := upgrade.Verify6XTablespaceDirectories([]string{"/does/not/exist/2"})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %#v want %#v", err, os.ErrNotExist)
+		}
+	})
+
+	t.Run("errors when the dbID directory does not contain a GPDB_6_ directory", func(t *testing.T) {
+		dbIDDir := testutils.MustMake6XTablespaceDir(t, 2)
+		defer testutils.MustRemoveAll(t, filepath.Dir(dbIDDir))
+
+		testutils.MustRemoveAll(t, filepath.Join(dbIDDir, "GPDB_6_301908232"))
+		testutils.MustWriteToFile(t, filepath.Join(dbIDDir, "GPDB_6_file"), "")
+
+		err := upgrade.Verify6XTablespaceDirectories([]string{dbIDDir})
+		if !errors.Is(err, upgrade.ErrInvalidTablespaceDirectory) {
+			t.Errorf("got error %#v want %#v", err, upgrade.ErrInvalidTablespaceDirectory)
+		}
+	})
+	t.Run("reports every invalid dbID directory", func(t *testing.T) {
+		dbIDDir := testutils.MustMake6XTablespaceDir(t, 2)
+		defer testutils.MustRemoveAll(t, filepath.Dir(dbIDDir))
+
+		testutils.MustRemoveAll(t, filepath.Join(dbIDDir, "GPDB_6_301908232"))
+
+		err := upgrade.Verify6XTablespaceDirectories([]string{"/does/not/exist/2", dbIDDir})
+
+		var errs errorlist.Errors
+		if !errors.As(err, &errs) {
+			t.Fatalf("got error %#v want type %T", err, errs)
+		}
+
+		if len(errs) != 2 {
+			t.Fatalf("got %d errors want 2", len(errs))
+		}
+
+		if !errors.Is(errs[0], os.ErrNotExist) {
+			t.Errorf("got error %#v want %#v", errs[0], os.ErrNotExist)
+		}
+
+		if !errors.Is(errs[1], upgrade.ErrInvalidTablespaceDirectory) {
+			t.Errorf("got error %#v want %#v", errs[1], upgrade.ErrInvalidTablespaceDirectory)
+		}
+	})
+}
+
 func setupDirs(t *testing.T, subdirectories []string, requiredPaths []string) (tmpDir string, createdDirectories []string) {
 	var err error
 	tmpDir, err = ioutil.TempDir("", "")
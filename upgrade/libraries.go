@@ -0,0 +1,53 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// sharedLibrarySuffix is appended by the server when loading a library named
+// without it.
+const sharedLibrarySuffix = ".so"
+
+// LibraryPath returns where the server of the GPHOME loads the library from.
+// Libraries named without a directory or within $libdir are loaded from the
+// package library directory.
+func LibraryPath(gphome, library string) string {
+	library = strings.TrimPrefix(library, "$libdir/")
+	if filepath.IsAbs(library) {
+		return library
+	}
+
+	return filepath.Join(gphome, "lib", "postgresql", library)
+}
+
+// ExtensionControlPath returns the control file of the extension within the
+// GPHOME.
+func ExtensionControlPath(gphome, extension string) string {
+	return filepath.Join(gphome, "share", "postgresql", "extension", extension+".control")
+}
+
+// MissingLibraries returns the libraries and extensions which are not
+// installed in the GPHOME. As for the server, a library is found with or
+// without the shared library suffix.
+func MissingLibraries(gphome string, libraries, extensions []string) ([]string, []string) {
+	var missingLibraries []string
+	for _, library := range libraries {
+		path := LibraryPath(gphome, library)
+		if !PathExists(path) && !PathExists(path+sharedLibrarySuffix) {
+			missingLibraries = append(missingLibraries, library)
+		}
+	}
+
+	var missingExtensions []string
+	for _, extension := range extensions {
+		if !PathExists(ExtensionControlPath(gphome, extension)) {
+			missingExtensions = append(missingExtensions, extension)
+		}
+	}
+
+	return missingLibraries, missingExtensions
+}
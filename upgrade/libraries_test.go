@@ -0,0 +1,58 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+func TestLibraryPath(t *testing.T) {
+	cases := map[string]string{
+		"$libdir/postgis-2.5":      "/usr/local/gpdb/lib/postgresql/postgis-2.5",
+		"metrics_collector":        "/usr/local/gpdb/lib/postgresql/metrics_collector",
+		"/opt/madlib/libmadlib.so": "/opt/madlib/libmadlib.so",
+	}
+
+	for library, expected := range cases {
+		if path := upgrade.LibraryPath("/usr/local/gpdb", library); path != expected {
+			t.Errorf("LibraryPath(%q) got %q want %q", library, path, expected)
+		}
+	}
+}
+
+func TestMissingLibraries(t *testing.T) {
+	gphome := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, gphome)
+
+	libDir := filepath.Join(gphome, "lib", "postgresql")
+	extensionDir := filepath.Join(gphome, "share", "postgresql", "extension")
+	for _, dir := range []string{libDir, extensionDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("creating %q: %v", dir, err)
+		}
+	}
+
+	testutils.MustWriteToFile(t, filepath.Join(libDir, "postgis-2.5.so"), "")
+	testutils.MustWriteToFile(t, filepath.Join(libDir, "gp_inject_fault"), "")
+	testutils.MustWriteToFile(t, filepath.Join(extensionDir, "postgis.control"), "")
+
+	libraries := []string{"$libdir/postgis-2.5", "gp_inject_fault", "$libdir/libmadlib"}
+	extensions := []string{"postgis", "madlib"}
+
+	missingLibraries, missingExtensions := upgrade.MissingLibraries(gphome, libraries, extensions)
+
+	if expected := []string{"$libdir/libmadlib"}; !reflect.DeepEqual(missingLibraries, expected) {
+		t.Errorf("got missing libraries %q want %q", missingLibraries, expected)
+	}
+
+	if expected := []string{"madlib"}; !reflect.DeepEqual(missingExtensions, expected) {
+		t.Errorf("got missing extensions %q want %q", missingExtensions, expected)
+	}
+}
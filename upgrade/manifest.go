@@ -0,0 +1,84 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"crypto/sha256"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+)
+
+// GPHomeManifest maps the files of the GPHOME, relative to it, to their
+// SHA-256 checksums. Symbolic links are not followed, and map to the checksum
+// of their target path instead, such that a link differs only when it points
+// elsewhere.
+func GPHomeManifest(gphome string) (map[string][]byte, error) {
+	// A GPHOME is commonly a link to the versioned installation.
+	root, err := filepath.EvalSymlinks(gphome)
+	if err != nil {
+		return nil, xerrors.Errorf("resolving GPHOME %q: %w", gphome, err)
+	}
+
+	manifest := make(map[string][]byte)
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		checksum, err := fileChecksum(path, info)
+		if err != nil {
+			return err
+		}
+
+		manifest[rel] = checksum
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("computing manifest of GPHOME %q: %w", gphome, err)
+	}
+
+	return manifest, nil
+}
+
+func fileChecksum(path string, info os.FileInfo) ([]byte, error) {
+	hash := sha256.New()
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return nil, err
+		}
+
+		hash.Write([]byte("symlink:" + target))
+		return hash.Sum(nil), nil
+	}
+
+	if !info.Mode().IsRegular() {
+		return hash.Sum(nil), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, err
+	}
+
+	return hash.Sum(nil), nil
+}
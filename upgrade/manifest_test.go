@@ -0,0 +1,59 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+func TestGPHomeManifest(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	gphome := filepath.Join(dir, "greenplum-db-7.0.0")
+	if err := os.MkdirAll(filepath.Join(gphome, "bin"), 0755); err != nil {
+		t.Fatalf("creating GPHOME: %v", err)
+	}
+
+	testutils.MustWriteToFile(t, filepath.Join(gphome, "bin", "postgres"), "postgres")
+	if err := os.Symlink("postgres", filepath.Join(gphome, "bin", "postmaster")); err != nil {
+		t.Fatalf("creating symlink: %v", err)
+	}
+
+	link := filepath.Join(dir, "greenplum-db")
+	if err := os.Symlink(gphome, link); err != nil {
+		t.Fatalf("creating symlink: %v", err)
+	}
+
+	manifest, err := upgrade.GPHomeManifest(link)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	if len(manifest) != 2 {
+		t.Errorf("got manifest %v want bin/postgres and bin/postmaster", manifest)
+	}
+
+	postgres := sha256.Sum256([]byte("postgres"))
+	if !bytes.Equal(manifest["bin/postgres"], postgres[:]) {
+		t.Errorf("got checksum %x want %x", manifest["bin/postgres"], postgres)
+	}
+
+	postmaster := sha256.Sum256([]byte("symlink:postgres"))
+	if !bytes.Equal(manifest["bin/postmaster"], postmaster[:]) {
+		t.Errorf("got checksum %x want %x", manifest["bin/postmaster"], postmaster)
+	}
+
+	_, err = upgrade.GPHomeManifest(filepath.Join(dir, "does-not-exist"))
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
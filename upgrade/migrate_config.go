@@ -0,0 +1,377 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const (
+	migratedSettingsBegin = "# BEGIN gpupgrade migrated settings"
+	migratedSettingsEnd   = "# END gpupgrade migrated settings"
+	migratedHbaBegin      = "# BEGIN gpupgrade migrated entries"
+	migratedHbaEnd        = "# END gpupgrade migrated entries"
+)
+
+// The actions taken for each source setting or pg_hba.conf entry migrated to
+// the target.
+const (
+	ConfigApplied = "applied"
+	ConfigRenamed = "renamed"
+	ConfigSkipped = "skipped"
+)
+
+// ConfigChange reports what was done with a source setting or pg_hba.conf
+// entry that differs from the target.
+type ConfigChange struct {
+	File   string
+	Name   string
+	Action string
+	Detail string
+}
+
+func (c ConfigChange) String() string {
+	return fmt.Sprintf("%s: %s %q: %s", c.File, c.Action, c.Name, c.Detail)
+}
+
+// Setting is a postgresql.conf parameter.
+type Setting struct {
+	Name  string
+	Value string
+}
+
+// managedSettings are set by gpinitsystem or gpupgrade for the target and
+// must not be carried over from the source.
+var managedSettings = map[string]string{
+	"port":                       "set by gpupgrade",
+	"data_directory":             "set by gpupgrade",
+	"config_file":                "set by gpupgrade",
+	"hba_file":                   "set by gpupgrade",
+	"ident_file":                 "set by gpupgrade",
+	"external_pid_file":          "set by gpupgrade",
+	"gp_dbid":                    "set by gpupgrade",
+	"gp_contentid":               "set by gpupgrade",
+	"gp_num_contents_in_cluster": "set by gpupgrade",
+	"include":                    "include directives are not migrated",
+	"include_dir":                "include directives are not migrated",
+	"include_if_exists":          "include directives are not migrated",
+	"shared_preload_libraries":   "set it manually once the libraries are installed for the target",
+	"dynamic_library_path":       "set it manually once the libraries are installed for the target",
+}
+
+// renamedSetting is a setting renamed in the target major version with the
+// same meaning, such that its value can be carried over.
+type renamedSetting struct {
+	major     uint64
+	renamedTo string
+}
+
+var renamedSettings = map[string]renamedSetting{
+	"unix_socket_directory": {major: 6, renamedTo: "unix_socket_directories"},
+}
+
+// TargetSettingNames returns the names of the settings known to the postgres
+// of the GPHOME, as listed by "postgres --describe-config".
+func TargetSettingNames(gphome string) (map[string]bool, error) {
+	postgres := filepath.Join(gphome, "bin", "postgres")
+
+	cmd := execCommand(postgres, "--describe-config")
+	gplog.Debug("running cmd %q", cmd.String())
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, xerrors.Errorf("describing settings of %q: %w", postgres, err)
+	}
+
+	names := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if name := strings.TrimSpace(fields[0]); name != "" {
+			names[strings.ToLower(name)] = true
+		}
+	}
+
+	if len(names) == 0 {
+		return nil, xerrors.Errorf("no settings described by %q", postgres)
+	}
+
+	return names, nil
+}
+
+// MigrateConfig carries the source postgresql.conf settings and pg_hba.conf
+// entries which differ from the target over to it. The settings are appended
+// in a gpupgrade-managed block, and the entries are placed in one ahead of the
+// target's own, and both are replaced when migrating again. The original
+// pg_hba.conf of a quiesced source is used. Settings not among targetSettings
+// are skipped, unless targetSettings is nil.
+func MigrateConfig(sourceDataDir, targetDataDir string, sourceVersion, targetVersion semver.Version, targetSettings map[string]bool) ([]ConfigChange, error) {
+	sourceConf, err := ioutil.ReadFile(filepath.Join(sourceDataDir, "postgresql.conf"))
+	if err != nil {
+		return nil, err
+	}
+
+	targetConfPath := filepath.Join(targetDataDir, "postgresql.conf")
+	targetConf, err := ioutil.ReadFile(targetConfPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceHbaPath := filepath.Join(sourceDataDir, "pg_hba.conf")
+	if PathExists(filepath.Join(sourceDataDir, HbaBackupName)) {
+		sourceHbaPath = filepath.Join(sourceDataDir, HbaBackupName)
+	}
+
+	sourceHba, err := ioutil.ReadFile(sourceHbaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	targetHbaPath := filepath.Join(targetDataDir, "pg_hba.conf")
+	targetHba, err := ioutil.ReadFile(targetHbaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := removeBlock(string(targetConf), migratedSettingsBegin, migratedSettingsEnd)
+	settings, changes := MigrateSettings(ParseSettings(string(sourceConf)), ParseSettings(conf), sourceVersion, targetVersion, targetSettings)
+
+	var lines []string
+	for _, setting := range settings {
+		lines = append(lines, fmt.Sprintf("%s = %s", setting.Name, setting.Value))
+	}
+
+	conf = appendBlock(conf, migratedSettingsBegin, migratedSettingsEnd, lines)
+	if err := utils.AtomicallyWrite(targetConfPath, []byte(conf)); err != nil {
+		return nil, xerrors.Errorf("writing %q: %w", targetConfPath, err)
+	}
+
+	hba := removeBlock(string(targetHba), migratedHbaBegin, migratedHbaEnd)
+	entries, hbaChanges := MigrateHbaEntries(string(sourceHba), hba)
+	changes = append(changes, hbaChanges...)
+
+	hba = prependBlock(hba, migratedHbaBegin, migratedHbaEnd, entries)
+	if err := utils.AtomicallyWrite(targetHbaPath, []byte(hba)); err != nil {
+		return nil, xerrors.Errorf("writing %q: %w", targetHbaPath, err)
+	}
+
+	return changes, nil
+}
+
+// MigrateSettings returns the source settings to apply to the target, being
+// those whose values differ from the target. Settings managed by gpupgrade are
+// skipped, and those renamed in the target version are renamed. Unless
+// targetSettings is nil, settings the target does not know are skipped, other
+// than the custom settings of extensions which are named with a prefix.
+func MigrateSettings(source, target []Setting, sourceVersion, targetVersion semver.Version, targetSettings map[string]bool) ([]Setting, []ConfigChange) {
+	targetValues := make(map[string]string)
+	for _, setting := range target {
+		targetValues[setting.Name] = setting.Value
+	}
+
+	var settings []Setting
+	var changes []ConfigChange
+	for _, setting := range source {
+		change := ConfigChange{File: "postgresql.conf", Name: setting.Name}
+
+		if reason, ok := managedSettings[setting.Name]; ok {
+			if unquote(targetValues[setting.Name]) != unquote(setting.Value) {
+				change.Action, change.Detail = ConfigSkipped, reason
+				changes = append(changes, change)
+			}
+			continue
+		}
+
+		if renamed, ok := renamedSettings[setting.Name]; ok && sourceVersion.Major < renamed.major && renamed.major <= targetVersion.Major {
+			change.Action, change.Detail = ConfigRenamed, fmt.Sprintf("to %s = %s", renamed.renamedTo, setting.Value)
+			changes = append(changes, change)
+			setting.Name = renamed.renamedTo
+		}
+
+		if targetSettings != nil && !targetSettings[setting.Name] && !strings.Contains(setting.Name, ".") {
+			change.Action, change.Detail = ConfigSkipped, fmt.Sprintf("not a setting of GPDB %d", targetVersion.Major)
+			changes = append(changes, change)
+			continue
+		}
+
+		current, ok := targetValues[setting.Name]
+		if ok && unquote(current) == unquote(setting.Value) {
+			continue
+		}
+
+		if change.Action == "" {
+			change.Action, change.Detail = ConfigApplied, fmt.Sprintf("%s replacing the target default", setting.Value)
+			if ok {
+				change.Detail = fmt.Sprintf("%s replacing %s", setting.Value, current)
+			}
+			changes = append(changes, change)
+		}
+
+		settings = append(settings, setting)
+	}
+
+	return settings, changes
+}
+
+// MigrateHbaEntries returns the source pg_hba.conf entries to place ahead of
+// the target entries when any are missing from the target. As the first
+// matching entry is used, all source entries are returned in their order such
+// that they take precedence over the target defaults as they did on the
+// source. Only those missing from the target are reported. Entries of a
+// quiesce block are not migrated.
+func MigrateHbaEntries(source, target string) ([]string, []ConfigChange) {
+	present := make(map[string]bool)
+	for _, entry := range hbaEntries(target) {
+		present[entry] = true
+	}
+
+	var entries []string
+	var changes []ConfigChange
+	seen := make(map[string]bool)
+	for _, entry := range hbaEntries(removeBlock(source, quiesceBegin, quiesceEnd)) {
+		if seen[entry] {
+			continue
+		}
+
+		seen[entry] = true
+		entries = append(entries, entry)
+
+		if !present[entry] {
+			changes = append(changes, ConfigChange{File: "pg_hba.conf", Name: entry, Action: ConfigApplied, Detail: "carried over from the source"})
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	return entries, changes
+}
+
+// ParseSettings returns the settings of a postgresql.conf in the order they
+// first appear, with the value of the last occurrence as it takes effect.
+func ParseSettings(contents string) []Setting {
+	var settings []Setting
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		end := strings.IndexAny(line, " \t=")
+		if end < 0 {
+			continue
+		}
+
+		name := strings.ToLower(line[:end])
+		value := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line[end:]), "="))
+
+		if i, ok := index[name]; ok {
+			settings[i].Value = value
+			continue
+		}
+
+		index[name] = len(settings)
+		settings = append(settings, Setting{Name: name, Value: value})
+	}
+
+	return settings
+}
+
+// stripComment removes a trailing comment which is not within a quoted value.
+func stripComment(line string) string {
+	quoted := false
+	for i, c := range line {
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case c == '#' && !quoted:
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+func unquote(value string) string {
+	return strings.Trim(value, "'")
+}
+
+func hbaEntries(contents string) []string {
+	var entries []string
+
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		entries = append(entries, strings.Join(fields, " "))
+	}
+
+	return entries
+}
+
+// removeBlock returns the contents without the lines between and including
+// the begin and end markers.
+func removeBlock(contents, begin, end string) string {
+	var lines []string
+	inBlock := false
+
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == begin:
+			inBlock = true
+		case line == end:
+			inBlock = false
+		case !inBlock:
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func appendBlock(contents, begin, end string, lines []string) string {
+	if len(lines) == 0 {
+		return contents
+	}
+
+	return contents + block(begin, end, lines)
+}
+
+func prependBlock(contents, begin, end string, lines []string) string {
+	if len(lines) == 0 {
+		return contents
+	}
+
+	return block(begin, end, lines) + contents
+}
+
+func block(begin, end string, lines []string) string {
+	block := append([]string{begin, "# Carried over from the source cluster by gpupgrade."}, lines...)
+	block = append(block, end)
+
+	return strings.Join(block, "\n") + "\n"
+}
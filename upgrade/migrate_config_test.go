@@ -0,0 +1,281 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+func postgresDescribeConfig() {
+	fmt.Println("port\tConnections and Authentication / Connection Settings\tSets the TCP port the server listens on.\tINTEGER")
+	fmt.Println("Work_Mem\tResource Usage / Memory\tSets the maximum memory to be used for query workspaces.\tINTEGER")
+	fmt.Println("")
+}
+
+func postgresDescribeConfig_Empty() {}
+
+func init() {
+	exectest.RegisterMains(
+		postgresDescribeConfig,
+		postgresDescribeConfig_Empty,
+	)
+}
+
+func TestTargetSettingNames(t *testing.T) {
+	t.Run("returns the lowercased names described by the target postgres", func(t *testing.T) {
+		upgrade.SetExecCommand(exectest.NewCommandWithVerifier(postgresDescribeConfig, func(name string, args ...string) {
+			if name != "/usr/local/target/bin/postgres" {
+				t.Errorf("got %q want the target postgres", name)
+			}
+
+			expected := []string{"--describe-config"}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+		defer upgrade.ResetExecCommand()
+
+		names, err := upgrade.TargetSettingNames("/usr/local/target")
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		expected := map[string]bool{"port": true, "work_mem": true}
+		if !reflect.DeepEqual(names, expected) {
+			t.Errorf("got %v want %v", names, expected)
+		}
+	})
+
+	t.Run("errors when postgres fails", func(t *testing.T) {
+		upgrade.SetExecCommand(exectest.NewCommand(Failure))
+		defer upgrade.ResetExecCommand()
+
+		_, err := upgrade.TargetSettingNames("/usr/local/target")
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+
+	t.Run("errors when no settings are described", func(t *testing.T) {
+		upgrade.SetExecCommand(exectest.NewCommand(postgresDescribeConfig_Empty))
+		defer upgrade.ResetExecCommand()
+
+		_, err := upgrade.TargetSettingNames("/usr/local/target")
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
+
+func TestParseSettings(t *testing.T) {
+	contents := `# comment
+port = 5432 # the port
+work_mem='64MB'
+log_line_prefix = '%m # %p'
+Statement_Timeout 0
+work_mem = '128MB'
+`
+
+	expected := []upgrade.Setting{
+		{Name: "port", Value: "5432"},
+		{Name: "work_mem", Value: "'128MB'"},
+		{Name: "log_line_prefix", Value: "'%m # %p'"},
+		{Name: "statement_timeout", Value: "0"},
+	}
+
+	settings := upgrade.ParseSettings(contents)
+	if !reflect.DeepEqual(settings, expected) {
+		t.Errorf("got %v want %v", settings, expected)
+	}
+}
+
+func TestMigrateSettings(t *testing.T) {
+	source := []upgrade.Setting{
+		{Name: "port", Value: "5432"},
+		{Name: "max_connections", Value: "250"},
+		{Name: "work_mem", Value: "'64MB'"},
+		{Name: "statement_timeout", Value: "0"},
+		{Name: "unix_socket_directory", Value: "'/var/run'"},
+		{Name: "max_fsm_pages", Value: "200000"},
+		{Name: "shared_preload_libraries", Value: "'metrics_collector'"},
+		{Name: "metrics_collector.interval", Value: "30"},
+	}
+
+	target := []upgrade.Setting{
+		{Name: "port", Value: "50432"},
+		{Name: "max_connections", Value: "750"},
+		{Name: "statement_timeout", Value: "'0'"},
+	}
+
+	targetSettings := map[string]bool{
+		"port":                     true,
+		"max_connections":          true,
+		"work_mem":                 true,
+		"statement_timeout":        true,
+		"unix_socket_directories":  true,
+		"shared_preload_libraries": true,
+	}
+
+	settings, changes := upgrade.MigrateSettings(source, target, semver.MustParse("5.28.0"), semver.MustParse("6.20.0"), targetSettings)
+
+	expectedSettings := []upgrade.Setting{
+		{Name: "max_connections", Value: "250"},
+		{Name: "work_mem", Value: "'64MB'"},
+		{Name: "unix_socket_directories", Value: "'/var/run'"},
+		{Name: "metrics_collector.interval", Value: "30"},
+	}
+	if !reflect.DeepEqual(settings, expectedSettings) {
+		t.Errorf("got settings %v want %v", settings, expectedSettings)
+	}
+
+	expectedActions := map[string]string{
+		"port":                       upgrade.ConfigSkipped,
+		"max_connections":            upgrade.ConfigApplied,
+		"work_mem":                   upgrade.ConfigApplied,
+		"unix_socket_directory":      upgrade.ConfigRenamed,
+		"max_fsm_pages":              upgrade.ConfigSkipped,
+		"shared_preload_libraries":   upgrade.ConfigSkipped,
+		"metrics_collector.interval": upgrade.ConfigApplied,
+	}
+
+	actions := make(map[string]string)
+	for _, change := range changes {
+		actions[change.Name] = change.Action
+	}
+
+	if !reflect.DeepEqual(actions, expectedActions) {
+		t.Errorf("got actions %v want %v", actions, expectedActions)
+	}
+
+	t.Run("reports settings unknown to the target", func(t *testing.T) {
+		_, changes := upgrade.MigrateSettings([]upgrade.Setting{{Name: "checkpoint_segments", Value: "8"}}, nil,
+			semver.MustParse("6.20.0"), semver.MustParse("7.0.0"), map[string]bool{"max_wal_size": true})
+
+		expected := []upgrade.ConfigChange{
+			{File: "postgresql.conf", Name: "checkpoint_segments", Action: upgrade.ConfigSkipped, Detail: "not a setting of GPDB 7"},
+		}
+		if !reflect.DeepEqual(changes, expected) {
+			t.Errorf("got changes %v want %v", changes, expected)
+		}
+	})
+
+	t.Run("keeps all settings when the target settings are unknown", func(t *testing.T) {
+		settings, _ := upgrade.MigrateSettings([]upgrade.Setting{{Name: "checkpoint_segments", Value: "8"}}, nil,
+			semver.MustParse("5.28.0"), semver.MustParse("6.20.0"), nil)
+
+		expected := []upgrade.Setting{{Name: "checkpoint_segments", Value: "8"}}
+		if !reflect.DeepEqual(settings, expected) {
+			t.Errorf("got settings %v want %v", settings, expected)
+		}
+	})
+}
+
+func TestMigrateHbaEntries(t *testing.T) {
+	source := upgrade.QuiescedHbaConf(`local all gpadmin ident
+host  all  etl  10.0.0.0/8  md5
+`, []string{"gpadmin"})
+
+	target := `# gpinitsystem entries
+local all gpadmin ident
+`
+
+	entries, changes := upgrade.MigrateHbaEntries(source, target)
+
+	// The source entries keep their order ahead of the target entries.
+	expected := []string{"local all gpadmin ident", "host all etl 10.0.0.0/8 md5"}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("got entries %q want %q", entries, expected)
+	}
+
+	if len(changes) != 1 || changes[0].Name != "host all etl 10.0.0.0/8 md5" || changes[0].Action != upgrade.ConfigApplied {
+		t.Errorf("got changes %v want only the new entry applied", changes)
+	}
+
+	t.Run("returns no entries when the target has them all", func(t *testing.T) {
+		entries, changes := upgrade.MigrateHbaEntries("local all gpadmin ident\n", target)
+		if entries != nil || changes != nil {
+			t.Errorf("got entries %q and changes %v want none", entries, changes)
+		}
+	})
+}
+
+func TestMigrateConfig(t *testing.T) {
+	sourceDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, sourceDir)
+
+	targetDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, targetDir)
+
+	testutils.MustWriteToFile(t, filepath.Join(sourceDir, "postgresql.conf"), "port = 5432\nwork_mem = '64MB'\n")
+	testutils.MustWriteToFile(t, filepath.Join(sourceDir, "pg_hba.conf"), "host all etl 10.0.0.0/8 md5\n")
+	testutils.MustWriteToFile(t, filepath.Join(targetDir, "postgresql.conf"), "port = 50432\n")
+	testutils.MustWriteToFile(t, filepath.Join(targetDir, "pg_hba.conf"), "local all gpadmin ident\n")
+
+	expectedConf := `port = 50432
+# BEGIN gpupgrade migrated settings
+# Carried over from the source cluster by gpupgrade.
+work_mem = '64MB'
+# END gpupgrade migrated settings
+`
+
+	expectedHba := `# BEGIN gpupgrade migrated entries
+# Carried over from the source cluster by gpupgrade.
+host all etl 10.0.0.0/8 md5
+# END gpupgrade migrated entries
+local all gpadmin ident
+`
+
+	// Migrating again replaces the previously migrated blocks.
+	for i := 0; i < 2; i++ {
+		changes, err := upgrade.MigrateConfig(sourceDir, targetDir, semver.MustParse("6.20.0"), semver.MustParse("6.20.0"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if len(changes) != 3 {
+			t.Errorf("got changes %v want port skipped, work_mem and the entry applied", changes)
+		}
+
+		conf := testutils.MustReadFile(t, filepath.Join(targetDir, "postgresql.conf"))
+		if conf != expectedConf {
+			t.Errorf("got postgresql.conf\n%s\nwant\n%s", conf, expectedConf)
+		}
+
+		hba := testutils.MustReadFile(t, filepath.Join(targetDir, "pg_hba.conf"))
+		if hba != expectedHba {
+			t.Errorf("got pg_hba.conf\n%s\nwant\n%s", hba, expectedHba)
+		}
+	}
+
+	t.Run("uses the original pg_hba.conf of a quiesced source", func(t *testing.T) {
+		testutils.MustWriteToFile(t, filepath.Join(sourceDir, "pg_hba.conf"), "local all all reject\n")
+		testutils.MustWriteToFile(t, filepath.Join(sourceDir, upgrade.HbaBackupName), "host all etl 10.0.0.0/8 md5\n")
+
+		_, err := upgrade.MigrateConfig(sourceDir, targetDir, semver.MustParse("6.20.0"), semver.MustParse("6.20.0"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		hba := testutils.MustReadFile(t, filepath.Join(targetDir, "pg_hba.conf"))
+		if hba != expectedHba {
+			t.Errorf("got pg_hba.conf\n%s\nwant\n%s", hba, expectedHba)
+		}
+	})
+
+	t.Run("errors when the source configuration is missing", func(t *testing.T) {
+		_, err := upgrade.MigrateConfig("/does/not/exist", targetDir, semver.MustParse("6.20.0"), semver.MustParse("6.20.0"), nil)
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Placement roles name the kind of segment a placement rule applies to.
+const (
+	MasterPlacement  = "master"
+	StandbyPlacement = "standby"
+	PrimaryPlacement = "primary"
+	MirrorPlacement  = "mirror"
+)
+
+var placementRoles = []string{MasterPlacement, StandbyPlacement, PrimaryPlacement, MirrorPlacement}
+
+// PlacementRule places the target data directories of the segments on Host
+// with Role into BaseDir. An empty Host or Role matches any host or role.
+//
+// BaseDir may contain the placeholders {host}, {role} and {content} which are
+// replaced with the hostname, placement role and content ID of the segment.
+type PlacementRule struct {
+	Host    string
+	Role    string
+	BaseDir string
+}
+
+// DataDirPlacement decides where the target data directories are created. By
+// default they are placed next to the source data directories, which in copy
+// mode requires both copies to fit on the same filesystem.
+type DataDirPlacement []PlacementRule
+
+// ParseDataDirPlacement parses a comma separated list of rules of the form
+// [selector:]basedir. The selector is a placement role, a hostname, or a
+// hostname/role pair. A rule without a selector applies to every segment.
+// For example:
+//
+//   primary:/ssd/primary, mirror:/ssd/mirror, sdw3:/nvme/{role}
+func ParseDataDirPlacement(value string) (DataDirPlacement, error) {
+	var placement DataDirPlacement
+	seen := make(map[PlacementRule]bool)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		var rule PlacementRule
+		selector, baseDir := "", entry
+		if i := strings.Index(entry, ":"); i >= 0 {
+			selector, baseDir = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		}
+
+		switch parts := strings.SplitN(selector, "/", 2); {
+		case len(parts) == 2:
+			rule.Host, rule.Role = parts[0], parts[1]
+		case isPlacementRole(selector):
+			rule.Role = selector
+		default:
+			rule.Host = selector
+		}
+		rule.BaseDir = filepath.Clean(baseDir)
+
+		if err := rule.validate(); err != nil {
+			return nil, xerrors.Errorf("invalid placement %q: %w", entry, err)
+		}
+
+		key := PlacementRule{Host: rule.Host, Role: rule.Role}
+		if seen[key] {
+			return nil, xerrors.Errorf("placement %q is declared more than once", entry)
+		}
+		seen[key] = true
+
+		placement = append(placement, rule)
+	}
+
+	return placement, nil
+}
+
+func (r PlacementRule) validate() error {
+	if r.Role != "" && !isPlacementRole(r.Role) {
+		return xerrors.Errorf("role %q is not one of %s", r.Role, strings.Join(placementRoles, ", "))
+	}
+
+	if !filepath.IsAbs(r.BaseDir) {
+		return xerrors.Errorf("base directory %q must be an absolute path", r.BaseDir)
+	}
+
+	return nil
+}
+
+func isPlacementRole(role string) bool {
+	for _, r := range placementRoles {
+		if role == r {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r PlacementRule) String() string {
+	switch {
+	case r.Host != "" && r.Role != "":
+		return fmt.Sprintf("%s/%s:%s", r.Host, r.Role, r.BaseDir)
+	case r.Host != "":
+		return fmt.Sprintf("%s:%s", r.Host, r.BaseDir)
+	case r.Role != "":
+		return fmt.Sprintf("%s:%s", r.Role, r.BaseDir)
+	default:
+		return r.BaseDir
+	}
+}
+
+// BaseDir returns the base directory for the target data directory of a
+// segment, or an empty string when the target is placed next to the source.
+// The most specific matching rule wins: a hostname and role match, then a
+// hostname, then a role, and lastly a rule without a selector.
+func (p DataDirPlacement) BaseDir(host, role string, contentID int) string {
+	best, bestScore := PlacementRule{}, -1
+	for _, rule := range p {
+		if (rule.Host != "" && rule.Host != host) || (rule.Role != "" && rule.Role != role) {
+			continue
+		}
+
+		score := 0
+		if rule.Host != "" {
+			score += 2
+		}
+		if rule.Role != "" {
+			score += 1
+		}
+
+		if score > bestScore {
+			best, bestScore = rule, score
+		}
+	}
+
+	if bestScore < 0 {
+		return ""
+	}
+
+	return strings.NewReplacer(
+		"{host}", host,
+		"{role}", role,
+		"{content}", strconv.Itoa(contentID),
+	).Replace(best.BaseDir)
+}
+
+// PlacedDataDir returns the temporary target data directory for a source
+// data directory. It is TempDataDir moved into the base directory given by
+// the placement, if any.
+func (p DataDirPlacement) PlacedDataDir(datadir, segPrefix string, id ID, host, role string, contentID int) string {
+	target := TempDataDir(datadir, segPrefix, id)
+
+	baseDir := p.BaseDir(host, role, contentID)
+	if baseDir == "" {
+		return target
+	}
+
+	return filepath.Join(baseDir, filepath.Base(target))
+}
+
+// FinalDataDir returns the data directory of an upgraded segment after
+// finalize. The target data directory takes the name of the source data
+// directory but stays within its own parent directory, so the rename never
+// crosses filesystems. Without a placement this is the source data directory.
+func FinalDataDir(source, target string) string {
+	return filepath.Join(filepath.Dir(filepath.Clean(target)), filepath.Base(filepath.Clean(source)))
+}
+
+// ArchiveDataDir returns the path the source data directory is archived to
+// during finalize. The archive stays within the parent directory of the
+// source, so the rename never crosses filesystems.
+func ArchiveDataDir(source, target string) string {
+	return filepath.Join(filepath.Dir(filepath.Clean(source)), filepath.Base(filepath.Clean(target))+OldSuffix)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+func TestParseDataDirPlacement(t *testing.T) {
+	t.Run("parses the placement rules", func(t *testing.T) {
+		placement, err := upgrade.ParseDataDirPlacement("primary:/ssd/primary, mirror : /ssd/mirror/ ,sdw3:/nvme,sdw3/master:/x,/default")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := upgrade.DataDirPlacement{
+			{Role: "primary", BaseDir: "/ssd/primary"},
+			{Role: "mirror", BaseDir: "/ssd/mirror"},
+			{Host: "sdw3", BaseDir: "/nvme"},
+			{Host: "sdw3", Role: "master", BaseDir: "/x"},
+			{BaseDir: "/default"},
+		}
+		if !reflect.DeepEqual(placement, expected) {
+			t.Errorf("got %+v want %+v", placement, expected)
+		}
+	})
+
+	errCases := []struct {
+		name  string
+		value string
+	}{
+		{"relative base directory", "primary:ssd"},
+		{"unknown role", "sdw1/segment:/ssd"},
+		{"duplicate rules", "primary:/ssd/a,primary:/ssd/b"},
+		{"duplicate default rules", "/ssd/a,/ssd/b"},
+	}
+
+	for _, c := range errCases {
+		t.Run("errors on "+c.name, func(t *testing.T) {
+			_, err := upgrade.ParseDataDirPlacement(c.value)
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestDataDirPlacement(t *testing.T) {
+	var id upgrade.ID
+
+	placement := upgrade.DataDirPlacement{
+		{Role: "primary", BaseDir: "/ssd/primary"},
+		{Host: "sdw2", BaseDir: "/nvme/{role}/{content}"},
+		{Host: "sdw2", Role: "mirror", BaseDir: "/nvme/mirrors"},
+	}
+
+	cases := []struct {
+		datadir  string
+		host     string
+		role     string
+		content  int
+		expected string
+	}{
+		{"/data/master/seg-1", "mdw", "master", -1, "/data/master/seg.AAAAAAAAAAA.-1"},
+		{"/data/primary/seg0", "sdw1", "primary", 0, "/ssd/primary/seg.AAAAAAAAAAA.0"},
+		{"/data/primary/seg1", "sdw2", "primary", 1, "/nvme/primary/1/seg.AAAAAAAAAAA.1"},
+		{"/data/mirror/seg0", "sdw2", "mirror", 0, "/nvme/mirrors/seg.AAAAAAAAAAA.0"},
+		{"/data/mirror/seg1", "sdw1", "mirror", 1, "/data/mirror/seg.AAAAAAAAAAA.1"},
+	}
+
+	for _, c := range cases {
+		actual := placement.PlacedDataDir(c.datadir, "seg", id, c.host, c.role, c.content)
+		if actual != c.expected {
+			t.Errorf("PlacedDataDir(%q, %q, %q) = %q, want %q", c.datadir, c.host, c.role, actual, c.expected)
+		}
+	}
+}
+
+func TestFinalAndArchiveDataDir(t *testing.T) {
+	cases := []struct {
+		source          string
+		target          string
+		expectedFinal   string
+		expectedArchive string
+	}{
+		{"/data/seg0", "/data/seg.AAAAAAAAAAA.0", "/data/seg0", "/data/seg.AAAAAAAAAAA.0.old"},
+		{"/data/seg0", "/ssd/seg.AAAAAAAAAAA.0", "/ssd/seg0", "/data/seg.AAAAAAAAAAA.0.old"},
+	}
+
+	for _, c := range cases {
+		if actual := upgrade.FinalDataDir(c.source, c.target); actual != c.expectedFinal {
+			t.Errorf("FinalDataDir(%q, %q) = %q, want %q", c.source, c.target, actual, c.expectedFinal)
+		}
+
+		if actual := upgrade.ArchiveDataDir(c.source, c.target); actual != c.expectedArchive {
+			t.Errorf("ArchiveDataDir(%q, %q) = %q, want %q", c.source, c.target, actual, c.expectedArchive)
+		}
+	}
+}
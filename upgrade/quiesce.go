@@ -0,0 +1,175 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// HbaBackupName is the copy of the original pg_hba.conf kept in the data
+// directory while it is quiesced.
+const HbaBackupName = "pg_hba.conf.gpupgrade"
+
+const (
+	quiesceBegin = "# BEGIN gpupgrade quiesce"
+	quiesceEnd   = "# END gpupgrade quiesce"
+)
+
+// Quiesce blocks client connections to the segment other than those of the
+// admins. It saves the original pg_hba.conf, prepends a deny block to it, and
+// reloads the segment when it is running. It is idempotent as the deny block is
+// always added to the saved original.
+func Quiesce(binDir, dataDir string, admins []string) error {
+	path := filepath.Join(dataDir, "pg_hba.conf")
+	backup := filepath.Join(dataDir, HbaBackupName)
+
+	if !PathExists(backup) {
+		original, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if err := utils.AtomicallyWrite(backup, original); err != nil {
+			return xerrors.Errorf("saving %q: %w", path, err)
+		}
+	}
+
+	original, err := ioutil.ReadFile(backup)
+	if err != nil {
+		return err
+	}
+
+	gplog.Debug("quiescing %q for %q", path, admins)
+	if err := utils.AtomicallyWrite(path, []byte(QuiescedHbaConf(string(original), admins))); err != nil {
+		return err
+	}
+
+	return reload(binDir, dataDir)
+}
+
+// Unquiesce restores the original pg_hba.conf saved by Quiesce, and reloads
+// the segment when it is running. It is idempotent as there is nothing to
+// restore once the saved original is moved back.
+func Unquiesce(binDir, dataDir string) error {
+	path := filepath.Join(dataDir, "pg_hba.conf")
+	backup := filepath.Join(dataDir, HbaBackupName)
+
+	if !PathExists(backup) {
+		gplog.Debug("%q is not quiesced", path)
+		return nil
+	}
+
+	gplog.Debug("restoring %q from %q", path, backup)
+	if err := utils.System.Rename(backup, path); err != nil {
+		return err
+	}
+
+	return reload(binDir, dataDir)
+}
+
+// QuiescedHbaConf returns the original pg_hba.conf with a deny block
+// prepended. The block keeps the original rules applying to the admins, limited
+// to the admins, such that their access is unchanged, and then rejects all
+// other connections. Replication connections are unaffected as they are not
+// matched by the "all" database.
+func QuiescedHbaConf(original string, admins []string) string {
+	block := []string{
+		quiesceBegin,
+		fmt.Sprintf("# Client connections other than those of %s are rejected during the upgrade.", strings.Join(admins, ", ")),
+		fmt.Sprintf("# The original file is saved as %s and is restored by gpupgrade.", HbaBackupName),
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(original))
+	for scanner.Scan() {
+		if rule, ok := adminRule(scanner.Text(), admins); ok {
+			block = append(block, rule)
+		}
+	}
+
+	block = append(block,
+		"local all all reject",
+		"host all all 0.0.0.0/0 reject",
+		"host all all ::/0 reject",
+		quiesceEnd,
+	)
+
+	return strings.Join(block, "\n") + "\n" + original
+}
+
+// adminRule returns the pg_hba.conf rule limited to the admins it applies to.
+// Rules using quoting, groups, or files are not kept, as whether they apply to
+// the admins cannot be determined from the rule alone.
+func adminRule(line string, admins []string) (string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || strings.HasPrefix(fields[0], "#") {
+		return "", false
+	}
+
+	for _, field := range fields[:3] {
+		if strings.ContainsAny(field, `"+@\`) {
+			return "", false
+		}
+	}
+
+	var users []string
+	for _, user := range strings.Split(fields[2], ",") {
+		for _, admin := range admins {
+			if user == admin || user == "all" {
+				users = appendUnique(users, admin)
+			}
+		}
+	}
+
+	if len(users) == 0 {
+		return "", false
+	}
+
+	fields[2] = strings.Join(users, ",")
+	return strings.Join(fields, " "), true
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+// reload has a running segment reread its pg_hba.conf. A stopped segment reads
+// it on start.
+func reload(binDir, dataDir string) error {
+	pgCtl := filepath.Join(binDir, "pg_ctl")
+
+	status := execCommand(pgCtl, "status", "-D", dataDir)
+	if err := status.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			gplog.Debug("not reloading stopped segment %q", dataDir)
+			return nil
+		}
+
+		return xerrors.Errorf("checking status of %q: %w", dataDir, err)
+	}
+
+	output, err := execCommand(pgCtl, "reload", "-D", dataDir).CombinedOutput()
+	if err != nil {
+		return xerrors.Errorf("reloading %q failed with %q: %w", dataDir, string(output), err)
+	}
+
+	return nil
+}
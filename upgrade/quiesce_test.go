@@ -0,0 +1,143 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+const hbaConf = `# TYPE  DATABASE  USER  ADDRESS  METHOD
+local all gpadmin ident
+host all all 10.0.0.0/8 md5
+host all etl 10.0.0.0/8 md5
+host all "quoted" 10.0.0.0/8 md5
+host all +group 10.0.0.0/8 md5
+local replication gpadmin ident
+`
+
+func TestQuiescedHbaConf(t *testing.T) {
+	conf := upgrade.QuiescedHbaConf(hbaConf, []string{"gpadmin", "dba"})
+
+	expected := `# BEGIN gpupgrade quiesce
+# Client connections other than those of gpadmin, dba are rejected during the upgrade.
+# The original file is saved as pg_hba.conf.gpupgrade and is restored by gpupgrade.
+local all gpadmin ident
+host all gpadmin,dba 10.0.0.0/8 md5
+local replication gpadmin ident
+local all all reject
+host all all 0.0.0.0/0 reject
+host all all ::/0 reject
+# END gpupgrade quiesce
+` + hbaConf
+
+	if conf != expected {
+		t.Errorf("got\n%s\nwant\n%s", conf, expected)
+	}
+}
+
+func TestQuiesce(t *testing.T) {
+	testlog.SetupLogger()
+
+	dataDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dataDir)
+
+	path := filepath.Join(dataDir, "pg_hba.conf")
+	backup := filepath.Join(dataDir, upgrade.HbaBackupName)
+	admins := []string{"gpadmin"}
+
+	t.Run("quiesces and unquiesces a running segment", func(t *testing.T) {
+		testutils.MustWriteToFile(t, path, hbaConf)
+
+		var calls []string
+		upgrade.SetExecCommand(exectest.NewCommandWithVerifier(Success, func(name string, args ...string) {
+			calls = append(calls, filepath.Base(name)+" "+strings.Join(args, " "))
+		}))
+		defer upgrade.ResetExecCommand()
+
+		err := upgrade.Quiesce("/usr/local/gpdb/bin", dataDir, admins)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		// Quiescing again keeps a single deny block.
+		err = upgrade.Quiesce("/usr/local/gpdb/bin", dataDir, admins)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		contents := testutils.MustReadFile(t, path)
+		if contents != upgrade.QuiescedHbaConf(hbaConf, admins) {
+			t.Errorf("got pg_hba.conf\n%s\nwant the quiesced original", contents)
+		}
+
+		if saved := testutils.MustReadFile(t, backup); saved != hbaConf {
+			t.Errorf("got saved pg_hba.conf %q want %q", saved, hbaConf)
+		}
+
+		err = upgrade.Unquiesce("/usr/local/gpdb/bin", dataDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if contents := testutils.MustReadFile(t, path); contents != hbaConf {
+			t.Errorf("got pg_hba.conf %q want %q", contents, hbaConf)
+		}
+
+		if upgrade.PathExists(backup) {
+			t.Errorf("expected %q to be removed", backup)
+		}
+
+		expected := "pg_ctl reload -D " + dataDir
+		if len(calls) != 6 || calls[1] != expected || calls[5] != expected {
+			t.Errorf("got calls %q want status and reload for each change", calls)
+		}
+	})
+
+	t.Run("does not reload a stopped segment", func(t *testing.T) {
+		testutils.MustWriteToFile(t, path, hbaConf)
+
+		var calls []string
+		upgrade.SetExecCommand(exectest.NewCommandWithVerifier(Failure, func(name string, args ...string) {
+			calls = append(calls, args[0])
+		}))
+		defer upgrade.ResetExecCommand()
+
+		err := upgrade.Quiesce("/usr/local/gpdb/bin", dataDir, admins)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		err = upgrade.Unquiesce("/usr/local/gpdb/bin", dataDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if len(calls) != 2 || calls[0] != "status" || calls[1] != "status" {
+			t.Errorf("got calls %q want only status", calls)
+		}
+	})
+
+	t.Run("unquiesce does nothing when not quiesced", func(t *testing.T) {
+		testutils.MustWriteToFile(t, path, hbaConf)
+
+		upgrade.SetExecCommand(nil)
+		defer upgrade.ResetExecCommand()
+
+		err := upgrade.Unquiesce("/usr/local/gpdb/bin", dataDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if contents := testutils.MustReadFile(t, path); contents != hbaConf {
+			t.Errorf("got pg_hba.conf %q want %q", contents, hbaConf)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// ReplicationSlot is the physical replication slot of a primary used by its
+// mirror, as created by gpaddmirrors.
+const ReplicationSlot = "internal_wal_replication_slot"
+
+// ReplicationHbaEntries returns the pg_hba.conf entries gpaddmirrors adds to a
+// primary such that the user can connect and replicate from each address.
+func ReplicationHbaEntries(user string, addresses []string) []string {
+	var entries []string
+	for _, address := range addresses {
+		entries = append(entries,
+			fmt.Sprintf("host\tall\t%s\t%s\ttrust", user, address),
+			fmt.Sprintf("host\treplication\t%s\t%s\ttrust", user, address))
+	}
+
+	return entries
+}
+
+// AddHbaEntries appends the entries missing from the pg_hba.conf of the data
+// directory. It is idempotent as entries already present are not added again.
+func AddHbaEntries(dataDir string, entries []string) error {
+	path := filepath.Join(dataDir, "pg_hba.conf")
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(contents), "\n") {
+		existing[strings.Join(strings.Fields(line), " ")] = true
+	}
+
+	hba := string(contents)
+	if hba != "" && !strings.HasSuffix(hba, "\n") {
+		hba += "\n"
+	}
+
+	added := false
+	for _, entry := range entries {
+		if existing[strings.Join(strings.Fields(entry), " ")] {
+			continue
+		}
+
+		gplog.Debug("adding %q to %q", entry, path)
+		hba += entry + "\n"
+		added = true
+	}
+
+	if !added {
+		return nil
+	}
+
+	return utils.AtomicallyWrite(path, []byte(hba))
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const SegmentStatusFileName = "segment_status.json"
+
+// SegmentStatus records the progress of pg_upgrade for a single segment. It is
+// stored in the segment's pg_upgrade working directory so that revert can
+// determine which source data directories were modified.
+type SegmentStatus struct {
+	LinkMode  bool
+	StartTime time.Time
+	EndTime   time.Time
+
+	// Recorded is false when no status was stored, such as when pg_upgrade
+	// was never started or the status was lost.
+	Recorded bool `json:"-"`
+}
+
+func (s SegmentStatus) Started() bool {
+	return !s.StartTime.IsZero()
+}
+
+func (s SegmentStatus) Finished() bool {
+	return !s.EndTime.IsZero()
+}
+
+// Modified returns true if pg_upgrade was started in link mode, in which case
+// the source data directory shares its files with the target cluster and can
+// no longer be assumed to match its mirror.
+func (s SegmentStatus) Modified() bool {
+	return s.LinkMode && s.Started()
+}
+
+// ReadSegmentStatus returns the status stored in the given pg_upgrade working
+// directory. The zero status, which is not Recorded, is returned if no status
+// was stored.
+func ReadSegmentStatus(workDir string) (SegmentStatus, error) {
+	var status SegmentStatus
+
+	path := filepath.Join(workDir, SegmentStatusFileName)
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return status, nil
+	}
+	if err != nil {
+		return status, err
+	}
+
+	if err := json.Unmarshal(data, &status); err != nil {
+		return status, xerrors.Errorf("decode segment status %q: %w", path, err)
+	}
+
+	status.Recorded = true
+	return status, nil
+}
+
+func writeSegmentStatus(workDir string, status SegmentStatus) error {
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return utils.AtomicallyWrite(filepath.Join(workDir, SegmentStatusFileName), data)
+}
+
+// MarkUpgradeStarted records that pg_upgrade is about to run against the
+// segment. The original start time is kept when pg_upgrade is rerun, since the
+// source data directory may have been modified by the earlier attempt.
+func MarkUpgradeStarted(workDir string, linkMode bool) error {
+	status, err := ReadSegmentStatus(workDir)
+	if err != nil {
+		return err
+	}
+
+	if !status.Started() {
+		status.StartTime = time.Now()
+	}
+
+	status.LinkMode = status.LinkMode || linkMode
+	status.EndTime = time.Time{}
+
+	return writeSegmentStatus(workDir, status)
+}
+
+// MarkUpgradeFinished records that pg_upgrade completed successfully against
+// the segment.
+func MarkUpgradeFinished(workDir string) error {
+	status, err := ReadSegmentStatus(workDir)
+	if err != nil {
+		return err
+	}
+
+	status.EndTime = time.Now()
+
+	return writeSegmentStatus(workDir, status)
+}
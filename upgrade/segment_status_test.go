@@ -0,0 +1,120 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+func TestSegmentStatus(t *testing.T) {
+	t.Run("returns the zero status when pg_upgrade was never started", func(t *testing.T) {
+		wd := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, wd)
+
+		status, err := upgrade.ReadSegmentStatus(wd)
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+
+		if status.Started() || status.Finished() || status.Modified() || status.Recorded {
+			t.Errorf("got status %+v want zero status", status)
+		}
+	})
+
+	t.Run("records the start and finish of a link mode upgrade", func(t *testing.T) {
+		wd := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, wd)
+
+		if err := upgrade.MarkUpgradeStarted(wd, true); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		status, err := upgrade.ReadSegmentStatus(wd)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if !status.Modified() || status.Finished() || !status.Recorded {
+			t.Errorf("got status %+v want recorded, modified, and not finished", status)
+		}
+
+		if err := upgrade.MarkUpgradeFinished(wd); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		finished, err := upgrade.ReadSegmentStatus(wd)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if !finished.Finished() {
+			t.Errorf("got status %+v want finished", finished)
+		}
+
+		if !finished.StartTime.Equal(status.StartTime) {
+			t.Errorf("got start time %v want %v", finished.StartTime, status.StartTime)
+		}
+	})
+
+	t.Run("keeps the original start time when pg_upgrade is rerun", func(t *testing.T) {
+		wd := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, wd)
+
+		if err := upgrade.MarkUpgradeStarted(wd, true); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		first, err := upgrade.ReadSegmentStatus(wd)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if err := upgrade.MarkUpgradeStarted(wd, true); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		second, err := upgrade.ReadSegmentStatus(wd)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if !second.StartTime.Equal(first.StartTime) {
+			t.Errorf("got start time %v want %v", second.StartTime, first.StartTime)
+		}
+	})
+
+	t.Run("copy mode upgrades do not modify the source cluster", func(t *testing.T) {
+		wd := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, wd)
+
+		if err := upgrade.MarkUpgradeStarted(wd, false); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		status, err := upgrade.ReadSegmentStatus(wd)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if status.Modified() {
+			t.Errorf("got status %+v want unmodified", status)
+		}
+	})
+
+	t.Run("errors when the status file is invalid", func(t *testing.T) {
+		wd := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, wd)
+
+		testutils.MustWriteToFile(t, filepath.Join(wd, upgrade.SegmentStatusFileName), "{")
+
+		_, err := upgrade.ReadSegmentStatus(wd)
+		if err == nil {
+			t.Error("expected error, returned nil")
+		}
+	})
+}
@@ -4,6 +4,9 @@
 package disk
 
 import (
+	"os"
+	"path/filepath"
+
 	"golang.org/x/sys/unix"
 	"golang.org/x/xerrors"
 
@@ -32,6 +35,8 @@ type Disk interface {
 // compares the available space to the required disk ratio. Any filesystems that
 // don't have enough space will be given an entry in the returned SpaceFailures
 // map. Note that this is one entry per filesystem, not one entry per path.
+// Paths that do not exist yet are checked using their nearest existing parent
+// directory.
 //
 // This function ignores space that has been reserved for the superuser (i.e.
 // the difference between "free" and "avail" in statfs(2)). It does not consider
@@ -59,6 +64,8 @@ func CheckUsage(d Disk, requiredRatio float64, paths ...string) (SpaceFailures,
 	}
 
 	for _, path := range paths {
+		path = existingPath(d, path)
+
 		usage, err := d.Usage(path)
 		if err != nil {
 			return nil, xerrors.Errorf("getting fs usage for %s: %w", path, err)
@@ -95,6 +102,49 @@ func CheckUsage(d Disk, requiredRatio float64, paths ...string) (SpaceFailures,
 	return failures, nil
 }
 
+// existingPath returns the path, or its nearest existing parent directory when
+// the path has not been created yet.
+func existingPath(d Disk, path string) string {
+	for {
+		if _, err := d.Stat(path); !os.IsNotExist(err) {
+			return path
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+
+		path = parent
+	}
+}
+
+// DirectorySize returns the total size in bytes of the regular files within
+// the directory. Symbolic links are not followed. A directory that does not
+// exist has a size of zero.
+func DirectorySize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			size += uint64(info.Size())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, xerrors.Errorf("computing size of %q: %w", dir, err)
+	}
+
+	return size, nil
+}
+
 // Local is a standard implementation of the Disk interface that uses gosigar
 // and unix.Stat to obtain statistics for the local machine.
 var Local = local{}
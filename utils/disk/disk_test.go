@@ -168,6 +168,52 @@ func TestCheckUsage(t *testing.T) {
 		}
 	})
 
+	t.Run("checks the nearest existing parent of paths that do not exist", func(t *testing.T) {
+		d := testDisk{
+			err: errors.New("should never happen"),
+
+			filesystems: func() (sigar.FileSystemList, error) {
+				return sigar.FileSystemList{List: []sigar.FileSystem{
+					{DirName: "/"},
+				}}, nil
+			},
+
+			usage: func(path string) (sigar.FileSystemUsage, error) {
+				if path != "/data" {
+					return sigar.FileSystemUsage{}, unix.ENOENT
+				}
+
+				u := sigar.FileSystemUsage{Total: size, Avail: scale(size, 0.25)}
+				u.Free = u.Avail
+				u.Used = u.Total - u.Free
+				return u, nil
+			},
+
+			stat: func(path string) (*unix.Stat_t, error) {
+				if strings.HasPrefix(path, "/data/") {
+					return nil, unix.ENOENT
+				}
+
+				return &unix.Stat_t{Dev: 1}, nil
+			},
+		}
+
+		actual, err := disk.CheckUsage(d, 0.8, "/data/primary/gpseg0")
+		if err != nil {
+			t.Errorf("returned error %#v", err)
+		}
+
+		expected := disk.SpaceFailures{
+			"/": &idl.CheckDiskSpaceReply_DiskUsage{
+				Required:  scale(size, 0.8),
+				Available: scale(size, 0.25),
+			},
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("returned %v want %v", actual, expected)
+		}
+	})
+
 	// regression test to catch float representation errors
 	t.Run("does floating point math correctly", func(t *testing.T) {
 		d := testDisk{